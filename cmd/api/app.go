@@ -41,10 +41,13 @@ type App struct {
 	tracerProvider *sdktrace.TracerProvider
 	metrics        *observability.Metrics
 	taxonomyRepo   *repository.TaxonomyRepository
+	// embeddingBacklog is nil when embeddings or EMBEDDING_TENANT_BACKLOG_THRESHOLD are off.
+	embeddingBacklog *service.EmbeddingTenantBacklog
 }
 
 var (
 	errEmbeddingProviderAPIKeyRequired     = errors.New("EMBEDDING_PROVIDER_API_KEY is required for this provider")
+	errEmbeddingRequired                   = errors.New("EMBEDDING_REQUIRED is set but embeddings are not configured")
 	errEmbeddingGoogleGeminiConfigRequired = errors.New(
 		"google-gemini requires EMBEDDING_GOOGLE_CLOUD_PROJECT and EMBEDDING_GOOGLE_CLOUD_LOCATION")
 )
@@ -84,7 +87,9 @@ func setupEmbeddingSearchHandler(
 	cfg *config.Config,
 	embeddingProviderName, embeddingModel, embeddingDocPrefix string,
 	feedbackRecordsService *service.FeedbackRecordsService,
+	subjectErasureService *service.SubjectErasureService,
 	embeddingsRepo *repository.EmbeddingsRepository,
+	tenantSettings service.TenantSettingsReader,
 	embeddingMetrics observability.EmbeddingMetrics,
 	metrics *observability.Metrics,
 	meterProvider *sdkmetric.MeterProvider,
@@ -108,10 +113,40 @@ func setupEmbeddingSearchHandler(
 		return nil, fmt.Errorf("create embedding client: %w", err)
 	}
 
+	retryCfg := service.EmbeddingRetryConfig{
+		AttemptTimeout: cfg.Embedding.HTTPTimeout.Duration(),
+		MaxRetries:     cfg.Embedding.HTTPMaxRetries,
+		Metrics:        embeddingMetrics,
+	}
+	embeddingClient = service.WithEmbeddingRetry(embeddingClient, retryCfg)
+
+	if cfg.Embedding.StartupProbe {
+		err := service.RunEmbeddingStartupProbe(ctx, embeddingClient, embeddingProviderName, embeddingModel, cfg.Embedding.Required)
+		if err != nil {
+			return nil, fmt.Errorf("%w (EMBEDDING_REQUIRED is set)", err)
+		}
+	}
+
+	var secondary *service.SecondaryEmbeddingModel
+	if cfg.Embedding.SecondaryModel != "" {
+		secondary, err = service.NewSecondaryEmbeddingModel(ctx, embeddingCfg, cfg.Embedding.SecondaryModel, retryCfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	embeddingWorker := workers.NewFeedbackEmbeddingWorker(
 		feedbackRecordsService, embeddingClient, embeddingDocPrefix, embeddingMetrics)
+	embeddingWorker.SetSecondaryModel(secondary)
 	river.AddWorker(riverWorkers, embeddingWorker)
 
+	// Inline path for POST /v1/feedback-records?sync_embedding=true; same client and prefix as
+	// the worker so the stored vector is identical to what the queued job would produce.
+	if feedbackRecordsService != nil {
+		feedbackRecordsService.SetSyncEmbedder(service.NewSyncEmbedder(
+			embeddingClient, embeddingDocPrefix, cfg.Embedding.SyncTimeout.Duration(), cfg.Embedding.SyncMaxConcurrent))
+	}
+
 	queryCache, err := lru.New[string, []float32](searchQueryCacheSize)
 	if err != nil {
 		return nil, fmt.Errorf("create search query cache: %w", err)
@@ -125,12 +160,21 @@ func setupEmbeddingSearchHandler(
 	searchService := service.NewSearchService(service.SearchServiceParams{
 		EmbeddingClient: embeddingClient,
 		EmbeddingsRepo:  embeddingsRepo,
+		KeywordRepo:     embeddingsRepo,
 		Model:           embeddingModel,
+		Secondary:       secondary,
+		TenantSettings:  tenantSettings,
+		MaxQueryLen:     cfg.Search.MaxQueryLen,
 		QueryCache:      queryCache,
 		CacheMetrics:    cacheMetrics,
 		Logger:          slog.Default(),
 	})
 
+	// Erasures evict the erased records' text from this cache (a search may have used it verbatim).
+	if subjectErasureService != nil {
+		subjectErasureService.SetQueryCache(searchService)
+	}
+
 	// Surface HNSW iterative-scan degradation (pgvector < 0.8 fallback) as a gauge so capped recall
 	// is alertable, not just a one-time log line. No-op meter when metrics are disabled.
 	var meter metric.Meter
@@ -173,6 +217,19 @@ func setupMetrics(cfg *config.Config) (*sdkmetric.MeterProvider, *observability.
 // NewApp builds and wires all components. It does not start the HTTP server or River;
 // call Run to start and block until shutdown or failure.
 func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
+	// Strict mode: a deployment that depends on search must not start with it silently disabled
+	// (unset, partial, or unsupported EMBEDDING_PROVIDER/EMBEDDING_MODEL). Checked before anything
+	// is started, so there is nothing to clean up.
+	if cfg.Embedding.Required {
+		if provider, _ := embeddingProviderAndModel(cfg); provider == "" {
+			return nil, fmt.Errorf("%w: set a supported EMBEDDING_PROVIDER and EMBEDDING_MODEL", errEmbeddingRequired)
+		}
+	}
+
+	if err := cfg.Embedding.CheckAllowedModels(); err != nil {
+		return nil, fmt.Errorf("embedding model allowlist: %w", err)
+	}
+
 	var (
 		err           error
 		meterProvider *sdkmetric.MeterProvider
@@ -240,6 +297,8 @@ func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
 	webhooksRepo := repository.NewWebhooksRepository(db)
 	webhookSender := service.NewWebhookSenderImpl(
 		webhooksRepo, webhookMetrics, cfg.Webhook.URLBlacklist, cfg.Webhook.HTTPTimeout.Duration(), nil)
+	webhookSender.SetMaxConcurrentPerHost(cfg.Webhook.MaxConcurrentPerHost)
+	webhookSender.SetUserAgent(cfg.Webhook.UserAgent)
 
 	deps := workers.RiverDeps{
 		WebhooksRepo:       webhooksRepo,
@@ -272,6 +331,14 @@ func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
 		cfg.Translation.DefaultLanguage,
 	)
 	feedbackRecordsService.SetTaxonomyEmbeddingModel(taxonomyEmbeddingEnqueueModel)
+	subjectErasureService := service.NewSubjectErasureService(feedbackRecordsRepo, messageManager)
+	feedbackRecordsService.SetEmbeddingBulkPriority(cfg.Embedding.BulkJobPriority)
+	feedbackRecordsService.SetRejectImpreciseValueNumber(cfg.FeedbackRecords.RejectImpreciseValueNumber)
+	feedbackRecordsService.SetBatchMaxRecords(cfg.FeedbackRecords.BatchMaxRecords)
+	feedbackRecordsService.SetFacets(service.FacetSettings{
+		SamplePercent:           cfg.Facets.SamplePercent,
+		ApproximateRowThreshold: cfg.Facets.ApproximateRowThreshold,
+	})
 
 	// The eager-clear (nulling stale enrichment outputs on a value_text edit) fires only on this
 	// API PATCH path, so wire its counter here; the worker/backfill service instances leave it unset.
@@ -280,9 +347,43 @@ func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
 	}
 
 	// Tenant settings service: shared by the emotions worker's authoritative gate (registered
-	// below), the settings HTTP handler, and the enqueue-path settings cache.
+	// below), the settings HTTP handler, and the enqueue-path settings cache. embedding_model may only
+	// name a configured embedding model.
 	tenantSettingsRepo := repository.NewTenantSettingsRepository(db)
 	tenantSettingsService := service.NewTenantSettingsService(tenantSettingsRepo)
+	tenantSettingsService.SetEmbeddingModels(embeddingModel, cfg.Embedding.SecondaryModel)
+	tenantSettingsService.SetAllowedEmbeddingModels(cfg.Embedding.AllowedModels)
+
+	// Translation, sentiment, and emotion enqueue providers all resolve a per-tenant setting on
+	// the enqueue path (translation's target language; the sentiment and emotion per-directory
+	// switches), as do search (the tenant's embedding_model and search_min_score) and the embedding
+	// provider (embedding_model), so they share one short-TTL cache over tenant settings. The cache
+	// is evicted on a settings write (below) so a toggle is visible to the gates immediately, not
+	// after TTL expiry.
+	translationEnabled := cfg.Translation.Provider != "" && cfg.Translation.Model != ""
+
+	var tenantSettingsCache *service.CachedTenantSettings
+
+	if translationEnabled || cfg.Sentiment.Enabled() || cfg.Emotions.Enabled() || embeddingProviderName != "" {
+		var cacheMetrics observability.CacheMetrics
+		if metrics != nil {
+			cacheMetrics = metrics.Cache
+		}
+
+		tenantSettingsCache = service.NewCachedTenantSettings(
+			tenantSettingsService,
+			cfg.TenantSettingsCache.Size, cfg.TenantSettingsCache.TTL.Duration(),
+			cacheMetrics,
+		)
+	}
+
+	// Exports are answered 503 while EXPORT_DIR is unset; the worker is registered only so the
+	// insert-only River client accepts the job kind.
+	exportService := service.NewExportService(
+		repository.NewExportJobsRepository(db), feedbackRecordsRepo, cfg.Export.Dir, cfg.Export.MaxAttempts)
+	if cfg.Export.Dir != "" {
+		deps.ExportService = exportService
+	}
 
 	// Shared worker/queue registration first (webhook + optional embedding added below).
 	riverWorkers, queues := workers.NewRiverWorkersAndQueues(cfg, deps, 1)
@@ -292,12 +393,18 @@ func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
 	if embeddingProviderName != "" {
 		embeddingDocPrefix := service.EmbeddingPrefixForProvider(embeddingProviderName)
 
+		if err := service.CheckEmbeddingColumnDimensions(context.Background(), embeddingsRepo); err != nil {
+			cleanupNewAppStartupFailure(context.Background(), messageManager, nil, tracerProvider, meterProvider)
+
+			return nil, err
+		}
+
 		var err error
 
 		searchHandler, err = setupEmbeddingSearchHandler(
 			context.Background(), cfg,
 			embeddingProviderName, embeddingModel, embeddingDocPrefix,
-			feedbackRecordsService, embeddingsRepo, embeddingMetrics,
+			feedbackRecordsService, subjectErasureService, embeddingsRepo, tenantSettingsCache, embeddingMetrics,
 			metrics, meterProvider, riverWorkers)
 		if err != nil {
 			cleanupNewAppStartupFailure(context.Background(), messageManager, nil, tracerProvider, meterProvider)
@@ -414,6 +521,7 @@ func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
 
 	// Enable backfill on the same service instance the embedding worker uses (avoids nil inserter if worker ever calls BackfillEmbeddings).
 	feedbackRecordsService.SetEmbeddingInserter(riverClient)
+	exportService.SetInserter(riverClient)
 
 	webhookEnqueueInitialBackoff := time.Duration(cfg.Webhook.EnqueueInitialBackoffMs) * time.Millisecond
 
@@ -425,10 +533,20 @@ func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
 		cfg.Webhook.EnqueueMaxRetries, webhookEnqueueInitialBackoff, webhookEnqueueMaxBackoff,
 		webhookMetrics,
 	)
-	messageManager.RegisterProvider(webhookProvider)
+	if cfg.Webhook.DebounceWindowMs > 0 {
+		messageManager.RegisterProvider(service.NewWebhookDebouncer(
+			webhookProvider, time.Duration(cfg.Webhook.DebounceWindowMs)*time.Millisecond, perEventTimeout))
+	} else {
+		messageManager.RegisterProvider(webhookProvider)
+	}
+
+	var embeddingBacklog *service.EmbeddingTenantBacklog
 
 	if embeddingProviderName != "" {
 		docPrefix := service.EmbeddingPrefixForProvider(embeddingProviderName)
+		// Every provider below shares the embeddings queue, so one backlog demotes all of a busy
+		// tenant's new jobs.
+		embeddingBacklog = service.NewEmbeddingTenantBacklog(cfg.Embedding.TenantBacklogThreshold)
 		embeddingProv := service.NewEmbeddingProvider(
 			riverClient,
 			embeddingModelForDB,
@@ -437,7 +555,26 @@ func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
 			docPrefix,
 			embeddingMetrics,
 		)
+		embeddingProv.SetTenantBacklog(embeddingBacklog)
 		messageManager.RegisterProvider(embeddingProv)
+		feedbackRecordsService.SetBatchEmbeddingEnqueuer(embeddingProv)
+		// Deleted records' queued embedding jobs (every model) would only run to a not-found skip.
+		embeddingJobCanceller := service.NewEmbeddingJobCanceller(riverClient)
+		messageManager.RegisterProvider(embeddingJobCanceller)
+		subjectErasureService.SetJobCanceller(embeddingJobCanceller)
+
+		if cfg.Embedding.SecondaryModel != "" {
+			secondaryEmbeddingProv := service.NewSecondaryEmbeddingProvider(
+				riverClient,
+				cfg.Embedding.SecondaryModel,
+				service.EmbeddingsQueueName,
+				cfg.Embedding.MaxAttempts,
+				docPrefix,
+				embeddingMetrics,
+			)
+			secondaryEmbeddingProv.SetTenantBacklog(embeddingBacklog)
+			messageManager.RegisterProvider(secondaryEmbeddingProv)
+		}
 
 		if taxonomyEmbeddingEnqueueModel != "" {
 			taxonomyEmbeddingProv := service.NewEmbeddingProviderForInputKind(
@@ -449,38 +586,27 @@ func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
 				embeddingMetrics,
 				models.EmbeddingInputKindTaxonomyTranslated,
 			)
+			taxonomyEmbeddingProv.SetTenantBacklog(embeddingBacklog)
 			messageManager.RegisterProvider(taxonomyEmbeddingProv)
 		}
 	}
 
 	webhooksService := service.NewWebhooksService(webhooksRepo, messageManager, cfg.Webhook.MaxCount, cfg.Webhook.URLBlacklist)
 	webhooksHandler := handlers.NewWebhooksHandler(webhooksService)
+	webhookDeadLettersService := service.NewWebhookDeadLettersService(webhooksRepo, cfg.Webhook.DeliveryMaxAttempts)
+	webhookDeadLettersService.SetInserter(riverClient)
+	webhookDeadLettersHandler := handlers.NewWebhookDeadLettersHandler(webhookDeadLettersService)
+	webhookDeliveriesHandler := handlers.NewWebhookDeliveriesHandler(service.NewWebhookDeliveriesService(webhooksRepo))
 	tenantDataService := service.NewTenantDataService(tenantDataRepo)
+	tenantDataService.SetExportDir(cfg.Export.Dir)
 	tenantDataHandler := handlers.NewTenantDataHandler(tenantDataService)
+	subjectErasureHandler := handlers.NewSubjectErasureHandler(subjectErasureService)
+	maintenance := middleware.NewMaintenanceMode(cfg.Server.MaintenanceMode)
+	adminHandler := handlers.NewAdminHandler(feedbackRecordsService, maintenance)
+	exportsHandler := handlers.NewExportsHandler(exportService)
 
 	tenantSettingsHandler := handlers.NewTenantSettingsHandler(tenantSettingsService)
 
-	// Translation, sentiment, and emotion enqueue providers all resolve a per-tenant setting on
-	// the enqueue path (translation's target language; the sentiment and emotion per-directory
-	// switches), so they share one short-TTL cache over tenant settings. The cache is evicted on a
-	// settings write (below) so a toggle is visible to the gates immediately, not after TTL expiry.
-	translationEnabled := cfg.Translation.Provider != "" && cfg.Translation.Model != ""
-
-	var tenantSettingsCache *service.CachedTenantSettings
-
-	if translationEnabled || cfg.Sentiment.Enabled() || cfg.Emotions.Enabled() {
-		var cacheMetrics observability.CacheMetrics
-		if metrics != nil {
-			cacheMetrics = metrics.Cache
-		}
-
-		tenantSettingsCache = service.NewCachedTenantSettings(
-			tenantSettingsService,
-			cfg.TenantSettingsCache.Size, cfg.TenantSettingsCache.TTL.Duration(),
-			cacheMetrics,
-		)
-	}
-
 	// Translation enqueue provider: resolves the tenant's target language and enqueues a
 	// translation job. Gated on TRANSLATION_PROVIDER+MODEL.
 	if translationEnabled {
@@ -541,11 +667,15 @@ func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
 		Starter:               taxonomyStarter,
 		EmbeddingModel:        taxonomyEmbeddingModel,
 		MinimumEmbeddingCount: cfg.Taxonomy.MinimumEmbeddedRecords,
+		MaxNodeLevel:          cfg.Taxonomy.MaxNodeLevel,
 	})
 	taxonomyHandler := handlers.NewTaxonomyHandler(taxonomyService)
 	feedbackRecordsHandler := handlers.NewFeedbackRecordsHandler(feedbackRecordsService)
 	taxonomyInternalHandler := handlers.NewTaxonomyInternalHandler(taxonomyService)
-	healthHandler := handlers.NewHealthHandler()
+	healthHandler := handlers.NewHealthHandler(readinessChecks(cfg, db)...)
+	if embeddingModel != "" && cfg.Readiness.CheckRiver {
+		healthHandler.SetEmbeddingPipelineStatus(embeddingPipelineStatus(db))
+	}
 
 	openapiHandler, err := handlers.NewOpenAPIHandler(handlers.ResolveOpenAPISpecPath(), cfg.Server.PublicBaseURL)
 	if err != nil {
@@ -555,27 +685,80 @@ func NewApp(cfg *config.Config, db *pgxpool.Pool) (*App, error) {
 	}
 
 	server := newHTTPServer(
-		cfg, healthHandler, openapiHandler, feedbackRecordsHandler, webhooksHandler, tenantDataHandler,
-		tenantSettingsHandler, searchHandler,
-		taxonomyHandler, taxonomyInternalHandler,
+		cfg, healthHandler, openapiHandler, feedbackRecordsHandler, webhooksHandler, webhookDeadLettersHandler,
+		webhookDeliveriesHandler, tenantDataHandler, subjectErasureHandler, tenantSettingsHandler, searchHandler, adminHandler, exportsHandler,
+		taxonomyHandler, taxonomyInternalHandler, maintenance,
 		meterProvider, tracerProvider,
 	)
 
 	return &App{
-		cfg:            cfg,
-		db:             db,
-		server:         server,
-		river:          riverClient,
-		message:        messageManager,
-		meterProvider:  meterProvider,
-		tracerProvider: tracerProvider,
-		metrics:        metrics,
-		taxonomyRepo:   taxonomyRepo,
+		cfg:              cfg,
+		db:               db,
+		server:           server,
+		river:            riverClient,
+		message:          messageManager,
+		meterProvider:    meterProvider,
+		tracerProvider:   tracerProvider,
+		metrics:          metrics,
+		taxonomyRepo:     taxonomyRepo,
+		embeddingBacklog: embeddingBacklog,
 	}, nil
 }
 
+// readinessChecks builds the GET /ready dependency checks, each toggled by READINESS_CHECK_*.
+// The River check verifies the API can reach River's job table (it is insert-only here, so the
+// table, not a running client, is the dependency that matters).
+func readinessChecks(cfg *config.Config, db *pgxpool.Pool) []handlers.ReadinessCheck {
+	return []handlers.ReadinessCheck{
+		{
+			Name:    "db",
+			Enabled: cfg.Readiness.CheckDB,
+			Check: func(ctx context.Context) error {
+				if err := db.Ping(ctx); err != nil {
+					return fmt.Errorf("ping database: %w", err)
+				}
+
+				return nil
+			},
+		},
+		{
+			Name:    "river",
+			Enabled: cfg.Readiness.CheckRiver,
+			Check: func(ctx context.Context) error {
+				if _, err := db.Exec(ctx, "SELECT 1 FROM river_job LIMIT 1"); err != nil {
+					return fmt.Errorf("query river_job: %w", err)
+				}
+
+				return nil
+			},
+		},
+	}
+}
+
+// embeddingPipelineStatus reads the embedding queue's last completion and backlog from River's job
+// table for GET /ready. Both come from one statement; the completed lookup is served by River's
+// (state, finalized_at) index, and the backlog count by its fetch index.
+func embeddingPipelineStatus(db *pgxpool.Pool) func(ctx context.Context) (*handlers.EmbeddingPipelineStatus, error) {
+	return func(ctx context.Context) (*handlers.EmbeddingPipelineStatus, error) {
+		var status handlers.EmbeddingPipelineStatus
+
+		err := db.QueryRow(ctx,
+			`SELECT
+				(SELECT MAX(finalized_at) FROM river_job WHERE queue = $1 AND state = $2),
+				(SELECT COUNT(*) FROM river_job WHERE queue = $1 AND state IN ($3, $4, $5))`,
+			service.EmbeddingsQueueName, rivertype.JobStateCompleted,
+			rivertype.JobStateAvailable, rivertype.JobStateRetryable, rivertype.JobStateScheduled,
+		).Scan(&status.LastCompletedAt, &status.Pending)
+		if err != nil {
+			return nil, fmt.Errorf("query embedding queue status: %w", err)
+		}
+
+		return &status, nil
+	}
+}
+
 // newHTTPServer builds the HTTP server and muxes (no auth on /health or /openapi.*, API key on /v1/,
-// internal taxonomy token on /internal/v1/taxonomy/ when configured).
+// admin key on /v1/admin/ and internal taxonomy token on /internal/v1/taxonomy/ when configured).
 // Handler chain: RequestID -> otelhttp(Logging(mux)) so access logs get trace_id/span_id from context.
 func newHTTPServer(
 	cfg *config.Config,
@@ -583,58 +766,106 @@ func newHTTPServer(
 	openapi *handlers.OpenAPIHandler,
 	feedback *handlers.FeedbackRecordsHandler,
 	webhooks *handlers.WebhooksHandler,
+	webhookDeadLetters *handlers.WebhookDeadLettersHandler,
+	webhookDeliveries *handlers.WebhookDeliveriesHandler,
 	tenantData *handlers.TenantDataHandler,
+	subjectErasure *handlers.SubjectErasureHandler,
 	tenantSettings *handlers.TenantSettingsHandler,
 	search *handlers.SearchHandler,
+	admin *handlers.AdminHandler,
+	exports *handlers.ExportsHandler,
 	taxonomy *handlers.TaxonomyHandler,
 	taxonomyInternal *handlers.TaxonomyInternalHandler,
+	maintenance *middleware.MaintenanceMode,
 	meterProvider *sdkmetric.MeterProvider,
 	tracerProvider *sdktrace.TracerProvider,
 ) *http.Server {
 	public := http.NewServeMux()
 	public.HandleFunc("GET /health", health.Check)
+	public.HandleFunc("GET /ready", health.Ready)
 	public.HandleFunc("GET /openapi.yaml", openapi.YAML)
 	public.HandleFunc("GET /openapi.json", openapi.JSON)
 
 	protected := http.NewServeMux()
 	protected.HandleFunc("POST /v1/feedback-records", feedback.Create)
+	protected.HandleFunc("POST /v1/feedback-records/batch", feedback.CreateBatch)
 	protected.HandleFunc("GET /v1/feedback-records", feedback.List)
 	protected.HandleFunc("GET /v1/feedback-records/count", feedback.Count)
+	protected.HandleFunc("GET /v1/feedback-records/tags", feedback.Tags)
+	protected.HandleFunc("GET /v1/feedback-records/stats", feedback.Stats)
+	protected.HandleFunc("GET /v1/feedback-records/export", feedback.Export)
 	protected.HandleFunc("GET /v1/feedback-records/{id}", feedback.Get)
 	protected.HandleFunc("PATCH /v1/feedback-records/{id}", feedback.Update)
 	protected.HandleFunc("DELETE /v1/feedback-records/{id}", feedback.Delete)
 	protected.HandleFunc("DELETE /v1/feedback-records", feedback.DeleteByUser)
 
+	// Export status lives under /v1/exports/ because any two-segment GET below /v1/feedback-records/
+	// would collide with GET /v1/feedback-records/{id}/similar in the mux.
+	protected.HandleFunc("POST /v1/feedback-records/export", exports.Create)
+	protected.HandleFunc("GET /v1/exports/{job_id}", exports.Get)
+	protected.HandleFunc("GET /v1/exports/{job_id}/download", exports.Download)
+
 	protected.HandleFunc("POST /v1/webhooks", webhooks.Create)
 	protected.HandleFunc("GET /v1/webhooks", webhooks.List)
 	protected.HandleFunc("GET /v1/webhooks/{id}", webhooks.Get)
 	protected.HandleFunc("PATCH /v1/webhooks/{id}", webhooks.Update)
 	protected.HandleFunc("DELETE /v1/webhooks/{id}", webhooks.Delete)
+	protected.HandleFunc("GET /v1/webhooks/{id}/dead-letters", webhookDeadLetters.List)
+	protected.HandleFunc("POST /v1/webhooks/{id}/dead-letters/{dead_letter_id}/retry", webhookDeadLetters.Retry)
+	protected.HandleFunc("GET /v1/webhooks/{id}/deliveries", webhookDeliveries.List)
 	protected.HandleFunc("DELETE /v1/tenants/{tenant_id}/data", tenantData.Delete)
+	protected.HandleFunc("POST /v1/gdpr/erase", subjectErasure.Erase)
 	protected.HandleFunc("GET /v1/tenants/{tenant_id}/settings", tenantSettings.Get)
 	protected.HandleFunc("PUT /v1/tenants/{tenant_id}/settings", tenantSettings.Update)
 	protected.HandleFunc("PATCH /v1/tenants/{tenant_id}/settings", tenantSettings.Patch)
 
 	// Search endpoints are always registered; when embeddings are disabled, the handler returns 503.
 	protected.HandleFunc("POST /v1/feedback-records/search/semantic", search.SemanticSearch)
+	protected.HandleFunc("POST /v1/feedback-records/search/hybrid", search.HybridSearch)
 	protected.HandleFunc("GET /v1/feedback-records/{id}/similar", search.SimilarFeedback)
+	protected.HandleFunc("POST /v1/feedback-records/similar", search.SimilarToText)
 
 	protected.HandleFunc("GET /v1/taxonomy/fields", taxonomy.ListFields)
 	protected.HandleFunc("POST /v1/taxonomy/runs", taxonomy.CreateRun)
 	protected.HandleFunc("GET /v1/taxonomy/runs", taxonomy.ListRuns)
 	protected.HandleFunc("GET /v1/taxonomy/runs/active/tree", taxonomy.GetActiveTree)
 	protected.HandleFunc("GET /v1/taxonomy/runs/{run_id}", taxonomy.GetRun)
+	protected.HandleFunc("POST /v1/taxonomy/runs/{run_id}/cancel", taxonomy.CancelRun)
 	protected.HandleFunc("GET /v1/taxonomy/runs/{run_id}/tree", taxonomy.GetTree)
 	protected.HandleFunc("GET /v1/taxonomy/runs/{run_id}/record-counts", taxonomy.RecordCounts)
 	protected.HandleFunc("PATCH /v1/taxonomy/nodes/{node_id}", taxonomy.RenameNode)
+	protected.HandleFunc("DELETE /v1/taxonomy/nodes", taxonomy.RemoveNodes)
 	protected.HandleFunc("DELETE /v1/taxonomy/nodes/{node_id}", taxonomy.RemoveNode)
+	protected.HandleFunc("POST /v1/taxonomy/nodes/{node_id}/move", taxonomy.MoveNode)
+	protected.HandleFunc("POST /v1/taxonomy/nodes/{node_id}/merge", taxonomy.MergeNode)
 	protected.HandleFunc("GET /v1/taxonomy/nodes/{node_id}/records", taxonomy.ListNodeRecords)
+	protected.HandleFunc("GET /v1/feedback-records/{id}/related-topics", taxonomy.RelatedTopics)
 
-	protectedWithAuth := middleware.Auth(cfg.Server.HubAPIKey)(protected)
+	// Maintenance mode sits behind auth, so only authenticated callers learn that writes are paused.
+	rejectWrites := middleware.RejectWritesDuringMaintenance(maintenance, cfg.Server.MaintenanceRetryAfter.Duration())
+	// The body cap runs after auth, so unauthenticated callers get 401 whatever they send.
+	// Internal taxonomy routes are exempt: a run's result upload scales with the dataset.
+	limitBody := middleware.MaxRequestBody(cfg.Server.MaxRequestBodyBytes)
+	protectedWithAuth := middleware.Auth(cfg.Server.HubAPIKey)(limitBody(rejectWrites(protected)))
 
 	mux := http.NewServeMux()
 	mux.Handle("/v1/", protectedWithAuth)
 
+	// Admin routes take their own key, so the everyday API key cannot trigger bulk operations.
+	// The more specific /v1/admin/ pattern wins over /v1/; unmounted, they fall through to a 404.
+	if cfg.Server.AdminAPIKey != "" {
+		adminMux := http.NewServeMux()
+		adminMux.Handle("POST /v1/admin/reembed", rejectWrites(http.HandlerFunc(admin.Reembed)))
+		adminMux.HandleFunc("GET /v1/admin/maintenance", admin.GetMaintenance)
+		adminMux.HandleFunc("PUT /v1/admin/maintenance", admin.SetMaintenance)
+		// The feedback-record reads are mirrored here because only the admin key may pass
+		// include_deleted=true; the /v1/ routes answer it with 403.
+		adminMux.HandleFunc("GET /v1/admin/feedback-records", feedback.List)
+		adminMux.HandleFunc("GET /v1/admin/feedback-records/count", feedback.Count)
+		adminMux.HandleFunc("GET /v1/admin/feedback-records/export", feedback.Export)
+		mux.Handle("/v1/admin/", middleware.Auth(cfg.Server.AdminAPIKey)(limitBody(middleware.AdminScope(adminMux))))
+	}
+
 	if cfg.Taxonomy.HubInternalAPIToken != "" {
 		internalTaxonomy := http.NewServeMux()
 		internalTaxonomy.HandleFunc("GET /internal/v1/taxonomy/auth-check", taxonomyInternal.AuthCheck)
@@ -649,9 +880,9 @@ func newHTTPServer(
 	mux.Handle("/", public)
 
 	otelOpts := []otelhttp.Option{
-		// Skip tracing and HTTP metrics for health checks to reduce noise.
+		// Skip tracing and HTTP metrics for health and readiness probes to reduce noise.
 		otelhttp.WithFilter(func(r *http.Request) bool {
-			return r.URL.Path != "/health"
+			return r.URL.Path != "/health" && r.URL.Path != "/ready"
 		}),
 	}
 	if meterProvider != nil {
@@ -662,10 +893,17 @@ func newHTTPServer(
 		otelOpts = append(otelOpts, otelhttp.WithTracerProvider(tracerProvider))
 	}
 
-	// ProblemErrors normalizes ServeMux's plain-text 404/405 into problem+json.
-	// Logging runs inside otelhttp so r.Context() has the span when we log (trace_id/span_id in access logs).
-	inner := middleware.Logging(middleware.ProblemErrors(mux))
+	// ProblemErrors normalizes ServeMux's plain-text 404/405 into problem+json; ResponseEnvelope,
+	// outside it, wraps those problems too for clients that opt into the {data, meta, errors} shape.
+	// Logging runs inside otelhttp so r.Context() has the span when we log (trace_id/span_id in access logs),
+	// and inside ClientIP so the client_ip it logs is the one resolved through TRUSTED_PROXIES.
+	requestLog := middleware.RequestLogConfig{
+		SlowThreshold:   time.Duration(cfg.Server.SlowRequestThresholdMs) * time.Millisecond,
+		AlwaysLogErrors: cfg.Server.LogRequestErrors,
+	}
+	inner := middleware.Logging(requestLog)(middleware.PrettyJSON(middleware.ResponseEnvelope(middleware.ProblemErrors(mux))))
 	handler := otelhttp.NewHandler(inner, "hub-api", otelOpts...)
+	handler = middleware.ClientIP(cfg.Server.TrustedProxies)(handler)
 	handler = middleware.RequestID(handler)
 
 	const (
@@ -692,6 +930,18 @@ func (a *App) Run(ctx context.Context) error {
 		go runRiverQueueDepthPoller(ctx, a.db, a.metrics.Events)
 	}
 
+	// Per-tenant embedding backlog: feeds the tenant demotion and/or its gauge, whichever is on.
+	if provider, _ := embeddingProviderAndModel(a.cfg); provider != "" {
+		var eventMetrics observability.EventMetrics
+		if a.metrics != nil {
+			eventMetrics = a.metrics.Events
+		}
+
+		if a.embeddingBacklog != nil || eventMetrics != nil {
+			go runEmbeddingTenantDepthPoller(ctx, a.db, a.embeddingBacklog, eventMetrics)
+		}
+	}
+
 	// Reap taxonomy runs orphaned in a non-terminal state, but only when the taxonomy service is wired
 	// (no runs exist otherwise, so the sweep would be pointless).
 	if a.taxonomyRepo != nil && (a.cfg.Taxonomy.ServiceURL != "" || a.cfg.Taxonomy.ServiceToken != "") {
@@ -728,9 +978,14 @@ var riverDepthQueues = []string{
 	service.TranslationBackfillsQueueName,
 	service.SentimentsQueueName,
 	service.EmotionsQueueName,
+	service.ExportsQueueName,
 }
 
-// runRiverQueueDepthPoller periodically updates the per-queue River backlog gauge. Covering
+// riverPriorityLevels is the number of River job priorities (1 runs first, 4 last).
+const riverPriorityLevels = 4
+
+// runRiverQueueDepthPoller periodically updates the per-queue River backlog gauges (in total and
+// per job priority, which separates bulk backfills from interactive jobs sharing a queue). Covering
 // every declared queue (not just default) means a provider outage or a backfill piling tens of
 // thousands of jobs into an enrichment queue is visible in metrics before users notice the lag.
 func runRiverQueueDepthPoller(ctx context.Context, db *pgxpool.Pool, eventMetrics observability.EventMetrics) {
@@ -739,9 +994,9 @@ func runRiverQueueDepthPoller(ctx context.Context, db *pgxpool.Pool, eventMetric
 
 	update := func() {
 		rows, err := db.Query(ctx,
-			`SELECT queue, COUNT(*), EXTRACT(EPOCH FROM (now() - MIN(created_at)))::float8 FROM river_job
+			`SELECT queue, priority, COUNT(*), EXTRACT(EPOCH FROM (now() - MIN(created_at)))::float8 FROM river_job
 			 WHERE queue = ANY($1) AND state IN ($2, $3, $4)
-			 GROUP BY queue`,
+			 GROUP BY queue, priority`,
 			riverDepthQueues,
 			rivertype.JobStateAvailable, rivertype.JobStateRetryable, rivertype.JobStateScheduled,
 		)
@@ -754,21 +1009,29 @@ func runRiverQueueDepthPoller(ctx context.Context, db *pgxpool.Pool, eventMetric
 
 		counts := make(map[string]int, len(riverDepthQueues))
 		ages := make(map[string]float64, len(riverDepthQueues))
+		priorityCounts := make(map[string][riverPriorityLevels + 1]int, len(riverDepthQueues))
 
 		for rows.Next() {
 			var (
-				queue string
-				count int
-				age   float64
+				queue    string
+				priority int
+				count    int
+				age      float64
 			)
-			if err := rows.Scan(&queue, &count, &age); err != nil {
+			if err := rows.Scan(&queue, &priority, &count, &age); err != nil {
 				slog.WarnContext(ctx, "river queue depth scan failed", "error", err)
 
 				return
 			}
 
-			counts[queue] = count
-			ages[queue] = age
+			counts[queue] += count
+			ages[queue] = max(ages[queue], age)
+
+			if priority >= 1 && priority <= riverPriorityLevels {
+				byPriority := priorityCounts[queue]
+				byPriority[priority] = count
+				priorityCounts[queue] = byPriority
+			}
 		}
 
 		if err := rows.Err(); err != nil {
@@ -780,6 +1043,80 @@ func runRiverQueueDepthPoller(ctx context.Context, db *pgxpool.Pool, eventMetric
 		for _, queue := range riverDepthQueues {
 			eventMetrics.SetRiverQueueDepth(queue, counts[queue])
 			eventMetrics.SetRiverQueueOldestAge(queue, ages[queue])
+
+			for priority := 1; priority <= riverPriorityLevels; priority++ {
+				eventMetrics.SetRiverQueuePriorityDepth(queue, priority, priorityCounts[queue][priority])
+			}
+		}
+	}
+
+	update()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
+
+// runEmbeddingTenantDepthPoller periodically counts the queued embedding jobs of the tenants with
+// the deepest backlog (the top service.EmbeddingTenantBacklogTopN, by the tenant_id jobs carry) and
+// hands the counts to the backlog, which demotes those over the threshold, and to the per-tenant
+// gauge. Only the top tenants are reported, so the gauge's tenant label stays bounded. Either
+// backlog or eventMetrics may be nil.
+func runEmbeddingTenantDepthPoller(
+	ctx context.Context, db *pgxpool.Pool, backlog *service.EmbeddingTenantBacklog, eventMetrics observability.EventMetrics,
+) {
+	ticker := time.NewTicker(riverQueueDepthInterval)
+	defer ticker.Stop()
+
+	update := func() {
+		rows, err := db.Query(ctx,
+			`SELECT args->>'tenant_id', COUNT(*) FROM river_job
+			 WHERE queue = $1 AND state IN ($2, $3, $4) AND args->>'tenant_id' <> ''
+			 GROUP BY 1 ORDER BY 2 DESC LIMIT $5`,
+			service.EmbeddingsQueueName,
+			rivertype.JobStateAvailable, rivertype.JobStateRetryable, rivertype.JobStateScheduled,
+			service.EmbeddingTenantBacklogTopN,
+		)
+		if err != nil {
+			slog.WarnContext(ctx, "embedding tenant depth poll failed", "error", err)
+
+			return
+		}
+		defer rows.Close()
+
+		depths := make(map[string]int, service.EmbeddingTenantBacklogTopN)
+
+		for rows.Next() {
+			var (
+				tenantID string
+				count    int
+			)
+			if err := rows.Scan(&tenantID, &count); err != nil {
+				slog.WarnContext(ctx, "embedding tenant depth scan failed", "error", err)
+
+				return
+			}
+
+			depths[tenantID] = count
+		}
+
+		if err := rows.Err(); err != nil {
+			slog.WarnContext(ctx, "embedding tenant depth poll failed", "error", err)
+
+			return
+		}
+
+		if backlog != nil {
+			backlog.Update(depths)
+		}
+
+		if eventMetrics != nil {
+			eventMetrics.SetEmbeddingTenantDepths(depths)
 		}
 	}
 
@@ -16,7 +16,9 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/formbricks/hub/internal/api/handlers"
+	"github.com/formbricks/hub/internal/api/middleware"
 	"github.com/formbricks/hub/internal/config"
+	"github.com/formbricks/hub/internal/models"
 	"github.com/formbricks/hub/internal/service"
 )
 
@@ -120,6 +122,8 @@ func TestSetupEmbeddingSearchHandler(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 		river.NewWorkers(),
 	)
 	if err != nil {
@@ -143,6 +147,8 @@ func TestSetupEmbeddingSearchHandlerValidatesConfig(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 		river.NewWorkers(),
 	)
 	if !errors.Is(err, service.ErrEmbeddingProviderAPIKey) {
@@ -150,6 +156,27 @@ func TestSetupEmbeddingSearchHandlerValidatesConfig(t *testing.T) {
 	}
 }
 
+func TestNewAppFailsWhenEmbeddingRequiredButDisabled(t *testing.T) {
+	cfg := &config.Config{Embedding: config.EmbeddingConfig{Required: true, Provider: "unsupported", Model: "m"}}
+
+	// Rejected before anything is started or touches the (nil) database.
+	_, err := NewApp(cfg, nil)
+	if !errors.Is(err, errEmbeddingRequired) {
+		t.Fatalf("NewApp() error = %v, want %v", err, errEmbeddingRequired)
+	}
+}
+
+func TestNewAppFailsWhenEmbeddingModelNotAllowed(t *testing.T) {
+	cfg := &config.Config{Embedding: config.EmbeddingConfig{
+		Provider: "openai", Model: "text-embedding-ada-002", AllowedModels: config.ModelList{"text-embedding-3-small"},
+	}}
+
+	_, err := NewApp(cfg, nil)
+	if !errors.Is(err, config.ErrEmbeddingModelNotAllowed) {
+		t.Fatalf("NewApp() error = %v, want %v", err, config.ErrEmbeddingModelNotAllowed)
+	}
+}
+
 func TestShutdownObservabilityWithNilProviders(t *testing.T) {
 	if err := shutdownObservability(context.Background(), nil, nil); err != nil {
 		t.Fatalf("shutdownObservability() error = %v, want nil", err)
@@ -260,6 +287,108 @@ func TestNewHTTPServerKeepsTenantDataRoutesProtected(t *testing.T) {
 	}
 }
 
+type stubAdminService struct{}
+
+func (stubAdminService) ReembedFeedbackRecords(context.Context, *models.ReembedRequest) (int, error) {
+	return 3, nil
+}
+
+func TestNewHTTPServerAdminRouteRequiresAdminKey(t *testing.T) {
+	server := newTestHTTPServer(t)
+
+	tests := []struct {
+		name       string
+		authHeader string
+		wantStatus int
+	}{
+		{name: "missing auth", wantStatus: http.StatusUnauthorized},
+		{name: "public API key rejected", authHeader: "Bearer test-api-key", wantStatus: http.StatusUnauthorized},
+		{name: "admin key accepted", authHeader: "Bearer test-admin-key", wantStatus: http.StatusAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			request := httptest.NewRequestWithContext(
+				context.Background(), http.MethodPost, "/v1/admin/reembed", strings.NewReader(`{"tenant_id":"org-1"}`))
+			if tt.authHeader != "" {
+				request.Header.Set("Authorization", tt.authHeader)
+			}
+
+			server.Handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("POST /v1/admin/reembed status = %d, want %d; body=%s",
+					recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+		})
+	}
+}
+
+// TestNewHTTPServerRejectsOversizedBody checks that MAX_REQUEST_BODY_BYTES applies to /v1 routes
+// that have no cap of their own, behind auth.
+func TestNewHTTPServerRejectsOversizedBody(t *testing.T) {
+	server := newTestHTTPServer(t)
+	body := `{"query":"` + strings.Repeat("a", 1<<20) + `"}`
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequestWithContext(
+		context.Background(), http.MethodPost, "/v1/feedback-records/search/semantic", strings.NewReader(body))
+	request.Header.Set("Authorization", "Bearer test-api-key")
+	server.Handler.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("POST /v1/feedback-records/search/semantic status = %d, want 413; body=%s",
+			recorder.Code, recorder.Body.String())
+	}
+}
+
+// TestNewHTTPServerMaintenanceModeRejectsWrites flips maintenance mode through the admin API and
+// checks that /v1 writes get 503 with Retry-After while reads and the switch itself still work.
+func TestNewHTTPServerMaintenanceModeRejectsWrites(t *testing.T) {
+	server := newTestHTTPServer(t)
+
+	serve := func(method, path, key, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequestWithContext(context.Background(), method, path, strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer "+key)
+		server.Handler.ServeHTTP(recorder, request)
+
+		return recorder
+	}
+
+	if rec := serve(http.MethodPut, "/v1/admin/maintenance", "test-admin-key", `{"enabled":true}`); rec.Code != http.StatusOK {
+		t.Fatalf("PUT /v1/admin/maintenance status = %d, want 200; body=%s", rec.Code, rec.Body.String())
+	}
+
+	for _, write := range []struct{ method, path string }{
+		{http.MethodPost, "/v1/feedback-records"},
+		{http.MethodDelete, "/v1/tenants/test-tenant-id/data"},
+	} {
+		rec := serve(write.method, write.path, "test-api-key", `{}`)
+		if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") != "30" {
+			t.Fatalf("%s %s status = %d, Retry-After = %q; want 503 and 30", write.method, write.path,
+				rec.Code, rec.Header().Get("Retry-After"))
+		}
+	}
+
+	if rec := serve(http.MethodPost, "/v1/admin/reembed", "test-admin-key", `{"tenant_id":"org-1"}`); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("POST /v1/admin/reembed status = %d, want 503", rec.Code)
+	}
+
+	rec := serve(http.MethodGet, "/v1/admin/maintenance", "test-admin-key", "")
+	if !strings.Contains(rec.Body.String(), `"enabled":true`) {
+		t.Fatalf("GET /v1/admin/maintenance body = %s, want enabled", rec.Body.String())
+	}
+
+	serve(http.MethodPut, "/v1/admin/maintenance", "test-admin-key", `{"enabled":false}`)
+
+	if rec := serve(http.MethodPost, "/v1/admin/reembed", "test-admin-key", `{"tenant_id":"org-1"}`); rec.Code != http.StatusAccepted {
+		t.Fatalf("POST /v1/admin/reembed after disabling status = %d, want 202", rec.Code)
+	}
+}
+
 func TestNewHTTPServerInternalTaxonomyRouteRequiresInternalToken(t *testing.T) {
 	server := newTestHTTPServer(t)
 
@@ -363,11 +492,15 @@ func newTestHTTPServerWithConfig(t *testing.T, publicBaseURL string, taxonomy co
 
 	cfg := &config.Config{
 		Server: config.ServerConfig{
-			Port:      "0",
-			HubAPIKey: "test-api-key",
+			Port:                  "0",
+			HubAPIKey:             "test-api-key",
+			AdminAPIKey:           "test-admin-key",
+			MaintenanceRetryAfter: config.DurationSec(30 * time.Second),
+			MaxRequestBodyBytes:   1 << 20,
 		},
 		Taxonomy: taxonomy,
 	}
+	maintenance := middleware.NewMaintenanceMode(false)
 
 	return newHTTPServer(
 		cfg,
@@ -375,11 +508,17 @@ func newTestHTTPServerWithConfig(t *testing.T, publicBaseURL string, taxonomy co
 		newTestOpenAPIHandler(t, publicBaseURL),
 		handlers.NewFeedbackRecordsHandler(nil),
 		handlers.NewWebhooksHandler(nil),
+		handlers.NewWebhookDeadLettersHandler(nil),
+		handlers.NewWebhookDeliveriesHandler(nil),
 		handlers.NewTenantDataHandler(nil),
+		handlers.NewSubjectErasureHandler(nil),
 		handlers.NewTenantSettingsHandler(nil),
 		handlers.NewSearchHandler(nil),
+		handlers.NewAdminHandler(stubAdminService{}, maintenance),
+		handlers.NewExportsHandler(nil),
 		handlers.NewTaxonomyHandler(nil),
 		handlers.NewTaxonomyInternalHandler(),
+		maintenance,
 		nil,
 		nil,
 	)
@@ -5,14 +5,21 @@
 // stale outputs), and from tenants that edited records while their enrichment toggle was off
 // (re-enabling does not re-enrich by itself). Run this one-off to recover any of those; hub-worker
 // (or the API process) runs the enqueued jobs. Select the type with -type sentiment|emotions.
+//
+// -concurrency and -rate throttle the job inserts against a production database, and progress
+// (throughput, ETA) is logged after every page. SIGINT/SIGTERM stops the run after the page in
+// flight has been enqueued; a second signal exits immediately.
 package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/google/uuid"
 	"github.com/riverqueue/river"
@@ -43,6 +50,8 @@ func main() {
 
 func run() int {
 	enrichType := flag.String("type", "", "enrichment type to backfill: sentiment | emotions")
+	concurrency := flag.Int("concurrency", 1, "job inserts to run at once (keep below DATABASE_MAX_CONNS)")
+	rate := flag.Float64("rate", 0, "max job inserts per second across all workers (0 = unlimited)")
 
 	flag.Parse()
 
@@ -52,6 +61,13 @@ func run() int {
 		return exitFailure
 	}
 
+	if *concurrency < 1 || *rate < 0 {
+		slog.Error("invalid pacing; -concurrency must be at least 1 and -rate not negative",
+			"concurrency", *concurrency, "rate", *rate)
+
+		return exitFailure
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		slog.Error("Failed to load configuration", "error", err)
@@ -65,9 +81,14 @@ func run() int {
 		return exitFailure
 	}
 
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// Once the first signal has asked for a graceful stop, restore the default handling so a
+	// second one kills the process.
+	context.AfterFunc(ctx, stop)
 
-	db, err := database.NewPostgresPool(ctx, cfg.Database.URL, database.WithPoolConfig(cfg.Database.PoolConfig()))
+	db, err := database.NewPostgresPoolWithRetry(ctx, cfg.Database.URL, cfg.Database.ConnectRetry(),
+		database.WithPoolConfig(cfg.Database.PoolConfig()))
 	if err != nil {
 		slog.Error("Failed to connect to database", "error", err)
 
@@ -87,9 +108,10 @@ func run() int {
 	riverWorkers := river.NewWorkers()
 
 	var (
-		queueName   string
-		maxAttempts int
-		runBackfill classifyBackfillFunc
+		queueName    string
+		maxAttempts  int
+		runBackfill  classifyBackfillFunc
+		countTargets func(ctx context.Context) (int, error)
 	)
 
 	switch *enrichType {
@@ -119,6 +141,7 @@ func run() int {
 		queueName = service.SentimentsQueueName
 		maxAttempts = classifyMaxAttempts(cfg.Sentiment.MaxAttempts)
 		runBackfill = feedbackRecordsService.BackfillSentiment
+		countTargets = repo.CountSentimentBackfillTargets
 	case "emotions":
 		if cfg.Emotions.Provider == "" || cfg.Emotions.Model == "" {
 			slog.Error("emotions is not configured (EMOTIONS_PROVIDER and EMOTIONS_MODEL required)")
@@ -145,6 +168,7 @@ func run() int {
 		queueName = service.EmotionsQueueName
 		maxAttempts = classifyMaxAttempts(cfg.Emotions.MaxAttempts)
 		runBackfill = feedbackRecordsService.BackfillEmotions
+		countTargets = repo.CountEmotionsBackfillTargets
 	}
 
 	riverClient, err := river.NewClient(riverpgxv5.New(db), &river.Config{
@@ -161,7 +185,27 @@ func run() int {
 	// previous run's completed jobs (River's unique states include completed).
 	runID := uuid.NewString()
 
+	// The up-front count only feeds the ETA, so a failure costs the estimate, not the run.
+	total, err := countTargets(ctx)
+	if err != nil {
+		slog.Warn("Failed to count backfill targets; progress will have no ETA", "type", *enrichType, "error", err)
+	}
+
+	feedbackRecordsService.SetBackfillPacing(service.BackfillPacing{
+		Concurrency: *concurrency,
+		Rate:        *rate,
+		Total:       total,
+		Progress:    service.LogBackfillProgress,
+	})
+
 	enqueued, err := runBackfill(ctx, riverClient, queueName, maxAttempts, runID)
+	if errors.Is(err, service.ErrBackfillInterrupted) {
+		slog.Warn("Backfill interrupted after finishing the page in flight", "type", *enrichType, "enqueued", enqueued)
+		fmt.Printf("Interrupted: enqueued %d %s job(s); re-run to continue.\n", enqueued, *enrichType)
+
+		return exitFailure
+	}
+
 	if err != nil {
 		slog.Error("Backfill failed", "type", *enrichType, "error", err)
 
@@ -5,6 +5,15 @@
 // With -prune-stale-models it instead deletes embedding rows left behind by previous
 // EMBEDDING_MODEL values. Run the prune only AFTER a model migration's backfill has
 // completed (stale rows are invisible to reads but bloat the shared HNSW index).
+//
+// With -secondary it backfills EMBEDDING_SECONDARY_MODEL instead, so an A/B comparison covers
+// records created before the secondary model was configured.
+//
+// -concurrency and -rate throttle the job inserts against a production database, and progress
+// (throughput, ETA) is logged after every page. -batch-size n enqueues one job per n records, each
+// embedding its records with one provider call; the counts are then of batch jobs, not records.
+// SIGINT/SIGTERM stops the run after the page in flight has been enqueued; a second signal exits
+// immediately.
 package main
 
 import (
@@ -14,6 +23,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	pgxvec "github.com/pgvector/pgvector-go/pgx"
 	"github.com/riverqueue/river"
@@ -28,8 +39,9 @@ import (
 )
 
 var (
-	errEmbeddingProviderRequired = errors.New("EMBEDDING_PROVIDER is required")
-	errEmbeddingModelRequired    = errors.New("EMBEDDING_MODEL is required")
+	errEmbeddingProviderRequired       = errors.New("EMBEDDING_PROVIDER is required")
+	errEmbeddingModelRequired          = errors.New("EMBEDDING_MODEL is required")
+	errEmbeddingSecondaryModelRequired = errors.New("-secondary requires EMBEDDING_SECONDARY_MODEL")
 )
 
 const (
@@ -51,9 +63,28 @@ func run() int {
 			"(run only after a model migration's backfill has completed)")
 	taxonomyMode := flag.Bool("taxonomy", false,
 		"backfill taxonomy embeddings from translated text using TAXONOMY_EMBEDDING_MODEL or taxonomy:<EMBEDDING_MODEL>:translated-v1")
+	secondaryMode := flag.Bool("secondary", false, "backfill raw embeddings for EMBEDDING_SECONDARY_MODEL")
+	concurrency := flag.Int("concurrency", 1, "job inserts to run at once (keep below DATABASE_MAX_CONNS)")
+	rate := flag.Float64("rate", 0, "max job inserts per second across all workers (0 = unlimited)")
+	batchSize := flag.Int("batch-size", 1,
+		fmt.Sprintf("records per embedding job, embedded with one provider call (1 = one job per record, max %d)",
+			service.MaxEmbeddingBatchSize))
 
 	flag.Parse()
 
+	if *concurrency < 1 || *rate < 0 {
+		slog.Error("invalid pacing; -concurrency must be at least 1 and -rate not negative",
+			"concurrency", *concurrency, "rate", *rate)
+
+		return exitFailure
+	}
+
+	if *batchSize < 1 || *batchSize > service.MaxEmbeddingBatchSize {
+		slog.Error("invalid -batch-size", "batch_size", *batchSize, "max", service.MaxEmbeddingBatchSize)
+
+		return exitFailure
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		slog.Error("Failed to load configuration", "error", err)
@@ -72,9 +103,13 @@ func run() int {
 		maxAttempts = defaultEmbeddingMaxAttempts
 	}
 
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// Once the first signal has asked for a graceful stop, restore the default handling so a
+	// second one kills the process.
+	context.AfterFunc(ctx, stop)
 
-	db, err := database.NewPostgresPool(ctx, cfg.Database.URL,
+	db, err := database.NewPostgresPoolWithRetry(ctx, cfg.Database.URL, cfg.Database.ConnectRetry(),
 		database.WithPoolConfig(cfg.Database.PoolConfig()),
 		database.WithAfterConnect(pgxvec.RegisterTypes),
 	)
@@ -119,32 +154,50 @@ func run() int {
 	targetModel := embeddingModelForDB
 	inputKind := models.EmbeddingInputKindRaw
 
-	if *taxonomyMode {
+	switch {
+	case *taxonomyMode && *secondaryMode:
+		slog.Error("-taxonomy cannot be combined with -secondary")
+
+		return exitFailure
+	case *taxonomyMode:
 		targetModel = taxonomyEmbeddingModel
 		inputKind = models.EmbeddingInputKindTaxonomyTranslated
+	case *secondaryMode:
+		if cfg.Embedding.SecondaryModel == "" {
+			slog.Error(errEmbeddingSecondaryModelRequired.Error())
+
+			return exitFailure
+		}
+
+		targetModel = cfg.Embedding.SecondaryModel
 	}
 
 	repo := repository.NewFeedbackRecordsRepository(db)
 	embeddingsRepo := repository.NewEmbeddingsRepository(db)
 
 	if *pruneStaleModels {
-		if *taxonomyMode {
-			slog.Error("-taxonomy cannot be combined with -prune-stale-models")
+		if *taxonomyMode || *secondaryMode {
+			slog.Error("-taxonomy and -secondary cannot be combined with -prune-stale-models")
 
 			return exitFailure
 		}
 
+		// The secondary model's rows are current too: pruning them would end an A/B run.
+		keptModels := []string{embeddingModelForDB, taxonomyEmbeddingModel}
+		if cfg.Embedding.SecondaryModel != "" {
+			keptModels = append(keptModels, cfg.Embedding.SecondaryModel)
+		}
+
 		deleted, pruneErr := embeddingsRepo.DeleteEmbeddingsForOtherModels(
-			ctx, embeddingModelForDB, pruneBatchSize, taxonomyEmbeddingModel)
+			ctx, embeddingModelForDB, pruneBatchSize, keptModels[1:]...)
 		if pruneErr != nil {
 			slog.Error("Prune failed", "error", pruneErr, "deleted_before_failure", deleted)
 
 			return exitFailure
 		}
 
-		slog.Info("Prune complete", "deleted", deleted, "kept_models", []string{embeddingModelForDB, taxonomyEmbeddingModel})
-		fmt.Printf("Deleted %d stale-model embedding row(s); kept models %q and %q.\n",
-			deleted, embeddingModelForDB, taxonomyEmbeddingModel)
+		slog.Info("Prune complete", "deleted", deleted, "kept_models", keptModels)
+		fmt.Printf("Deleted %d stale-model embedding row(s); kept models %q.\n", deleted, keptModels)
 
 		return exitSuccess
 	}
@@ -159,6 +212,8 @@ func run() int {
 		maxAttempts,
 		"", // translation default unused: embeddings backfill only
 	)
+	feedbackRecordsService.SetEmbeddingBulkPriority(cfg.Embedding.BulkJobPriority)
+	feedbackRecordsService.SetEmbeddingBatchSize(*batchSize)
 
 	embeddingClient, err := service.NewEmbeddingClient(ctx, embeddingCfg)
 	if err != nil {
@@ -167,10 +222,16 @@ func run() int {
 		return exitFailure
 	}
 
+	embeddingClient = service.WithEmbeddingRetry(embeddingClient, service.EmbeddingRetryConfig{
+		AttemptTimeout: cfg.Embedding.HTTPTimeout.Duration(),
+		MaxRetries:     cfg.Embedding.HTTPMaxRetries,
+	})
+
 	docPrefix := service.EmbeddingPrefixForProvider(providerCanonical)
 	embeddingWorker := workers.NewFeedbackEmbeddingWorker(feedbackRecordsService, embeddingClient, docPrefix, nil)
 	riverWorkers := river.NewWorkers()
 	river.AddWorker(riverWorkers, embeddingWorker)
+	river.AddWorker(riverWorkers, workers.NewFeedbackEmbeddingBatchWorker(embeddingWorker))
 
 	// Producer-only: we only enqueue jobs; workers run in hub-worker. River requires the job kind
 	// to be registered (worker added above) and MaxWorkers > 0 when a queue is declared.
@@ -188,7 +249,27 @@ func run() int {
 
 	feedbackRecordsService.SetEmbeddingInserter(riverClient)
 
+	// The up-front count only feeds the ETA, so a failure costs the estimate, not the run.
+	total, err := embeddingsRepo.CountFeedbackRecordsForBackfillByInputKind(ctx, targetModel, inputKind)
+	if err != nil {
+		slog.Warn("Failed to count backfill targets; progress will have no ETA", "error", err)
+	}
+
+	feedbackRecordsService.SetBackfillPacing(service.BackfillPacing{
+		Concurrency: *concurrency,
+		Rate:        *rate,
+		Total:       total,
+		Progress:    service.LogBackfillProgress,
+	})
+
 	enqueued, err := feedbackRecordsService.BackfillEmbeddingsWithInputKind(ctx, targetModel, inputKind)
+	if errors.Is(err, service.ErrBackfillInterrupted) {
+		slog.Warn("Backfill interrupted after finishing the page in flight", "enqueued", enqueued)
+		fmt.Printf("Interrupted: enqueued %d embedding job(s) for model %q; re-run to continue.\n", enqueued, targetModel)
+
+		return exitFailure
+	}
+
 	if err != nil {
 		slog.Error("Backfill failed", "error", err)
 
@@ -2,14 +2,21 @@
 // tenant has a target language configured and whose value_text is not yet translated
 // to it (missing or stale). Run this one-off after enabling translation or changing a
 // tenant's target language; hub-worker (or the API process) runs the jobs.
+//
+// -concurrency and -rate throttle the job inserts against a production database, and progress
+// (throughput, ETA) is logged after every page. SIGINT/SIGTERM stops the run after the page in
+// flight has been enqueued; a second signal exits immediately.
 package main
 
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/google/uuid"
 	"github.com/riverqueue/river"
@@ -38,6 +45,18 @@ func main() {
 }
 
 func run() int {
+	concurrency := flag.Int("concurrency", 1, "job inserts to run at once (keep below DATABASE_MAX_CONNS)")
+	rate := flag.Float64("rate", 0, "max job inserts per second across all workers (0 = unlimited)")
+
+	flag.Parse()
+
+	if *concurrency < 1 || *rate < 0 {
+		slog.Error("invalid pacing; -concurrency must be at least 1 and -rate not negative",
+			"concurrency", *concurrency, "rate", *rate)
+
+		return exitFailure
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		slog.Error("Failed to load configuration", "error", err)
@@ -68,9 +87,13 @@ func run() int {
 		maxAttempts = defaultTranslationMaxAttempts
 	}
 
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// Once the first signal has asked for a graceful stop, restore the default handling so a
+	// second one kills the process.
+	context.AfterFunc(ctx, stop)
 
-	db, err := database.NewPostgresPool(ctx, cfg.Database.URL,
+	db, err := database.NewPostgresPoolWithRetry(ctx, cfg.Database.URL, cfg.Database.ConnectRetry(),
 		database.WithPoolConfig(cfg.Database.PoolConfig()),
 	)
 	if err != nil {
@@ -120,8 +143,28 @@ func run() int {
 	// previous run's completed jobs (River's unique states include completed).
 	runID := uuid.NewString()
 
+	// The up-front count only feeds the ETA, so a failure costs the estimate, not the run.
+	total, err := repo.CountTranslationBackfillTargets(ctx, cfg.Translation.DefaultLanguage)
+	if err != nil {
+		slog.Warn("Failed to count backfill targets; progress will have no ETA", "error", err)
+	}
+
+	feedbackRecordsService.SetBackfillPacing(service.BackfillPacing{
+		Concurrency: *concurrency,
+		Rate:        *rate,
+		Total:       total,
+		Progress:    service.LogBackfillProgress,
+	})
+
 	enqueued, err := feedbackRecordsService.BackfillTranslations(
 		ctx, riverClient, service.TranslationsQueueName, maxAttempts, runID)
+	if errors.Is(err, service.ErrBackfillInterrupted) {
+		slog.Warn("Backfill interrupted after finishing the page in flight", "enqueued", enqueued)
+		fmt.Printf("Interrupted: enqueued %d translation job(s); re-run to continue.\n", enqueued)
+
+		return exitFailure
+	}
+
 	if err != nil {
 		slog.Error("Backfill failed", "error", err)
 
@@ -0,0 +1,103 @@
+// fix-topic-levels verifies that every taxonomy (topic) node's level matches its depth in the
+// tree — the root is level 0, its children level 1, and so on — and repairs the ones that do not.
+// Levels drift only through writes that bypass the API's move endpoint (bulk imports, direct SQL),
+// and a drifted level misapplies everything keyed by it (e.g. the move endpoint's depth limit).
+// Run it with -dry-run first to see what would change; without -dry-run each affected run is
+// repaired in its own short transaction and the number of fixed nodes reported.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/formbricks/hub/internal/config"
+	"github.com/formbricks/hub/internal/repository"
+	"github.com/formbricks/hub/pkg/database"
+)
+
+const (
+	exitSuccess = 0
+	exitFailure = 1
+)
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	dryRun := flag.Bool("dry-run", false, "report drifted levels without changing them")
+
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+
+		return exitFailure
+	}
+
+	if cfg.Database.URL == "" || cfg.Database.URL == config.DefaultDatabaseURL {
+		slog.Error("DATABASE_URL must be set explicitly for this binary (do not use the default test URL)")
+
+		return exitFailure
+	}
+
+	ctx := context.Background()
+
+	db, err := database.NewPostgresPoolWithRetry(ctx, cfg.Database.URL, cfg.Database.ConnectRetry(),
+		database.WithPoolConfig(cfg.Database.PoolConfig()),
+	)
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+
+		return exitFailure
+	}
+	defer db.Close()
+
+	repo := repository.NewTaxonomyRepository(db)
+
+	drift, err := repo.ListNodeLevelDrift(ctx)
+	if err != nil {
+		slog.Error("Failed to verify topic levels", "error", err)
+
+		return exitFailure
+	}
+
+	drifted := 0
+	for _, run := range drift {
+		drifted += run.DriftedNodes
+
+		slog.Info("Topic levels drifted", "run_id", run.RunID, "tenant_id", run.TenantID, "nodes", run.DriftedNodes)
+	}
+
+	if *dryRun {
+		fmt.Printf("Dry run: %d topic node(s) in %d run(s) have a level that does not match their depth.\n",
+			drifted, len(drift))
+
+		return exitSuccess
+	}
+
+	// Runs are repaired one at a time; a failure stops the command, and a re-run picks up the
+	// remaining runs (repaired ones no longer drift).
+	var fixed int64
+
+	for _, run := range drift {
+		n, err := repo.RepairNodeLevels(ctx, run.RunID, run.TenantID)
+		if err != nil {
+			slog.Error("Failed to repair topic levels", "run_id", run.RunID, "fixed_so_far", fixed, "error", err)
+
+			return exitFailure
+		}
+
+		fixed += n
+	}
+
+	slog.Info("Topic level repair complete", "runs", len(drift), "fixed", fixed)
+
+	fmt.Printf("Fixed %d topic node level(s) in %d run(s).\n", fixed, len(drift))
+
+	return exitSuccess
+}
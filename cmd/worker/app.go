@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -29,8 +30,22 @@ type WorkerApp struct {
 	tracerProvider *sdktrace.TracerProvider
 }
 
+// errEmbeddingRequired is returned when EMBEDDING_REQUIRED is set but embeddings are disabled.
+var errEmbeddingRequired = errors.New("EMBEDDING_REQUIRED is set but embeddings are not configured")
+
 // NewWorkerApp builds the River client with all workers and returns an app that runs only River.
 func NewWorkerApp(cfg *config.Config, db *pgxpool.Pool) (*WorkerApp, error) {
+	// Strict mode (see the API's NewApp): refuse to start with embeddings silently disabled.
+	if cfg.Embedding.Required {
+		if provider, _ := embeddingProviderAndModel(cfg); provider == "" {
+			return nil, fmt.Errorf("%w: set a supported EMBEDDING_PROVIDER and EMBEDDING_MODEL", errEmbeddingRequired)
+		}
+	}
+
+	if err := cfg.Embedding.CheckAllowedModels(); err != nil {
+		return nil, fmt.Errorf("embedding model allowlist: %w", err)
+	}
+
 	var (
 		metrics        *observability.Metrics
 		meterProvider  *sdkmetric.MeterProvider
@@ -85,12 +100,19 @@ func NewWorkerApp(cfg *config.Config, db *pgxpool.Pool) (*WorkerApp, error) {
 
 	webhookSender := service.NewWebhookSenderImpl(
 		webhooksRepo, webhookMetrics, cfg.Webhook.URLBlacklist, cfg.Webhook.HTTPTimeout.Duration(), nil)
+	webhookSender.SetMaxConcurrentPerHost(cfg.Webhook.MaxConcurrentPerHost)
+	webhookSender.SetUserAgent(cfg.Webhook.UserAgent)
 
 	deps := workers.RiverDeps{
 		WebhooksRepo:       webhooksRepo,
 		WebhookSender:      webhookSender,
 		WebhookHTTPTimeout: cfg.Webhook.HTTPTimeout.Duration(),
 		WebhookMetrics:     webhookMetrics,
+		// Expired idempotency keys and soft-deleted records past retention are swept by periodic
+		// jobs this process schedules.
+		IdempotencyKeysRepo:     repository.NewIdempotencyKeysRepository(db),
+		FeedbackRecordPurgeRepo: repository.NewFeedbackRecordsRepository(db),
+		FeedbackRetention:       cfg.FeedbackRecords.RetentionPeriod(),
 	}
 
 	providerName, embeddingModel := embeddingProviderAndModel(cfg)
@@ -120,6 +142,13 @@ func NewWorkerApp(cfg *config.Config, db *pgxpool.Pool) (*WorkerApp, error) {
 			return nil, fmt.Errorf("embedding config: %w", err)
 		}
 
+		if err := service.CheckEmbeddingColumnDimensions(
+			context.Background(), repository.NewEmbeddingsRepository(db)); err != nil {
+			shutdownObservability(context.Background(), meterProvider, tracerProvider)
+
+			return nil, err
+		}
+
 		embeddingClient, err := service.NewEmbeddingClient(context.Background(), embeddingCfg)
 		if err != nil {
 			shutdownObservability(context.Background(), meterProvider, tracerProvider)
@@ -127,6 +156,33 @@ func NewWorkerApp(cfg *config.Config, db *pgxpool.Pool) (*WorkerApp, error) {
 			return nil, fmt.Errorf("create embedding client: %w", err)
 		}
 
+		retryCfg := service.EmbeddingRetryConfig{
+			AttemptTimeout: cfg.Embedding.HTTPTimeout.Duration(),
+			MaxRetries:     cfg.Embedding.HTTPMaxRetries,
+			Metrics:        embeddingMetrics,
+		}
+		embeddingClient = service.WithEmbeddingRetry(embeddingClient, retryCfg)
+
+		if cfg.Embedding.StartupProbe {
+			err := service.RunEmbeddingStartupProbe(
+				context.Background(), embeddingClient, providerName, embeddingModel, cfg.Embedding.Required)
+			if err != nil {
+				shutdownObservability(context.Background(), meterProvider, tracerProvider)
+
+				return nil, fmt.Errorf("%w (EMBEDDING_REQUIRED is set)", err)
+			}
+		}
+
+		if cfg.Embedding.SecondaryModel != "" {
+			deps.EmbeddingSecondary, err = service.NewSecondaryEmbeddingModel(
+				context.Background(), embeddingCfg, cfg.Embedding.SecondaryModel, retryCfg)
+			if err != nil {
+				shutdownObservability(context.Background(), meterProvider, tracerProvider)
+
+				return nil, err
+			}
+		}
+
 		feedbackRecordsRepo := repository.NewFeedbackRecordsRepository(db)
 		embeddingsRepo := repository.NewEmbeddingsRepository(db)
 		feedbackRecordsService := service.NewFeedbackRecordsService(
@@ -253,11 +309,17 @@ func NewWorkerApp(cfg *config.Config, db *pgxpool.Pool) (*WorkerApp, error) {
 		deps.EmotionsMetrics = emotionsMetrics
 	}
 
+	if cfg.Export.Dir != "" {
+		deps.ExportService = service.NewExportService(repository.NewExportJobsRepository(db),
+			repository.NewFeedbackRecordsRepository(db), cfg.Export.Dir, cfg.Export.MaxAttempts)
+	}
+
 	riverWorkers, queues := workers.NewRiverWorkersAndQueues(cfg, deps, 0)
 
 	riverCfg := &river.Config{
-		Queues:  queues,
-		Workers: riverWorkers,
+		Queues:       queues,
+		Workers:      riverWorkers,
+		PeriodicJobs: workers.NewPeriodicJobs(deps),
 	}
 	if cfg.River.JobTimeoutSec.Duration() > 0 {
 		riverCfg.JobTimeout = cfg.River.JobTimeoutSec.Duration()
@@ -12,6 +12,7 @@ import (
 	pgxvec "github.com/pgvector/pgvector-go/pgx"
 
 	"github.com/formbricks/hub/internal/config"
+	"github.com/formbricks/hub/internal/observability"
 	"github.com/formbricks/hub/pkg/database"
 )
 
@@ -32,6 +33,8 @@ func run() int {
 		return exitFailure
 	}
 
+	setupLogging(cfg.Server.LogLevel, cfg.Server.LogFormat)
+
 	if cfg.Database.URL == "" || cfg.Database.URL == config.DefaultDatabaseURL {
 		slog.Error("DATABASE_URL must be set explicitly for hub-worker (do not use the default test URL)")
 
@@ -40,7 +43,7 @@ func run() int {
 
 	ctx := context.Background()
 
-	db, err := database.NewPostgresPool(ctx, cfg.Database.URL,
+	db, err := database.NewPostgresPoolWithRetry(ctx, cfg.Database.URL, cfg.Database.ConnectRetry(),
 		database.WithPoolConfig(cfg.Database.PoolConfig()),
 		database.WithAfterConnect(pgxvec.RegisterTypes),
 	)
@@ -87,3 +90,10 @@ func run() int {
 
 	return exitSuccess
 }
+
+// setupLogging applies LOG_LEVEL and LOG_FORMAT like hub-api, with trace_id/span_id added to
+// records logged inside a traced job.
+func setupLogging(level, format string) {
+	handler := observability.NewLogHandler(os.Stdout, level, format)
+	slog.SetDefault(slog.New(observability.NewTraceContextHandler(handler)))
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/formbricks/hub/internal/api/response"
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/internal/service"
+)
+
+// AdminService defines the operator-only operations behind /v1/admin/.
+type AdminService interface {
+	ReembedFeedbackRecords(ctx context.Context, req *models.ReembedRequest) (int, error)
+}
+
+// MaintenanceSwitch is the runtime maintenance-mode flag (satisfied by *middleware.MaintenanceMode).
+type MaintenanceSwitch interface {
+	Enabled() bool
+	SetEnabled(enabled bool)
+}
+
+// MaintenanceModeRequest is the body for PUT /v1/admin/maintenance.
+type MaintenanceModeRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+// MaintenanceModeResponse reports the maintenance-mode state of the process that answered.
+type MaintenanceModeResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+// AdminHandler handles operator requests under /v1/admin/. Those routes are mounted only when
+// ADMIN_API_KEY is set and authenticate with that key, never the regular API key.
+type AdminHandler struct {
+	service     AdminService
+	maintenance MaintenanceSwitch
+}
+
+// NewAdminHandler creates a new admin handler.
+func NewAdminHandler(service AdminService, maintenance MaintenanceSwitch) *AdminHandler {
+	return &AdminHandler{service: service, maintenance: maintenance}
+}
+
+// GetMaintenance handles GET /v1/admin/maintenance.
+func (h *AdminHandler) GetMaintenance(w http.ResponseWriter, _ *http.Request) {
+	response.RespondJSON(w, http.StatusOK, MaintenanceModeResponse{Enabled: h.maintenance.Enabled()})
+}
+
+// SetMaintenance handles PUT /v1/admin/maintenance. The switch is this process's only: behind a
+// load balancer each replica has to be switched (or restarted with MAINTENANCE_MODE).
+func (h *AdminHandler) SetMaintenance(w http.ResponseWriter, r *http.Request) {
+	var req MaintenanceModeRequest
+	if err := decodeAndValidateJSON(r, &req); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	h.maintenance.SetEnabled(*req.Enabled)
+
+	slog.InfoContext(r.Context(), "Maintenance mode changed", "enabled", *req.Enabled)
+
+	response.RespondJSON(w, http.StatusOK, MaintenanceModeResponse{Enabled: *req.Enabled})
+}
+
+// Reembed handles POST /v1/admin/reembed.
+func (h *AdminHandler) Reembed(w http.ResponseWriter, r *http.Request) {
+	var req models.ReembedRequest
+
+	if !decodeRecordBody(w, r, &req) {
+		return
+	}
+
+	enqueued, err := h.service.ReembedFeedbackRecords(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, service.ErrEmbeddingBackfillNotConfigured) {
+			response.RespondServiceUnavailable(w, r, "Re-embedding is not available: embeddings are not configured.")
+
+			return
+		}
+
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusAccepted, models.ReembedResponse{Enqueued: enqueued})
+}
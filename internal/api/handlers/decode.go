@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/formbricks/hub/internal/api/response"
+	"github.com/formbricks/hub/internal/api/validation"
+)
+
+// decodeJSON is the one way handlers read a JSON request body: a single value, unknown fields
+// rejected. Failures come back as a RequestJSONDecodeError, which RespondError turns into a
+// specific 400 — "request body is required" for an empty body, "malformed JSON at offset N" for a
+// syntax error, and an invalid_params entry naming the field for a wrong type or unknown field —
+// instead of one opaque "invalid body". A body cut off by http.MaxBytesReader (the global
+// MAX_REQUEST_BODY_BYTES cap, or a tighter per-handler one) becomes a 413 instead.
+func decodeJSON(r *http.Request, dst any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return fmt.Errorf("decode request JSON: %w", response.NewRequestJSONDecodeError(err))
+	}
+
+	return nil
+}
+
+// decodeAndValidateJSON is decodeJSON followed by struct validation of the decoded body.
+func decodeAndValidateJSON(r *http.Request, dst any) error {
+	if err := decodeJSON(r, dst); err != nil {
+		return err
+	}
+
+	if err := validation.ValidateStruct(dst); err != nil {
+		return fmt.Errorf("validate request body: %w", err)
+	}
+
+	return nil
+}
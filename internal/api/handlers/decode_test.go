@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/api/response"
+)
+
+// TestDecodeJSONProblemDetails locks the decode failures integrators see: each mistake gets its
+// own detail or invalid_params entry rather than a generic "invalid body".
+func TestDecodeJSONProblemDetails(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantDetail string
+		wantParam  string
+	}{
+		{name: "empty body", body: "", wantDetail: "request body is required"},
+		{name: "syntax error", body: `{"name": x}`, wantDetail: "malformed JSON at offset 10: invalid character 'x' looking for beginning of value"},
+		{name: "truncated", body: `{"name":`, wantDetail: "malformed JSON: unexpected end of input"},
+		{name: "wrong type names the field", body: `{"name": 1}`, wantParam: "name"},
+		{name: "unknown field", body: `{"nme": "x"}`, wantParam: "nme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst struct {
+				Name string `json:"name"`
+			}
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/v1/x", bytes.NewReader([]byte(tt.body)))
+			err := decodeJSON(req, &dst)
+			require.Error(t, err)
+
+			rec := httptest.NewRecorder()
+			response.RespondError(rec, req, err)
+
+			var problem response.ProblemDetails
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, http.StatusBadRequest, problem.Status)
+
+			if tt.wantDetail != "" {
+				assert.Equal(t, tt.wantDetail, problem.Detail)
+			}
+
+			if tt.wantParam != "" {
+				require.Len(t, problem.InvalidParams, 1)
+				assert.Equal(t, tt.wantParam, problem.InvalidParams[0].Name)
+			}
+		})
+	}
+}
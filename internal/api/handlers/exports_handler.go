@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/api/response"
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/internal/service"
+)
+
+// ExportsService defines the interface for asynchronous feedback export business logic.
+type ExportsService interface {
+	CreateExport(ctx context.Context, req *models.CreateExportJobRequest) (*models.ExportJob, error)
+	GetExport(ctx context.Context, id uuid.UUID) (*models.ExportJob, error)
+	ExportFile(ctx context.Context, id uuid.UUID) (*models.ExportJob, string, error)
+}
+
+// exportContentTypes maps an export format to its download Content-Type.
+var exportContentTypes = map[models.ExportFormat]string{
+	models.ExportFormatCSV:   "text/csv; charset=utf-8",
+	models.ExportFormatJSONL: "application/x-ndjson",
+}
+
+// ExportsHandler handles asynchronous feedback export requests.
+type ExportsHandler struct {
+	service ExportsService
+}
+
+// NewExportsHandler creates a new exports handler.
+func NewExportsHandler(service ExportsService) *ExportsHandler {
+	return &ExportsHandler{service: service}
+}
+
+// Create handles POST /v1/feedback-records/export. It responds 202 with the pending job; the
+// client polls GET /v1/exports/{job_id} until the job is completed (or failed).
+func (h *ExportsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateExportJobRequest
+
+	if !decodeRecordBody(w, r, &req) {
+		return
+	}
+
+	job, err := h.service.CreateExport(r.Context(), &req)
+	if err != nil {
+		respondExportError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusAccepted, withDownloadURL(job))
+}
+
+// Get handles GET /v1/exports/{job_id}.
+func (h *ExportsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseExportJobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.service.GetExport(r.Context(), id)
+	if err != nil {
+		respondExportError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusOK, withDownloadURL(job))
+}
+
+// Download handles GET /v1/exports/{job_id}/download: the completed export's file, served with
+// range support so a large download can resume.
+func (h *ExportsHandler) Download(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseExportJobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, filePath, err := h.service.ExportFile(r.Context(), id)
+	if err != nil {
+		respondExportError(w, r, err)
+
+		return
+	}
+
+	file, err := os.Open(filePath) //nolint:gosec // path is built by the service from EXPORT_DIR and a generated name
+	if err != nil {
+		slog.ErrorContext(r.Context(), "open export file failed", "export_job_id", id, "error", err)
+		response.RespondProblem(w, r, http.StatusGone, "export file is no longer available")
+
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	fileName := path.Base(filePath)
+	w.Header().Set("Content-Type", exportContentTypes[job.Format])
+	w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	w.Header().Set("Cache-Control", "private, no-store")
+	http.ServeContent(w, r, fileName, info.ModTime(), file)
+}
+
+func parseExportJobID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue("job_id"))
+	if err != nil {
+		response.RespondInvalidParams(w, r, response.InvalidParam{Name: "job_id", Reason: "must be a valid UUID"})
+
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func respondExportError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrExportNotConfigured) {
+		response.RespondServiceUnavailable(w, r, "Exports are not available: EXPORT_DIR is not configured.")
+
+		return
+	}
+
+	response.RespondError(w, r, err)
+}
+
+// withDownloadURL sets the job's download link (relative to the API base) once it is downloadable.
+func withDownloadURL(job *models.ExportJob) *models.ExportJob {
+	if job.Status == models.ExportJobStatusCompleted {
+		job.DownloadURL = "/v1/exports/" + job.ID.String() + "/download"
+	}
+
+	return job
+}
@@ -3,26 +3,50 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/formbricks/hub/internal/api/middleware"
 	"github.com/formbricks/hub/internal/api/response"
 	"github.com/formbricks/hub/internal/api/validation"
+	"github.com/formbricks/hub/internal/huberrors"
 	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/pkg/jsonpatch"
 )
 
 // FeedbackRecordsService defines the interface for feedback records business logic.
 type FeedbackRecordsService interface {
 	CreateFeedbackRecord(ctx context.Context, req *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error)
+	CreateFeedbackRecordWithSyncEmbedding(
+		ctx context.Context, req *models.CreateFeedbackRecordRequest,
+	) (*models.FeedbackRecord, error)
+	CreateFeedbackRecordWithoutEmbedding(
+		ctx context.Context, req *models.CreateFeedbackRecordRequest,
+	) (*models.FeedbackRecord, error)
+	CreateFeedbackRecordsBatch(ctx context.Context, items []models.FeedbackRecordBatchItem) error
+	CreateFeedbackRecordsBatchWithoutEmbedding(ctx context.Context, items []models.FeedbackRecordBatchItem) error
 	GetFeedbackRecord(ctx context.Context, id uuid.UUID) (*models.FeedbackRecord, error)
 	ListFeedbackRecords(ctx context.Context, filters *models.ListFeedbackRecordsFilters) (*models.ListFeedbackRecordsResponse, error)
+	StreamFeedbackRecords(
+		ctx context.Context, filters *models.ListFeedbackRecordsFilters, format models.ExportFormat, w io.Writer, pageDone func() error,
+	) (int64, error)
 	UpdateFeedbackRecord(ctx context.Context, id uuid.UUID, req *models.UpdateFeedbackRecordRequest) (*models.FeedbackRecord, error)
 	DeleteFeedbackRecord(ctx context.Context, id uuid.UUID) error
 	CountFeedbackRecords(ctx context.Context, filters *models.ListFeedbackRecordsFilters) (int, error)
+	ListFeedbackRecordTags(
+		ctx context.Context, filters *models.ListFeedbackRecordTagsFilters,
+	) (*models.ListFeedbackRecordTagsResponse, error)
+	GetFeedbackRecordStats(
+		ctx context.Context, filters *models.FeedbackRecordStatsFilters,
+	) (*models.FeedbackRecordStatsResponse, error)
 	DeleteFeedbackRecordsByUser(ctx context.Context, filters *models.DeleteFeedbackRecordsByUserFilters) (int, error)
 }
 
@@ -44,30 +68,35 @@ func NewFeedbackRecordsHandler(service FeedbackRecordsService) *FeedbackRecordsH
 // while blocking multi-megabyte abuse before it is read into memory.
 const maxFeedbackRecordBodyBytes = 512 << 10
 
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
 // decodeRecordBody bounds, decodes (rejecting unknown fields), and validates a feedback-record
 // request body. It writes the matching problem response — 413 for an oversized body, 400 for
 // malformed JSON, unknown fields, or invalid values — and returns false when it has already
 // responded, so callers just `return`. Mirrors decodeSettingsBody.
 func decodeRecordBody(w http.ResponseWriter, r *http.Request, dst any) bool {
-	r.Body = http.MaxBytesReader(w, r.Body, maxFeedbackRecordBodyBytes)
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-
-	if err := decoder.Decode(dst); err != nil {
-		var maxBytesErr *http.MaxBytesError
-		if errors.As(err, &maxBytesErr) {
-			response.RespondProblem(w, r, http.StatusRequestEntityTooLarge, "request body too large")
-
-			return false
-		}
+	if !decodeRecordJSON(w, r, dst) {
+		return false
+	}
 
-		response.RespondError(w, r, response.NewRequestJSONDecodeError(err))
+	if err := validation.ValidateStruct(dst); err != nil {
+		response.RespondError(w, r, err)
 
 		return false
 	}
 
-	if err := validation.ValidateStruct(dst); err != nil {
+	return true
+}
+
+// decodeRecordJSON is decodeRecordBody without the struct validation, for bodies that are not
+// a struct (a JSON Patch document is an array of operations).
+func decodeRecordJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, maxFeedbackRecordBodyBytes)
+
+	if err := decodeJSON(r, dst); err != nil {
 		response.RespondError(w, r, err)
 
 		return false
@@ -76,25 +105,162 @@ func decodeRecordBody(w http.ResponseWriter, r *http.Request, dst any) bool {
 	return true
 }
 
-// Create handles POST /v1/feedback-records.
+// Create handles POST /v1/feedback-records. The optional sync_embedding=true query parameter
+// computes the record's embedding before responding (falling back to async on timeout); async
+// remains the default because the inline call adds a provider round trip to the response.
+// skip_embedding=true enqueues no embedding at all, for historical imports that are embedded
+// afterwards by the embedding backfill; the two are mutually exclusive.
+//
+// An Idempotency-Key header makes a retried create safe: a repeat of the key within 24 hours
+// returns the original record with 200 (and Idempotent-Replayed: true) instead of a duplicate.
 func (h *FeedbackRecordsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	syncEmbedding, ok := parseBoolQueryParam(w, r, "sync_embedding")
+	if !ok {
+		return
+	}
+
+	skipEmbedding, ok := parseBoolQueryParam(w, r, "skip_embedding")
+	if !ok {
+		return
+	}
+
+	if syncEmbedding && skipEmbedding {
+		response.RespondInvalidParams(w, r,
+			response.InvalidParam{Name: "skip_embedding", Reason: "cannot be combined with sync_embedding=true"})
+
+		return
+	}
+
 	var req models.CreateFeedbackRecordRequest
 
 	if !decodeRecordBody(w, r, &req) {
 		return
 	}
 
-	record, err := h.service.CreateFeedbackRecord(r.Context(), &req)
+	req.IdempotencyKey = r.Header.Get(idempotencyKeyHeader)
+
+	create := h.service.CreateFeedbackRecord
+
+	switch {
+	case syncEmbedding:
+		create = h.service.CreateFeedbackRecordWithSyncEmbedding
+	case skipEmbedding:
+		create = h.service.CreateFeedbackRecordWithoutEmbedding
+	}
+
+	record, err := create(r.Context(), &req)
 	if err != nil {
 		response.RespondError(w, r, err)
 
 		return
 	}
 
+	if record.Replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+		response.RespondJSON(w, http.StatusOK, record)
+
+		return
+	}
+
 	response.RespondJSON(w, http.StatusCreated, record)
 }
 
-// Get handles GET /v1/feedback-records/{id}.
+// FeedbackRecordBatchResult is the outcome of one record of a batch create, at its index in the
+// request: the created record's id, or the problem that kept it out.
+type FeedbackRecordBatchResult struct {
+	Index int                      `json:"index"`
+	ID    *uuid.UUID               `json:"id,omitempty"`
+	Error *response.ProblemDetails `json:"error,omitempty"`
+}
+
+// FeedbackRecordsBatchResponse is the body of POST /v1/feedback-records/batch.
+type FeedbackRecordsBatchResponse struct {
+	Created int                         `json:"created"`
+	Failed  int                         `json:"failed"`
+	Results []FeedbackRecordBatchResult `json:"results"`
+}
+
+// CreateBatch handles POST /v1/feedback-records/batch. Each record is validated and inserted on its
+// own inside one transaction, so invalid or conflicting records fail alone: the response lists every
+// record's outcome by index, with 201 when all were created and 207 Multi-Status otherwise. A
+// problem with the batch as a whole (malformed JSON, too many records) is answered as usual and
+// creates nothing. The body is bounded by MAX_REQUEST_BODY_BYTES only. skip_embedding=true
+// enqueues no embeddings, as on Create, for historical imports embedded later by the backfill.
+func (h *FeedbackRecordsHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	skipEmbedding, ok := parseBoolQueryParam(w, r, "skip_embedding")
+	if !ok {
+		return
+	}
+
+	var req models.CreateFeedbackRecordsBatchRequest
+
+	if err := decodeJSON(r, &req); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	items := make([]models.FeedbackRecordBatchItem, len(req.Records))
+	for i := range req.Records {
+		items[i].Request = &req.Records[i]
+		items[i].Err = validation.ValidateStruct(&req.Records[i])
+	}
+
+	createBatch := h.service.CreateFeedbackRecordsBatch
+	if skipEmbedding {
+		createBatch = h.service.CreateFeedbackRecordsBatchWithoutEmbedding
+	}
+
+	if err := createBatch(r.Context(), items); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	resp := FeedbackRecordsBatchResponse{Results: make([]FeedbackRecordBatchResult, len(items))}
+
+	for i, item := range items {
+		resp.Results[i].Index = i
+
+		if item.Err != nil {
+			resp.Results[i].Error = response.ItemProblem(r, item.Err)
+			resp.Failed++
+
+			continue
+		}
+
+		resp.Results[i].ID = &item.Record.ID
+		resp.Created++
+	}
+
+	status := http.StatusCreated
+	if resp.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	response.RespondJSON(w, status, resp)
+}
+
+// parseBoolQueryParam reads an optional boolean query parameter (absent is false). On a value
+// strconv.ParseBool rejects it writes the validation problem and returns false.
+func parseBoolQueryParam(w http.ResponseWriter, r *http.Request, name string) (value, ok bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, true
+	}
+
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		response.RespondInvalidParams(w, r, response.InvalidParam{Name: name, Reason: "must be true or false"})
+
+		return false, false
+	}
+
+	return parsed, true
+}
+
+// Get handles GET /v1/feedback-records/{id}. It honors If-None-Match / If-Modified-Since so
+// polling clients get a bodiless 304 while the record is unchanged.
 func (h *FeedbackRecordsHandler) Get(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	if idStr == "" {
@@ -117,16 +283,34 @@ func (h *FeedbackRecordsHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.RespondJSON(w, http.StatusOK, record)
+	response.RespondJSONConditional(w, r, record, record.UpdatedAt)
 }
 
-// List handles GET /v1/feedback-records.
-func (h *FeedbackRecordsHandler) List(w http.ResponseWriter, r *http.Request) {
-	filters := &models.ListFeedbackRecordsFilters{}
-
+// decodeListFilters decodes and validates the list filters shared by list, count and export, and
+// rejects include_deleted=true with 403 unless the request came in under /v1/admin/: soft-deleted
+// records are an operator's recovery tool, not something every API key holder may read. It writes
+// the problem response and returns false when it has already responded.
+func decodeListFilters(w http.ResponseWriter, r *http.Request, filters *models.ListFeedbackRecordsFilters) bool {
 	if err := validation.ValidateAndDecodeQueryParams(r, filters); err != nil {
 		response.RespondError(w, r, err)
 
+		return false
+	}
+
+	if filters.IncludeDeleted && !middleware.IsAdminRequest(r.Context()) {
+		response.RespondProblem(w, r, http.StatusForbidden,
+			"include_deleted requires the admin API key; use the /v1/admin/feedback-records routes")
+
+		return false
+	}
+
+	return true
+}
+
+// List handles GET /v1/feedback-records and GET /v1/admin/feedback-records.
+func (h *FeedbackRecordsHandler) List(w http.ResponseWriter, r *http.Request) {
+	filters := &models.ListFeedbackRecordsFilters{}
+	if !decodeListFilters(w, r, filters) {
 		return
 	}
 
@@ -140,7 +324,106 @@ func (h *FeedbackRecordsHandler) List(w http.ResponseWriter, r *http.Request) {
 	response.RespondJSON(w, http.StatusOK, result)
 }
 
-// Update handles PATCH /v1/feedback-records/{id}.
+// exportPageWriteTimeout is the write deadline each page of a streamed export gets. The server's
+// WriteTimeout is one deadline for the whole response; a large export outlives it, so the deadline
+// is pushed out after every flushed page instead and only a stalled client still trips it.
+const exportPageWriteTimeout = 15 * time.Second
+
+// Export handles GET /v1/feedback-records/export and GET /v1/admin/feedback-records/export: every
+// record matching the list filters, streamed as CSV (default) or, with format=jsonl, one JSON
+// object per line. Unlike the asynchronous export (POST /v1/feedback-records/export) nothing is
+// stored; the response is written page by page and flushed after each one. Errors before the first
+// page are ordinary problem responses. Once the body has started the status is already sent, so a
+// later failure aborts the connection — the client sees a truncated transfer rather than a short
+// file that looks complete.
+func (h *FeedbackRecordsHandler) Export(w http.ResponseWriter, r *http.Request) {
+	filters := &models.ListFeedbackRecordsFilters{}
+	if !decodeListFilters(w, r, filters) {
+		return
+	}
+
+	format := models.ExportFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = models.ExportFormatCSV
+	}
+
+	if _, ok := exportContentTypes[format]; !ok {
+		response.RespondInvalidParams(w, r, response.InvalidParam{Name: "format", Reason: "must be csv or jsonl"})
+
+		return
+	}
+
+	stream := &exportStreamWriter{w: w, format: format}
+	controller := http.NewResponseController(w)
+
+	count, err := h.service.StreamFeedbackRecords(r.Context(), filters, format, stream, func() error {
+		stream.start()
+
+		if err := controller.SetWriteDeadline(time.Now().Add(exportPageWriteTimeout)); err != nil &&
+			!errors.Is(err, http.ErrNotSupported) {
+			return fmt.Errorf("extend export write deadline: %w", err)
+		}
+
+		if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return fmt.Errorf("flush export page: %w", err)
+		}
+
+		return nil
+	})
+	if err == nil {
+		return
+	}
+
+	if !stream.started {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	slog.ErrorContext(r.Context(), "feedback export stream failed", "records_written", count, "error", err)
+	panic(http.ErrAbortHandler)
+}
+
+// exportStreamWriter sends the export headers with the first byte of the body (or the first flush
+// of an empty body), so an error before then can still become a problem response.
+type exportStreamWriter struct {
+	w       http.ResponseWriter
+	format  models.ExportFormat
+	started bool
+}
+
+func (s *exportStreamWriter) start() {
+	if s.started {
+		return
+	}
+
+	s.started = true
+	header := s.w.Header()
+	header.Set("Content-Type", exportContentTypes[s.format])
+	header.Set("Content-Disposition", `attachment; filename="feedback-records.`+string(s.format)+`"`)
+	header.Set("Cache-Control", "private, no-store")
+	s.w.WriteHeader(http.StatusOK)
+}
+
+func (s *exportStreamWriter) Write(data []byte) (int, error) {
+	s.start()
+
+	n, err := s.w.Write(data)
+	if err != nil {
+		return n, fmt.Errorf("write export stream: %w", err)
+	}
+
+	return n, nil
+}
+
+// Update handles PATCH /v1/feedback-records/{id}. A Content-Type of application/json-patch+json
+// selects an RFC 6902 JSON Patch (see updateWithJSONPatch); any other body is the plain update
+// request, whose present members are set.
+//
+// Updates are unconditional unless the client opts in to optimistic locking by sending If-Match
+// (an ETag from a GET or a previous PATCH) or If-Unmodified-Since (its Last-Modified): the
+// update is then applied only if the record still matches, else 412 Precondition Failed. The
+// response carries the new ETag and Last-Modified so the next conditional update can chain on it.
 func (h *FeedbackRecordsHandler) Update(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	if idStr == "" {
@@ -156,12 +439,20 @@ func (h *FeedbackRecordsHandler) Update(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == models.JSONPatchContentType {
+		h.updateWithJSONPatch(w, r, id)
+
+		return
+	}
+
 	var req models.UpdateFeedbackRecordRequest
 
 	if !decodeRecordBody(w, r, &req) {
 		return
 	}
 
+	setUpdatePrecondition(r, &req)
+
 	record, err := h.service.UpdateFeedbackRecord(r.Context(), id, &req)
 	if err != nil {
 		response.RespondError(w, r, err)
@@ -169,7 +460,106 @@ func (h *FeedbackRecordsHandler) Update(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	response.RespondJSON(w, http.StatusOK, record)
+	response.RespondJSONWithValidators(w, r, http.StatusOK, record, record.UpdatedAt)
+}
+
+// setUpdatePrecondition makes req conditional on the request's If-Match / If-Unmodified-Since
+// headers, if any. The repository evaluates it against the row it locks for the write, and the
+// ETag is computed from that row exactly as Get serializes it, so a client's ETag matches until
+// the record actually changes.
+func setUpdatePrecondition(r *http.Request, req *models.UpdateFeedbackRecordRequest) {
+	if !response.HasWritePreconditions(r) {
+		return
+	}
+
+	req.Precondition = func(current *models.FeedbackRecord) bool {
+		return response.WritePreconditionsHold(r, current, current.UpdatedAt)
+	}
+}
+
+// jsonPatchAttempts bounds how often updateWithJSONPatch re-reads the record and re-applies the
+// patch after a concurrent edit landed between its read and its write.
+const jsonPatchAttempts = 3
+
+// updateWithJSONPatch applies a JSON Patch to the current record and persists the result through
+// the regular update path, so validation, events, and enrichment clearing behave exactly as for
+// a plain update. Only the updatable members may be written (source_type, field_id, and the
+// rest are read-only: 400); test ops may read any member and a failing one is a 409.
+//
+// The patch is applied to a read taken before the write, so the write is conditional on the
+// locked row still being that read (same updated_at): test ops and appends such as /tags/- then
+// never act on stale state. When another write committed in between, the patch is re-applied to
+// a fresh read, up to jsonPatchAttempts times, then the request fails with 409. A client that
+// sends If-Match / If-Unmodified-Since gets its own precondition instead, and 412 on a mismatch.
+func (h *FeedbackRecordsHandler) updateWithJSONPatch(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	var patch jsonpatch.Patch
+
+	if !decodeRecordJSON(w, r, &patch) {
+		return
+	}
+
+	var (
+		record *models.FeedbackRecord
+		err    error
+	)
+
+	conditional := response.HasWritePreconditions(r)
+
+	for range jsonPatchAttempts {
+		record, err = h.applyJSONPatch(r, id, patch, conditional)
+		if conditional || !errors.Is(err, huberrors.ErrPreconditionFailed) {
+			break
+		}
+	}
+
+	if !conditional && errors.Is(err, huberrors.ErrPreconditionFailed) {
+		err = huberrors.NewConflictError("feedback record kept changing while the patch was applied; retry the request")
+	}
+
+	if err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSONWithValidators(w, r, http.StatusOK, record, record.UpdatedAt)
+}
+
+// applyJSONPatch is one read-patch-write round of updateWithJSONPatch. Unless conditional (the
+// client sent its own preconditions) it fails with huberrors.ErrPreconditionFailed when the record
+// changed after the read.
+func (h *FeedbackRecordsHandler) applyJSONPatch(
+	r *http.Request, id uuid.UUID, patch jsonpatch.Patch, conditional bool,
+) (*models.FeedbackRecord, error) {
+	current, err := h.service.GetFeedbackRecord(r.Context(), id)
+	if err != nil {
+		return nil, fmt.Errorf("read feedback record: %w", err)
+	}
+
+	req, err := models.NewUpdateRequestFromJSONPatch(current, patch)
+	if err != nil {
+		return nil, fmt.Errorf("apply json patch: %w", err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validate patched record: %w", err)
+	}
+
+	if conditional {
+		setUpdatePrecondition(r, req)
+	} else {
+		readAt := current.UpdatedAt
+		req.Precondition = func(locked *models.FeedbackRecord) bool {
+			return locked.UpdatedAt.Equal(readAt)
+		}
+	}
+
+	record, err := h.service.UpdateFeedbackRecord(r.Context(), id, req)
+	if err != nil {
+		return nil, fmt.Errorf("update feedback record: %w", err)
+	}
+
+	return record, nil
 }
 
 // Delete handles DELETE /v1/feedback-records/{id}.
@@ -232,9 +622,26 @@ func (h *FeedbackRecordsHandler) DeleteByUser(w http.ResponseWriter, r *http.Req
 	response.RespondJSON(w, http.StatusOK, resp)
 }
 
-// Count handles GET /v1/feedback-records/count.
+// Count handles GET /v1/feedback-records/count and GET /v1/admin/feedback-records/count.
 func (h *FeedbackRecordsHandler) Count(w http.ResponseWriter, r *http.Request) {
 	filters := &models.ListFeedbackRecordsFilters{}
+	if !decodeListFilters(w, r, filters) {
+		return
+	}
+
+	count, err := h.service.CountFeedbackRecords(r.Context(), filters)
+	if err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusOK, models.CountFeedbackRecordsResponse{Count: int64(count)})
+}
+
+// Stats handles GET /v1/feedback-records/stats.
+func (h *FeedbackRecordsHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	filters := &models.FeedbackRecordStatsFilters{}
 
 	if err := validation.ValidateAndDecodeQueryParams(r, filters); err != nil {
 		response.RespondError(w, r, err)
@@ -242,12 +649,32 @@ func (h *FeedbackRecordsHandler) Count(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	count, err := h.service.CountFeedbackRecords(r.Context(), filters)
+	result, err := h.service.GetFeedbackRecordStats(r.Context(), filters)
 	if err != nil {
 		response.RespondError(w, r, err)
 
 		return
 	}
 
-	response.RespondJSON(w, http.StatusOK, models.CountFeedbackRecordsResponse{Count: int64(count)})
+	response.RespondJSON(w, http.StatusOK, result)
+}
+
+// Tags handles GET /v1/feedback-records/tags.
+func (h *FeedbackRecordsHandler) Tags(w http.ResponseWriter, r *http.Request) {
+	filters := &models.ListFeedbackRecordTagsFilters{}
+
+	if err := validation.ValidateAndDecodeQueryParams(r, filters); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	result, err := h.service.ListFeedbackRecordTags(r.Context(), filters)
+	if err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusOK, result)
 }
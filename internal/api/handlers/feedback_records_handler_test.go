@@ -4,15 +4,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/formbricks/hub/internal/api/middleware"
 	"github.com/formbricks/hub/internal/api/response"
 	"github.com/formbricks/hub/internal/huberrors"
 	"github.com/formbricks/hub/internal/models"
@@ -22,7 +25,22 @@ import (
 type mockFeedbackRecordsService struct {
 	countFunc        func(ctx context.Context, filters *models.ListFeedbackRecordsFilters) (int, error)
 	createFunc       func(ctx context.Context, req *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error)
+	createSyncFunc   func(ctx context.Context, req *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error)
+	createSkipFunc   func(ctx context.Context, req *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error)
+	createBatchFunc  func(ctx context.Context, items []models.FeedbackRecordBatchItem) error
+	createBatchSkip  func(ctx context.Context, items []models.FeedbackRecordBatchItem) error
 	deleteByUserFunc func(ctx context.Context, filters *models.DeleteFeedbackRecordsByUserFilters) (int, error)
+	getFunc          func(ctx context.Context, id uuid.UUID) (*models.FeedbackRecord, error)
+	updateFunc       func(ctx context.Context, id uuid.UUID, req *models.UpdateFeedbackRecordRequest) (*models.FeedbackRecord, error)
+	listTagsFunc     func(
+		ctx context.Context, filters *models.ListFeedbackRecordTagsFilters,
+	) (*models.ListFeedbackRecordTagsResponse, error)
+	statsFunc func(
+		ctx context.Context, filters *models.FeedbackRecordStatsFilters,
+	) (*models.FeedbackRecordStatsResponse, error)
+	streamFunc func(
+		ctx context.Context, filters *models.ListFeedbackRecordsFilters, format models.ExportFormat, w io.Writer, pageDone func() error,
+	) (int64, error)
 }
 
 func (m *mockFeedbackRecordsService) CreateFeedbackRecord(
@@ -35,7 +53,51 @@ func (m *mockFeedbackRecordsService) CreateFeedbackRecord(
 	return nil, nil
 }
 
-func (m *mockFeedbackRecordsService) GetFeedbackRecord(context.Context, uuid.UUID) (*models.FeedbackRecord, error) {
+func (m *mockFeedbackRecordsService) CreateFeedbackRecordWithSyncEmbedding(
+	ctx context.Context, req *models.CreateFeedbackRecordRequest,
+) (*models.FeedbackRecord, error) {
+	if m.createSyncFunc != nil {
+		return m.createSyncFunc(ctx, req)
+	}
+
+	return nil, nil
+}
+
+func (m *mockFeedbackRecordsService) CreateFeedbackRecordWithoutEmbedding(
+	ctx context.Context, req *models.CreateFeedbackRecordRequest,
+) (*models.FeedbackRecord, error) {
+	if m.createSkipFunc != nil {
+		return m.createSkipFunc(ctx, req)
+	}
+
+	return nil, nil
+}
+
+func (m *mockFeedbackRecordsService) CreateFeedbackRecordsBatch(
+	ctx context.Context, items []models.FeedbackRecordBatchItem,
+) error {
+	if m.createBatchFunc != nil {
+		return m.createBatchFunc(ctx, items)
+	}
+
+	return nil
+}
+
+func (m *mockFeedbackRecordsService) CreateFeedbackRecordsBatchWithoutEmbedding(
+	ctx context.Context, items []models.FeedbackRecordBatchItem,
+) error {
+	if m.createBatchSkip != nil {
+		return m.createBatchSkip(ctx, items)
+	}
+
+	return nil
+}
+
+func (m *mockFeedbackRecordsService) GetFeedbackRecord(ctx context.Context, id uuid.UUID) (*models.FeedbackRecord, error) {
+	if m.getFunc != nil {
+		return m.getFunc(ctx, id)
+	}
+
 	return nil, nil
 }
 
@@ -46,8 +108,12 @@ func (m *mockFeedbackRecordsService) ListFeedbackRecords(
 }
 
 func (m *mockFeedbackRecordsService) UpdateFeedbackRecord(
-	context.Context, uuid.UUID, *models.UpdateFeedbackRecordRequest,
+	ctx context.Context, id uuid.UUID, req *models.UpdateFeedbackRecordRequest,
 ) (*models.FeedbackRecord, error) {
+	if m.updateFunc != nil {
+		return m.updateFunc(ctx, id, req)
+	}
+
 	return nil, nil
 }
 
@@ -75,6 +141,36 @@ func (m *mockFeedbackRecordsService) DeleteFeedbackRecordsByUser(
 	return 0, nil
 }
 
+func (m *mockFeedbackRecordsService) ListFeedbackRecordTags(
+	ctx context.Context, filters *models.ListFeedbackRecordTagsFilters,
+) (*models.ListFeedbackRecordTagsResponse, error) {
+	if m.listTagsFunc != nil {
+		return m.listTagsFunc(ctx, filters)
+	}
+
+	return &models.ListFeedbackRecordTagsResponse{}, nil
+}
+
+func (m *mockFeedbackRecordsService) GetFeedbackRecordStats(
+	ctx context.Context, filters *models.FeedbackRecordStatsFilters,
+) (*models.FeedbackRecordStatsResponse, error) {
+	if m.statsFunc != nil {
+		return m.statsFunc(ctx, filters)
+	}
+
+	return &models.FeedbackRecordStatsResponse{}, nil
+}
+
+func (m *mockFeedbackRecordsService) StreamFeedbackRecords(
+	ctx context.Context, filters *models.ListFeedbackRecordsFilters, format models.ExportFormat, w io.Writer, pageDone func() error,
+) (int64, error) {
+	if m.streamFunc != nil {
+		return m.streamFunc(ctx, filters, format, w, pageDone)
+	}
+
+	return 0, pageDone()
+}
+
 func TestFeedbackRecordsHandler_List(t *testing.T) {
 	t.Run("missing tenant_id returns 400", func(t *testing.T) {
 		mock := &mockFeedbackRecordsService{}
@@ -109,6 +205,37 @@ func TestFeedbackRecordsHandler_List(t *testing.T) {
 		assert.Equal(t, "since", problem.InvalidParams[0].Name)
 		assert.Equal(t, "must be in RFC3339 (ISO 8601) format", problem.InvalidParams[0].Reason)
 	})
+
+	t.Run("invalid tag_match returns 400", func(t *testing.T) {
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{})
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet,
+			"http://test/v1/feedback-records?tenant_id=org-123&tag=bug&tag_match=some", http.NoBody)
+		rec := httptest.NewRecorder()
+
+		handler.List(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("include_deleted needs the admin scope", func(t *testing.T) {
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{})
+		target := "http://test/v1/feedback-records?tenant_id=org-123&include_deleted=true"
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, target, http.NoBody)
+		rec := httptest.NewRecorder()
+
+		handler.List(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+
+		req = httptest.NewRequestWithContext(context.Background(), http.MethodGet, target, http.NoBody)
+		rec = httptest.NewRecorder()
+
+		middleware.AdminScope(http.HandlerFunc(handler.List)).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
 }
 
 func TestFeedbackRecordsHandler_Create(t *testing.T) {
@@ -147,6 +274,34 @@ func TestFeedbackRecordsHandler_Create(t *testing.T) {
 		assert.Equal(t, "org-123", got.TenantID)
 	})
 
+	t.Run("idempotency key is passed through and a replay returns 200", func(t *testing.T) {
+		recordID := uuid.Must(uuid.NewV7())
+		mock := &mockFeedbackRecordsService{
+			createFunc: func(_ context.Context, req *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+				assert.Equal(t, "row-42", req.IdempotencyKey)
+
+				return &models.FeedbackRecord{ID: recordID, FieldType: req.FieldType, TenantID: req.TenantID, Replayed: true}, nil
+			},
+		}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		req := httptest.NewRequestWithContext(
+			context.Background(), http.MethodPost, "http://test/v1/feedback-records", feedbackRecordCreateBody(t, "org-123"),
+		)
+		req.Header.Set("Idempotency-Key", "row-42")
+
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "true", rec.Header().Get("Idempotent-Replayed"))
+
+		var got models.FeedbackRecord
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, recordID, got.ID)
+	})
+
 	t.Run("invalid field_type returns field-level problem details", func(t *testing.T) {
 		mock := &mockFeedbackRecordsService{}
 		handler := NewFeedbackRecordsHandler(mock)
@@ -218,6 +373,100 @@ func TestFeedbackRecordsHandler_Create(t *testing.T) {
 
 		assert.Equal(t, http.StatusConflict, rec.Code)
 	})
+
+	t.Run("sync_embedding=true uses the sync create path", func(t *testing.T) {
+		var asyncCalls, syncCalls int
+
+		mock := &mockFeedbackRecordsService{
+			createFunc: func(_ context.Context, _ *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+				asyncCalls++
+
+				return &models.FeedbackRecord{}, nil
+			},
+			createSyncFunc: func(_ context.Context, _ *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+				syncCalls++
+
+				return &models.FeedbackRecord{}, nil
+			},
+		}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost,
+			"http://test/v1/feedback-records?sync_embedding=true", feedbackRecordCreateBody(t, "org-123"))
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 1, syncCalls)
+		assert.Zero(t, asyncCalls)
+	})
+
+	t.Run("invalid sync_embedding returns validation problem", func(t *testing.T) {
+		mock := &mockFeedbackRecordsService{}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost,
+			"http://test/v1/feedback-records?sync_embedding=maybe", feedbackRecordCreateBody(t, "org-123"))
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var problem response.ProblemDetails
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		require.Len(t, problem.InvalidParams, 1)
+		assert.Equal(t, "sync_embedding", problem.InvalidParams[0].Name)
+	})
+
+	t.Run("skip_embedding=true uses the create path without embedding", func(t *testing.T) {
+		var asyncCalls, skipCalls int
+
+		mock := &mockFeedbackRecordsService{
+			createFunc: func(_ context.Context, _ *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+				asyncCalls++
+
+				return &models.FeedbackRecord{}, nil
+			},
+			createSkipFunc: func(_ context.Context, _ *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+				skipCalls++
+
+				return &models.FeedbackRecord{}, nil
+			},
+		}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost,
+			"http://test/v1/feedback-records?skip_embedding=true", feedbackRecordCreateBody(t, "org-123"))
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 1, skipCalls)
+		assert.Zero(t, asyncCalls)
+	})
+
+	t.Run("skip_embedding with sync_embedding returns validation problem", func(t *testing.T) {
+		mock := &mockFeedbackRecordsService{}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost,
+			"http://test/v1/feedback-records?sync_embedding=true&skip_embedding=true", feedbackRecordCreateBody(t, "org-123"))
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var problem response.ProblemDetails
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		require.Len(t, problem.InvalidParams, 1)
+		assert.Equal(t, "skip_embedding", problem.InvalidParams[0].Name)
+	})
 }
 
 func feedbackRecordCreateBody(t *testing.T, tenantID string) *bytes.Reader {
@@ -235,6 +484,343 @@ func feedbackRecordCreateBody(t *testing.T, tenantID string) *bytes.Reader {
 	return bytes.NewReader(body)
 }
 
+func TestFeedbackRecordsHandler_CreateBatch(t *testing.T) {
+	batchBody := `{"records": [
+		{"source_type": "survey", "field_id": "q1", "field_type": "text", "tenant_id": "org-123", "submission_id": "s1"},
+		{"source_type": "survey", "field_id": "q1", "field_type": "text", "tenant_id": "org-123", "submission_id": ""},
+		{"source_type": "survey", "field_id": "q1", "field_type": "text", "tenant_id": "org-123", "submission_id": "s3"}
+	]}`
+
+	t.Run("partial failure returns 207 with per-item results", func(t *testing.T) {
+		recordID := uuid.Must(uuid.NewV7())
+		mock := &mockFeedbackRecordsService{
+			createBatchFunc: func(_ context.Context, items []models.FeedbackRecordBatchItem) error {
+				require.Len(t, items, 3)
+				require.Error(t, items[1].Err, "struct validation runs per item in the handler")
+
+				items[0].Record = &models.FeedbackRecord{ID: recordID}
+				items[2].Err = huberrors.NewConflictError("a feedback record with this tenant_id, submission_id, and field_id already exists")
+
+				return nil
+			},
+		}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		req := httptest.NewRequestWithContext(
+			context.Background(), http.MethodPost, "http://test/v1/feedback-records/batch", strings.NewReader(batchBody),
+		)
+		rec := httptest.NewRecorder()
+
+		handler.CreateBatch(rec, req)
+
+		assert.Equal(t, http.StatusMultiStatus, rec.Code)
+
+		var got FeedbackRecordsBatchResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, 1, got.Created)
+		assert.Equal(t, 2, got.Failed)
+		require.Len(t, got.Results, 3)
+		assert.Equal(t, recordID, *got.Results[0].ID)
+		assert.Nil(t, got.Results[0].Error)
+		assert.Equal(t, 1, got.Results[1].Index)
+		require.NotNil(t, got.Results[1].Error)
+		assert.Equal(t, http.StatusBadRequest, got.Results[1].Error.Status)
+		assert.Equal(t, "submission_id", got.Results[1].Error.InvalidParams[0].Name)
+		require.NotNil(t, got.Results[2].Error)
+		assert.Equal(t, http.StatusConflict, got.Results[2].Error.Status)
+	})
+
+	t.Run("all created returns 201", func(t *testing.T) {
+		mock := &mockFeedbackRecordsService{
+			createBatchFunc: func(_ context.Context, items []models.FeedbackRecordBatchItem) error {
+				for i := range items {
+					items[i].Record = &models.FeedbackRecord{ID: uuid.Must(uuid.NewV7())}
+				}
+
+				return nil
+			},
+		}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		body := `{"records": [{"source_type": "survey", "field_id": "q1", "field_type": "text", "tenant_id": "org-123", "submission_id": "s1"}]}`
+		req := httptest.NewRequestWithContext(
+			context.Background(), http.MethodPost, "http://test/v1/feedback-records/batch", strings.NewReader(body),
+		)
+		rec := httptest.NewRecorder()
+
+		handler.CreateBatch(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("skip_embedding selects the create without embeddings", func(t *testing.T) {
+		skipped := false
+		mock := &mockFeedbackRecordsService{
+			createBatchFunc: func(context.Context, []models.FeedbackRecordBatchItem) error {
+				t.Fatal("skip_embedding=true must not take the embedding path")
+
+				return nil
+			},
+			createBatchSkip: func(_ context.Context, items []models.FeedbackRecordBatchItem) error {
+				skipped = true
+
+				for i := range items {
+					items[i].Record = &models.FeedbackRecord{ID: uuid.Must(uuid.NewV7())}
+				}
+
+				return nil
+			},
+		}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		body := `{"records": [{"source_type": "survey", "field_id": "q1", "field_type": "text", "tenant_id": "org-123", "submission_id": "s1"}]}`
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost,
+			"http://test/v1/feedback-records/batch?skip_embedding=true", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.CreateBatch(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		assert.True(t, skipped)
+	})
+
+	t.Run("invalid skip_embedding returns 400", func(t *testing.T) {
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{})
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost,
+			"http://test/v1/feedback-records/batch?skip_embedding=maybe", strings.NewReader(batchBody))
+		rec := httptest.NewRecorder()
+
+		handler.CreateBatch(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("a batch-level error creates nothing", func(t *testing.T) {
+		mock := &mockFeedbackRecordsService{
+			createBatchFunc: func(context.Context, []models.FeedbackRecordBatchItem) error {
+				return huberrors.NewValidationError("records", "must contain at most 2 records (got 3)")
+			},
+		}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		req := httptest.NewRequestWithContext(
+			context.Background(), http.MethodPost, "http://test/v1/feedback-records/batch", strings.NewReader(batchBody),
+		)
+		rec := httptest.NewRecorder()
+
+		handler.CreateBatch(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestFeedbackRecordsHandler_UpdateWithJSONPatch(t *testing.T) {
+	recordID := uuid.Must(uuid.NewV7())
+	text := "old"
+	current := &models.FeedbackRecord{
+		ID: recordID, SourceType: "survey", FieldID: "q1", FieldType: models.FieldTypeText, TenantID: "org-1",
+		ValueText: &text, Metadata: json.RawMessage(`{"a":1}`), Tags: []string{"bug"},
+	}
+
+	patchRequest := func(body string) *http.Request {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPatch,
+			"http://test/v1/feedback-records/"+recordID.String(), bytes.NewReader([]byte(body)))
+		req.SetPathValue("id", recordID.String())
+		req.Header.Set("Content-Type", "application/json-patch+json; charset=utf-8")
+
+		return req
+	}
+
+	t.Run("applies ops through the update path", func(t *testing.T) {
+		var got *models.UpdateFeedbackRecordRequest
+
+		mock := &mockFeedbackRecordsService{
+			getFunc: func(context.Context, uuid.UUID) (*models.FeedbackRecord, error) { return current, nil },
+			updateFunc: func(_ context.Context, _ uuid.UUID, req *models.UpdateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+				got = req
+
+				return current, nil
+			},
+		}
+		rec := httptest.NewRecorder()
+
+		NewFeedbackRecordsHandler(mock).Update(rec, patchRequest(`[
+			{"op":"test","path":"/source_type","value":"survey"},
+			{"op":"add","path":"/metadata/b","value":2},
+			{"op":"add","path":"/tags/-","value":"ux"}
+		]`))
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NotNil(t, got)
+		assert.JSONEq(t, `{"a":1,"b":2}`, string(got.Metadata))
+		require.NotNil(t, got.Tags)
+		assert.Equal(t, []string{"bug", "ux"}, *got.Tags)
+		assert.Nil(t, got.ValueText, "untouched fields are not sent to the update")
+		assert.NotNil(t, got.Precondition, "the write is conditional on the record the patch was applied to")
+	})
+
+	// The mock stands in for the repository: it evaluates the precondition against the row as it
+	// is at write time, which a concurrent edit moved past the handler's read.
+	t.Run("a concurrent edit re-applies the patch to a fresh read", func(t *testing.T) {
+		stale := *current
+		stale.UpdatedAt = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+		edited := *current
+		edited.Tags = []string{"bug", "perf"}
+		edited.UpdatedAt = stale.UpdatedAt.Add(time.Second)
+
+		reads := []*models.FeedbackRecord{&stale, &edited}
+
+		var written []string
+
+		mock := &mockFeedbackRecordsService{
+			getFunc: func(context.Context, uuid.UUID) (*models.FeedbackRecord, error) {
+				read := reads[0]
+				reads = reads[1:]
+
+				return read, nil
+			},
+			updateFunc: func(_ context.Context, _ uuid.UUID, req *models.UpdateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+				if !req.Precondition(&edited) {
+					return nil, huberrors.NewPreconditionFailedError("modified")
+				}
+
+				written = *req.Tags
+
+				return &edited, nil
+			},
+		}
+		rec := httptest.NewRecorder()
+
+		NewFeedbackRecordsHandler(mock).Update(rec, patchRequest(`[{"op":"add","path":"/tags/-","value":"ux"}]`))
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, []string{"bug", "perf", "ux"}, written, "the tag added meanwhile is kept")
+	})
+
+	t.Run("a record that keeps changing is 409", func(t *testing.T) {
+		updates := 0
+
+		mock := &mockFeedbackRecordsService{
+			getFunc: func(context.Context, uuid.UUID) (*models.FeedbackRecord, error) { return current, nil },
+			updateFunc: func(context.Context, uuid.UUID, *models.UpdateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+				updates++
+
+				return nil, huberrors.NewPreconditionFailedError("modified")
+			},
+		}
+		rec := httptest.NewRecorder()
+
+		NewFeedbackRecordsHandler(mock).Update(rec, patchRequest(`[{"op":"add","path":"/tags/-","value":"ux"}]`))
+
+		assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+		assert.Equal(t, jsonPatchAttempts, updates)
+	})
+
+	for name, tc := range map[string]struct {
+		body string
+		want int
+	}{
+		"immutable submission_id": {body: `[{"op":"replace","path":"/submission_id","value":"x"}]`, want: http.StatusBadRequest},
+		"immutable field_id":      {body: `[{"op":"remove","path":"/field_id"}]`, want: http.StatusBadRequest},
+		"move out of a read-only": {body: `[{"op":"move","from":"/field_id","path":"/value_id"}]`, want: http.StatusBadRequest},
+		"removing a field":        {body: `[{"op":"remove","path":"/value_text"}]`, want: http.StatusBadRequest},
+		"wrong value type":        {body: `[{"op":"replace","path":"/value_text","value":5}]`, want: http.StatusBadRequest},
+		"missing path":            {body: `[{"op":"replace","path":"/value_id","value":"x"}]`, want: http.StatusBadRequest},
+		"malformed op":            {body: `[{"op":"merge","path":"/value_text"}]`, want: http.StatusBadRequest},
+		"failed test":             {body: `[{"op":"test","path":"/value_text","value":"new"}]`, want: http.StatusConflict},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mock := &mockFeedbackRecordsService{
+				getFunc: func(context.Context, uuid.UUID) (*models.FeedbackRecord, error) { return current, nil },
+				updateFunc: func(context.Context, uuid.UUID, *models.UpdateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+					t.Fatal("a rejected patch must not reach the update")
+
+					return nil, nil
+				},
+			}
+			rec := httptest.NewRecorder()
+
+			NewFeedbackRecordsHandler(mock).Update(rec, patchRequest(tc.body))
+
+			assert.Equal(t, tc.want, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestFeedbackRecordsHandler_UpdatePrecondition(t *testing.T) {
+	recordID := uuid.Must(uuid.NewV7())
+	text := "old"
+	current := &models.FeedbackRecord{
+		ID: recordID, SourceType: "survey", FieldID: "q1", FieldType: models.FieldTypeText, TenantID: "org-1",
+		ValueText: &text, UpdatedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	getRec := httptest.NewRecorder()
+	response.RespondJSONConditional(getRec, httptest.NewRequestWithContext(context.Background(), http.MethodGet,
+		"http://test/v1/feedback-records/"+recordID.String(), http.NoBody), current, current.UpdatedAt)
+	etag := getRec.Header().Get("ETag")
+
+	// The mock stands in for the repository: it evaluates the precondition against the current row.
+	update := func(t *testing.T, header, value string) (*httptest.ResponseRecorder, *models.UpdateFeedbackRecordRequest) {
+		t.Helper()
+
+		var got *models.UpdateFeedbackRecordRequest
+
+		mock := &mockFeedbackRecordsService{
+			updateFunc: func(_ context.Context, _ uuid.UUID, req *models.UpdateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+				got = req
+				if req.Precondition != nil && !req.Precondition(current) {
+					return nil, huberrors.NewPreconditionFailedError("modified")
+				}
+
+				return current, nil
+			},
+		}
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPatch,
+			"http://test/v1/feedback-records/"+recordID.String(), bytes.NewReader([]byte(`{"value_text":"new"}`)))
+		req.SetPathValue("id", recordID.String())
+		req.Header.Set("Content-Type", "application/json")
+
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+
+		rec := httptest.NewRecorder()
+		NewFeedbackRecordsHandler(mock).Update(rec, req)
+
+		return rec, got
+	}
+
+	t.Run("without headers the update is unconditional", func(t *testing.T) {
+		rec, got := update(t, "", "")
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Nil(t, got.Precondition)
+		assert.Equal(t, etag, rec.Header().Get("ETag"), "the response carries the validator for the next conditional update")
+	})
+
+	t.Run("matching if-match applies the update", func(t *testing.T) {
+		rec, got := update(t, "If-Match", etag)
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.NotNil(t, got.Precondition)
+	})
+
+	t.Run("stale if-match is 412", func(t *testing.T) {
+		rec, _ := update(t, "If-Match", `"stale"`)
+
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code, rec.Body.String())
+	})
+
+	t.Run("if-unmodified-since before the last change is 412", func(t *testing.T) {
+		rec, _ := update(t, "If-Unmodified-Since", "Sun, 01 Mar 2026 09:59:59 GMT")
+
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code, rec.Body.String())
+	})
+}
+
 func TestFeedbackRecordsHandler_DeleteByUser(t *testing.T) {
 	t.Run("success returns 200 with deleted_count and message", func(t *testing.T) {
 		mock := &mockFeedbackRecordsService{
@@ -556,3 +1142,231 @@ func TestFeedbackRecordsHandler_Count(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	})
 }
+
+func TestFeedbackRecordsHandler_Stats(t *testing.T) {
+	t.Run("success decodes the range and returns the stats", func(t *testing.T) {
+		var got *models.FeedbackRecordStatsFilters
+
+		mock := &mockFeedbackRecordsService{
+			statsFunc: func(
+				_ context.Context, filters *models.FeedbackRecordStatsFilters,
+			) (*models.FeedbackRecordStatsResponse, error) {
+				got = filters
+
+				return &models.FeedbackRecordStatsResponse{
+					Total:        3,
+					BySourceType: map[string]int64{"formbricks": 3},
+					ByFieldType:  map[string]int64{"text": 2, "rating": 1},
+					ByDay:        []models.FeedbackRecordDayCount{{Day: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Count: 3}},
+				}, nil
+			},
+		}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet,
+			"http://test/v1/feedback-records/stats?tenant_id=org-123&since=2026-10-01T00:00:00Z", http.NoBody)
+		rec := httptest.NewRecorder()
+
+		handler.Stats(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, got)
+		assert.Equal(t, "org-123", got.TenantID)
+		require.NotNil(t, got.Since)
+		assert.Nil(t, got.Until)
+		assert.JSONEq(t, `{"total":3,"by_source_type":{"formbricks":3},"by_field_type":{"text":2,"rating":1},
+			"by_day":[{"day":"2026-10-01T00:00:00Z","count":3}]}`, rec.Body.String())
+	})
+
+	t.Run("missing tenant_id is rejected", func(t *testing.T) {
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{})
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet,
+			"http://test/v1/feedback-records/stats", http.NoBody)
+		rec := httptest.NewRecorder()
+
+		handler.Stats(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestFeedbackRecordsHandler_Tags(t *testing.T) {
+	t.Run("success returns tag counts", func(t *testing.T) {
+		var got *models.ListFeedbackRecordTagsFilters
+
+		mock := &mockFeedbackRecordsService{
+			listTagsFunc: func(
+				_ context.Context, filters *models.ListFeedbackRecordTagsFilters,
+			) (*models.ListFeedbackRecordTagsResponse, error) {
+				got = filters
+
+				return &models.ListFeedbackRecordTagsResponse{
+					Data: []models.FeedbackRecordTagCount{{Tag: "bug", Count: 2}},
+				}, nil
+			},
+		}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet,
+			"http://test/v1/feedback-records/tags?tenant_id=org-123&limit=10", http.NoBody)
+		rec := httptest.NewRecorder()
+
+		handler.Tags(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, got)
+		assert.Equal(t, "org-123", got.TenantID)
+		assert.Equal(t, 10, got.Limit)
+
+		var body models.ListFeedbackRecordTagsResponse
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, []models.FeedbackRecordTagCount{{Tag: "bug", Count: 2}}, body.Data)
+	})
+
+	t.Run("approximate is decoded", func(t *testing.T) {
+		var got *models.ListFeedbackRecordTagsFilters
+
+		mock := &mockFeedbackRecordsService{
+			listTagsFunc: func(
+				_ context.Context, filters *models.ListFeedbackRecordTagsFilters,
+			) (*models.ListFeedbackRecordTagsResponse, error) {
+				got = filters
+
+				return &models.ListFeedbackRecordTagsResponse{Approximate: true}, nil
+			},
+		}
+		handler := NewFeedbackRecordsHandler(mock)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet,
+			"http://test/v1/feedback-records/tags?tenant_id=org-123&approximate=true", http.NoBody)
+		rec := httptest.NewRecorder()
+
+		handler.Tags(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, got)
+		assert.True(t, got.Approximate)
+		assert.Contains(t, rec.Body.String(), `"approximate":true`)
+	})
+
+	t.Run("missing tenant_id returns 400", func(t *testing.T) {
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{})
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet,
+			"http://test/v1/feedback-records/tags", http.NoBody)
+		rec := httptest.NewRecorder()
+
+		handler.Tags(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestFeedbackRecordsHandler_Export(t *testing.T) {
+	exportRequest := func(query string) *http.Request {
+		return httptest.NewRequestWithContext(context.Background(), http.MethodGet,
+			"http://test/v1/feedback-records/export?"+query, http.NoBody)
+	}
+
+	t.Run("streams CSV by default with attachment headers", func(t *testing.T) {
+		var gotFilters *models.ListFeedbackRecordsFilters
+
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{
+			streamFunc: func(
+				_ context.Context, filters *models.ListFeedbackRecordsFilters, format models.ExportFormat, w io.Writer, pageDone func() error,
+			) (int64, error) {
+				gotFilters = filters
+
+				assert.Equal(t, models.ExportFormatCSV, format)
+				_, err := io.WriteString(w, "id,tenant_id\n")
+				require.NoError(t, err)
+
+				return 1, pageDone()
+			},
+		})
+
+		rec := httptest.NewRecorder()
+		handler.Export(rec, exportRequest("tenant_id=org-123&source_type=survey"))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="feedback-records.csv"`, rec.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id,tenant_id\n", rec.Body.String())
+		assert.True(t, rec.Flushed, "each page is flushed")
+		require.NotNil(t, gotFilters.SourceType)
+		assert.Equal(t, "survey", *gotFilters.SourceType)
+	})
+
+	t.Run("empty jsonl export still sends the headers", func(t *testing.T) {
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{})
+
+		rec := httptest.NewRecorder()
+		handler.Export(rec, exportRequest("tenant_id=org-123&format=jsonl"))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("unknown format returns 400", func(t *testing.T) {
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{})
+
+		rec := httptest.NewRecorder()
+		handler.Export(rec, exportRequest("tenant_id=org-123&format=xlsx"))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("missing tenant_id returns 400 without streaming", func(t *testing.T) {
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{
+			streamFunc: func(context.Context, *models.ListFeedbackRecordsFilters, models.ExportFormat, io.Writer, func() error) (int64, error) {
+				t.Fatal("export streamed without a tenant_id")
+
+				return 0, nil
+			},
+		})
+
+		for _, query := range []string{"", "format=jsonl", "tenant_id="} {
+			rec := httptest.NewRecorder()
+			handler.Export(rec, exportRequest(query))
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code, "query %q", query)
+			assert.Empty(t, rec.Header().Get("Content-Disposition"), "query %q", query)
+		}
+	})
+
+	t.Run("error before the first page is a problem response", func(t *testing.T) {
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{
+			streamFunc: func(context.Context, *models.ListFeedbackRecordsFilters, models.ExportFormat, io.Writer, func() error) (int64, error) {
+				return 0, huberrors.NewValidationError("tag", "invalid tag")
+			},
+		})
+
+		rec := httptest.NewRecorder()
+		handler.Export(rec, exportRequest("tenant_id=org-123"))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("error after the body started aborts the response", func(t *testing.T) {
+		handler := NewFeedbackRecordsHandler(&mockFeedbackRecordsService{
+			streamFunc: func(
+				_ context.Context, _ *models.ListFeedbackRecordsFilters, _ models.ExportFormat, w io.Writer, pageDone func() error,
+			) (int64, error) {
+				_, _ = io.WriteString(w, "partial\n")
+				require.NoError(t, pageDone())
+
+				return 1, assert.AnError
+			},
+		})
+
+		rec := httptest.NewRecorder()
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handler.Export(rec, exportRequest("tenant_id=org-123"))
+		})
+	})
+}
@@ -1,16 +1,75 @@
 package handlers
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
+	"time"
+
+	"github.com/formbricks/hub/internal/api/response"
 )
 
+// readinessCheckTimeout bounds each dependency check so one hung dependency cannot stall the
+// probe past the orchestrator's own timeout (which would hide which dependency is the problem).
+const readinessCheckTimeout = 2 * time.Second
+
+// Readiness check statuses reported per dependency.
+const (
+	readinessStatusOK       = "ok"
+	readinessStatusFail     = "fail"
+	readinessStatusDisabled = "disabled"
+)
+
+// ReadinessCheck is one dependency probed by GET /ready. A disabled check is reported as
+// "disabled" and never affects the overall result, so operators can tailor what "ready"
+// means to a partial deployment without the endpoint lying about what it verified.
+type ReadinessCheck struct {
+	Name    string
+	Enabled bool
+	Check   func(ctx context.Context) error
+}
+
+// ReadinessCheckResult is the per-dependency outcome in the readiness response. The failure
+// cause is logged, not returned: /ready is unauthenticated, and driver errors can name hosts.
+type ReadinessCheckResult struct {
+	Status string `json:"status"`
+}
+
+// ReadinessResponse is the body of GET /ready: overall status plus every check's result.
+type ReadinessResponse struct {
+	Status     string                          `json:"status"`
+	Checks     map[string]ReadinessCheckResult `json:"checks"`
+	Embeddings *EmbeddingPipelineStatus        `json:"embeddings,omitempty"`
+}
+
+// EmbeddingPipelineStatus reports the embedding queue's progress in GET /ready. The queue-depth
+// gauge alone cannot tell a busy pipeline from a stalled one (an expired provider key fails every
+// job while the backlog grows), so alerting compares the last success against the backlog.
+// LastCompletedAt is null when no embedding job completed within River's completed-job retention
+// (RIVER_COMPLETED_JOB_RETENTION_SECONDS).
+type EmbeddingPipelineStatus struct {
+	LastCompletedAt *time.Time `json:"last_completed_at"`
+	Pending         int64      `json:"pending"`
+}
+
 // HealthHandler handles health check requests.
-type HealthHandler struct{}
+type HealthHandler struct {
+	checks          []ReadinessCheck
+	embeddingStatus func(ctx context.Context) (*EmbeddingPipelineStatus, error)
+}
 
-// NewHealthHandler creates a new health handler.
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+// NewHealthHandler creates a new health handler. checks are the dependencies GET /ready probes;
+// GET /health is a liveness check and never consults them.
+func NewHealthHandler(checks ...ReadinessCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// SetEmbeddingPipelineStatus adds the embedding pipeline's status to GET /ready. It is
+// informational and never makes the service not-ready: a stalled provider does not stop the API
+// from serving, and pulling replicas out of rotation would not fix it. A failed lookup is logged
+// and the section omitted.
+func (h *HealthHandler) SetEmbeddingPipelineStatus(status func(ctx context.Context) (*EmbeddingPipelineStatus, error)) {
+	h.embeddingStatus = status
 }
 
 // Check handles GET /health.
@@ -21,3 +80,56 @@ func (h *HealthHandler) Check(w http.ResponseWriter, _ *http.Request) {
 		slog.Error("Failed to write health check response", "error", err)
 	}
 }
+
+// Ready handles GET /ready. It runs every enabled check and responds 200 when all pass, 503
+// otherwise; the body always lists each check's status so a failing probe names its dependency.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	resp := ReadinessResponse{
+		Status: "ready",
+		Checks: make(map[string]ReadinessCheckResult, len(h.checks)),
+	}
+
+	for _, check := range h.checks {
+		if !check.Enabled {
+			resp.Checks[check.Name] = ReadinessCheckResult{Status: readinessStatusDisabled}
+
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+		err := check.Check(ctx)
+
+		cancel()
+
+		if err != nil {
+			slog.WarnContext(r.Context(), "readiness check failed", "check", check.Name, "error", err)
+
+			resp.Status = "not_ready"
+			resp.Checks[check.Name] = ReadinessCheckResult{Status: readinessStatusFail}
+
+			continue
+		}
+
+		resp.Checks[check.Name] = ReadinessCheckResult{Status: readinessStatusOK}
+	}
+
+	if h.embeddingStatus != nil {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+		embeddings, err := h.embeddingStatus(ctx)
+
+		cancel()
+
+		if err != nil {
+			slog.WarnContext(r.Context(), "embedding pipeline status failed", "error", err)
+		} else {
+			resp.Embeddings = embeddings
+		}
+	}
+
+	status := http.StatusOK
+	if resp.Status != "ready" {
+		status = http.StatusServiceUnavailable
+	}
+
+	response.RespondJSON(w, status, resp)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler_Ready(t *testing.T) {
+	pass := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name       string
+		checks     []ReadinessCheck
+		wantStatus int
+		wantBody   ReadinessResponse
+	}{
+		{
+			name:       "all checks pass",
+			checks:     []ReadinessCheck{{Name: "db", Enabled: true, Check: pass}, {Name: "river", Enabled: true, Check: pass}},
+			wantStatus: http.StatusOK,
+			wantBody: ReadinessResponse{Status: "ready", Checks: map[string]ReadinessCheckResult{
+				"db": {Status: "ok"}, "river": {Status: "ok"},
+			}},
+		},
+		{
+			name:       "failing check names the dependency",
+			checks:     []ReadinessCheck{{Name: "db", Enabled: true, Check: pass}, {Name: "river", Enabled: true, Check: fail}},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody: ReadinessResponse{Status: "not_ready", Checks: map[string]ReadinessCheckResult{
+				"db": {Status: "ok"}, "river": {Status: "fail"},
+			}},
+		},
+		{
+			name:       "disabled check is reported but not run",
+			checks:     []ReadinessCheck{{Name: "db", Enabled: true, Check: pass}, {Name: "river", Enabled: false, Check: fail}},
+			wantStatus: http.StatusOK,
+			wantBody: ReadinessResponse{Status: "ready", Checks: map[string]ReadinessCheckResult{
+				"db": {Status: "ok"}, "river": {Status: "disabled"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHealthHandler(tt.checks...)
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "http://test/ready", http.NoBody)
+			rec := httptest.NewRecorder()
+
+			handler.Ready(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			var got ReadinessResponse
+
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestHealthHandler_ReadyEmbeddingPipelineStatus(t *testing.T) {
+	pass := func(context.Context) error { return nil }
+	ready := func(t *testing.T, handler *HealthHandler) (int, ReadinessResponse) {
+		t.Helper()
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "http://test/ready", http.NoBody)
+		rec := httptest.NewRecorder()
+
+		handler.Ready(rec, req)
+
+		var got ReadinessResponse
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+
+		return rec.Code, got
+	}
+
+	t.Run("reports last completion and backlog", func(t *testing.T) {
+		lastCompleted := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		handler := NewHealthHandler(ReadinessCheck{Name: "db", Enabled: true, Check: pass})
+		handler.SetEmbeddingPipelineStatus(func(context.Context) (*EmbeddingPipelineStatus, error) {
+			return &EmbeddingPipelineStatus{LastCompletedAt: &lastCompleted, Pending: 42}, nil
+		})
+
+		code, got := ready(t, handler)
+		assert.Equal(t, http.StatusOK, code)
+		require.NotNil(t, got.Embeddings)
+		assert.Equal(t, int64(42), got.Embeddings.Pending)
+		assert.True(t, lastCompleted.Equal(*got.Embeddings.LastCompletedAt))
+	})
+
+	t.Run("a failed lookup is omitted and does not fail readiness", func(t *testing.T) {
+		handler := NewHealthHandler(ReadinessCheck{Name: "db", Enabled: true, Check: pass})
+		handler.SetEmbeddingPipelineStatus(func(context.Context) (*EmbeddingPipelineStatus, error) {
+			return nil, errors.New("query timeout")
+		})
+
+		code, got := ready(t, handler)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", got.Status)
+		assert.Nil(t, got.Embeddings)
+	})
+}
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"math"
 	"net/http"
@@ -18,10 +17,18 @@ import (
 
 // SearchService defines the interface for semantic search and similar feedback.
 type SearchService interface {
-	SemanticSearch(ctx context.Context, query, tenantID string, limit int, minScore float64, cursor string) (
+	SemanticSearch(ctx context.Context, query, tenantID, model string, limit int, minScore float64, cursor string) (
 		service.SearchResult, error)
-	SimilarFeedback(ctx context.Context, feedbackRecordID uuid.UUID, limit int, minScore float64, cursor string) (
+	SimilarFeedback(
+		ctx context.Context, feedbackRecordID uuid.UUID, model string, limit int, minScore float64, cursor string,
+	) (service.SearchResult, error)
+	SimilarToText(ctx context.Context, text, tenantID, model string, limit int, minScore float64, cursor string) (
 		service.SearchResult, error)
+	HybridSearch(ctx context.Context, query, tenantID, model string, limit int, keywordWeight float64) (
+		service.HybridSearchResult, error)
+	// DefaultMinScore is the score floor used when a request omits min_score: the tenant's
+	// search_min_score setting, else service.DefaultSearchMinScore.
+	DefaultMinScore(ctx context.Context, tenantID string) float64
 }
 
 // SearchHandler handles HTTP requests for semantic search and similar feedback.
@@ -35,26 +42,76 @@ func NewSearchHandler(service SearchService) *SearchHandler {
 }
 
 // SemanticSearchRequest is the body for POST /v1/feedback-records/search/semantic (snake_case for consistency with data model).
+// Model optionally selects EMBEDDING_SECONDARY_MODEL for A/B comparison; omitted means the tenant's
+// embedding_model setting, or EMBEDDING_MODEL when unset.
 type SemanticSearchRequest struct {
 	Query    string `json:"query"`
 	TenantID string `json:"tenant_id"`
+	Model    string `json:"model,omitempty"`
+}
+
+// SimilarToTextRequest is the body for POST /v1/feedback-records/similar. Unlike semantic search,
+// paging and the score floor travel in the body too; omitted they take the search defaults
+// (limit 10, and the tenant's search_min_score or 0.7).
+type SimilarToTextRequest struct {
+	Text     string   `json:"text"`
+	TenantID string   `json:"tenant_id"`
+	Model    string   `json:"model,omitempty"`
+	Limit    *int     `json:"limit,omitempty"     validate:"omitempty,min=1,max=100"`
+	MinScore *float64 `json:"min_score,omitempty" validate:"omitempty,min=0,max=1"`
+	Cursor   string   `json:"cursor,omitempty"`
+}
+
+// HybridSearchRequest is the body for POST /v1/feedback-records/search/hybrid. KeywordWeight is
+// the keyword ranking's share of the fused score, in [0, 1]; omitted it is 0.5.
+type HybridSearchRequest struct {
+	Query         string   `json:"query"`
+	TenantID      string   `json:"tenant_id"`
+	Model         string   `json:"model,omitempty"`
+	KeywordWeight *float64 `json:"keyword_weight,omitempty" validate:"omitempty,min=0,max=1"`
 }
 
 // SemanticSearchResponse is the response for semantic search and similar feedback (consistent with list endpoints: data, limit).
+// Explain is only set for semantic search with explain=true.
 type SemanticSearchResponse struct {
 	Data       []SemanticSearchResultItem `json:"data"`
 	Limit      int                        `json:"limit"`
 	NextCursor string                     `json:"next_cursor,omitempty"`
+	Explain    *SearchExplain             `json:"explain,omitempty"`
 }
 
 // SemanticSearchResultItem is one result: feedback_record_id, score, field_label, value_text (snake_case).
 type SemanticSearchResultItem struct {
-	FeedbackRecordID uuid.UUID `json:"feedback_record_id"`
-	Score            float64   `json:"score"`
-	FieldLabel       string    `json:"field_label"`
-	ValueText        string    `json:"value_text"` // value_text of the feedback record (the text that was embedded)
+	FeedbackRecordID uuid.UUID            `json:"feedback_record_id"`
+	Score            float64              `json:"score"`
+	FieldLabel       string               `json:"field_label"`
+	ValueText        string               `json:"value_text"` // value_text of the feedback record (the text that was embedded)
+	Explain          *SearchResultExplain `json:"explain,omitempty"`
 }
 
+// SearchExplain describes how a semantic search was run (explain=true): the model searched, the
+// score floor applied, and whether the query vector came from the query embedding cache. Semantic
+// search is vector-only, so ranking is the cosine similarity alone; there is no keyword score or
+// fusion (see HybridSearch for that).
+type SearchExplain struct {
+	Mode                string  `json:"mode"`
+	Model               string  `json:"model"`
+	MinScore            float64 `json:"min_score"`
+	QueryEmbeddingCache string  `json:"query_embedding_cache"`
+}
+
+// SearchResultExplain is one result's score breakdown. The min_score floor is applied in the
+// query, so every returned row passed it; PassedThreshold is stated rather than left implied.
+type SearchResultExplain struct {
+	VectorSimilarity float64 `json:"vector_similarity"`
+	CosineDistance   float64 `json:"cosine_distance"`
+	PassedThreshold  bool    `json:"passed_threshold"`
+}
+
+// searchModeSemantic names semantic search in explain output, so a client reading it never has to
+// guess whether keyword scoring took part (only the hybrid endpoint fuses keyword matches in).
+const searchModeSemantic = "semantic"
+
 const (
 	defaultSearchLimit = 10
 	maxSearchLimit     = 100
@@ -76,12 +133,120 @@ func (h *SearchHandler) SemanticSearch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req SemanticSearchRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.RespondError(w, r, err)
 
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
+		return
+	}
 
-	if err := decoder.Decode(&req); err != nil {
-		response.RespondError(w, r, response.NewRequestJSONDecodeError(err))
+	if req.TenantID == "" {
+		response.RespondInvalidParams(w, r, response.InvalidParam{Name: "tenant_id", Reason: "is required"})
+
+		return
+	}
+
+	explain := false
+
+	if raw := r.URL.Query().Get("explain"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.RespondInvalidParams(w, r, response.InvalidParam{Name: "explain", Reason: "must be true or false"})
+
+			return
+		}
+
+		explain = parsed
+	}
+
+	limit := parseLimit(r.URL.Query().Get("limit"), defaultSearchLimit, maxSearchLimit)
+	cursor := strings.TrimSpace(r.URL.Query().Get("cursor"))
+	minScore := parseMinScore(r.URL.Query().Get("min_score"), func() float64 {
+		return h.service.DefaultMinScore(r.Context(), req.TenantID)
+	})
+
+	res, err := h.service.SemanticSearch(r.Context(), req.Query, req.TenantID, req.Model, limit, minScore, cursor)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownEmbeddingModel) {
+			respondUnknownEmbeddingModel(w, r)
+
+			return
+		}
+
+		if errors.Is(err, service.ErrMissingTenantID) {
+			response.RespondInvalidParams(w, r, response.InvalidParam{Name: "tenant_id", Reason: "is required"})
+
+			return
+		}
+
+		if errors.Is(err, service.ErrEmptyQuery) {
+			response.RespondInvalidParams(w, r, response.InvalidParam{Name: "query", Reason: "is required and must be non-empty"})
+
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidCursor) {
+			response.RespondInvalidParams(w, r, response.InvalidParam{Name: "cursor", Reason: response.InvalidCursorReason})
+
+			return
+		}
+
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	resp := SemanticSearchResponse{
+		Data:       toResultItems(res.Results),
+		Limit:      limit,
+		NextCursor: res.NextCursor,
+	}
+
+	if explain {
+		explainResults(&resp, res, minScore)
+	}
+
+	response.RespondJSON(w, http.StatusOK, resp)
+}
+
+// HybridSearchResponse is the response for hybrid search: the fused top results, best first. There
+// is no next_cursor; the fusion ranks a fixed candidate set, so raise limit for more.
+type HybridSearchResponse struct {
+	Data          []HybridSearchResultItem `json:"data"`
+	Limit         int                      `json:"limit"`
+	Model         string                   `json:"model"`
+	KeywordWeight float64                  `json:"keyword_weight"`
+}
+
+// HybridSearchResultItem is one hybrid result; Score is the fused reciprocal rank score.
+type HybridSearchResultItem struct {
+	FeedbackRecordID uuid.UUID            `json:"feedback_record_id"`
+	Score            float64              `json:"score"`
+	FieldLabel       string               `json:"field_label"`
+	ValueText        string               `json:"value_text"`
+	ScoreBreakdown   HybridScoreBreakdown `json:"score_breakdown"`
+}
+
+// HybridScoreBreakdown shows where a hybrid result's score came from: its 1-based rank in the
+// keyword and vector searches and its cosine similarity to the query, each null when that search
+// did not return the record.
+type HybridScoreBreakdown struct {
+	KeywordRank *int     `json:"keyword_rank"`
+	VectorRank  *int     `json:"vector_rank"`
+	VectorScore *float64 `json:"vector_score"`
+}
+
+// HybridSearch handles POST /v1/feedback-records/search/hybrid: keyword and semantic search fused
+// with reciprocal rank fusion.
+func (h *SearchHandler) HybridSearch(w http.ResponseWriter, r *http.Request) {
+	if h.service == nil {
+		response.RespondServiceUnavailable(w, r, "Hybrid search is not available: embeddings are not configured.")
+
+		return
+	}
+
+	var req HybridSearchRequest
+	if err := decodeAndValidateJSON(r, &req); err != nil {
+		response.RespondError(w, r, err)
 
 		return
 	}
@@ -92,12 +257,21 @@ func (h *SearchHandler) SemanticSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	keywordWeight := service.DefaultHybridKeywordWeight
+	if req.KeywordWeight != nil {
+		keywordWeight = *req.KeywordWeight
+	}
+
 	limit := parseLimit(r.URL.Query().Get("limit"), defaultSearchLimit, maxSearchLimit)
-	cursor := strings.TrimSpace(r.URL.Query().Get("cursor"))
-	minScore := parseMinScore(r.URL.Query().Get("min_score"))
 
-	res, err := h.service.SemanticSearch(r.Context(), req.Query, req.TenantID, limit, minScore, cursor)
+	res, err := h.service.HybridSearch(r.Context(), req.Query, req.TenantID, req.Model, limit, keywordWeight)
 	if err != nil {
+		if errors.Is(err, service.ErrUnknownEmbeddingModel) {
+			respondUnknownEmbeddingModel(w, r)
+
+			return
+		}
+
 		if errors.Is(err, service.ErrMissingTenantID) {
 			response.RespondInvalidParams(w, r, response.InvalidParam{Name: "tenant_id", Reason: "is required"})
 
@@ -110,6 +284,83 @@ func (h *SearchHandler) SemanticSearch(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	items := make([]HybridSearchResultItem, len(res.Hits))
+	for i, hit := range res.Hits {
+		items[i] = HybridSearchResultItem{
+			FeedbackRecordID: hit.Record.FeedbackRecordID,
+			Score:            hit.Record.Score,
+			FieldLabel:       hit.Record.FieldLabel,
+			ValueText:        hit.Record.ValueText,
+			ScoreBreakdown: HybridScoreBreakdown{
+				KeywordRank: hit.KeywordRank,
+				VectorRank:  hit.VectorRank,
+				VectorScore: hit.VectorScore,
+			},
+		}
+	}
+
+	response.RespondJSON(w, http.StatusOK, HybridSearchResponse{
+		Data:          items,
+		Limit:         limit,
+		Model:         res.Model,
+		KeywordWeight: res.KeywordWeight,
+	})
+}
+
+// SimilarToText handles POST /v1/feedback-records/similar: feedback similar to a text snippet
+// rather than to an existing record.
+func (h *SearchHandler) SimilarToText(w http.ResponseWriter, r *http.Request) {
+	if h.service == nil {
+		response.RespondServiceUnavailable(w, r, "Similar feedback is not available: embeddings are not configured.")
+
+		return
+	}
+
+	var req SimilarToTextRequest
+	if err := decodeAndValidateJSON(r, &req); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	if req.TenantID == "" {
+		response.RespondInvalidParams(w, r, response.InvalidParam{Name: "tenant_id", Reason: "is required"})
+
+		return
+	}
+
+	limit := defaultSearchLimit
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	var minScore float64
+	if req.MinScore != nil {
+		minScore = *req.MinScore
+	} else {
+		minScore = h.service.DefaultMinScore(r.Context(), req.TenantID)
+	}
+
+	res, err := h.service.SimilarToText(
+		r.Context(), req.Text, req.TenantID, req.Model, limit, minScore, strings.TrimSpace(req.Cursor))
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownEmbeddingModel) {
+			respondUnknownEmbeddingModel(w, r)
+
+			return
+		}
+
+		if errors.Is(err, service.ErrEmptyQuery) {
+			response.RespondInvalidParams(w, r, response.InvalidParam{Name: "text", Reason: "is required and must be non-empty"})
+
+			return
+		}
+
 		if errors.Is(err, service.ErrInvalidCursor) {
 			response.RespondInvalidParams(w, r, response.InvalidParam{Name: "cursor", Reason: response.InvalidCursorReason})
 
@@ -159,10 +410,18 @@ func (h *SearchHandler) SimilarFeedback(w http.ResponseWriter, r *http.Request)
 
 	limit := parseLimit(r.URL.Query().Get("limit"), defaultSearchLimit, maxSearchLimit)
 	cursor := strings.TrimSpace(r.URL.Query().Get("cursor"))
-	minScore := parseMinScore(r.URL.Query().Get("min_score"))
+	// The source record's tenant is only known inside the lookup, so an omitted min_score takes
+	// the global default here rather than a tenant's search_min_score.
+	minScore := parseMinScore(r.URL.Query().Get("min_score"), func() float64 { return service.DefaultSearchMinScore })
 
-	res, err := h.service.SimilarFeedback(r.Context(), id, limit, minScore, cursor)
+	res, err := h.service.SimilarFeedback(r.Context(), id, r.URL.Query().Get("model"), limit, minScore, cursor)
 	if err != nil {
+		if errors.Is(err, service.ErrUnknownEmbeddingModel) {
+			respondUnknownEmbeddingModel(w, r)
+
+			return
+		}
+
 		if errors.Is(err, service.ErrMissingTenantID) {
 			response.RespondNotFound(w, r, "Source feedback record not found or has no tenant")
 
@@ -170,7 +429,7 @@ func (h *SearchHandler) SimilarFeedback(w http.ResponseWriter, r *http.Request)
 		}
 
 		if errors.Is(err, service.ErrEmbeddingNotFound) {
-			response.RespondNotFound(w, r, "Feedback record has no embedding for the current model")
+			response.RespondNotFound(w, r, "Feedback record has no embedding for the selected model")
 
 			return
 		}
@@ -193,6 +452,12 @@ func (h *SearchHandler) SimilarFeedback(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+func respondUnknownEmbeddingModel(w http.ResponseWriter, r *http.Request) {
+	response.RespondInvalidParams(w, r, response.InvalidParam{
+		Name: "model", Reason: "must be the configured embedding model or secondary embedding model",
+	})
+}
+
 // parseLimit returns the query param as int clamped to [1, upperBound]; default def when param is missing or invalid.
 func parseLimit(s string, def, upperBound int) int {
 	if s == "" {
@@ -207,18 +472,16 @@ func parseLimit(s string, def, upperBound int) int {
 	return min(n, upperBound)
 }
 
-// defaultMinScore is the default minimum similarity score when the query param is omitted (reduces noise).
-const defaultMinScore = 0.7
-
-// parseMinScore returns the query param "min_score" as a float in [0,1]; default defaultMinScore.
-func parseMinScore(s string) float64 {
+// parseMinScore returns the query param "min_score" as a float in [0,1]; when it is missing or
+// invalid, the floor from def (called only then, so a settings read is skipped when not needed).
+func parseMinScore(s string, def func() float64) float64 {
 	if s == "" {
-		return defaultMinScore
+		return def()
 	}
 
 	val, err := strconv.ParseFloat(s, 64)
 	if err != nil {
-		return defaultMinScore
+		return def()
 	}
 
 	if val < 0 {
@@ -241,3 +504,21 @@ func toResultItems(results []models.FeedbackRecordWithScore) []SemanticSearchRes
 
 	return items
 }
+
+// explainResults attaches the explain=true breakdown to a semantic search response.
+func explainResults(resp *SemanticSearchResponse, res service.SearchResult, minScore float64) {
+	resp.Explain = &SearchExplain{
+		Mode:                searchModeSemantic,
+		Model:               res.Model,
+		MinScore:            minScore,
+		QueryEmbeddingCache: res.QueryEmbeddingCache,
+	}
+
+	for i := range resp.Data {
+		resp.Data[i].Explain = &SearchResultExplain{
+			VectorSimilarity: res.Results[i].Score,
+			CosineDistance:   res.Results[i].Distance,
+			PassedThreshold:  res.Results[i].Score >= minScore,
+		}
+	}
+}
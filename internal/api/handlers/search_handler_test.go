@@ -17,32 +17,65 @@ import (
 )
 
 type mockSearchService struct {
-	semanticFunc func(ctx context.Context, query, tenantID string, limit int, minScore float64,
+	semanticFunc func(ctx context.Context, query, tenantID, model string, limit int, minScore float64,
 		cursor string) (service.SearchResult, error)
-	similarFunc func(ctx context.Context, feedbackRecordID uuid.UUID, limit int, minScore float64,
+	similarFunc func(ctx context.Context, feedbackRecordID uuid.UUID, model string, limit int, minScore float64,
 		cursor string) (service.SearchResult, error)
+	similarToTextFunc func(ctx context.Context, text, tenantID, model string, limit int, minScore float64,
+		cursor string) (service.SearchResult, error)
+	hybridFunc func(ctx context.Context, query, tenantID, model string, limit int,
+		keywordWeight float64) (service.HybridSearchResult, error)
+	defaultMinScoreFunc func(ctx context.Context, tenantID string) float64
 }
 
 func (m *mockSearchService) SemanticSearch(
-	ctx context.Context, query, tenantID string, limit int, minScore float64, cursor string,
+	ctx context.Context, query, tenantID, model string, limit int, minScore float64, cursor string,
 ) (service.SearchResult, error) {
 	if m.semanticFunc != nil {
-		return m.semanticFunc(ctx, query, tenantID, limit, minScore, cursor)
+		return m.semanticFunc(ctx, query, tenantID, model, limit, minScore, cursor)
 	}
 
 	return service.SearchResult{}, nil
 }
 
 func (m *mockSearchService) SimilarFeedback(
-	ctx context.Context, feedbackRecordID uuid.UUID, limit int, minScore float64, cursor string,
+	ctx context.Context, feedbackRecordID uuid.UUID, model string, limit int, minScore float64, cursor string,
 ) (service.SearchResult, error) {
 	if m.similarFunc != nil {
-		return m.similarFunc(ctx, feedbackRecordID, limit, minScore, cursor)
+		return m.similarFunc(ctx, feedbackRecordID, model, limit, minScore, cursor)
+	}
+
+	return service.SearchResult{}, nil
+}
+
+func (m *mockSearchService) SimilarToText(
+	ctx context.Context, text, tenantID, model string, limit int, minScore float64, cursor string,
+) (service.SearchResult, error) {
+	if m.similarToTextFunc != nil {
+		return m.similarToTextFunc(ctx, text, tenantID, model, limit, minScore, cursor)
 	}
 
 	return service.SearchResult{}, nil
 }
 
+func (m *mockSearchService) HybridSearch(
+	ctx context.Context, query, tenantID, model string, limit int, keywordWeight float64,
+) (service.HybridSearchResult, error) {
+	if m.hybridFunc != nil {
+		return m.hybridFunc(ctx, query, tenantID, model, limit, keywordWeight)
+	}
+
+	return service.HybridSearchResult{}, nil
+}
+
+func (m *mockSearchService) DefaultMinScore(ctx context.Context, tenantID string) float64 {
+	if m.defaultMinScoreFunc != nil {
+		return m.defaultMinScoreFunc(ctx, tenantID)
+	}
+
+	return service.DefaultSearchMinScore
+}
+
 func TestSearchHandler_SemanticSearch(t *testing.T) {
 	t.Run("missing tenant_id returns 400", func(t *testing.T) {
 		handler := NewSearchHandler(&mockSearchService{})
@@ -61,7 +94,7 @@ func TestSearchHandler_SemanticSearch(t *testing.T) {
 	t.Run("empty query returns 400", func(t *testing.T) {
 		called := false
 		mock := &mockSearchService{
-			semanticFunc: func(_ context.Context, _, _ string, _ int, _ float64, _ string) (service.SearchResult, error) {
+			semanticFunc: func(_ context.Context, _, _, _ string, _ int, _ float64, _ string) (service.SearchResult, error) {
 				called = true
 
 				return service.SearchResult{}, service.ErrEmptyQuery
@@ -87,7 +120,7 @@ func TestSearchHandler_SemanticSearch(t *testing.T) {
 		val1 := "Login is very slow."
 		val2 := "Dashboard loads fast."
 		mock := &mockSearchService{
-			semanticFunc: func(_ context.Context, query, tenantID string, limit int, minScore float64,
+			semanticFunc: func(_ context.Context, query, tenantID, _ string, limit int, minScore float64,
 				cursor string,
 			) (service.SearchResult, error) {
 				assert.Equal(t, "login is slow", query)
@@ -130,11 +163,64 @@ func TestSearchHandler_SemanticSearch(t *testing.T) {
 		assert.InDelta(t, 0.85, resp.Data[1].Score, 1e-9)
 		assert.Equal(t, "Label2", resp.Data[1].FieldLabel)
 		assert.Equal(t, val2, resp.Data[1].ValueText)
+		assert.Nil(t, resp.Explain, "explain output is opt-in")
+		assert.Nil(t, resp.Data[0].Explain)
+	})
+
+	t.Run("explain=true adds the score breakdown", func(t *testing.T) {
+		mock := &mockSearchService{
+			semanticFunc: func(_ context.Context, _, _, _ string, _ int, _ float64, _ string) (service.SearchResult, error) {
+				return service.SearchResult{
+					Results: []models.FeedbackRecordWithScore{
+						{FeedbackRecordID: uuid.New(), Score: 0.8, Distance: 0.2},
+					},
+					Model:               "model-a",
+					QueryEmbeddingCache: service.QueryEmbeddingCacheHit,
+				}, nil
+			},
+		}
+		handler := NewSearchHandler(mock)
+		body := []byte(`{"query":"login is slow","tenant_id":"env-1"}`)
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost,
+			"http://test/v1/feedback-records/search/semantic?explain=true&min_score=0.75", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+
+		handler.SemanticSearch(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp SemanticSearchResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Explain)
+		assert.Equal(t, SearchExplain{
+			Mode: "semantic", Model: "model-a", MinScore: 0.75, QueryEmbeddingCache: "hit",
+		}, *resp.Explain)
+		require.Len(t, resp.Data, 1)
+		require.NotNil(t, resp.Data[0].Explain)
+		assert.InDelta(t, 0.8, resp.Data[0].Explain.VectorSimilarity, 1e-9)
+		assert.InDelta(t, 0.2, resp.Data[0].Explain.CosineDistance, 1e-9)
+		assert.True(t, resp.Data[0].Explain.PassedThreshold)
+	})
+
+	t.Run("invalid explain returns 400", func(t *testing.T) {
+		handler := NewSearchHandler(&mockSearchService{})
+		body := []byte(`{"query":"login is slow","tenant_id":"env-1"}`)
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost,
+			"http://test/v1/feedback-records/search/semantic?explain=maybe", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+
+		handler.SemanticSearch(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 
 	t.Run("invalid cursor returns 400", func(t *testing.T) {
 		mock := &mockSearchService{
-			semanticFunc: func(_ context.Context, _, _ string, _ int, _ float64, cursor string) (service.SearchResult, error) {
+			semanticFunc: func(_ context.Context, _, _, _ string, _ int, _ float64, cursor string) (service.SearchResult, error) {
 				if cursor != "" {
 					return service.SearchResult{}, service.ErrInvalidCursor
 				}
@@ -163,7 +249,7 @@ func TestSearchHandler_SimilarFeedback(t *testing.T) {
 		id := uuid.MustParse("018e1234-5678-9abc-def0-123456789abc")
 		similarID := uuid.MustParse("018e1234-5678-9abc-def0-aaaaaaaaaaaa")
 		mock := &mockSearchService{
-			similarFunc: func(_ context.Context, fid uuid.UUID, limit int, minScore float64,
+			similarFunc: func(_ context.Context, fid uuid.UUID, _ string, limit int, minScore float64,
 				cursor string,
 			) (service.SearchResult, error) {
 				assert.Equal(t, id, fid)
@@ -191,7 +277,7 @@ func TestSearchHandler_SimilarFeedback(t *testing.T) {
 
 	t.Run("embedding not found returns 404", func(t *testing.T) {
 		mock := &mockSearchService{
-			similarFunc: func(_ context.Context, _ uuid.UUID, _ int, _ float64, _ string) (service.SearchResult, error) {
+			similarFunc: func(_ context.Context, _ uuid.UUID, _ string, _ int, _ float64, _ string) (service.SearchResult, error) {
 				return service.SearchResult{}, service.ErrEmbeddingNotFound
 			},
 		}
@@ -209,7 +295,7 @@ func TestSearchHandler_SimilarFeedback(t *testing.T) {
 	t.Run("source record without tenant returns 404", func(t *testing.T) {
 		id := uuid.MustParse("018e1234-5678-9abc-def0-123456789abc")
 		mock := &mockSearchService{
-			similarFunc: func(_ context.Context, fid uuid.UUID, _ int, _ float64, _ string) (service.SearchResult, error) {
+			similarFunc: func(_ context.Context, fid uuid.UUID, _ string, _ int, _ float64, _ string) (service.SearchResult, error) {
 				assert.Equal(t, id, fid)
 
 				return service.SearchResult{}, service.ErrMissingTenantID
@@ -231,7 +317,7 @@ func TestSearchHandler_SimilarFeedback(t *testing.T) {
 		similarID := uuid.MustParse("018e1234-5678-9abc-def0-aaaaaaaaaaaa")
 		similarVal := "Similar feedback text."
 		mock := &mockSearchService{
-			similarFunc: func(_ context.Context, fid uuid.UUID, limit int, minScore float64,
+			similarFunc: func(_ context.Context, fid uuid.UUID, _ string, limit int, minScore float64,
 				cursor string,
 			) (service.SearchResult, error) {
 				assert.Equal(t, id, fid)
@@ -269,6 +355,225 @@ func TestSearchHandler_SimilarFeedback(t *testing.T) {
 	})
 }
 
+func TestSearchHandler_SimilarToText(t *testing.T) {
+	const similarTextURL = "http://test/v1/feedback-records/similar"
+
+	post := func(handler *SearchHandler, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, similarTextURL, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		handler.SimilarToText(rec, req)
+
+		return rec
+	}
+
+	t.Run("defaults limit and min_score", func(t *testing.T) {
+		mock := &mockSearchService{
+			similarToTextFunc: func(_ context.Context, text, tenantID, _ string, limit int, minScore float64,
+				cursor string,
+			) (service.SearchResult, error) {
+				assert.Equal(t, "checkout keeps failing", text)
+				assert.Equal(t, "env-1", tenantID)
+				assert.Equal(t, 10, limit)
+				assert.InDelta(t, 0.7, minScore, 1e-9)
+				assert.Empty(t, cursor)
+
+				return service.SearchResult{}, nil
+			},
+		}
+
+		rec := post(NewSearchHandler(mock), `{"text":"checkout keeps failing","tenant_id":"env-1"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("omitted min_score takes the tenant default", func(t *testing.T) {
+		mock := &mockSearchService{
+			defaultMinScoreFunc: func(_ context.Context, tenantID string) float64 {
+				assert.Equal(t, "env-1", tenantID)
+
+				return 0.55
+			},
+			similarToTextFunc: func(_ context.Context, _, _, _ string, _ int, minScore float64, _ string) (service.SearchResult, error) {
+				assert.InDelta(t, 0.55, minScore, 1e-9)
+
+				return service.SearchResult{}, nil
+			},
+		}
+
+		rec := post(NewSearchHandler(mock), `{"text":"slow","tenant_id":"env-1"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("body limit and min_score are passed through", func(t *testing.T) {
+		mock := &mockSearchService{
+			similarToTextFunc: func(_ context.Context, _, _, _ string, limit int, minScore float64, _ string) (service.SearchResult, error) {
+				assert.Equal(t, 5, limit)
+				assert.InDelta(t, 0, minScore, 1e-9, "an explicit zero is not replaced by the default")
+
+				return service.SearchResult{}, nil
+			},
+		}
+
+		rec := post(NewSearchHandler(mock), `{"text":"slow","tenant_id":"env-1","limit":5,"min_score":0}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp SemanticSearchResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 5, resp.Limit)
+	})
+
+	t.Run("invalid input returns 400", func(t *testing.T) {
+		handler := NewSearchHandler(&mockSearchService{
+			similarToTextFunc: func(_ context.Context, _, _, _ string, _ int, _ float64, _ string) (service.SearchResult, error) {
+				return service.SearchResult{}, service.ErrEmptyQuery
+			},
+		})
+
+		for _, body := range []string{
+			`{"text":"slow"}`,
+			`{"text":"slow","tenant_id":"env-1","limit":101}`,
+			`{"text":"slow","tenant_id":"env-1","min_score":1.5}`,
+			`{"text":" ","tenant_id":"env-1"}`,
+		} {
+			assert.Equal(t, http.StatusBadRequest, post(handler, body).Code, body)
+		}
+	})
+
+	t.Run("embeddings disabled returns 503", func(t *testing.T) {
+		rec := post(NewSearchHandler(nil), `{"text":"slow","tenant_id":"env-1"}`)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
+
+func TestSearchHandler_HybridSearch(t *testing.T) {
+	const hybridURL = "http://test/v1/feedback-records/search/hybrid"
+
+	post := func(handler *SearchHandler, url, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		handler.HybridSearch(rec, req)
+
+		return rec
+	}
+
+	t.Run("defaults keyword_weight and returns the score breakdown", func(t *testing.T) {
+		id := uuid.New()
+		keywordRank := 3
+		mock := &mockSearchService{
+			hybridFunc: func(_ context.Context, query, tenantID, _ string, limit int, keywordWeight float64,
+			) (service.HybridSearchResult, error) {
+				assert.Equal(t, "ERR-42", query)
+				assert.Equal(t, "env-1", tenantID)
+				assert.Equal(t, 5, limit)
+				assert.InDelta(t, 0.5, keywordWeight, 1e-9)
+
+				return service.HybridSearchResult{
+					Model:         "test-model",
+					KeywordWeight: keywordWeight,
+					Hits: []service.HybridSearchHit{{
+						Record:      models.FeedbackRecordWithScore{FeedbackRecordID: id, Score: 0.0079, ValueText: "ERR-42 at checkout"},
+						KeywordRank: &keywordRank,
+					}},
+				}, nil
+			},
+		}
+
+		rec := post(NewSearchHandler(mock), hybridURL+"?limit=5", `{"query":"ERR-42","tenant_id":"env-1"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"score_breakdown":{"keyword_rank":3,"vector_rank":null,"vector_score":null}`)
+
+		var resp HybridSearchResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 5, resp.Limit)
+		assert.Equal(t, "test-model", resp.Model)
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, id, resp.Data[0].FeedbackRecordID)
+	})
+
+	t.Run("body keyword_weight is passed through", func(t *testing.T) {
+		mock := &mockSearchService{
+			hybridFunc: func(_ context.Context, _, _, _ string, _ int, keywordWeight float64) (service.HybridSearchResult, error) {
+				assert.InDelta(t, 0, keywordWeight, 1e-9, "an explicit zero is not replaced by the default")
+
+				return service.HybridSearchResult{}, nil
+			},
+		}
+
+		rec := post(NewSearchHandler(mock), hybridURL, `{"query":"slow","tenant_id":"env-1","keyword_weight":0}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid input returns 400", func(t *testing.T) {
+		handler := NewSearchHandler(&mockSearchService{
+			hybridFunc: func(context.Context, string, string, string, int, float64) (service.HybridSearchResult, error) {
+				return service.HybridSearchResult{}, service.ErrEmptyQuery
+			},
+		})
+
+		for _, body := range []string{
+			`{"query":"slow"}`,
+			`{"query":"slow","tenant_id":"env-1","keyword_weight":1.5}`,
+			`{"query":" ","tenant_id":"env-1"}`,
+		} {
+			assert.Equal(t, http.StatusBadRequest, post(handler, hybridURL, body).Code, body)
+		}
+	})
+
+	t.Run("embeddings disabled returns 503", func(t *testing.T) {
+		rec := post(NewSearchHandler(nil), hybridURL, `{"query":"slow","tenant_id":"env-1"}`)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
+
+func TestSearchHandler_Model(t *testing.T) {
+	t.Run("semantic search passes the body model", func(t *testing.T) {
+		var got string
+
+		handler := NewSearchHandler(&mockSearchService{
+			semanticFunc: func(_ context.Context, _, _, model string, _ int, _ float64, _ string) (service.SearchResult, error) {
+				got = model
+
+				return service.SearchResult{}, nil
+			},
+		})
+		body := []byte(`{"query":"login is slow","tenant_id":"env-1","model":"candidate"}`)
+		req := httptest.NewRequestWithContext(context.Background(),
+			http.MethodPost, "http://test/v1/feedback-records/search/semantic", bytes.NewReader(body))
+
+		rec := httptest.NewRecorder()
+
+		handler.SemanticSearch(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "candidate", got)
+	})
+
+	t.Run("similar feedback passes the query model; unknown model is 400", func(t *testing.T) {
+		var got string
+
+		handler := NewSearchHandler(&mockSearchService{
+			similarFunc: func(_ context.Context, _ uuid.UUID, model string, _ int, _ float64, _ string) (service.SearchResult, error) {
+				got = model
+
+				return service.SearchResult{}, service.ErrUnknownEmbeddingModel
+			},
+		})
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, similarURL+"?model=other", nil)
+		req.SetPathValue("id", uuid.NewString())
+
+		rec := httptest.NewRecorder()
+
+		handler.SimilarFeedback(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "other", got)
+		assert.Contains(t, rec.Body.String(), `"model"`)
+	})
+}
+
 func TestSearchHandler_MethodNotAllowedSetsAllowHeader(t *testing.T) {
 	t.Run("semantic search wrong method advertises POST", func(t *testing.T) {
 		handler := NewSearchHandler(&mockSearchService{})
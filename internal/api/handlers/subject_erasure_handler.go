@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/formbricks/hub/internal/api/response"
+	"github.com/formbricks/hub/internal/models"
+)
+
+// SubjectErasureService defines the interface for GDPR subject erasure business logic.
+type SubjectErasureService interface {
+	EraseSubject(ctx context.Context, req *models.SubjectErasureRequest) (*models.SubjectErasureReport, error)
+}
+
+// SubjectErasureHandler handles GDPR subject erasure requests.
+type SubjectErasureHandler struct {
+	service SubjectErasureService
+}
+
+// NewSubjectErasureHandler creates a new subject erasure handler.
+func NewSubjectErasureHandler(service SubjectErasureService) *SubjectErasureHandler {
+	return &SubjectErasureHandler{service: service}
+}
+
+// Erase handles POST /v1/gdpr/erase.
+func (h *SubjectErasureHandler) Erase(w http.ResponseWriter, r *http.Request) {
+	var req models.SubjectErasureRequest
+	if err := decodeAndValidateJSON(r, &req); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	report, err := h.service.EraseSubject(r.Context(), &req)
+	if err != nil {
+		// Mirrors DeleteByUser: the identifiers being erased are never logged, only their shape.
+		tenantIDLength := 0
+		if req.TenantID != nil {
+			tenantIDLength = len(*req.TenantID)
+		}
+
+		response.RespondErrorWithLogAttrs(w, r, err,
+			"user_id_length", len(req.UserID),
+			"tenant_id_present", tenantIDLength > 0,
+			"tenant_id_length", tenantIDLength,
+		)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/api/response"
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+)
+
+type mockSubjectErasureService struct {
+	eraseFunc func(ctx context.Context, req *models.SubjectErasureRequest) (*models.SubjectErasureReport, error)
+}
+
+func (m *mockSubjectErasureService) EraseSubject(
+	ctx context.Context, req *models.SubjectErasureRequest,
+) (*models.SubjectErasureReport, error) {
+	if m.eraseFunc != nil {
+		return m.eraseFunc(ctx, req)
+	}
+
+	return nil, nil
+}
+
+func TestSubjectErasureHandler_Erase(t *testing.T) {
+	t.Run("success returns the report", func(t *testing.T) {
+		mock := &mockSubjectErasureService{
+			eraseFunc: func(_ context.Context, req *models.SubjectErasureRequest) (*models.SubjectErasureReport, error) {
+				assert.Equal(t, "user-1", req.UserID)
+				require.NotNil(t, req.TenantID)
+				assert.Equal(t, "org-123", *req.TenantID)
+
+				return &models.SubjectErasureReport{
+					UserID:                 req.UserID,
+					TenantID:               req.TenantID,
+					DeletedFeedbackRecords: 2,
+					Tenants:                []models.SubjectErasureTenant{{TenantID: "org-123", DeletedFeedbackRecords: 2}},
+					DeletedEmbeddings:      2,
+					CanceledEmbeddingJobs:  1,
+				}, nil
+			},
+		}
+		handler := NewSubjectErasureHandler(mock)
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "http://test/v1/gdpr/erase",
+			strings.NewReader(`{"user_id":"user-1","tenant_id":"org-123"}`))
+		rec := httptest.NewRecorder()
+
+		handler.Erase(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp models.SubjectErasureReport
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, int64(2), resp.DeletedFeedbackRecords)
+		assert.Equal(t, int64(1), resp.CanceledEmbeddingJobs)
+		require.Len(t, resp.Tenants, 1)
+		assert.Equal(t, "org-123", resp.Tenants[0].TenantID)
+	})
+
+	t.Run("missing user_id returns validation problem", func(t *testing.T) {
+		handler := NewSubjectErasureHandler(&mockSubjectErasureService{})
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "http://test/v1/gdpr/erase",
+			strings.NewReader(`{"tenant_id":"org-123"}`))
+		rec := httptest.NewRecorder()
+
+		handler.Erase(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var problem response.ProblemDetails
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		require.NotEmpty(t, problem.InvalidParams)
+		assert.Equal(t, "user_id", problem.InvalidParams[0].Name)
+	})
+
+	t.Run("tenant under purge returns conflict", func(t *testing.T) {
+		mock := &mockSubjectErasureService{
+			eraseFunc: func(context.Context, *models.SubjectErasureRequest) (*models.SubjectErasureReport, error) {
+				return nil, huberrors.NewTenantWriteConflictError("tenant is being purged; retry")
+			},
+		}
+		handler := NewSubjectErasureHandler(mock)
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "http://test/v1/gdpr/erase",
+			strings.NewReader(`{"user_id":"user-1"}`))
+		rec := httptest.NewRecorder()
+
+		handler.Erase(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
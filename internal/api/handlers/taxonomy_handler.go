@@ -2,9 +2,7 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -21,10 +19,20 @@ type TaxonomyService interface {
 	StartManualRun(ctx context.Context, req models.CreateTaxonomyRunRequest) (*models.CreateTaxonomyRunResponse, error)
 	ListRuns(ctx context.Context, filters models.ListTaxonomyRunsFilters) (*models.ListTaxonomyRunsResponse, error)
 	GetRun(ctx context.Context, runID uuid.UUID, tenantID string) (*models.TaxonomyRun, error)
-	GetActiveTree(ctx context.Context, scope models.TaxonomyScope) (*models.TaxonomyTreeResponse, error)
-	GetTree(ctx context.Context, runID uuid.UUID, tenantID string) (*models.TaxonomyTreeResponse, error)
+	CancelRun(ctx context.Context, runID uuid.UUID, tenantID string) (*models.TaxonomyRun, error)
+	GetActiveTree(
+		ctx context.Context, scope models.TaxonomyScope, opts models.TaxonomyTreeOptions,
+	) (*models.TaxonomyTreeResponse, error)
+	GetTree(
+		ctx context.Context, runID uuid.UUID, tenantID string, opts models.TaxonomyTreeOptions,
+	) (*models.TaxonomyTreeResponse, error)
 	RenameNode(ctx context.Context, nodeID uuid.UUID, req models.RenameTaxonomyNodeRequest) (*models.TaxonomyNode, error)
 	RemoveNode(ctx context.Context, nodeID uuid.UUID, filters models.RemoveTaxonomyNodeFilters) (*models.TaxonomyNode, error)
+	RemoveNodes(ctx context.Context, req models.RemoveTaxonomyNodesRequest) (*models.RemoveTaxonomyNodesResponse, error)
+	MoveNode(ctx context.Context, nodeID uuid.UUID, req models.MoveTaxonomyNodeRequest) (*models.TaxonomyNode, error)
+	MergeNode(
+		ctx context.Context, nodeID uuid.UUID, req models.MergeTaxonomyNodeRequest,
+	) (*models.MergeTaxonomyNodeResponse, error)
 	ListNodeRecords(
 		ctx context.Context,
 		nodeID uuid.UUID,
@@ -35,6 +43,11 @@ type TaxonomyService interface {
 		runID uuid.UUID,
 		tenantID string,
 	) (*models.TaxonomyRecordCountsResponse, error)
+	RelatedTopics(
+		ctx context.Context,
+		feedbackRecordID uuid.UUID,
+		filters models.RelatedTopicsFilters,
+	) (*models.RelatedTopicsResponse, error)
 }
 
 // TaxonomyHandler hosts public taxonomy API endpoints.
@@ -139,14 +152,43 @@ func (h *TaxonomyHandler) GetRun(w http.ResponseWriter, r *http.Request) {
 	response.RespondJSON(w, http.StatusOK, result)
 }
 
-// GetActiveTree returns the active taxonomy tree for a field scope.
+// CancelRun cancels a pending or running taxonomy run; a run that already finished is a conflict.
+func (h *TaxonomyHandler) CancelRun(w http.ResponseWriter, r *http.Request) {
+	if h.service == nil {
+		response.RespondServiceUnavailable(w, r, "Taxonomy is not available.")
+
+		return
+	}
+
+	runID, ok := parseUUIDPathValue(w, r, "run_id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.CancelRun(r.Context(), runID, r.URL.Query().Get("tenant_id"))
+	if err != nil {
+		respondTaxonomyError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusOK, result)
+}
+
+// GetActiveTree returns the active taxonomy tree for a field scope. The whole tree is loaded in
+// one query; max_depth and sort shape it (see taxonomyTreeOptionsFromQuery).
 func (h *TaxonomyHandler) GetActiveTree(w http.ResponseWriter, r *http.Request) {
 	scope, ok := taxonomyScopeFromQuery(w, r)
 	if !ok {
 		return
 	}
 
-	result, err := h.service.GetActiveTree(r.Context(), scope)
+	opts, ok := taxonomyTreeOptionsFromQuery(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.GetActiveTree(r.Context(), scope, opts)
 	if err != nil {
 		respondTaxonomyError(w, r, err)
 
@@ -156,14 +198,19 @@ func (h *TaxonomyHandler) GetActiveTree(w http.ResponseWriter, r *http.Request)
 	response.RespondJSON(w, http.StatusOK, result)
 }
 
-// GetTree returns a taxonomy tree for a run.
+// GetTree returns a taxonomy tree for a run, shaped by max_depth and sort like GetActiveTree.
 func (h *TaxonomyHandler) GetTree(w http.ResponseWriter, r *http.Request) {
 	runID, ok := parseUUIDPathValue(w, r, "run_id")
 	if !ok {
 		return
 	}
 
-	result, err := h.service.GetTree(r.Context(), runID, r.URL.Query().Get("tenant_id"))
+	opts, ok := taxonomyTreeOptionsFromQuery(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.GetTree(r.Context(), runID, r.URL.Query().Get("tenant_id"), opts)
 	if err != nil {
 		respondTaxonomyError(w, r, err)
 
@@ -238,6 +285,74 @@ func (h *TaxonomyHandler) RemoveNode(w http.ResponseWriter, r *http.Request) {
 	response.RespondJSON(w, http.StatusOK, result)
 }
 
+// RemoveNodes soft-removes a batch of taxonomy nodes (DELETE /v1/taxonomy/nodes), or with
+// dry_run reports what removing them would hide.
+func (h *TaxonomyHandler) RemoveNodes(w http.ResponseWriter, r *http.Request) {
+	var req models.RemoveTaxonomyNodesRequest
+	if err := decodeAndValidateJSON(r, &req); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	result, err := h.service.RemoveNodes(r.Context(), req)
+	if err != nil {
+		respondTaxonomyError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusOK, result)
+}
+
+// MoveNode moves a taxonomy node and its subtree under a new parent node.
+func (h *TaxonomyHandler) MoveNode(w http.ResponseWriter, r *http.Request) {
+	nodeID, ok := parseUUIDPathValue(w, r, "node_id")
+	if !ok {
+		return
+	}
+
+	var req models.MoveTaxonomyNodeRequest
+	if err := decodeAndValidateJSON(r, &req); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	result, err := h.service.MoveNode(r.Context(), nodeID, req)
+	if err != nil {
+		respondTaxonomyError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusOK, result)
+}
+
+// MergeNode merges a taxonomy node into another node, moving its feedback records and children.
+func (h *TaxonomyHandler) MergeNode(w http.ResponseWriter, r *http.Request) {
+	nodeID, ok := parseUUIDPathValue(w, r, "node_id")
+	if !ok {
+		return
+	}
+
+	var req models.MergeTaxonomyNodeRequest
+	if err := decodeAndValidateJSON(r, &req); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	result, err := h.service.MergeNode(r.Context(), nodeID, req)
+	if err != nil {
+		respondTaxonomyError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusOK, result)
+}
+
 // ListNodeRecords returns feedback records assigned to a taxonomy node.
 func (h *TaxonomyHandler) ListNodeRecords(w http.ResponseWriter, r *http.Request) {
 	nodeID, ok := parseUUIDPathValue(w, r, "node_id")
@@ -262,19 +377,37 @@ func (h *TaxonomyHandler) ListNodeRecords(w http.ResponseWriter, r *http.Request
 	response.RespondJSON(w, http.StatusOK, result)
 }
 
-func decodeAndValidateJSON(r *http.Request, dst any) error {
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
+// RelatedTopics returns the topics most similar to a feedback record (GET
+// /v1/feedback-records/{id}/related-topics). A record that has not been embedded for taxonomy yet
+// is a 422: the record exists, but it cannot be compared until its embedding job has run.
+func (h *TaxonomyHandler) RelatedTopics(w http.ResponseWriter, r *http.Request) {
+	recordID, ok := parseUUIDPathValue(w, r, "id")
+	if !ok {
+		return
+	}
+
+	filters := models.RelatedTopicsFilters{}
+	if err := validation.ValidateAndDecodeQueryParams(r, &filters); err != nil {
+		response.RespondError(w, r, err)
 
-	if err := decoder.Decode(dst); err != nil {
-		return fmt.Errorf("decode request JSON: %w", response.NewRequestJSONDecodeError(err))
+		return
 	}
 
-	if err := validation.ValidateStruct(dst); err != nil {
-		return fmt.Errorf("validate request body: %w", err)
+	result, err := h.service.RelatedTopics(r.Context(), recordID, filters)
+	if err != nil {
+		if errors.Is(err, service.ErrEmbeddingNotFound) {
+			response.RespondProblem(w, r, http.StatusUnprocessableEntity,
+				"Feedback record has no taxonomy embedding yet; retry once its embedding job has run.")
+
+			return
+		}
+
+		respondTaxonomyError(w, r, err)
+
+		return
 	}
 
-	return nil
+	response.RespondJSON(w, http.StatusOK, result)
 }
 
 func parseUUIDPathValue(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
@@ -312,6 +445,19 @@ func taxonomyScopeFromQuery(w http.ResponseWriter, r *http.Request) (models.Taxo
 	return scope, true
 }
 
+// taxonomyTreeOptionsFromQuery reads the optional max_depth and sort parameters of the tree
+// endpoints, answering 400 for invalid values.
+func taxonomyTreeOptionsFromQuery(w http.ResponseWriter, r *http.Request) (models.TaxonomyTreeOptions, bool) {
+	var opts models.TaxonomyTreeOptions
+	if err := validation.ValidateAndDecodeQueryParams(r, &opts); err != nil {
+		response.RespondError(w, r, err)
+
+		return models.TaxonomyTreeOptions{}, false
+	}
+
+	return opts, true
+}
+
 func respondTaxonomyError(w http.ResponseWriter, r *http.Request, err error) {
 	if errors.Is(err, service.ErrTaxonomyEmbeddingsNotConfigured) {
 		response.RespondServiceUnavailable(w, r, "Taxonomy requires Hub embeddings to be configured.")
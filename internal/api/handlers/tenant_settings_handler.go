@@ -2,8 +2,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/formbricks/hub/internal/api/response"
@@ -105,18 +103,8 @@ func decodeSettingsBody(w http.ResponseWriter, r *http.Request, dst any) bool {
 	// with 413 rather than read into memory.
 	r.Body = http.MaxBytesReader(w, r.Body, maxSettingsRequestBodyBytes)
 
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-
-	if err := decoder.Decode(dst); err != nil {
-		var maxBytesErr *http.MaxBytesError
-		if errors.As(err, &maxBytesErr) {
-			response.RespondProblem(w, r, http.StatusRequestEntityTooLarge, "request body too large")
-
-			return false
-		}
-
-		response.RespondError(w, r, response.NewRequestJSONDecodeError(err))
+	if err := decodeJSON(r, dst); err != nil {
+		response.RespondError(w, r, err)
 
 		return false
 	}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/api/response"
+	"github.com/formbricks/hub/internal/api/validation"
+	"github.com/formbricks/hub/internal/models"
+)
+
+// WebhookDeadLettersService defines the interface for a webhook's permanently failed deliveries.
+type WebhookDeadLettersService interface {
+	ListDeadLetters(
+		ctx context.Context, webhookID uuid.UUID, filters *models.ListWebhookDeadLettersFilters,
+	) (*models.ListWebhookDeadLettersResponse, error)
+	RetryDeadLetter(ctx context.Context, webhookID, id uuid.UUID) (*models.WebhookDeadLetter, error)
+}
+
+// WebhookDeadLettersHandler handles HTTP requests for webhook dead letters.
+type WebhookDeadLettersHandler struct {
+	service WebhookDeadLettersService
+}
+
+// NewWebhookDeadLettersHandler creates a new webhook dead letters handler.
+func NewWebhookDeadLettersHandler(service WebhookDeadLettersService) *WebhookDeadLettersHandler {
+	return &WebhookDeadLettersHandler{service: service}
+}
+
+// List handles GET /v1/webhooks/{id}/dead-letters.
+func (h *WebhookDeadLettersHandler) List(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := parseUUIDPathValue(w, r, "id")
+	if !ok {
+		return
+	}
+
+	filters := &models.ListWebhookDeadLettersFilters{}
+
+	if err := validation.ValidateAndDecodeQueryParams(r, filters); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	result, err := h.service.ListDeadLetters(r.Context(), webhookID, filters)
+	if err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusOK, result)
+}
+
+// Retry handles POST /v1/webhooks/{id}/dead-letters/{dead_letter_id}/retry. The delivery is
+// enqueued, not sent inline, so the response is 202 with the updated dead letter.
+func (h *WebhookDeadLettersHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := parseUUIDPathValue(w, r, "id")
+	if !ok {
+		return
+	}
+
+	id, ok := parseUUIDPathValue(w, r, "dead_letter_id")
+	if !ok {
+		return
+	}
+
+	deadLetter, err := h.service.RetryDeadLetter(r.Context(), webhookID, id)
+	if err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusAccepted, deadLetter)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/api/response"
+	"github.com/formbricks/hub/internal/api/validation"
+	"github.com/formbricks/hub/internal/models"
+)
+
+// WebhookDeliveriesService defines the interface for a webhook's delivery log.
+type WebhookDeliveriesService interface {
+	ListDeliveries(
+		ctx context.Context, webhookID uuid.UUID, filters *models.ListWebhookDeliveriesFilters,
+	) (*models.ListWebhookDeliveriesResponse, error)
+}
+
+// WebhookDeliveriesHandler handles HTTP requests for webhook delivery attempts.
+type WebhookDeliveriesHandler struct {
+	service WebhookDeliveriesService
+}
+
+// NewWebhookDeliveriesHandler creates a new webhook deliveries handler.
+func NewWebhookDeliveriesHandler(service WebhookDeliveriesService) *WebhookDeliveriesHandler {
+	return &WebhookDeliveriesHandler{service: service}
+}
+
+// List handles GET /v1/webhooks/{id}/deliveries.
+func (h *WebhookDeliveriesHandler) List(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := parseUUIDPathValue(w, r, "id")
+	if !ok {
+		return
+	}
+
+	filters := &models.ListWebhookDeliveriesFilters{}
+
+	if err := validation.ValidateAndDecodeQueryParams(r, filters); err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	result, err := h.service.ListDeliveries(r.Context(), webhookID, filters)
+	if err != nil {
+		response.RespondError(w, r, err)
+
+		return
+	}
+
+	response.RespondJSON(w, http.StatusOK, result)
+}
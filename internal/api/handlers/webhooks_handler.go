@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -34,17 +33,7 @@ func NewWebhooksHandler(service WebhooksService) *WebhooksHandler {
 // Create handles POST /v1/webhooks.
 func (h *WebhooksHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateWebhookRequest
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-
-	if err := decoder.Decode(&req); err != nil {
-		response.RespondError(w, r, response.NewRequestJSONDecodeError(err))
-
-		return
-	}
-
-	if err := validation.ValidateStruct(&req); err != nil {
+	if err := decodeAndValidateJSON(r, &req); err != nil {
 		response.RespondError(w, r, err)
 
 		return
@@ -60,7 +49,7 @@ func (h *WebhooksHandler) Create(w http.ResponseWriter, r *http.Request) {
 	response.RespondJSON(w, http.StatusCreated, webhook)
 }
 
-// Get handles GET /v1/webhooks/{id}.
+// Get handles GET /v1/webhooks/{id}, with conditional-request (ETag / Last-Modified) support.
 func (h *WebhooksHandler) Get(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	if idStr == "" {
@@ -84,7 +73,7 @@ func (h *WebhooksHandler) Get(w http.ResponseWriter, r *http.Request) {
 	}
 
 	public := models.ToWebhookPublic(*webhook)
-	response.RespondJSON(w, http.StatusOK, &public)
+	response.RespondJSONConditional(w, r, &public, webhook.UpdatedAt)
 }
 
 // List handles GET /v1/webhooks.
@@ -133,17 +122,7 @@ func (h *WebhooksHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req models.UpdateWebhookRequest
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-
-	if err := decoder.Decode(&req); err != nil {
-		response.RespondError(w, r, response.NewRequestJSONDecodeError(err))
-
-		return
-	}
-
-	if err := validation.ValidateStruct(&req); err != nil {
+	if err := decodeAndValidateJSON(r, &req); err != nil {
 		response.RespondError(w, r, err)
 
 		return
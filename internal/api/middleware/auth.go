@@ -2,6 +2,7 @@
 package middleware
 
 import (
+	"context"
 	"crypto/subtle"
 	"net/http"
 	"strings"
@@ -62,3 +63,21 @@ func Auth(apiKey string) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// adminScopeKey is the context key marking a request authenticated with the admin API key.
+type adminScopeKey struct{}
+
+// AdminScope marks every request it passes on as an admin request, for handlers shared between
+// /v1/ and /v1/admin/ that unlock operator-only options. Mount it only behind Auth(adminAPIKey).
+func AdminScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), adminScopeKey{}, true)))
+	})
+}
+
+// IsAdminRequest reports whether the request came through AdminScope.
+func IsAdminRequest(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminScopeKey{}).(bool)
+
+	return admin
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/formbricks/hub/internal/api/response"
+)
+
+// MaxRequestBody caps request bodies at limit bytes. A request whose Content-Length already
+// exceeds it is answered 413 before the handler runs; otherwise the body is wrapped in
+// http.MaxBytesReader, so a chunked or understated body fails mid-decode and the handler's
+// RespondError turns the *http.MaxBytesError into the same 413. Handlers may still apply a
+// tighter cap of their own.
+func MaxRequestBody(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				response.RespondProblem(w, r, http.StatusRequestEntityTooLarge,
+					fmt.Sprintf("request body too large (limit %d bytes)", limit))
+
+				return
+			}
+
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
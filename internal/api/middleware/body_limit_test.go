@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxRequestBody(t *testing.T) {
+	t.Run("declared oversized body is rejected before the handler", func(t *testing.T) {
+		called := false
+		handler := MaxRequestBody(8)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true }))
+
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/v1/feedback-records",
+			strings.NewReader(`{"value": "0123456789"}`))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Contains(t, rec.Body.String(), "request body too large (limit 8 bytes)")
+	})
+
+	t.Run("undeclared oversized body fails while reading", func(t *testing.T) {
+		var readErr error
+
+		handler := MaxRequestBody(8)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			_, readErr = io.ReadAll(r.Body)
+		}))
+
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/v1/feedback-records",
+			io.NopCloser(strings.NewReader(`{"value": "0123456789"}`)))
+		req.ContentLength = -1
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		var maxBytesErr *http.MaxBytesError
+		require.True(t, errors.As(readErr, &maxBytesErr))
+		assert.Equal(t, int64(8), maxBytesErr.Limit)
+	})
+
+	t.Run("body within the limit passes through", func(t *testing.T) {
+		var body []byte
+
+		handler := MaxRequestBody(64)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			body, _ = io.ReadAll(r.Body)
+		}))
+
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/v1/feedback-records",
+			strings.NewReader(`{"value": "ok"}`))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.JSONEq(t, `{"value": "ok"}`, string(body))
+	})
+}
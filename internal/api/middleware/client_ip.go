@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// clientIPKey is the context key for the resolved client IP.
+type clientIPKey struct{}
+
+// ClientIPFromContext returns the client IP resolved by the ClientIP middleware, or "" if the
+// middleware did not run.
+func ClientIPFromContext(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+
+	return ""
+}
+
+// ClientIP resolves the real client IP once per request and stores it in the context for the
+// access log and anything else keyed by client. Forwarding headers are only believed when the TCP
+// peer is one of trustedProxies: a client talking to Hub directly could otherwise claim any
+// address by sending its own X-Forwarded-For. With no trusted proxies the peer address is used.
+func ClientIP(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := resolveClientIP(r, trustedProxies)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPKey{}, ip)))
+		})
+	}
+}
+
+func resolveClientIP(r *http.Request, trusted []netip.Prefix) string {
+	peer, ok := parseIP(r.RemoteAddr)
+	if !ok {
+		return r.RemoteAddr
+	}
+
+	if !isTrusted(peer, trusted) {
+		return peer.String()
+	}
+
+	// X-Forwarded-For is appended to by each proxy, so only its right end is trustworthy: walk
+	// from the right past our own proxies and take the first hop they did not add. Everything to
+	// its left was written by the client and may be forged.
+	if hops := forwardedFor(r.Header); len(hops) > 0 {
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, ok := parseIP(hops[i])
+			if !ok {
+				// An unparsable hop is where the trusted chain ends; trusting anything left of
+				// it would let the client choose.
+				return peer.String()
+			}
+
+			if !isTrusted(hop, trusted) || i == 0 {
+				return hop.String()
+			}
+		}
+	}
+
+	if realIP, ok := parseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ok {
+		return realIP.String()
+	}
+
+	return peer.String()
+}
+
+// forwardedFor returns the X-Forwarded-For hops in order, across repeated header lines.
+func forwardedFor(h http.Header) []string {
+	var hops []string
+
+	for _, line := range h.Values("X-Forwarded-For") {
+		for hop := range strings.SplitSeq(line, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+
+	return hops
+}
+
+// parseIP accepts a bare address or host:port (RemoteAddr, some proxies' X-Forwarded-For).
+func parseIP(s string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+
+	return addr.Unmap().WithZone(""), true
+}
+
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("192.168.1.10/32")}
+
+	tests := []struct {
+		name       string
+		trusted    []netip.Prefix
+		remoteAddr string
+		xff        []string
+		realIP     string
+		want       string
+	}{
+		{name: "no trusted proxies ignores headers", remoteAddr: "10.1.2.3:5000", xff: []string{"203.0.113.7"}, want: "10.1.2.3"},
+		{name: "untrusted peer ignores spoofed headers", trusted: trusted, remoteAddr: "198.51.100.4:5000",
+			xff: []string{"203.0.113.7"}, realIP: "203.0.113.8", want: "198.51.100.4"},
+		{name: "trusted peer uses forwarded client", trusted: trusted, remoteAddr: "10.1.2.3:5000",
+			xff: []string{"203.0.113.7"}, want: "203.0.113.7"},
+		{name: "rightmost untrusted hop wins over forged left entries", trusted: trusted, remoteAddr: "10.1.2.3:5000",
+			xff: []string{"1.1.1.1, 203.0.113.7, 192.168.1.10"}, want: "203.0.113.7"},
+		{name: "repeated header lines are one list", trusted: trusted, remoteAddr: "10.1.2.3:5000",
+			xff: []string{"1.1.1.1", "203.0.113.7"}, want: "203.0.113.7"},
+		{name: "all hops trusted falls back to leftmost", trusted: trusted, remoteAddr: "10.1.2.3:5000",
+			xff: []string{"10.9.9.9, 10.8.8.8"}, want: "10.9.9.9"},
+		{name: "garbage hop stops at the peer", trusted: trusted, remoteAddr: "10.1.2.3:5000",
+			xff: []string{"203.0.113.7, not-an-ip"}, want: "10.1.2.3"},
+		{name: "x-real-ip when no forwarded-for", trusted: trusted, remoteAddr: "10.1.2.3:5000",
+			realIP: "203.0.113.9", want: "203.0.113.9"},
+		{name: "ipv4-mapped peer matches ipv4 prefix", trusted: trusted, remoteAddr: "[::ffff:10.1.2.3]:5000",
+			xff: []string{"2001:db8::1"}, want: "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+
+			handler := ClientIP(tt.trusted)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = ClientIPFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/feedback-records", http.NoBody)
+			req.RemoteAddr = tt.remoteAddr
+
+			for _, v := range tt.xff {
+				req.Header.Add("X-Forwarded-For", v)
+			}
+
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
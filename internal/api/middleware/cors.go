@@ -7,7 +7,9 @@ func CORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-None-Match, If-Modified-Since, If-Match, If-Unmodified-Since, X-Response-Envelope")
+		// Cross-origin scripts can only read the conditional-request validators if they are exposed.
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, Last-Modified")
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/formbricks/hub/internal/api/response"
+	"github.com/formbricks/hub/internal/observability"
+)
+
+// ResponseEnvelope wraps JSON responses in response.Envelope for requests that
+// send "X-Response-Envelope: true"; every other request is passed through
+// untouched, so bare bodies stay the default. It sits outside ProblemErrors so
+// routing-level 404/405 problems are enveloped too, and it adds
+// Vary: X-Response-Envelope to every response so caches keep the two shapes
+// apart.
+func ResponseEnvelope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", response.EnvelopeHeader)
+
+		if !response.EnvelopeRequested(r.Header.Get(response.EnvelopeHeader)) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		requestID := observability.RequestIDFromContext(r.Context())
+		ew := &jsonRewriteWriter{
+			ResponseWriter: w,
+			name:           "envelope",
+			rewrite: func(body []byte, contentType string) ([]byte, error) {
+				return response.WrapEnvelope(body, contentType, requestID)
+			},
+		}
+		next.ServeHTTP(ew, r)
+		ew.finish(r)
+	})
+}
+
+// jsonRewriteWriter buffers a JSON body so rewrite can transform it once the
+// handler is done (ResponseEnvelope, PrettyJSON). Like problemErrorWriter it
+// decides at WriteHeader time: bodiless statuses and non-JSON media types (CSV
+// exports, the OpenAPI document) are streamed straight through and never
+// buffered.
+type jsonRewriteWriter struct {
+	http.ResponseWriter
+
+	name    string
+	rewrite func(body []byte, contentType string) ([]byte, error)
+
+	buf         bytes.Buffer
+	status      int
+	wroteHeader bool
+	buffering   bool
+}
+
+// Unwrap exposes the wrapped ResponseWriter to http.NewResponseController.
+func (w *jsonRewriteWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *jsonRewriteWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+
+	w.wroteHeader = true
+	w.status = code
+
+	if code != http.StatusNoContent && code != http.StatusNotModified &&
+		response.IsEnvelopable(w.Header().Get("Content-Type")) {
+		w.buffering = true
+
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *jsonRewriteWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.buffering {
+		return w.buf.Write(data) //nolint:wrapcheck // bytes.Buffer writes never fail
+	}
+
+	n, err := w.ResponseWriter.Write(data)
+	if err != nil {
+		return n, fmt.Errorf("write response: %w", err)
+	}
+
+	return n, nil
+}
+
+// finish writes the rewritten body of a buffered response. If the rewrite
+// fails the original body is sent as-is rather than lost.
+func (w *jsonRewriteWriter) finish(r *http.Request) {
+	if !w.buffering {
+		return
+	}
+
+	body := w.buf.Bytes()
+
+	rewritten, err := w.rewrite(body, w.Header().Get("Content-Type"))
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to rewrite response", "rewrite", w.name, "error", err)
+	} else {
+		body = rewritten
+	}
+
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if _, err := w.ResponseWriter.Write(body); err != nil {
+		slog.DebugContext(r.Context(), "Failed to write rewritten response", "rewrite", w.name, "error", err)
+	}
+}
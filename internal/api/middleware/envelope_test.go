@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/api/response"
+	"github.com/formbricks/hub/internal/observability"
+)
+
+func serveEnveloped(t *testing.T, handler http.Handler, optIn string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	ctx := context.WithValue(t.Context(), observability.RequestIDKey, "req-1")
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/v1/feedback-records", http.NoBody)
+
+	if optIn != "" {
+		req.Header.Set(response.EnvelopeHeader, optIn)
+	}
+
+	rec := httptest.NewRecorder()
+	ResponseEnvelope(handler).ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestResponseEnvelopeIsOptIn(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		response.RespondJSON(w, http.StatusOK, map[string]string{"id": "1"})
+	})
+
+	for _, value := range []string{"", "false", "yes-please"} {
+		rec := serveEnveloped(t, handler, value)
+
+		assert.JSONEq(t, `{"id":"1"}`, rec.Body.String(), "header %q keeps the bare body", value)
+		assert.Equal(t, response.EnvelopeHeader, rec.Header().Get("Vary"))
+	}
+}
+
+func TestResponseEnvelopeWrapsObject(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		response.RespondJSON(w, http.StatusCreated, map[string]string{"id": "1"})
+	})
+
+	rec := serveEnveloped(t, handler, "true")
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"data":{"id":"1"},"meta":{"request_id":"req-1"},"errors":[]}`, rec.Body.String())
+}
+
+func TestResponseEnvelopeLiftsListPagination(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		response.RespondJSON(w, http.StatusOK, map[string]any{
+			"data": []map[string]string{{"id": "1"}}, "limit": 1, "next_cursor": "abc",
+		})
+	})
+
+	rec := serveEnveloped(t, handler, "true")
+
+	assert.JSONEq(t, `{"data":[{"id":"1"}],"meta":{"limit":1,"next_cursor":"abc","request_id":"req-1"},"errors":[]}`,
+		rec.Body.String())
+}
+
+func TestResponseEnvelopeMovesProblemToErrors(t *testing.T) {
+	rec := serveEnveloped(t, ProblemErrors(http.NotFoundHandler()), "true")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+
+	var envelope struct {
+		Data   any                       `json:"data"`
+		Meta   map[string]string         `json:"meta"`
+		Errors []response.ProblemDetails `json:"errors"`
+	}
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	assert.Nil(t, envelope.Data)
+	assert.Equal(t, "req-1", envelope.Meta["request_id"])
+	require.Len(t, envelope.Errors, 1)
+	assert.Equal(t, response.CodeNotFound, envelope.Errors[0].Code)
+	assert.Equal(t, "/v1/feedback-records", envelope.Errors[0].Instance)
+}
+
+func TestResponseEnvelopeLeavesNonJSONAndEmptyResponses(t *testing.T) {
+	csv := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("id\n1\n"))
+	})
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	rec := serveEnveloped(t, csv, "true")
+	assert.Equal(t, "id\n1\n", rec.Body.String())
+
+	rec = serveEnveloped(t, noContent, "true")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
@@ -25,23 +25,55 @@ func (rw *responseWriter) Unwrap() http.ResponseWriter {
 	return rw.ResponseWriter
 }
 
-// Logging middleware logs HTTP requests.
-func Logging(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		rw := &responseWriter{
-			ResponseWriter: w,
-			statusCode:     http.StatusOK,
-		}
-
-		next.ServeHTTP(rw, r)
-
-		slog.InfoContext(r.Context(), "HTTP request",
-			"method", r.Method,
-			"path", r.URL.Path,
-			"status", rw.statusCode,
-			"duration", time.Since(start),
-		)
-	})
+// RequestLogConfig tunes the access log. A zero SlowThreshold logs every request at info (the
+// default). A positive one logs only the latency outliers by default: requests taking at least
+// SlowThreshold at warn with full detail, all others at debug. AlwaysLogErrors keeps 5xx responses
+// at warn whatever their duration, so failures stay visible when fast requests are demoted.
+type RequestLogConfig struct {
+	SlowThreshold   time.Duration
+	AlwaysLogErrors bool
+}
+
+// Logging middleware logs HTTP requests per cfg.
+func Logging(cfg RequestLogConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			rw := &responseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			next.ServeHTTP(rw, r)
+
+			duration := time.Since(start)
+			attrs := []any{
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", rw.statusCode,
+				"duration", duration,
+			}
+			if ip := ClientIPFromContext(r.Context()); ip != "" {
+				attrs = append(attrs, "client_ip", ip)
+			}
+
+			switch {
+			case cfg.SlowThreshold <= 0:
+				slog.InfoContext(r.Context(), "HTTP request", attrs...)
+			case duration >= cfg.SlowThreshold:
+				// The full detail is what makes an outlier diagnosable after the fact: which
+				// filters the query carried and which client sent it.
+				slog.WarnContext(r.Context(), "Slow HTTP request", append(attrs,
+					"query", r.URL.RawQuery,
+					"user_agent", r.UserAgent(),
+					"threshold", cfg.SlowThreshold,
+				)...)
+			case cfg.AlwaysLogErrors && rw.statusCode >= http.StatusInternalServerError:
+				slog.WarnContext(r.Context(), "HTTP request failed", attrs...)
+			default:
+				slog.DebugContext(r.Context(), "HTTP request", attrs...)
+			}
+		})
+	}
 }
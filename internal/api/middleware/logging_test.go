@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureRequestLog serves one request through Logging and returns the access log record.
+func captureRequestLog(t *testing.T, cfg RequestLogConfig, status int, delay time.Duration) map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	handler := Logging(cfg)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(status)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(),
+		httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/feedback-records?tenant_id=org-1", http.NoBody))
+
+	var record map[string]any
+
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+
+	return record
+}
+
+func TestLogging(t *testing.T) {
+	slow := RequestLogConfig{SlowThreshold: 20 * time.Millisecond, AlwaysLogErrors: true}
+
+	tests := []struct {
+		name      string
+		cfg       RequestLogConfig
+		status    int
+		delay     time.Duration
+		wantLevel string
+		wantQuery bool
+	}{
+		{name: "no threshold logs every request at info", cfg: RequestLogConfig{}, status: http.StatusOK, wantLevel: "INFO"},
+		{name: "fast request is demoted to debug", cfg: slow, status: http.StatusOK, wantLevel: "DEBUG"},
+		{name: "slow request logs at warn with detail", cfg: slow, status: http.StatusOK, delay: 25 * time.Millisecond,
+			wantLevel: "WARN", wantQuery: true},
+		{name: "fast server error stays visible", cfg: slow, status: http.StatusInternalServerError, wantLevel: "WARN"},
+		{name: "fast server error is demoted when errors are not always logged",
+			cfg: RequestLogConfig{SlowThreshold: slow.SlowThreshold}, status: http.StatusInternalServerError, wantLevel: "DEBUG"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			record := captureRequestLog(t, tc.cfg, tc.status, tc.delay)
+
+			assert.Equal(t, tc.wantLevel, record["level"])
+			assert.InDelta(t, tc.status, record["status"], 0)
+
+			if tc.wantQuery {
+				assert.Equal(t, "tenant_id=org-1", record["query"])
+			} else {
+				assert.NotContains(t, record, "query")
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/formbricks/hub/internal/api/response"
+)
+
+// MaintenanceMode is the process-wide maintenance flag. It starts from MAINTENANCE_MODE and can be
+// flipped at runtime through the admin API; the flag is per process, so in a multi-replica
+// deployment every replica must be switched (or restarted with the env var).
+type MaintenanceMode struct {
+	enabled atomic.Bool
+}
+
+// NewMaintenanceMode creates the flag with its initial state.
+func NewMaintenanceMode(enabled bool) *MaintenanceMode {
+	m := &MaintenanceMode{}
+	m.enabled.Store(enabled)
+
+	return m
+}
+
+// Enabled reports whether writes are currently rejected.
+func (m *MaintenanceMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// SetEnabled turns maintenance mode on or off; it takes effect for the next request.
+func (m *MaintenanceMode) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// RejectWritesDuringMaintenance answers every mutating request with 503 and a Retry-After of
+// retryAfter while mode is enabled, and passes reads (GET, HEAD, OPTIONS) through untouched, so
+// dashboards keep working during a migration or incident. It only wraps the routes it is applied
+// to: /health, /ready and the switch itself stay reachable.
+func RejectWritesDuringMaintenance(mode *MaintenanceMode, retryAfter time.Duration) func(http.Handler) http.Handler {
+	retryAfterSec := strconv.Itoa(int(retryAfter.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if mode.Enabled() && !isReadMethod(r.Method) {
+				w.Header().Set("Retry-After", retryAfterSec)
+				response.RespondServiceUnavailable(w, r, "Hub is in maintenance mode; writes are temporarily disabled.")
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/formbricks/hub/internal/api/response"
+)
+
+// PrettyJSON indents the JSON responses of requests that send ?pretty=true or "X-Pretty: true";
+// every other request is passed through untouched. It sits outside ResponseEnvelope so enveloped
+// bodies are indented too, and adds Vary: X-Pretty so caches keep the two renderings apart.
+func PrettyJSON(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", response.PrettyHeader)
+
+		if !response.PrettyRequested(r) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		pw := &jsonRewriteWriter{
+			ResponseWriter: w,
+			name:           "pretty",
+			rewrite: func(body []byte, _ string) ([]byte, error) {
+				return response.IndentJSON(body)
+			},
+		}
+		next.ServeHTTP(pw, r)
+		pw.finish(r)
+	})
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/formbricks/hub/internal/api/response"
+)
+
+func servePretty(t *testing.T, handler http.Handler, target, header string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, target, http.NoBody)
+	if header != "" {
+		req.Header.Set(response.PrettyHeader, header)
+	}
+
+	rec := httptest.NewRecorder()
+	PrettyJSON(handler).ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestPrettyJSON(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		response.RespondJSON(w, http.StatusCreated, map[string]any{"id": "1", "tags": []string{"a"}})
+	})
+	indented := "{\n  \"id\": \"1\",\n  \"tags\": [\n    \"a\"\n  ]\n}\n"
+
+	t.Run("compact by default", func(t *testing.T) {
+		rec := servePretty(t, handler, "/v1/feedback-records", "")
+
+		assert.Equal(t, "{\"id\":\"1\",\"tags\":[\"a\"]}\n", rec.Body.String())
+		assert.Equal(t, response.PrettyHeader, rec.Header().Get("Vary"))
+	})
+
+	t.Run("query parameter", func(t *testing.T) {
+		rec := servePretty(t, handler, "/v1/feedback-records?pretty=true", "")
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, indented, rec.Body.String())
+	})
+
+	t.Run("header", func(t *testing.T) {
+		assert.Equal(t, indented, servePretty(t, handler, "/v1/feedback-records", "1").Body.String())
+	})
+
+	t.Run("non-JSON bodies pass through", func(t *testing.T) {
+		csv := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("id\n1\n"))
+		})
+
+		assert.Equal(t, "id\n1\n", servePretty(t, csv, "/v1/exports/x/download?pretty=true", "").Body.String())
+	})
+}
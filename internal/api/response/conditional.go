@@ -0,0 +1,171 @@
+package response
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// etagHashHexLen is how many hex digits of the body's SHA-256 the ETag carries: 128 bits is
+// ample to make an accidental collision between two versions of one resource irrelevant.
+const etagHashHexLen = 32
+
+// RespondJSONConditional writes a 200 JSON response for a single resource with validators, or a
+// bodiless 304 Not Modified when the client's cached copy is still current.
+//
+// The ETag is a hash of the serialized body rather than of updated_at, so it changes for every
+// visible difference — including fields a response mapping derives or omits — and never for an
+// invisible one. lastModified (the resource's updated_at; zero to omit) backs If-Modified-Since
+// for clients that only keep a timestamp. Per RFC 9110 §13.2.2, If-None-Match takes precedence:
+// when present, If-Modified-Since is ignored. Cache-Control is "private, no-cache" — tenant data
+// must not sit in shared caches, and every reuse must revalidate (which is exactly the 304 path).
+func RespondJSONConditional(w http.ResponseWriter, r *http.Request, data any, lastModified time.Time) {
+	respondJSONWithValidators(w, r, http.StatusOK, data, lastModified, true)
+}
+
+// RespondJSONWithValidators writes a JSON response carrying the same ETag and Last-Modified
+// validators as RespondJSONConditional, without evaluating If-None-Match / If-Modified-Since. A
+// write endpoint uses it so the client can chain its next conditional update (If-Match) on the
+// representation it just received instead of re-reading the resource first.
+func RespondJSONWithValidators(w http.ResponseWriter, r *http.Request, status int, data any, lastModified time.Time) {
+	respondJSONWithValidators(w, r, status, data, lastModified, false)
+}
+
+func respondJSONWithValidators(
+	w http.ResponseWriter, r *http.Request, status int, data any, lastModified time.Time, conditional bool,
+) {
+	body, etag, err := encodeWithETag(data)
+	if err != nil {
+		slog.Error("Failed to encode JSON response", "error", err)
+		RespondProblem(w, r, http.StatusInternalServerError, "failed to encode response")
+
+		return
+	}
+
+	header := w.Header()
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", "private, no-cache")
+
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if conditional && notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	header.Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if _, err := w.Write(body); err != nil {
+		slog.Error("Failed to write JSON response", "error", err)
+	}
+}
+
+// encodeWithETag serializes data exactly as the response body is written and derives its ETag.
+func encodeWithETag(data any) (body []byte, etag string, err error) {
+	var buf bytes.Buffer
+
+	// Encoder (not Marshal) so the body is byte-identical to RespondJSON's, trailing newline included.
+	if err := json.NewEncoder(&buf).Encode(data); err != nil {
+		return nil, "", fmt.Errorf("encode JSON response: %w", err)
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+
+	return buf.Bytes(), `"` + hex.EncodeToString(sum[:])[:etagHashHexLen] + `"`, nil
+}
+
+// HasWritePreconditions reports whether the request carries If-Match or If-Unmodified-Since,
+// i.e. whether the client opted in to optimistic concurrency control for its write.
+func HasWritePreconditions(r *http.Request) bool {
+	return r.Header.Get("If-Match") != "" || r.Header.Get("If-Unmodified-Since") != ""
+}
+
+// WritePreconditionsHold evaluates the request's If-Match / If-Unmodified-Since headers against
+// the resource's current representation (data, serialized and hashed exactly as a GET would
+// return it, so the ETag a client read is the one compared) and updated_at. A false result means
+// the resource changed since the client read it: the write must be refused with 412.
+//
+// Per RFC 9110 §13.2.2, If-Match takes precedence: when present, If-Unmodified-Since is ignored.
+// If-Match uses the strong comparison — a weak tag never matches — and "*" matches any current
+// representation. An unparsable If-Unmodified-Since is ignored (RFC 9110 §13.1.4).
+func WritePreconditionsHold(r *http.Request, data any, lastModified time.Time) bool {
+	if im := r.Header.Get("If-Match"); im != "" {
+		_, etag, err := encodeWithETag(data)
+		if err != nil {
+			// The current version cannot be identified, so it cannot be shown to match.
+			return false
+		}
+
+		return etagListMatchesStrong(im, etag)
+	}
+
+	ius := r.Header.Get("If-Unmodified-Since")
+	if ius == "" {
+		return true
+	}
+
+	since, err := http.ParseTime(ius)
+	if err != nil {
+		return true
+	}
+
+	// Same one-second resolution as If-Modified-Since (see notModified).
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// notModified evaluates the request's conditional headers against the current validators.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagListMatches(inm, etag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false // an unparsable date is ignored, not an error (RFC 9110 §13.1.3)
+	}
+
+	// HTTP dates have one-second resolution; compare at that resolution or a sub-second
+	// updated_at would never test as "not after" the date the client echoed back.
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagListMatches reports whether an If-None-Match list ("*" or comma-separated entity tags)
+// matches etag, using the weak comparison RFC 9110 prescribes for If-None-Match (W/ is ignored).
+func etagListMatches(list, etag string) bool {
+	for candidate := range strings.SplitSeq(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// etagListMatchesStrong is etagListMatches with the strong comparison RFC 9110 prescribes for
+// If-Match: a weak (W/) candidate never matches.
+func etagListMatchesStrong(list, etag string) bool {
+	for candidate := range strings.SplitSeq(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strconv"
+)
+
+// EnvelopeHeader is the request header that opts a client into enveloped
+// responses. Bare bodies remain the default so existing clients are unaffected;
+// a client whose framework assumes {data, meta, errors} sends
+// "X-Response-Envelope: true" on each request.
+const EnvelopeHeader = "X-Response-Envelope"
+
+// Envelope is the {data, meta, errors} shape an opted-in client receives.
+// Data is the bare success body (or a list's items); Errors holds the problem
+// document of an error response, whose status code is left unchanged. Meta
+// carries request_id plus any list members beside the items (limit,
+// next_cursor, total, ...), so pagination lives in one predictable place.
+type Envelope struct {
+	Data   json.RawMessage            `json:"data"`
+	Meta   map[string]json.RawMessage `json:"meta"`
+	Errors []json.RawMessage          `json:"errors"`
+}
+
+// EnvelopeRequested reports whether the header value opts into the envelope.
+// Anything that does not parse as a true boolean keeps the bare response.
+func EnvelopeRequested(value string) bool {
+	enabled, err := strconv.ParseBool(value)
+
+	return err == nil && enabled
+}
+
+// IsEnvelopable reports whether a response with contentType has a JSON body the
+// envelope can wrap. Downloads, the OpenAPI document and other media types are
+// written as-is.
+func IsEnvelopable(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	return err == nil && (mediaType == "application/json" || mediaType == problemContentType)
+}
+
+// WrapEnvelope wraps a JSON response body in an Envelope. A problem+json body
+// becomes the single entry of errors with null data; a list object (one whose
+// "data" member is an array) is split into its items and its pagination meta;
+// any other body becomes data unchanged.
+func WrapEnvelope(body []byte, contentType, requestID string) ([]byte, error) {
+	envelope := Envelope{
+		Data:   json.RawMessage("null"),
+		Meta:   map[string]json.RawMessage{},
+		Errors: []json.RawMessage{},
+	}
+
+	body = bytes.TrimSpace(body)
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == problemContentType:
+		envelope.Errors = append(envelope.Errors, body)
+	case len(body) > 0:
+		envelope.Data = body
+
+		var members map[string]json.RawMessage
+		if json.Unmarshal(body, &members) == nil && isJSONArray(members["data"]) {
+			envelope.Data = members["data"]
+			delete(members, "data")
+
+			envelope.Meta = members
+		}
+	}
+
+	if requestID != "" {
+		id, _ := json.Marshal(requestID) // a string always encodes
+		envelope.Meta["request_id"] = id
+	}
+
+	out, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("encode response envelope: %w", err)
+	}
+
+	return append(out, '\n'), nil
+}
+
+func isJSONArray(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
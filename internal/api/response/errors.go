@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/iancoleman/strcase"
@@ -40,6 +41,13 @@ func problemFromError(err error) ProblemDetails {
 		return problem
 	}
 
+	// A body cut off by http.MaxBytesReader surfaces as a decode error; answer 413, not 400.
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return newProblem(http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body too large (limit %d bytes)", maxBytesErr.Limit))
+	}
+
 	var requestJSONErr *RequestJSONDecodeError
 	if errors.As(err, &requestJSONErr) {
 		if problem, ok := problemFromJSONDecodeError(requestJSONErr.Unwrap()); ok {
@@ -97,6 +105,11 @@ func problemFromError(err error) ProblemDetails {
 		return newProblem(http.StatusConflict, conflictErr.Error())
 	}
 
+	var preconditionErr *huberrors.PreconditionFailedError
+	if errors.As(err, &preconditionErr) {
+		return newProblem(http.StatusPreconditionFailed, preconditionErr.Error())
+	}
+
 	var limitErr *huberrors.LimitExceededError
 	if errors.As(err, &limitErr) {
 		return newProblem(http.StatusForbidden, limitErr.Error())
@@ -149,14 +162,29 @@ func problemFromJSONDecodeError(err error) (ProblemDetails, bool) {
 	}
 
 	// json.SyntaxError covers malformed JSON; io.ErrUnexpectedEOF covers truncated
-	// payloads (e.g. `{"x":`). Both are client mistakes, not server failures.
+	// payloads (e.g. `{"x":`). Both are client mistakes, not server failures. The byte
+	// offset is what lets an integrator find the mistake in a large payload.
 	var syntaxErr *json.SyntaxError
-	if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
-		return newProblem(http.StatusBadRequest, "Invalid JSON: "+err.Error()), true
+	if errors.As(err, &syntaxErr) {
+		return newProblem(http.StatusBadRequest,
+			fmt.Sprintf("malformed JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error())), true
 	}
 
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return newProblem(http.StatusBadRequest, "malformed JSON: unexpected end of input"), true
+	}
+
+	// A bare io.EOF means the decoder saw no JSON value at all: the body was empty.
 	if errors.Is(err, io.EOF) {
-		return newProblem(http.StatusBadRequest, "Invalid request body"), true
+		return newProblem(http.StatusBadRequest, "request body is required"), true
+	}
+
+	// A timestamp field (collected_at, value_date, since, ...) fails inside time.Time's own
+	// UnmarshalJSON, which does not report the field, so the rejected value identifies it instead.
+	var timeErr *time.ParseError
+	if errors.As(err, &timeErr) {
+		return newProblem(http.StatusBadRequest, fmt.Sprintf(
+			"invalid timestamp %q: must be RFC 3339, e.g. 2024-01-15T10:30:00Z", timeErr.Value)), true
 	}
 
 	var typeErr *json.UnmarshalTypeError
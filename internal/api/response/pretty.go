@@ -0,0 +1,43 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// PrettyHeader and PrettyQueryParam opt a request into indented JSON, for reading responses by
+// hand (curl, the CLI examples). Compact output stays the default: machine clients gain nothing
+// from the whitespace and pay for it on every list page.
+const (
+	PrettyHeader     = "X-Pretty"
+	PrettyQueryParam = "pretty"
+)
+
+// PrettyRequested reports whether r asks for indented JSON via ?pretty=true or "X-Pretty: true".
+// Anything that does not parse as a true boolean keeps the compact response.
+func PrettyRequested(r *http.Request) bool {
+	for _, value := range []string{r.URL.Query().Get(PrettyQueryParam), r.Header.Get(PrettyHeader)} {
+		if enabled, err := strconv.ParseBool(value); err == nil && enabled {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IndentJSON re-indents a JSON response body with two spaces, keeping the trailing newline
+// json.Encoder writes.
+func IndentJSON(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := json.Indent(&buf, bytes.TrimSpace(body), "", "  "); err != nil {
+		return nil, fmt.Errorf("indent response: %w", err)
+	}
+
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
@@ -16,6 +16,7 @@ const (
 	ProblemTypeConflict            = "https://hub.formbricks.com/problems/conflict"
 	ProblemTypeTenantWriteConflict = "https://hub.formbricks.com/problems/tenant-write-conflict"
 	ProblemTypeMethodNotAllowed    = "https://hub.formbricks.com/problems/method-not-allowed"
+	ProblemTypePreconditionFailed  = "https://hub.formbricks.com/problems/precondition-failed"
 	ProblemTypeContentTooLarge     = "https://hub.formbricks.com/problems/content-too-large"
 	ProblemTypeServiceUnavailable  = "https://hub.formbricks.com/problems/service-unavailable"
 	ProblemTypeInternalServerError = "https://hub.formbricks.com/problems/internal-server-error"
@@ -35,6 +36,7 @@ const (
 	CodeConflict            = "conflict"
 	CodeTenantWriteConflict = "tenant_write_conflict"
 	CodeMethodNotAllowed    = "method_not_allowed"
+	CodePreconditionFailed  = "precondition_failed"
 	CodeContentTooLarge     = "content_too_large"
 	CodeServiceUnavailable  = "service_unavailable"
 	CodeInternalServerError = "internal_server_error"
@@ -104,6 +106,8 @@ func codeForStatus(status int) string {
 		return CodeConflict
 	case http.StatusMethodNotAllowed:
 		return CodeMethodNotAllowed
+	case http.StatusPreconditionFailed:
+		return CodePreconditionFailed
 	case http.StatusRequestEntityTooLarge:
 		return CodeContentTooLarge
 	case http.StatusServiceUnavailable:
@@ -134,6 +138,8 @@ func problemTypeForStatus(status int) string {
 		return ProblemTypeConflict
 	case http.StatusMethodNotAllowed:
 		return ProblemTypeMethodNotAllowed
+	case http.StatusPreconditionFailed:
+		return ProblemTypePreconditionFailed
 	case http.StatusRequestEntityTooLarge:
 		return ProblemTypeContentTooLarge
 	case http.StatusServiceUnavailable:
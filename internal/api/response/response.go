@@ -30,6 +30,21 @@ func RespondErrorWithLogAttrs(w http.ResponseWriter, r *http.Request, err error,
 	writeProblem(w, r, problemFromError(err), err, attrs...)
 }
 
+// ItemProblem maps the error of one item of a batch request to the problem RespondError would
+// write for it, for embedding in the batch's own response. Only server errors are logged (with
+// their cause): rejected items are reported to the caller, and logging each would flood the log.
+func ItemProblem(r *http.Request, err error) *ProblemDetails {
+	problem := problemFromError(err)
+	problem.Instance = r.URL.Path
+	problem.RequestID = observability.RequestIDFromContext(r.Context())
+
+	if problem.Status >= http.StatusInternalServerError {
+		logProblem(r.Context(), r, problem, err)
+	}
+
+	return &problem
+}
+
 // RespondProblem writes an explicit problem response when there is no error
 // value to map, e.g. for request preconditions like a missing path parameter
 // or an unsupported HTTP method.
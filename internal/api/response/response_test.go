@@ -66,6 +66,10 @@ func TestRespondErrorMapping(t *testing.T) {
 			name: "conflict", err: huberrors.NewConflictError("already exists"),
 			wantStatus: http.StatusConflict, wantCode: CodeConflict, wantType: ProblemTypeConflict,
 		},
+		{
+			name: "precondition failed", err: huberrors.NewPreconditionFailedError("modified since read"),
+			wantStatus: http.StatusPreconditionFailed, wantCode: CodePreconditionFailed, wantType: ProblemTypePreconditionFailed,
+		},
 		{
 			name: "tenant write conflict", err: huberrors.NewTenantWriteConflictError("tenant data purge in progress; retry later"),
 			wantStatus: http.StatusConflict, wantCode: CodeTenantWriteConflict, wantType: ProblemTypeTenantWriteConflict,
@@ -188,7 +192,7 @@ func TestRespondErrorJSONDecodeFailures(t *testing.T) {
 		problem := decodeProblem(t, rec)
 		assert.Equal(t, http.StatusBadRequest, problem.Status)
 		assert.Equal(t, CodeBadRequest, problem.Code)
-		assert.Contains(t, problem.Detail, "Invalid JSON")
+		assert.Equal(t, "malformed JSON at offset 2: invalid character 'n' looking for beginning of object key string", problem.Detail)
 		assert.Empty(t, problem.InvalidParams)
 	})
 
@@ -247,7 +251,41 @@ func TestRespondErrorJSONDecodeFailures(t *testing.T) {
 		problem := decodeProblem(t, rec)
 		assert.Equal(t, http.StatusBadRequest, problem.Status)
 		assert.Equal(t, CodeBadRequest, problem.Code)
-		assert.Equal(t, "Invalid request body", problem.Detail)
+		assert.Equal(t, "request body is required", problem.Detail)
+	})
+
+	t.Run("malformed timestamp names the rejected value", func(t *testing.T) {
+		var dst struct {
+			CollectedAt time.Time `json:"collected_at"`
+		}
+
+		err := json.NewDecoder(strings.NewReader(`{"collected_at": "yesterday"}`)).Decode(&dst)
+		require.Error(t, err)
+
+		rec := httptest.NewRecorder()
+		RespondError(rec, newReq(t, http.MethodPost, "/v1/x"), NewRequestJSONDecodeError(err))
+
+		problem := decodeProblem(t, rec)
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, `invalid timestamp "yesterday": must be RFC 3339, e.g. 2024-01-15T10:30:00Z`, problem.Detail)
+	})
+
+	t.Run("oversized body is content too large", func(t *testing.T) {
+		var dst struct {
+			Value string `json:"value"`
+		}
+
+		rec := httptest.NewRecorder()
+		body := http.MaxBytesReader(rec, io.NopCloser(strings.NewReader(`{"value": "0123456789"}`)), 8)
+		err := json.NewDecoder(body).Decode(&dst)
+		require.Error(t, err)
+
+		RespondError(rec, newReq(t, http.MethodPost, "/v1/x"), NewRequestJSONDecodeError(err))
+
+		problem := decodeProblem(t, rec)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, problem.Status)
+		assert.Equal(t, CodeContentTooLarge, problem.Code)
+		assert.Equal(t, "request body too large (limit 8 bytes)", problem.Detail)
 	})
 
 	t.Run("raw json-like error is not treated as request decode", func(t *testing.T) {
@@ -474,7 +512,7 @@ func TestRespondErrorTruncatedJSONIsBadRequest(t *testing.T) {
 	problem := decodeProblem(t, rec)
 	assert.Equal(t, http.StatusBadRequest, problem.Status)
 	assert.Equal(t, CodeBadRequest, problem.Code)
-	assert.Contains(t, problem.Detail, "Invalid JSON")
+	assert.Equal(t, "malformed JSON: unexpected end of input", problem.Detail)
 }
 
 func TestProblemResponseMirrorsRequestIDIntoHeader(t *testing.T) {
@@ -658,3 +696,119 @@ func jsonTagName(field reflect.StructField) string {
 
 	return name
 }
+
+func TestRespondJSONConditional(t *testing.T) {
+	updatedAt := time.Date(2026, 3, 1, 10, 0, 0, 500_000_000, time.UTC)
+	data := map[string]string{"id": "abc"}
+
+	first := httptest.NewRecorder()
+	RespondJSONConditional(first, newReq(t, http.MethodGet, "/v1/x"), data, updatedAt)
+
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.JSONEq(t, `{"id":"abc"}`, first.Body.String())
+	assert.Equal(t, "private, no-cache", first.Header().Get("Cache-Control"))
+	assert.Equal(t, "Sun, 01 Mar 2026 10:00:00 GMT", first.Header().Get("Last-Modified"))
+
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{name: "matching etag", header: "If-None-Match", value: etag, want: http.StatusNotModified},
+		{name: "weak form in a list", header: "If-None-Match", value: `"stale", W/` + etag, want: http.StatusNotModified},
+		{name: "wildcard", header: "If-None-Match", value: "*", want: http.StatusNotModified},
+		{name: "stale etag", header: "If-None-Match", value: `"stale"`, want: http.StatusOK},
+		{name: "modified since equal date", header: "If-Modified-Since", value: "Sun, 01 Mar 2026 10:00:00 GMT", want: http.StatusNotModified},
+		{name: "modified since older date", header: "If-Modified-Since", value: "Sun, 01 Mar 2026 09:59:59 GMT", want: http.StatusOK},
+		{name: "unparsable date is ignored", header: "If-Modified-Since", value: "yesterday", want: http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newReq(t, http.MethodGet, "/v1/x")
+			r.Header.Set(tc.header, tc.value)
+
+			rec := httptest.NewRecorder()
+			RespondJSONConditional(rec, r, data, updatedAt)
+
+			assert.Equal(t, tc.want, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+
+			if tc.want == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("if-none-match takes precedence over if-modified-since", func(t *testing.T) {
+		r := newReq(t, http.MethodGet, "/v1/x")
+		r.Header.Set("If-None-Match", `"stale"`)
+		r.Header.Set("If-Modified-Since", "Sun, 01 Mar 2026 10:00:00 GMT")
+
+		rec := httptest.NewRecorder()
+		RespondJSONConditional(rec, r, data, updatedAt)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestWritePreconditionsHold(t *testing.T) {
+	updatedAt := time.Date(2026, 3, 1, 10, 0, 0, 500_000_000, time.UTC)
+	data := map[string]string{"id": "abc"}
+
+	read := httptest.NewRecorder()
+	RespondJSONConditional(read, newReq(t, http.MethodGet, "/v1/x"), data, updatedAt)
+
+	etag := read.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "no preconditions", headers: nil, want: true},
+		{name: "matching etag", headers: map[string]string{"If-Match": etag}, want: true},
+		{name: "matching etag in a list", headers: map[string]string{"If-Match": `"stale", ` + etag}, want: true},
+		{name: "wildcard", headers: map[string]string{"If-Match": "*"}, want: true},
+		{name: "stale etag", headers: map[string]string{"If-Match": `"stale"`}, want: false},
+		{name: "weak etag never matches", headers: map[string]string{"If-Match": "W/" + etag}, want: false},
+		{name: "unmodified since equal date", headers: map[string]string{"If-Unmodified-Since": "Sun, 01 Mar 2026 10:00:00 GMT"}, want: true},
+		{name: "modified after date", headers: map[string]string{"If-Unmodified-Since": "Sun, 01 Mar 2026 09:59:59 GMT"}, want: false},
+		{name: "unparsable date is ignored", headers: map[string]string{"If-Unmodified-Since": "yesterday"}, want: true},
+		{
+			name:    "if-match takes precedence over if-unmodified-since",
+			headers: map[string]string{"If-Match": etag, "If-Unmodified-Since": "Sun, 01 Mar 2026 09:59:59 GMT"},
+			want:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newReq(t, http.MethodPatch, "/v1/x")
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+
+			assert.Equal(t, len(tc.headers) > 0, HasWritePreconditions(r))
+			assert.Equal(t, tc.want, WritePreconditionsHold(r, data, updatedAt))
+		})
+	}
+}
+
+func TestRespondJSONWithValidatorsIgnoresReadConditions(t *testing.T) {
+	r := newReq(t, http.MethodPatch, "/v1/x")
+	r.Header.Set("If-None-Match", "*")
+
+	rec := httptest.NewRecorder()
+	RespondJSONWithValidators(rec, r, http.StatusOK, map[string]string{"id": "abc"}, time.Time{})
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+	assert.Empty(t, rec.Header().Get("Last-Modified"))
+	assert.JSONEq(t, `{"id":"abc"}`, rec.Body.String())
+}
@@ -5,8 +5,10 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/netip"
 	"net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +29,14 @@ var (
 	ErrMessagePublisherPerEventTimeout = errors.New("MESSAGE_PUBLISHER_PER_EVENT_TIMEOUT_SECONDS must be a positive integer")
 	ErrShutdownTimeoutSeconds          = errors.New("SHUTDOWN_TIMEOUT_SECONDS must be a positive integer")
 	ErrWebhookMaxCount                 = errors.New("WEBHOOK_MAX_COUNT must be a positive integer")
+	ErrWebhookDebounceWindow           = errors.New("WEBHOOK_DEBOUNCE_WINDOW_MS must be between 0 and 60000")
+	ErrWebhookMaxConcurrentPerHost     = errors.New("WEBHOOK_MAX_CONCURRENT_PER_HOST must not be negative")
+	ErrSlowRequestThreshold            = errors.New("LOG_SLOW_REQUEST_THRESHOLD_MS must not be negative")
+	ErrLogFormat                       = errors.New("LOG_FORMAT must be text or json")
+	ErrMaxRequestBodyBytes             = errors.New("MAX_REQUEST_BODY_BYTES must be a positive integer")
+	ErrFacetSamplePercent              = errors.New("FACET_SAMPLE_PERCENT must be greater than 0 and at most 100")
+	ErrFeedbackRetentionDays           = errors.New("FEEDBACK_RETENTION_DAYS must be a positive integer")
+	ErrFeedbackBatchMaxRecords         = errors.New("FEEDBACK_BATCH_MAX_RECORDS must be a positive integer")
 	ErrDatabaseMinConnsExceedsMax      = errors.New("DATABASE_MIN_CONNS must not exceed DATABASE_MAX_CONNS")
 	ErrInvalidPublicBaseURL            = errors.New("PUBLIC_BASE_URL must be an absolute http(s) URL without query or fragment")
 	ErrInvalidEmbeddingBaseURL         = errors.New("EMBEDDING_BASE_URL must be an absolute http(s) URL without query or fragment")
@@ -40,6 +50,16 @@ var (
 		".env file is malformed (fix quoting/characters; parse detail withheld to avoid logging secrets)")
 	ErrInvalidTranslationDefaultLanguage = errors.New("TRANSLATION_DEFAULT_LANGUAGE must be a valid BCP-47 locale (e.g. en-US)")
 	ErrInvalidTaxonomyServiceURL         = errors.New("TAXONOMY_SERVICE_URL must be an absolute http(s) URL without query or fragment")
+	ErrEmbeddingBulkJobPriority          = errors.New("EMBEDDING_BULK_JOB_PRIORITY must be between 1 and 4")
+	ErrEmbeddingTenantBacklogThreshold   = errors.New("EMBEDDING_TENANT_BACKLOG_THRESHOLD must not be negative")
+	ErrEmbeddingModelNotAllowed          = errors.New("embedding model is not in EMBEDDING_ALLOWED_MODELS")
+	ErrEmbeddingSecondaryModel           = errors.New("EMBEDDING_SECONDARY_MODEL must differ from EMBEDDING_MODEL and not be a taxonomy: key")
+)
+
+// Log output formats accepted by LOG_FORMAT.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
 )
 
 // DefaultDatabaseURL is the default connection URL when DATABASE_URL is unset (local/test only).
@@ -62,16 +82,40 @@ type Config struct {
 	TenantSettingsCache TenantSettingsCacheConfig
 	Taxonomy            TaxonomyConfig
 	TenantData          TenantDataConfig
+	Export              ExportConfig
+	Facets              FacetsConfig
+	FeedbackRecords     FeedbackRecordsConfig
+	Search              SearchConfig
+	Readiness           ReadinessConfig
 	Observability       ObservabilityConfig
 }
 
-// ServerConfig holds HTTP server and process settings.
+// ServerConfig holds HTTP server and process settings. AdminAPIKey authenticates the operator
+// endpoints under /v1/admin/; they are not mounted at all while it is empty.
 type ServerConfig struct {
 	Port            string      `env:"PORT"                     env-default:"8080"`
 	HubAPIKey       string      `env:"API_KEY"`
+	AdminAPIKey     string      `env:"ADMIN_API_KEY"`
 	PublicBaseURL   string      `env:"PUBLIC_BASE_URL"`
 	LogLevel        string      `env:"LOG_LEVEL"                env-default:"info"`
 	ShutdownTimeout DurationSec `env:"SHUTDOWN_TIMEOUT_SECONDS" env-default:"30"`
+	// LogFormat is LogFormatText (the default, for local dev) or LogFormatJSON, one JSON object per
+	// line for log aggregators; request_id, trace_id, and span_id are top-level fields either way.
+	LogFormat string `env:"LOG_FORMAT" env-default:"text"`
+	// SlowRequestThresholdMs (0 = off) demotes the access log of requests faster than it to debug
+	// and logs slower ones at warn; LogRequestErrors keeps 5xx responses at warn regardless.
+	SlowRequestThresholdMs int  `env:"LOG_SLOW_REQUEST_THRESHOLD_MS" env-default:"0"`
+	LogRequestErrors       bool `env:"LOG_REQUEST_ERRORS"            env-default:"true"`
+	// TrustedProxies lists the load balancers / reverse proxies whose X-Forwarded-For and
+	// X-Real-IP headers are believed. Empty (the default) trusts none: the client IP is the TCP peer.
+	TrustedProxies PrefixList `env:"TRUSTED_PROXIES"`
+	// MaintenanceMode starts the API rejecting writes on /v1 with 503 (reads keep working); the
+	// admin API can flip it at runtime. MaintenanceRetryAfter is the Retry-After sent meanwhile.
+	MaintenanceMode       bool        `env:"MAINTENANCE_MODE"                env-default:"false"`
+	MaintenanceRetryAfter DurationSec `env:"MAINTENANCE_RETRY_AFTER_SECONDS" env-default:"60"`
+	// MaxRequestBodyBytes caps every /v1 request body (1 MiB by default); larger bodies get 413.
+	// Endpoints with small payloads (feedback records, tenant settings) keep tighter caps of their own.
+	MaxRequestBodyBytes int64 `env:"MAX_REQUEST_BODY_BYTES" env-default:"1048576"`
 }
 
 // DatabaseConfig holds database connection settings.
@@ -83,6 +127,10 @@ type DatabaseConfig struct {
 	MaxConnIdleTime   DurationSec `env:"DATABASE_MAX_CONN_IDLE_TIME_SECONDS"  env-default:"1800"`
 	HealthCheckPeriod DurationSec `env:"DATABASE_HEALTH_CHECK_PERIOD_SECONDS" env-default:"60"`
 	ConnectTimeout    DurationSec `env:"DATABASE_CONNECT_TIMEOUT_SECONDS"     env-default:"10"`
+	// ConnectRetryAttempts / ConnectRetryMaxWait bound the startup wait for a database that is
+	// not up yet (orchestrated boots where app and DB start together). 1 attempt = no retry.
+	ConnectRetryAttempts int         `env:"DATABASE_CONNECT_RETRY_ATTEMPTS"          env-default:"10"`
+	ConnectRetryMaxWait  DurationSec `env:"DATABASE_CONNECT_RETRY_MAX_WAIT_SECONDS" env-default:"60"`
 }
 
 // PoolConfig returns database pool options for this config (for use with database.NewPostgresPool).
@@ -97,6 +145,14 @@ func (d *DatabaseConfig) PoolConfig() database.PoolConfig {
 	}
 }
 
+// ConnectRetry returns the initial-connect retry policy (for use with database.NewPostgresPoolWithRetry).
+func (d *DatabaseConfig) ConnectRetry() database.ConnectRetry {
+	return database.ConnectRetry{
+		Attempts: d.ConnectRetryAttempts,
+		MaxWait:  d.ConnectRetryMaxWait.Duration(),
+	}
+}
+
 // RiverConfig holds River client settings (worker process). Zero values mean use River defaults.
 // See https://pkg.go.dev/github.com/riverqueue/river#Config.
 type RiverConfig struct {
@@ -110,7 +166,23 @@ type RiverConfig struct {
 	ClientID string `env:"RIVER_CLIENT_ID" env-default:""`
 }
 
+// maxWebhookDebounceWindowMs caps WEBHOOK_DEBOUNCE_WINDOW_MS: held events live only in API
+// memory, so a long window means a long delivery delay and more to flush on shutdown.
+const maxWebhookDebounceWindowMs = 60000
+
+// maxRiverPriority is River's lowest job priority (priorities run 1..4, 1 first).
+const maxRiverPriority = 4
+
+// defaultEmbeddingTenantBacklogThreshold is EMBEDDING_TENANT_BACKLOG_THRESHOLD's default.
+const defaultEmbeddingTenantBacklogThreshold = 1000
+
 // WebhookConfig holds webhook delivery and enqueue settings.
+//
+// DebounceWindowMs (0 = off) coalesces feedback_record.created/updated webhook events for the same
+// record within the window into one delivery with the latest state (see service.WebhookDebouncer).
+// UserAgent (HTTP_USER_AGENT) replaces the default formbricks-hub/<version> User-Agent of outbound
+// deliveries. MaxConcurrentPerHost (0 = unlimited) caps one process's in-flight deliveries to a single
+// destination host; the rest wait for a slot (see WebhookSenderImpl.SetMaxConcurrentPerHost).
 type WebhookConfig struct {
 	DeliveryMaxConcurrent   int          `env:"WEBHOOK_DELIVERY_MAX_CONCURRENT"    env-default:"100"`
 	DeliveryMaxAttempts     int          `env:"WEBHOOK_DELIVERY_MAX_ATTEMPTS"      env-default:"3"`
@@ -120,6 +192,9 @@ type WebhookConfig struct {
 	EnqueueMaxRetries       int          `env:"WEBHOOK_ENQUEUE_MAX_RETRIES"        env-default:"3"`
 	EnqueueInitialBackoffMs int          `env:"WEBHOOK_ENQUEUE_INITIAL_BACKOFF_MS" env-default:"100"`
 	EnqueueMaxBackoffMs     int          `env:"WEBHOOK_ENQUEUE_MAX_BACKOFF_MS"     env-default:"2000"`
+	DebounceWindowMs        int          `env:"WEBHOOK_DEBOUNCE_WINDOW_MS"         env-default:"0"`
+	MaxConcurrentPerHost    int          `env:"WEBHOOK_MAX_CONCURRENT_PER_HOST"    env-default:"10"`
+	UserAgent               string       `env:"HTTP_USER_AGENT"`
 	URLBlacklist            BlacklistSet `env:"WEBHOOK_BLACKLIST"                  env-default:"localhost,127.0.0.1,::1,169.254.169.254"`
 }
 
@@ -130,6 +205,12 @@ type MessagePublisherConfig struct {
 }
 
 // EmbeddingConfig holds embedding provider and queue settings.
+//
+// Normalize (client-side L2 normalization) is deliberately NOT part of the stored model key:
+// every similarity path (HNSW halfvec_cosine_ops, the <=> searches) is cosine distance, which is
+// scale-invariant, so normalized and unnormalized vectors of one model compare correctly and
+// toggling it needs no re-embed — whereas keying on it would orphan every stored vector until a
+// full backfill. Revisit if an inner-product or L2 operator is ever added.
 type EmbeddingConfig struct {
 	ProviderAPIKey      string `env:"EMBEDDING_PROVIDER_API_KEY"`
 	Provider            string `env:"EMBEDDING_PROVIDER"`
@@ -138,8 +219,52 @@ type EmbeddingConfig struct {
 	MaxConcurrent       int    `env:"EMBEDDING_MAX_CONCURRENT"        env-default:"5"`
 	MaxAttempts         int    `env:"EMBEDDING_MAX_ATTEMPTS"          env-default:"3"`
 	Normalize           bool   `env:"EMBEDDING_NORMALIZE"             env-default:"false"`
+	Required            bool   `env:"EMBEDDING_REQUIRED"              env-default:"false"`
+	StartupProbe        bool   `env:"EMBEDDING_STARTUP_PROBE"         env-default:"false"`
 	GoogleCloudProject  string `env:"EMBEDDING_GOOGLE_CLOUD_PROJECT"`
 	GoogleCloudLocation string `env:"EMBEDDING_GOOGLE_CLOUD_LOCATION"`
+	// SecondaryModel is an optional second model of the same provider, embedded alongside Model
+	// so search quality can be compared (the search endpoints' model parameter) before switching.
+	// Its vectors must have the same dimensions; it doubles embedding cost while set.
+	SecondaryModel string `env:"EMBEDDING_SECONDARY_MODEL"`
+	// SyncTimeout bounds the inline embedding of POST /v1/feedback-records?sync_embedding=true
+	// (slot acquisition plus the provider call); past it the record falls back to the async job.
+	SyncTimeout DurationSec `env:"EMBEDDING_SYNC_TIMEOUT_SECONDS" env-default:"5"`
+	// SyncMaxConcurrent caps in-flight inline embeddings per API process, so sync clients cannot
+	// monopolize the provider's rate limit that the queued workers share.
+	SyncMaxConcurrent int `env:"EMBEDDING_SYNC_MAX_CONCURRENT" env-default:"4"`
+	// HTTPTimeout bounds each provider call, so a hung connection is abandoned and retried well
+	// before the job (or the sync path's SyncTimeout) runs out.
+	HTTPTimeout DurationSec `env:"EMBEDDING_HTTP_TIMEOUT_SECONDS" env-default:"15"`
+	// HTTPMaxRetries is how many times a transient provider failure (5xx, connection reset, the
+	// HTTPTimeout above) is retried inside one call, before River's job-level retry is involved.
+	// Rate limits are never retried here. 0 disables in-client retry.
+	HTTPMaxRetries int `env:"EMBEDDING_HTTP_MAX_RETRIES" env-default:"2"`
+	// BulkJobPriority is the River priority (1 highest .. 4 lowest) of backfill and re-embed jobs.
+	// Event-driven jobs run at 1 (2 for a backlogged tenant), so by default a tenant's bulk
+	// re-embedding never queues ahead of another tenant's newly created feedback; 1 restores
+	// plain FIFO.
+	BulkJobPriority int `env:"EMBEDDING_BULK_JOB_PRIORITY" env-default:"4"`
+	// TenantBacklogThreshold is the number of queued embedding jobs at which a tenant counts as
+	// backlogged: its new event-driven jobs then run at priority 2, behind every other tenant's
+	// (see service.EmbeddingTenantBacklog). 0 disables the demotion.
+	TenantBacklogThreshold int `env:"EMBEDDING_TENANT_BACKLOG_THRESHOLD" env-default:"1000"`
+	// AllowedModels is the operator's allowlist of embedding models (cost/compliance); empty
+	// allows any. Both binaries refuse to start with a model outside it (CheckAllowedModels), and
+	// a tenant's embedding_model setting is held to it too.
+	AllowedModels ModelList `env:"EMBEDDING_ALLOWED_MODELS"`
+}
+
+// CheckAllowedModels returns ErrEmbeddingModelNotAllowed when EMBEDDING_MODEL or
+// EMBEDDING_SECONDARY_MODEL is set to a model outside EMBEDDING_ALLOWED_MODELS.
+func (c EmbeddingConfig) CheckAllowedModels() error {
+	for _, model := range []string{c.Model, c.SecondaryModel} {
+		if model = strings.TrimSpace(model); model != "" && !c.AllowedModels.Allows(model) {
+			return fmt.Errorf("%w: %q (allowed: %s)", ErrEmbeddingModelNotAllowed, model, strings.Join(c.AllowedModels, ", "))
+		}
+	}
+
+	return nil
 }
 
 // TranslationConfig holds the feedback open-text translation enrichment settings
@@ -223,6 +348,8 @@ type TaxonomyConfig struct {
 	StuckRunTimeout DurationSec `env:"TAXONOMY_STUCK_RUN_TIMEOUT_SECONDS" env-default:"1800"`
 	// ReaperInterval is how often the reaper sweeps for stuck runs.
 	ReaperInterval DurationSec `env:"TAXONOMY_REAPER_INTERVAL_SECONDS" env-default:"60"`
+	// MaxNodeLevel is the deepest level (root = 0) a manual node move or merge may produce.
+	MaxNodeLevel int `env:"TAXONOMY_MAX_NODE_LEVEL" env-default:"5"`
 }
 
 // TenantDataConfig holds tenant data purge settings.
@@ -233,6 +360,56 @@ type TenantDataConfig struct {
 	PurgeLockTimeout DurationSec `env:"TENANT_PURGE_LOCK_TIMEOUT_SECONDS" env-default:"5"`
 }
 
+// ExportConfig holds the asynchronous feedback export settings. Exports are disabled unless Dir
+// is set. The worker writes files into Dir and the API serves them from it, so when hub-api and
+// hub-worker run separately Dir must be a volume both mount.
+type ExportConfig struct {
+	Dir         string `env:"EXPORT_DIR"`
+	MaxAttempts int    `env:"EXPORT_MAX_ATTEMPTS" env-default:"3"`
+}
+
+// FacetsConfig tunes the facet endpoints (GET /v1/feedback-records/tags). An approximate=true
+// request counts a TABLESAMPLE of SamplePercent of feedback_records' pages and scales the counts
+// up; an exact request whose table is estimated (pg_class.reltuples) above
+// ApproximateRowThreshold rows is answered exactly but flagged approximate_suggested. A threshold
+// of 0 never suggests.
+type FacetsConfig struct {
+	ApproximateRowThreshold int64   `env:"FACET_APPROXIMATE_ROW_THRESHOLD" env-default:"1000000"`
+	SamplePercent           float64 `env:"FACET_SAMPLE_PERCENT"            env-default:"1"`
+}
+
+// FeedbackRecordsConfig holds feedback record write settings. value_number is a float64, exact
+// for integers only up to 2^53-1 in magnitude; a larger one is rounded on decode. By default
+// such a write is stored and logged at warn; RejectImpreciseValueNumber turns it into a 400 so
+// clients carrying IDs or large money amounts learn to send them as text instead.
+// RetentionDays is how long a soft-deleted record stays recoverable before the worker's
+// periodic purge hard-deletes it. BatchMaxRecords caps the records of one
+// POST /v1/feedback-records/batch request.
+type FeedbackRecordsConfig struct {
+	RejectImpreciseValueNumber bool `env:"REJECT_IMPRECISE_VALUE_NUMBER" env-default:"false"`
+	RetentionDays              int  `env:"FEEDBACK_RETENTION_DAYS"       env-default:"30"`
+	BatchMaxRecords            int  `env:"FEEDBACK_BATCH_MAX_RECORDS"    env-default:"500"`
+}
+
+// RetentionPeriod returns RetentionDays as a duration.
+func (c *FeedbackRecordsConfig) RetentionPeriod() time.Duration {
+	return time.Duration(c.RetentionDays) * 24 * time.Hour
+}
+
+// SearchConfig tunes semantic search (POST /v1/feedback-records/search/semantic). MaxQueryLen
+// caps the query, in characters after trimming, that search embeds; a longer one is a 400.
+type SearchConfig struct {
+	MaxQueryLen int `env:"SEARCH_MAX_QUERY_LEN" env-default:"2000"`
+}
+
+// ReadinessConfig toggles the dependency checks behind GET /ready. All default on; a partial
+// deployment (e.g. an API whose River tables live elsewhere) turns off the checks that do not
+// apply to its topology instead of being reported not-ready forever.
+type ReadinessConfig struct {
+	CheckDB    bool `env:"READINESS_CHECK_DB"    env-default:"true"`
+	CheckRiver bool `env:"READINESS_CHECK_RIVER" env-default:"true"`
+}
+
 // ObservabilityConfig holds OpenTelemetry settings.
 type ObservabilityConfig struct {
 	MetricsExporter string `env:"OTEL_METRICS_EXPORTER"`
@@ -291,6 +468,69 @@ func parseBlacklist(s string) map[string]struct{} {
 	return out
 }
 
+// ModelList is a list of model names. It implements cleanenv.Setter by parsing a comma-separated
+// list; entries are trimmed and blanks dropped.
+type ModelList []string
+
+// SetValue implements cleanenv.Setter.
+func (l *ModelList) SetValue(s string) error {
+	var out ModelList
+
+	for part := range strings.SplitSeq(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+
+	*l = out
+
+	return nil
+}
+
+// Allows reports whether model is on the list; an empty list allows every model.
+func (l ModelList) Allows(model string) bool {
+	return len(l) == 0 || slices.Contains(l, model)
+}
+
+// PrefixList is a list of IP networks. It implements cleanenv.Setter by parsing a comma-separated
+// list of CIDRs; a bare address is taken as a single-host network (/32 or /128).
+type PrefixList []netip.Prefix
+
+// SetValue implements cleanenv.Setter. Unlike BlacklistSet it rejects malformed entries: a typo
+// in a trust list must stop startup rather than silently trust nothing (or the wrong network).
+func (p *PrefixList) SetValue(s string) error {
+	var out PrefixList
+
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if !strings.Contains(part, "/") {
+			addr, err := netip.ParseAddr(part)
+			if err != nil {
+				return fmt.Errorf("parse trusted proxy %q: %w", part, err)
+			}
+
+			out = append(out, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(part)
+		if err != nil {
+			return fmt.Errorf("parse trusted proxy %q: %w", part, err)
+		}
+
+		out = append(out, prefix.Masked())
+	}
+
+	*p = out
+
+	return nil
+}
+
 // Load reads configuration from .env (if present) and environment variables.
 // cleanenv supports .env in ReadConfig (see https://github.com/ilyakaznacheev/cleanenv).
 // If .env is missing, ReadEnv is used so config comes from the process environment only.
@@ -339,11 +579,21 @@ func applyDefaults(cfg *Config) {
 		cfg.Server.LogLevel = "info"
 	}
 
+	cfg.Server.LogFormat = strings.ToLower(strings.TrimSpace(cfg.Server.LogFormat))
+	if cfg.Server.LogFormat == "" {
+		cfg.Server.LogFormat = LogFormatText
+	}
+
 	const defaultShutdownSec = 30
 	if cfg.Server.ShutdownTimeout.Duration() == 0 {
 		cfg.Server.ShutdownTimeout = DurationSec(time.Duration(defaultShutdownSec) * time.Second)
 	}
 
+	const defaultMaintenanceRetryAfterSec = 60
+	if cfg.Server.MaintenanceRetryAfter.Duration() <= 0 {
+		cfg.Server.MaintenanceRetryAfter = DurationSec(time.Duration(defaultMaintenanceRetryAfterSec) * time.Second)
+	}
+
 	if cfg.Database.URL == "" {
 		cfg.Database.URL = DefaultDatabaseURL
 	}
@@ -352,6 +602,15 @@ func applyDefaults(cfg *Config) {
 		cfg.Database.MaxConns = 25
 	}
 
+	if cfg.Database.ConnectRetryAttempts <= 0 {
+		cfg.Database.ConnectRetryAttempts = 10
+	}
+
+	const defaultConnectRetryMaxWaitSec = 60
+	if cfg.Database.ConnectRetryMaxWait.Duration() <= 0 {
+		cfg.Database.ConnectRetryMaxWait = DurationSec(time.Duration(defaultConnectRetryMaxWaitSec) * time.Second)
+	}
+
 	if len(cfg.Webhook.URLBlacklist) == 0 {
 		cfg.Webhook.URLBlacklist = BlacklistSet(parseBlacklist("localhost,127.0.0.1,::1,169.254.169.254"))
 	}
@@ -392,6 +651,14 @@ func applyDefaults(cfg *Config) {
 		}
 	}
 
+	if _, ok := os.LookupEnv("EMBEDDING_BULK_JOB_PRIORITY"); !ok {
+		cfg.Embedding.BulkJobPriority = maxRiverPriority
+	}
+
+	if _, ok := os.LookupEnv("EMBEDDING_TENANT_BACKLOG_THRESHOLD"); !ok {
+		cfg.Embedding.TenantBacklogThreshold = defaultEmbeddingTenantBacklogThreshold
+	}
+
 	// Coerce unset/nonsensical worker tunables back to their defaults for every enrichment
 	// type. An explicit 0 would otherwise fail hub-worker startup (river rejects MaxWorkers 0)
 	// or, worse, flow into InsertOpts where River substitutes its default of 25 attempts — 25
@@ -411,6 +678,50 @@ func applyDefaults(cfg *Config) {
 		}
 	}
 
+	const (
+		defaultEmbeddingSyncTimeoutSec    = 5
+		defaultEmbeddingSyncMaxConcurrent = 4
+	)
+	if cfg.Embedding.SyncTimeout.Duration() <= 0 {
+		cfg.Embedding.SyncTimeout = DurationSec(time.Duration(defaultEmbeddingSyncTimeoutSec) * time.Second)
+	}
+
+	if cfg.Embedding.SyncMaxConcurrent <= 0 {
+		cfg.Embedding.SyncMaxConcurrent = defaultEmbeddingSyncMaxConcurrent
+	}
+
+	if cfg.Export.MaxAttempts <= 0 {
+		cfg.Export.MaxAttempts = 3
+	}
+
+	// An explicit 0 disables the approximate-mode hint, so only an unset variable takes the default.
+	const defaultFacetApproximateRowThreshold = 1_000_000
+	if _, ok := os.LookupEnv("FACET_APPROXIMATE_ROW_THRESHOLD"); !ok || cfg.Facets.ApproximateRowThreshold < 0 {
+		cfg.Facets.ApproximateRowThreshold = defaultFacetApproximateRowThreshold
+	}
+
+	if _, ok := os.LookupEnv("FACET_SAMPLE_PERCENT"); !ok {
+		cfg.Facets.SamplePercent = 1
+	}
+
+	const defaultSearchMaxQueryLen = 2000
+	if cfg.Search.MaxQueryLen <= 0 {
+		cfg.Search.MaxQueryLen = defaultSearchMaxQueryLen
+	}
+
+	const (
+		defaultEmbeddingHTTPTimeoutSec = 15
+		defaultEmbeddingHTTPMaxRetries = 2
+	)
+	if cfg.Embedding.HTTPTimeout.Duration() <= 0 {
+		cfg.Embedding.HTTPTimeout = DurationSec(time.Duration(defaultEmbeddingHTTPTimeoutSec) * time.Second)
+	}
+
+	// An explicit 0 disables in-client retry, so only an unset variable takes the default.
+	if _, ok := os.LookupEnv("EMBEDDING_HTTP_MAX_RETRIES"); !ok || cfg.Embedding.HTTPMaxRetries < 0 {
+		cfg.Embedding.HTTPMaxRetries = defaultEmbeddingHTTPMaxRetries
+	}
+
 	// Default the cache size only when the operator did not set it. An explicit 0 (or
 	// negative) disables the cache: NewCachedTenantSettings treats size <= 0 as "no
 	// caching". cleanenv does not reliably apply env-default to nested-struct fields, so
@@ -432,6 +743,27 @@ func applyDefaults(cfg *Config) {
 		cfg.Taxonomy.MinimumEmbeddedRecords = 20
 	}
 
+	// Like the readiness toggles below: only an unset variable takes the default, so an explicit
+	// "false" is kept.
+	if _, ok := os.LookupEnv("LOG_REQUEST_ERRORS"); !ok {
+		cfg.Server.LogRequestErrors = true
+	}
+
+	// Readiness checks default on. Like the cache size above, consult the env so an explicit
+	// "false" is kept while an unset toggle (which cleanenv may leave false on nested structs)
+	// is not silently disabled.
+	for _, toggle := range []struct {
+		key   string
+		value *bool
+	}{
+		{"READINESS_CHECK_DB", &cfg.Readiness.CheckDB},
+		{"READINESS_CHECK_RIVER", &cfg.Readiness.CheckRiver},
+	} {
+		if _, ok := os.LookupEnv(toggle.key); !ok {
+			*toggle.value = true
+		}
+	}
+
 	const defaultPurgeLockTimeoutSec = 5
 	if cfg.TenantData.PurgeLockTimeout.Duration() <= 0 {
 		cfg.TenantData.PurgeLockTimeout = DurationSec(time.Duration(defaultPurgeLockTimeoutSec) * time.Second)
@@ -467,6 +799,52 @@ func validate(cfg *Config) error {
 		return ErrWebhookMaxCount
 	}
 
+	if cfg.Webhook.DebounceWindowMs < 0 || cfg.Webhook.DebounceWindowMs > maxWebhookDebounceWindowMs {
+		return ErrWebhookDebounceWindow
+	}
+
+	if cfg.Webhook.MaxConcurrentPerHost < 0 {
+		return ErrWebhookMaxConcurrentPerHost
+	}
+
+	if cfg.Server.SlowRequestThresholdMs < 0 {
+		return ErrSlowRequestThreshold
+	}
+
+	if cfg.Server.LogFormat != LogFormatText && cfg.Server.LogFormat != LogFormatJSON {
+		return ErrLogFormat
+	}
+
+	if cfg.Server.MaxRequestBodyBytes <= 0 {
+		return ErrMaxRequestBodyBytes
+	}
+
+	if cfg.Embedding.BulkJobPriority < 1 || cfg.Embedding.BulkJobPriority > maxRiverPriority {
+		return ErrEmbeddingBulkJobPriority
+	}
+
+	if cfg.Embedding.TenantBacklogThreshold < 0 {
+		return ErrEmbeddingTenantBacklogThreshold
+	}
+
+	cfg.Embedding.SecondaryModel = strings.TrimSpace(cfg.Embedding.SecondaryModel)
+	if cfg.Embedding.SecondaryModel != "" && (cfg.Embedding.SecondaryModel == strings.TrimSpace(cfg.Embedding.Model) ||
+		strings.HasPrefix(cfg.Embedding.SecondaryModel, "taxonomy:")) {
+		return ErrEmbeddingSecondaryModel
+	}
+
+	if cfg.Facets.SamplePercent <= 0 || cfg.Facets.SamplePercent > 100 {
+		return ErrFacetSamplePercent
+	}
+
+	if cfg.FeedbackRecords.RetentionDays <= 0 {
+		return ErrFeedbackRetentionDays
+	}
+
+	if cfg.FeedbackRecords.BatchMaxRecords <= 0 {
+		return ErrFeedbackBatchMaxRecords
+	}
+
 	if cfg.Database.MinConns > cfg.Database.MaxConns {
 		return ErrDatabaseMinConnsExceedsMax
 	}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"net/netip"
 	"os"
 	"strings"
 	"testing"
@@ -225,6 +226,65 @@ func TestLoad_TenantSettingsCacheSize(t *testing.T) {
 	})
 }
 
+func TestLoad_EmbeddingHTTPRetry(t *testing.T) {
+	t.Run("unset applies the defaults", func(t *testing.T) {
+		t.Setenv("API_KEY", "test-api-key")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		if cfg.Embedding.HTTPTimeout.Duration() != 15*time.Second || cfg.Embedding.HTTPMaxRetries != 2 {
+			t.Fatalf("HTTPTimeout = %s, HTTPMaxRetries = %d; want 15s and 2",
+				cfg.Embedding.HTTPTimeout.Duration(), cfg.Embedding.HTTPMaxRetries)
+		}
+	})
+
+	t.Run("explicit 0 retries disables retry", func(t *testing.T) {
+		t.Setenv("API_KEY", "test-api-key")
+		t.Setenv("EMBEDDING_HTTP_MAX_RETRIES", "0")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		if cfg.Embedding.HTTPMaxRetries != 0 {
+			t.Fatalf("HTTPMaxRetries = %d, want 0", cfg.Embedding.HTTPMaxRetries)
+		}
+	})
+}
+
+func TestLoad_ReadinessChecks(t *testing.T) {
+	t.Run("unset defaults every check on", func(t *testing.T) {
+		t.Setenv("API_KEY", "test-api-key")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		if !cfg.Readiness.CheckDB || !cfg.Readiness.CheckRiver {
+			t.Fatalf("Readiness = %+v, want both checks enabled by default", cfg.Readiness)
+		}
+	})
+
+	t.Run("explicit false disables a check", func(t *testing.T) {
+		t.Setenv("API_KEY", "test-api-key")
+		t.Setenv("READINESS_CHECK_RIVER", "false")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		if !cfg.Readiness.CheckDB || cfg.Readiness.CheckRiver {
+			t.Fatalf("Readiness = %+v, want db on and river off", cfg.Readiness)
+		}
+	})
+}
+
 func TestLoad_EmbeddingGoogleCloudProject(t *testing.T) {
 	t.Setenv("API_KEY", "test-api-key")
 	t.Setenv("EMBEDDING_GOOGLE_CLOUD_PROJECT", "my-google-cloud-project")
@@ -539,6 +599,9 @@ func TestDatabasePoolConfig(t *testing.T) {
 		MaxConnIdleTime:   DurationSec(15 * time.Second),
 		HealthCheckPeriod: DurationSec(10 * time.Second),
 		ConnectTimeout:    DurationSec(5 * time.Second),
+
+		ConnectRetryAttempts: 4,
+		ConnectRetryMaxWait:  DurationSec(20 * time.Second),
 	}
 
 	got := cfg.PoolConfig()
@@ -566,6 +629,16 @@ func TestDatabasePoolConfig(t *testing.T) {
 	if got.ConnectTimeout != cfg.ConnectTimeout.Duration() {
 		t.Errorf("PoolConfig().ConnectTimeout = %v, want %v", got.ConnectTimeout, cfg.ConnectTimeout.Duration())
 	}
+
+	retry := cfg.ConnectRetry()
+
+	if retry.Attempts != cfg.ConnectRetryAttempts {
+		t.Errorf("ConnectRetry().Attempts = %d, want %d", retry.Attempts, cfg.ConnectRetryAttempts)
+	}
+
+	if retry.MaxWait != cfg.ConnectRetryMaxWait.Duration() {
+		t.Errorf("ConnectRetry().MaxWait = %v, want %v", retry.MaxWait, cfg.ConnectRetryMaxWait.Duration())
+	}
 }
 
 func TestDurationSecSetValue(t *testing.T) {
@@ -592,6 +665,75 @@ func TestDurationSecSetValue(t *testing.T) {
 	}
 }
 
+func TestPrefixListSetValue(t *testing.T) {
+	var prefixes PrefixList
+
+	if err := prefixes.SetValue(" 10.0.0.0/8, 192.168.1.10 ,2001:db8::/32,,10.1.2.3/16"); err != nil {
+		t.Fatalf("SetValue() error = %v, want nil", err)
+	}
+
+	want := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.10/32"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("10.1.0.0/16"),
+	}
+	if len(prefixes) != len(want) {
+		t.Fatalf("SetValue() parsed %v, want %v", prefixes, want)
+	}
+
+	for i := range want {
+		if prefixes[i] != want[i] {
+			t.Fatalf("prefix %d = %v, want %v", i, prefixes[i], want[i])
+		}
+	}
+
+	for _, bad := range []string{"10.0.0.0/33", "proxy.internal", "10.0.0.0/8,nope"} {
+		if err := prefixes.SetValue(bad); err == nil {
+			t.Fatalf("SetValue(%q) error = nil, want error", bad)
+		}
+	}
+}
+
+func TestEmbeddingCheckAllowedModels(t *testing.T) {
+	var allowed ModelList
+	if err := allowed.SetValue(" text-embedding-3-small ,, text-embedding-3-large"); err != nil {
+		t.Fatalf("SetValue() error = %v, want nil", err)
+	}
+
+	if len(allowed) != 2 || allowed[0] != "text-embedding-3-small" || allowed[1] != "text-embedding-3-large" {
+		t.Fatalf("SetValue() parsed %q, want the two trimmed models", allowed)
+	}
+
+	tests := []struct {
+		name string
+		cfg  EmbeddingConfig
+		want error
+	}{
+		{name: "empty allowlist allows any model", cfg: EmbeddingConfig{Model: "anything"}},
+		{name: "embeddings disabled", cfg: EmbeddingConfig{AllowedModels: allowed}},
+		{name: "allowed model", cfg: EmbeddingConfig{Model: "text-embedding-3-small", AllowedModels: allowed}},
+		{
+			name: "disallowed model",
+			cfg:  EmbeddingConfig{Model: "text-embedding-ada-002", AllowedModels: allowed},
+			want: ErrEmbeddingModelNotAllowed,
+		},
+		{
+			name: "disallowed secondary model",
+			cfg:  EmbeddingConfig{Model: "text-embedding-3-small", SecondaryModel: "expensive", AllowedModels: allowed},
+			want: ErrEmbeddingModelNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.CheckAllowedModels(); !errors.Is(err, tt.want) {
+				t.Fatalf("CheckAllowedModels() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
 func TestApplyDefaults(t *testing.T) {
 	t.Setenv("GOOGLE_CLOUD_PROJECT", "fallback-project")
 	t.Setenv("GOOGLE_CLOUD_LOCATION", "europe-west1")
@@ -632,6 +774,10 @@ func TestApplyDefaults(t *testing.T) {
 		t.Errorf("Webhook.HTTPTimeout = %v, want 15s", cfg.Webhook.HTTPTimeout.Duration())
 	}
 
+	if cfg.Server.MaintenanceRetryAfter.Duration() != 60*time.Second {
+		t.Errorf("Server.MaintenanceRetryAfter = %v, want 60s", cfg.Server.MaintenanceRetryAfter.Duration())
+	}
+
 	if cfg.Webhook.EnqueueMaxRetries != 3 {
 		t.Errorf("Webhook.EnqueueMaxRetries = %d, want 3", cfg.Webhook.EnqueueMaxRetries)
 	}
@@ -659,6 +805,22 @@ func TestApplyDefaults(t *testing.T) {
 	if cfg.Embedding.MaxAttempts != 3 {
 		t.Errorf("Embedding.MaxAttempts = %d, want 3", cfg.Embedding.MaxAttempts)
 	}
+
+	if !cfg.Server.LogRequestErrors {
+		t.Error("Server.LogRequestErrors = false, want true by default")
+	}
+
+	if cfg.Search.MaxQueryLen != 2000 {
+		t.Errorf("Search.MaxQueryLen = %d, want 2000", cfg.Search.MaxQueryLen)
+	}
+
+	if cfg.Embedding.BulkJobPriority != 4 {
+		t.Errorf("Embedding.BulkJobPriority = %d, want 4", cfg.Embedding.BulkJobPriority)
+	}
+
+	if cfg.Embedding.TenantBacklogThreshold != 1000 {
+		t.Errorf("Embedding.TenantBacklogThreshold = %d, want 1000", cfg.Embedding.TenantBacklogThreshold)
+	}
 }
 
 func TestValidateRejectsInvalidValues(t *testing.T) {
@@ -674,6 +836,34 @@ func TestValidateRejectsInvalidValues(t *testing.T) {
 			},
 			wantErr: ErrWebhookDeliveryMaxConcurrent,
 		},
+		{
+			name: "embedding bulk job priority out of range",
+			mutate: func(cfg *Config) {
+				cfg.Embedding.BulkJobPriority = 5
+			},
+			wantErr: ErrEmbeddingBulkJobPriority,
+		},
+		{
+			name: "negative embedding tenant backlog threshold",
+			mutate: func(cfg *Config) {
+				cfg.Embedding.TenantBacklogThreshold = -1
+			},
+			wantErr: ErrEmbeddingTenantBacklogThreshold,
+		},
+		{
+			name: "negative slow request threshold",
+			mutate: func(cfg *Config) {
+				cfg.Server.SlowRequestThresholdMs = -1
+			},
+			wantErr: ErrSlowRequestThreshold,
+		},
+		{
+			name: "unknown log format",
+			mutate: func(cfg *Config) {
+				cfg.Server.LogFormat = "logfmt"
+			},
+			wantErr: ErrLogFormat,
+		},
 		{
 			name: "webhook delivery max attempts",
 			mutate: func(cfg *Config) {
@@ -716,6 +906,77 @@ func TestValidateRejectsInvalidValues(t *testing.T) {
 			},
 			wantErr: ErrWebhookMaxCount,
 		},
+		{
+			name: "negative webhook debounce window",
+			mutate: func(cfg *Config) {
+				cfg.Webhook.DebounceWindowMs = -1
+			},
+			wantErr: ErrWebhookDebounceWindow,
+		},
+		{
+			name: "webhook debounce window too long",
+			mutate: func(cfg *Config) {
+				cfg.Webhook.DebounceWindowMs = maxWebhookDebounceWindowMs + 1
+			},
+			wantErr: ErrWebhookDebounceWindow,
+		},
+		{
+			name: "negative webhook per-host concurrency",
+			mutate: func(cfg *Config) {
+				cfg.Webhook.MaxConcurrentPerHost = -1
+			},
+			wantErr: ErrWebhookMaxConcurrentPerHost,
+		},
+		{
+			name: "secondary embedding model equals primary",
+			mutate: func(cfg *Config) {
+				cfg.Embedding.Model = "text-embedding-3-small"
+				cfg.Embedding.SecondaryModel = " text-embedding-3-small "
+			},
+			wantErr: ErrEmbeddingSecondaryModel,
+		},
+		{
+			name: "secondary embedding model uses taxonomy prefix",
+			mutate: func(cfg *Config) {
+				cfg.Embedding.SecondaryModel = "taxonomy:text-embedding-3-small:translated-v1"
+			},
+			wantErr: ErrEmbeddingSecondaryModel,
+		},
+		{
+			name: "zero facet sample percent",
+			mutate: func(cfg *Config) {
+				cfg.Facets.SamplePercent = 0
+			},
+			wantErr: ErrFacetSamplePercent,
+		},
+		{
+			name: "facet sample percent above 100",
+			mutate: func(cfg *Config) {
+				cfg.Facets.SamplePercent = 101
+			},
+			wantErr: ErrFacetSamplePercent,
+		},
+		{
+			name: "zero max request body bytes",
+			mutate: func(cfg *Config) {
+				cfg.Server.MaxRequestBodyBytes = 0
+			},
+			wantErr: ErrMaxRequestBodyBytes,
+		},
+		{
+			name: "zero feedback retention days",
+			mutate: func(cfg *Config) {
+				cfg.FeedbackRecords.RetentionDays = 0
+			},
+			wantErr: ErrFeedbackRetentionDays,
+		},
+		{
+			name: "zero feedback batch max records",
+			mutate: func(cfg *Config) {
+				cfg.FeedbackRecords.BatchMaxRecords = 0
+			},
+			wantErr: ErrFeedbackBatchMaxRecords,
+		},
 		{
 			name: "database min exceeds max",
 			mutate: func(cfg *Config) {
@@ -804,8 +1065,10 @@ func TestNormalizePublicBaseURLRejectsInvalidValues(t *testing.T) {
 func validValidationConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			ShutdownTimeout: DurationSec(time.Second),
-			PublicBaseURL:   "https://hub.example.com",
+			ShutdownTimeout:     DurationSec(time.Second),
+			PublicBaseURL:       "https://hub.example.com",
+			MaxRequestBodyBytes: 1 << 20,
+			LogFormat:           LogFormatText,
 		},
 		Database: DatabaseConfig{
 			MaxConns: 2,
@@ -821,6 +1084,9 @@ func validValidationConfig() *Config {
 			BufferSize:         1,
 			PerEventTimeoutSec: 1,
 		},
+		Embedding:       EmbeddingConfig{BulkJobPriority: 4},
+		Facets:          FacetsConfig{SamplePercent: 1},
+		FeedbackRecords: FeedbackRecordsConfig{RetentionDays: 30, BatchMaxRecords: 500},
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -43,6 +44,9 @@ type Client struct {
 	model      string
 	dimensions int
 	normalize  bool
+	// vertex is set for Google Cloud-hosted Gemini (NewGoogleGeminiClient); it bounds the
+	// embedding batch size (see embeddingBatchLimit).
+	vertex bool
 	// thinkingBudgetUnsupported latches once the configured model rejects a zero thinking
 	// budget (Pro models cannot disable thinking), so later calls fall back to the model's
 	// default thinking behavior instead of failing.
@@ -118,6 +122,7 @@ func NewGoogleGeminiClient(ctx context.Context, project, location string, opts .
 	client := &Client{
 		client:     genaiClient,
 		dimensions: models.EmbeddingVectorDimensions,
+		vertex:     true,
 	}
 	for _, opt := range opts {
 		opt(client)
@@ -265,17 +270,25 @@ func wrapGenerateContentError(err error) error {
 }
 
 // wrapGenaiError wraps an SDK error under op, mapping a 429 / RESOURCE_EXHAUSTED to a
-// huberrors.RateLimitError (carrying the retry hint) so callers can snooze. Shared by the
-// generate-content and embedding call paths — a throttled embedding backfill must snooze,
-// not burn retry attempts.
+// huberrors.RateLimitError (carrying the retry hint) so callers can snooze, and a 5xx to
+// huberrors.ErrProviderUnavailable so callers may retry. Shared by the generate-content and
+// embedding call paths — a throttled embedding backfill must snooze, not burn retry attempts.
 func wrapGenaiError(op string, err error) error {
 	wrapped := fmt.Errorf("%s: %w", op, err)
 
 	var apiErr genai.APIError
-	if errors.As(err, &apiErr) && (apiErr.Code == http.StatusTooManyRequests || apiErr.Status == "RESOURCE_EXHAUSTED") {
+	if !errors.As(err, &apiErr) {
+		return wrapped
+	}
+
+	if apiErr.Code == http.StatusTooManyRequests || apiErr.Status == "RESOURCE_EXHAUSTED" {
 		return huberrors.NewRateLimitError(genaiRetryAfter(apiErr), wrapped)
 	}
 
+	if apiErr.Code >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: %w", huberrors.ErrProviderUnavailable, wrapped)
+	}
+
 	return wrapped
 }
 
@@ -324,7 +337,74 @@ func (c *Client) embedWithTaskType(ctx context.Context, input, taskType string)
 		return nil, ErrNoEmbeddingInResponse
 	}
 
-	emb := resp.Embeddings[0].Values
+	return c.vector(resp.Embeddings[0].Values)
+}
+
+// CreateEmbeddingsBatch returns one RETRIEVAL_DOCUMENT embedding per input, in input order, sending
+// the inputs in as few requests as embeddingBatchLimit allows. Every input must be non-empty after
+// trimming. The API reports no per-input errors, so a failed request fails the whole call.
+func (c *Client) CreateEmbeddingsBatch(ctx context.Context, inputs []string) ([][]float32, error) {
+	if c.dimensions <= 0 || c.dimensions > math.MaxInt32 {
+		return nil, ErrInvalidDims
+	}
+
+	contents := make([]*genai.Content, len(inputs))
+
+	for i, input := range inputs {
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return nil, ErrEmptyInput
+		}
+
+		contents[i] = genai.NewContentFromText(input, genai.RoleUser)
+	}
+
+	dimInt32 := int32(c.dimensions)
+	out := make([][]float32, 0, len(contents))
+
+	for chunk := range slices.Chunk(contents, c.embeddingBatchLimit()) {
+		resp, err := c.client.Models.EmbedContent(ctx, c.model, chunk, &genai.EmbedContentConfig{
+			TaskType:             "RETRIEVAL_DOCUMENT",
+			OutputDimensionality: &dimInt32,
+		})
+		if err != nil {
+			return nil, wrapGenaiError("gemini embedding batch", err)
+		}
+
+		if len(resp.Embeddings) != len(chunk) {
+			return nil, fmt.Errorf("%w: got %d embeddings for %d inputs", ErrNoEmbeddingInResponse, len(resp.Embeddings), len(chunk))
+		}
+
+		for _, embedding := range resp.Embeddings {
+			vector, err := c.vector(embedding.Values)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, vector)
+		}
+	}
+
+	return out, nil
+}
+
+// embeddingBatchLimit is how many inputs one embedding request may carry: 100 on the Gemini API
+// (batchEmbedContents), 250 on Vertex AI's predict endpoint for the text-embedding models, and one
+// for Vertex's Gemini embedding models, which accept a single input per request — batching there
+// still saves job overhead, just not requests.
+func (c *Client) embeddingBatchLimit() int {
+	switch {
+	case !c.vertex:
+		return 100
+	case strings.Contains(c.model, "gemini") || strings.Contains(c.model, "maas"):
+		return 1
+	default:
+		return 250
+	}
+}
+
+// vector checks one response embedding against the configured dimensions and copies it.
+func (c *Client) vector(emb []float32) ([]float32, error) {
 	if len(emb) != c.dimensions {
 		return nil, fmt.Errorf("%w: got %d, want %d", ErrDimensionMismatch, len(emb), c.dimensions)
 	}
@@ -58,6 +58,34 @@ func TestClient_CreateEmbedding_whitespaceInput_returnsErrEmptyInput(t *testing.
 	assert.ErrorIs(t, err, ErrEmptyInput)
 }
 
+func TestClient_CreateEmbeddingsBatch_emptyInput_returnsErrEmptyInput(t *testing.T) {
+	client := &Client{dimensions: 2}
+
+	_, err := client.CreateEmbeddingsBatch(context.Background(), []string{"fine", "  "})
+	require.ErrorIs(t, err, ErrEmptyInput)
+}
+
+func TestClient_EmbeddingBatchLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		vertex bool
+		model  string
+		want   int
+	}{
+		{name: "gemini api", model: "gemini-embedding-001", want: 100},
+		{name: "vertex text-embedding", vertex: true, model: "text-embedding-005", want: 250},
+		{name: "vertex gemini embedding", vertex: true, model: "gemini-embedding-001", want: 1},
+		{name: "vertex maas", vertex: true, model: "publishers/google/models/maas-embed", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{vertex: tt.vertex, model: tt.model}
+			assert.Equal(t, tt.want, client.embeddingBatchLimit())
+		})
+	}
+}
+
 func TestGenaiRetryAfter(t *testing.T) {
 	const retryInfoType = "type.googleapis.com/google.rpc.RetryInfo"
 
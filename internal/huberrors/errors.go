@@ -136,6 +136,37 @@ func (e *ConflictError) Is(target error) bool {
 	return ok
 }
 
+// ErrPreconditionFailed is the sentinel for a conditional write (If-Match / If-Unmodified-Since)
+// refused because the resource changed since the client read it.
+var ErrPreconditionFailed = &PreconditionFailedError{}
+
+// PreconditionFailedError is returned when a write's optimistic-concurrency precondition no
+// longer holds against the resource's current version.
+type PreconditionFailedError struct {
+	Message string
+}
+
+// NewPreconditionFailedError creates a PreconditionFailedError with a custom message.
+func NewPreconditionFailedError(message string) *PreconditionFailedError {
+	return &PreconditionFailedError{Message: message}
+}
+
+// Error implements the error interface.
+func (e *PreconditionFailedError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return "precondition failed"
+}
+
+// Is implements the error interface for error comparison.
+func (e *PreconditionFailedError) Is(target error) bool {
+	_, ok := target.(*PreconditionFailedError)
+
+	return ok
+}
+
 // ErrTranslationSuperseded is the sentinel for a feedback-record translation write skipped
 // because the tenant's current target_language no longer matches the target the job was
 // enqueued for — e.g. the target changed, or the job was enqueued from a stale tenant-settings
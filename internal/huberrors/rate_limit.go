@@ -1,10 +1,16 @@
 package huberrors
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
 
+// ErrProviderUnavailable marks a provider server-side failure (HTTP 5xx): transient by nature,
+// so a client may retry the same request after a short backoff. Deliberately separate from
+// RateLimitError — throttling is the worker's to snooze, not the client's to hammer.
+var ErrProviderUnavailable = errors.New("provider unavailable")
+
 // RateLimitError marks a provider rate-limit response (HTTP 429 / RESOURCE_EXHAUSTED).
 // RetryAfter is the provider-suggested delay before retrying, or 0 when unknown. Enrichment
 // workers snooze for RetryAfter instead of consuming a retry attempt, so a burst against a
@@ -54,3 +54,18 @@ type FeedbackRecordWithScore struct {
 	// ulp and would duplicate or skip boundary rows across pages. Internal only, not in the API.
 	Distance float64 `json:"-"`
 }
+
+// ReembedRequest is the body of POST /v1/admin/reembed: which of one tenant's records to re-embed.
+// tenant_id is required; the other filters are optional, and since/until bound collected_at
+// inclusively.
+type ReembedRequest struct {
+	TenantID   string     `json:"tenant_id"             validate:"required,no_null_bytes,min=1,max=255"`
+	SourceType *string    `json:"source_type,omitempty" validate:"omitempty,no_null_bytes,min=1,max=255"`
+	Since      *time.Time `json:"since,omitempty"`
+	Until      *time.Time `json:"until,omitempty"`
+}
+
+// ReembedResponse reports how many embedding jobs a re-embed enqueued.
+type ReembedResponse struct {
+	Enqueued int `json:"enqueued"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportFormat is the file format of a feedback export.
+type ExportFormat string
+
+// Export formats.
+const (
+	ExportFormatCSV   ExportFormat = "csv"
+	ExportFormatJSONL ExportFormat = "jsonl"
+)
+
+// ExportJobStatus is the persisted lifecycle state of an export job.
+//
+// Allowed transitions are pending -> running -> completed|failed, plus running -> running when
+// River retries an attempt that died mid-write. A failed job is final; the client creates a new one.
+type ExportJobStatus string
+
+// Export job statuses.
+const (
+	ExportJobStatusPending   ExportJobStatus = "pending"
+	ExportJobStatusRunning   ExportJobStatus = "running"
+	ExportJobStatusCompleted ExportJobStatus = "completed"
+	ExportJobStatusFailed    ExportJobStatus = "failed"
+)
+
+// ExportJobFilters is the record selection an export snapshots at creation: a subset of the list
+// filters (tenant_id is the job's own column). Stored as the export_jobs.filters JSONB.
+type ExportJobFilters struct {
+	SourceType *string    `json:"source_type,omitempty" validate:"omitempty,no_null_bytes,min=1,max=255"`
+	SourceID   *string    `json:"source_id,omitempty"   validate:"omitempty,no_null_bytes,min=1,max=255"`
+	FieldID    *string    `json:"field_id,omitempty"    validate:"omitempty,no_null_bytes,min=1,max=255"`
+	Tags       []string   `json:"tags,omitempty"        validate:"omitempty,max=20,dive,no_null_bytes,min=1,max=64"`
+	Since      *time.Time `json:"since,omitempty"`
+	Until      *time.Time `json:"until,omitempty"`
+}
+
+// ListFilters maps the snapshot onto the list filters the export pages through.
+func (f *ExportJobFilters) ListFilters(tenantID string, limit int) *ListFeedbackRecordsFilters {
+	return &ListFeedbackRecordsFilters{
+		TenantID:   &tenantID,
+		SourceType: f.SourceType,
+		SourceID:   f.SourceID,
+		FieldID:    f.FieldID,
+		Tags:       f.Tags,
+		Since:      f.Since,
+		Until:      f.Until,
+		Limit:      limit,
+	}
+}
+
+// CreateExportJobRequest is the body of POST /v1/feedback-records/export.
+type CreateExportJobRequest struct {
+	TenantID string       `json:"tenant_id" validate:"required,no_null_bytes,min=1,max=255"`
+	Format   ExportFormat `json:"format"    validate:"required,oneof=csv jsonl"`
+	ExportJobFilters
+}
+
+// ExportJob is one asynchronous export. FileName is internal (relative to EXPORT_DIR); clients
+// get DownloadURL instead, set by the handler once the job has completed.
+type ExportJob struct {
+	ID          uuid.UUID        `json:"id"`
+	TenantID    string           `json:"tenant_id"`
+	Format      ExportFormat     `json:"format"`
+	Filters     ExportJobFilters `json:"filters"`
+	Status      ExportJobStatus  `json:"status"`
+	RecordCount int64            `json:"record_count"`
+	FileName    *string          `json:"-"`
+	Error       *string          `json:"error,omitempty"`
+	DownloadURL string           `json:"download_url,omitempty"`
+	StartedAt   *time.Time       `json:"started_at,omitempty"`
+	FinishedAt  *time.Time       `json:"finished_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/pkg/jsonpatch"
+)
+
+// JSONPatchContentType is the media type that selects RFC 6902 JSON Patch on
+// PATCH /v1/feedback-records/{id}; any other body is the plain update request.
+const JSONPatchContentType = "application/json-patch+json"
+
+// patchableFeedbackRecordFields are the record members a JSON Patch may write: exactly the
+// fields UpdateFeedbackRecordRequest can set, so a patch can never reach further than a plain
+// update. Every other member (id, created_at, field_id, enrichment outputs, ...) is read-only —
+// a test op may still reference it.
+var patchableFeedbackRecordFields = []string{
+	"value_text", "value_id", "value_number", "value_boolean", "value_date", "metadata", "language", "user_id", "tags",
+	"tenant_id", "source_type", "source_id", "source_name",
+}
+
+// NewUpdateRequestFromJSONPatch applies patch to record's JSON representation and returns the
+// update request that persists the result through the regular update path: only the members
+// whose value the patch actually changed are set. A write to a read-only member, a malformed or
+// inapplicable operation, or a patch that removes a field (the update path cannot clear one;
+// replace tags with [] to untag) is a validation error; a failed test op is a conflict, the
+// RFC's optimistic-concurrency signal.
+func NewUpdateRequestFromJSONPatch(record *FeedbackRecord, patch jsonpatch.Patch) (*UpdateFeedbackRecordRequest, error) {
+	if err := checkPatchTargets(patch); err != nil {
+		return nil, err
+	}
+
+	before, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("encode feedback record: %w", err)
+	}
+
+	after, err := patch.Apply(before)
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, huberrors.NewConflictError(err.Error())
+		}
+
+		return nil, huberrors.NewValidationError("patch", err.Error())
+	}
+
+	var beforeFields, afterFields map[string]json.RawMessage
+	if err := json.Unmarshal(before, &beforeFields); err != nil {
+		return nil, fmt.Errorf("decode feedback record: %w", err)
+	}
+
+	if err := json.Unmarshal(after, &afterFields); err != nil {
+		return nil, huberrors.NewValidationError("patch", "the patched record must remain a JSON object")
+	}
+
+	changes := make(map[string]json.RawMessage)
+
+	for _, field := range patchableFeedbackRecordFields {
+		if jsonValuesEqual(beforeFields[field], afterFields[field]) {
+			continue
+		}
+
+		if value := afterFields[field]; value == nil || string(value) == "null" {
+			return nil, huberrors.NewValidationError(field, field+" cannot be removed by a patch")
+		}
+
+		changes[field] = afterFields[field]
+	}
+
+	body, err := json.Marshal(changes)
+	if err != nil {
+		return nil, fmt.Errorf("encode patched fields: %w", err)
+	}
+
+	var req UpdateFeedbackRecordRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, huberrors.NewValidationError(typeErr.Field, typeErr.Field+" has an invalid type")
+		}
+
+		return nil, huberrors.NewValidationError("patch", err.Error())
+	}
+
+	return &req, nil
+}
+
+// checkPatchTargets rejects any writing operation whose path (or a move's source, which it
+// removes) is outside the patchable members.
+func checkPatchTargets(patch jsonpatch.Patch) error {
+	if err := patch.Validate(); err != nil {
+		return huberrors.NewValidationError("patch", err.Error())
+	}
+
+	for _, op := range patch {
+		if !op.Writes() {
+			continue
+		}
+
+		targets := []string{op.Path}
+		if op.Op == jsonpatch.OpMove {
+			targets = append(targets, op.From)
+		}
+
+		for _, target := range targets {
+			tokens, _ := jsonpatch.ParsePointer(target) // validated above
+			if len(tokens) == 0 {
+				return huberrors.NewValidationError("patch", op.Op+" cannot target the whole record")
+			}
+
+			if !slices.Contains(patchableFeedbackRecordFields, tokens[0]) {
+				return huberrors.NewValidationError(tokens[0], tokens[0]+" is read-only and cannot be patched")
+			}
+		}
+	}
+
+	return nil
+}
+
+// jsonValuesEqual compares two JSON values semantically, so re-encoding (member order, number
+// formatting) alone never counts as a change.
+func jsonValuesEqual(a, b json.RawMessage) bool {
+	var av, bv any
+
+	if a != nil {
+		if err := json.Unmarshal(a, &av); err != nil {
+			return false
+		}
+	}
+
+	if b != nil {
+		if err := json.Unmarshal(b, &bv); err != nil {
+			return false
+		}
+	}
+
+	return reflect.DeepEqual(av, bv)
+}
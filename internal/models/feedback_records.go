@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/huberrors"
 )
 
 // ErrInvalidFieldType is returned when a field type string is invalid (err113).
@@ -84,6 +86,37 @@ func (e *InvalidFieldTypeError) Unwrap() error {
 	return ErrInvalidFieldType
 }
 
+// CheckTypedValues rejects a typed value its field type cannot carry: value_number belongs to the
+// numeric types (nps, csat, ces, rating, number), value_boolean to boolean, and value_date to date.
+// value_text and value_id stay open to every type (a choice label, a comment, or an exact large
+// number sent as text). The error names the first offending field.
+func CheckTypedValues(fieldType FieldType, number *float64, boolean *bool, date *time.Time) error {
+	switch {
+	case number != nil && !fieldType.IsNumeric():
+		return typedValueError("value_number", fieldType)
+	case boolean != nil && fieldType != FieldTypeBoolean:
+		return typedValueError("value_boolean", fieldType)
+	case date != nil && fieldType != FieldTypeDate:
+		return typedValueError("value_date", fieldType)
+	}
+
+	return nil
+}
+
+// IsNumeric reports whether records of this field type carry their answer in value_number.
+func (t FieldType) IsNumeric() bool {
+	switch t {
+	case FieldTypeNPS, FieldTypeCSAT, FieldTypeCES, FieldTypeRating, FieldTypeNumber:
+		return true
+	default:
+		return false
+	}
+}
+
+func typedValueError(field string, fieldType FieldType) error {
+	return huberrors.NewValidationError(field, fmt.Sprintf("is not accepted for field_type %q", fieldType))
+}
+
 // ValidFieldTypeValues returns valid field_type values in API documentation order.
 func ValidFieldTypeValues() []string {
 	return append([]string(nil), validFieldTypeValueNames...)
@@ -291,6 +324,26 @@ type FeedbackRecord struct {
 	// the record is enriched (or emotions is disabled / the record is ineligible / no emotion was
 	// detected). Never an empty array — absence is NULL.
 	Emotions *[]EmotionValue `json:"emotions,omitempty"`
+	// Tags are analyst-curated labels (the human counterpart to the AI taxonomy), stored in
+	// NormalizeTags form. Never null on a persisted record — untagged is an empty array.
+	Tags []string `json:"tags"`
+	// DeletedAt is set on a soft-deleted record (DELETE /v1/feedback-records/{id}). Only reads
+	// that ask for deleted records (include_deleted=true) ever return one.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// EmbeddedInline marks a record whose raw embedding was already stored on the create request
+	// (sync_embedding=true, or a caller-supplied embedding), so the embedding provider skips the
+	// redundant job for its created event. Process-local only: never persisted or serialized.
+	EmbeddedInline bool `json:"-"`
+	// SkipEmbedding marks a record created with skip_embedding=true: the embedding providers enqueue
+	// no job for its created event, leaving it for a deliberate backfill. Process-local only.
+	SkipEmbedding bool `json:"-"`
+	// EmbeddingEnqueued marks a record whose raw embedding job was enqueued together with the rest
+	// of its batch create, so the primary embedding provider skips the per-record job for its
+	// created event. Process-local only.
+	EmbeddingEnqueued bool `json:"-"`
+	// Replayed marks a record returned for a repeated Idempotency-Key instead of being created:
+	// nothing was written, so no event is published and no job enqueued. Process-local only.
+	Replayed bool `json:"-"`
 }
 
 // IsTextField reports whether this record is an open-text field — the eligibility gate the text
@@ -326,6 +379,30 @@ type CreateFeedbackRecordRequest struct {
 	UserID          *string         `json:"user_id,omitempty"           validate:"omitempty,no_null_bytes,max=255"`
 	TenantID        string          `json:"tenant_id"                   validate:"required,no_null_bytes,max=255"`
 	SubmissionID    string          `json:"submission_id"               validate:"required,no_null_bytes,min=1,max=255"`
+	// Embedding is an optional pre-computed vector for the record's text (bring-your-own
+	// embeddings). When set it must have EmbeddingVectorDimensions entries and come from the same
+	// model the deployment is configured with (EMBEDDING_MODEL); it is stored with the record and
+	// no embedding job is enqueued. Write-only: never returned.
+	Embedding []float32 `json:"embedding,omitempty"`
+	Tags      []string  `json:"tags,omitempty"      validate:"omitempty,max=20,dive,no_null_bytes,min=1,max=64"`
+	// IdempotencyKey is the request's Idempotency-Key header (empty when absent). Set by the
+	// handler, never decoded from the body.
+	IdempotencyKey string `json:"-"`
+}
+
+// CreateFeedbackRecordsBatchRequest is the body of POST /v1/feedback-records/batch. Each record is
+// validated on its own, so one invalid record fails only its own item.
+type CreateFeedbackRecordsBatchRequest struct {
+	Records []CreateFeedbackRecordRequest `json:"records"`
+}
+
+// FeedbackRecordBatchItem is one record of a batch create. The handler sets Request, or Err when
+// the record failed request validation; the service leaves items that already carry an error alone
+// and sets Record or Err on the rest.
+type FeedbackRecordBatchItem struct {
+	Request *CreateFeedbackRecordRequest
+	Record  *FeedbackRecord
+	Err     error
 }
 
 // TranslationBackfillTarget is a feedback record that needs (re)translation to its
@@ -335,8 +412,12 @@ type TranslationBackfillTarget struct {
 	TargetLang       string
 }
 
-// UpdateFeedbackRecordRequest represents the request to update a feedback record
-// Only value fields, metadata, language, and user_id can be updated.
+// UpdateFeedbackRecordRequest represents the request to update a feedback record.
+// Value fields, metadata, language, user_id, tags, the source (source_type, source_id,
+// source_name), and tenant_id can be updated. tenant_id and the source exist to repair records a
+// misconfigured connector filed under the wrong tenant or source. Everything else is immutable:
+// id, created_at, collected_at, submission_id, the field_* members, and the server-generated
+// enrichment outputs.
 type UpdateFeedbackRecordRequest struct {
 	ValueText    *string         `json:"value_text,omitempty"    validate:"omitempty,no_null_bytes,max=30000"`
 	ValueID      *string         `json:"value_id,omitempty"      validate:"omitempty,no_null_bytes,max=255"`
@@ -346,6 +427,47 @@ type UpdateFeedbackRecordRequest struct {
 	Metadata     json.RawMessage `json:"metadata,omitempty"`
 	Language     *string         `json:"language,omitempty"      validate:"omitempty,no_null_bytes,max=10"`
 	UserID       *string         `json:"user_id,omitempty"       validate:"omitempty,no_null_bytes,max=255"`
+	// Tags replaces the record's whole tag set when present; an empty array clears it.
+	Tags *[]string `json:"tags,omitempty" validate:"omitempty,max=20,dive,no_null_bytes,min=1,max=64"`
+	// TenantID moves the record to another tenant. Refused while the record is a member of topics
+	// in its current tenant's taxonomy, which cannot follow it. Published as a delete in the old
+	// tenant and a create in the new one.
+	TenantID   *string `json:"tenant_id,omitempty"   validate:"omitempty,no_null_bytes,min=1,max=255"`
+	SourceType *string `json:"source_type,omitempty" validate:"omitempty,no_null_bytes,min=1,max=255"`
+	SourceID   *string `json:"source_id,omitempty"   validate:"omitempty,no_null_bytes,max=255"`
+	SourceName *string `json:"source_name,omitempty" validate:"omitempty,no_null_bytes,max=255"`
+	// Precondition, when set, makes the update conditional (optimistic locking): the repository
+	// calls it with the current row, read under the row lock the write takes, and a false return
+	// aborts the update with huberrors.ErrPreconditionFailed. The API sets it from If-Match /
+	// If-Unmodified-Since; nil (the default) updates unconditionally. Never part of the body.
+	Precondition func(current *FeedbackRecord) bool `json:"-"`
+}
+
+// Tag filter match modes for ListFeedbackRecordsFilters.TagMatch.
+const (
+	TagMatchAll = "all" // record carries every requested tag (AND); the default
+	TagMatchAny = "any" // record carries at least one requested tag (OR)
+)
+
+// NormalizeTags returns tags in their canonical stored form: trimmed, lowercased, and
+// de-duplicated (first occurrence wins), so "Bug" and "bug " are one label for filtering and
+// facets. The result is never nil (an empty input normalizes to an empty set, which is how the
+// column stores "untagged"). ok is false when a tag is blank after trimming.
+func NormalizeTags(tags []string) (normalized []string, ok bool) {
+	normalized = make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, false
+		}
+
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized, true
 }
 
 // FieldsChangedFrom returns the names of fields that are set in the update request AND differ
@@ -390,6 +512,27 @@ func (r *UpdateFeedbackRecordRequest) FieldsChangedFrom(old *FeedbackRecord) []s
 		fields = append(fields, "user_id")
 	}
 
+	// Order-sensitive: re-ordering the same tags counts as a change (conservative, like metadata).
+	if r.Tags != nil && !slices.Equal(old.Tags, *r.Tags) {
+		fields = append(fields, "tags")
+	}
+
+	if r.TenantID != nil && old.TenantID != *r.TenantID {
+		fields = append(fields, "tenant_id")
+	}
+
+	if r.SourceType != nil && old.SourceType != *r.SourceType {
+		fields = append(fields, "source_type")
+	}
+
+	if r.SourceID != nil && !stringPtrEqual(old.SourceID, r.SourceID) {
+		fields = append(fields, "source_id")
+	}
+
+	if r.SourceName != nil && !stringPtrEqual(old.SourceName, r.SourceName) {
+		fields = append(fields, "source_name")
+	}
+
 	return fields
 }
 
@@ -437,6 +580,26 @@ func (r *UpdateFeedbackRecordRequest) ChangedFields() []string {
 		fields = append(fields, "user_id")
 	}
 
+	if r.Tags != nil {
+		fields = append(fields, "tags")
+	}
+
+	if r.TenantID != nil {
+		fields = append(fields, "tenant_id")
+	}
+
+	if r.SourceType != nil {
+		fields = append(fields, "source_type")
+	}
+
+	if r.SourceID != nil {
+		fields = append(fields, "source_id")
+	}
+
+	if r.SourceName != nil {
+		fields = append(fields, "source_name")
+	}
+
 	return fields
 }
 
@@ -451,10 +614,17 @@ type ListFeedbackRecordsFilters struct {
 	FieldType    *FieldType `form:"field_type"     validate:"omitempty,field_type"`
 	ValueID      *string    `form:"value_id"       validate:"omitempty,no_null_bytes"`
 	UserID       *string    `form:"user_id"        validate:"omitempty,no_null_bytes"`
+	Tags         []string   `form:"tag"            validate:"omitempty,max=20,dive,no_null_bytes,min=1,max=64"` // repeatable
+	TagMatch     string     `form:"tag_match"      validate:"omitempty,oneof=all any"`                          // all (default) | any
 	Since        *time.Time `form:"since"          validate:"omitempty"`
 	Until        *time.Time `form:"until"          validate:"omitempty"`
+	Classified   *bool      `form:"classified"     validate:"omitempty"` // in a topic of its field's active taxonomy
 	Limit        int        `form:"limit"          validate:"omitempty,min=1,max=1000"`
 	Cursor       string     `form:"cursor"         validate:"omitempty"` // keyset; omit for first page, use next_cursor for next
+	// IncludeDeleted also returns soft-deleted records (DeletedAt set) until the retention purge
+	// removes them: the escape hatch for recovering an accidental delete. Only the /v1/admin/
+	// mirrors of list, count and export accept it; the regular routes answer it with 403.
+	IncludeDeleted bool `form:"include_deleted"`
 }
 
 // ListFeedbackRecordsResponse represents the response for listing feedback records.
@@ -464,6 +634,52 @@ type ListFeedbackRecordsResponse struct {
 	NextCursor string           `json:"next_cursor,omitempty"` // present when there may be more results
 }
 
+// ListFeedbackRecordTagsFilters represents query parameters for the tag facet. Approximate trades
+// accuracy for speed on large tables: counts come from a sample of the table, scaled up.
+type ListFeedbackRecordTagsFilters struct {
+	TenantID    string `form:"tenant_id"   validate:"required,no_null_bytes,min=1,max=255"`
+	Limit       int    `form:"limit"       validate:"omitempty,min=1,max=1000"`
+	Approximate bool   `form:"approximate"`
+}
+
+// FeedbackRecordTagCount is one tag and the number of the tenant's records carrying it.
+type FeedbackRecordTagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// ListFeedbackRecordTagsResponse represents the response for the tag facet, most-used first.
+// Approximate reports that the counts are sampled estimates; ApproximateSuggested marks an exact
+// answer from a table large enough that approximate=true is recommended.
+type ListFeedbackRecordTagsResponse struct {
+	Data                 []FeedbackRecordTagCount `json:"data"`
+	Approximate          bool                     `json:"approximate"`
+	ApproximateSuggested bool                     `json:"approximate_suggested,omitempty"`
+}
+
+// FeedbackRecordStatsFilters represents query parameters for the record volume stats. Since and
+// Until bound collected_at (both inclusive, like the list filters); omitted, the stats cover all time.
+type FeedbackRecordStatsFilters struct {
+	TenantID string     `form:"tenant_id" validate:"required,no_null_bytes,min=1,max=255"`
+	Since    *time.Time `form:"since"     validate:"omitempty"`
+	Until    *time.Time `form:"until"     validate:"omitempty"`
+}
+
+// FeedbackRecordDayCount is the number of records collected on one UTC day.
+type FeedbackRecordDayCount struct {
+	Day   time.Time `json:"day"`
+	Count int64     `json:"count"`
+}
+
+// FeedbackRecordStatsResponse holds a tenant's record counts grouped three ways over one range:
+// by source_type, by field_type, and per UTC day of collected_at (oldest first, empty days omitted).
+type FeedbackRecordStatsResponse struct {
+	Total        int64                    `json:"total"`
+	BySourceType map[string]int64         `json:"by_source_type"`
+	ByFieldType  map[string]int64         `json:"by_field_type"`
+	ByDay        []FeedbackRecordDayCount `json:"by_day"`
+}
+
 // DeleteFeedbackRecordsByUserFilters represents query parameters for deleting feedback records by user.
 type DeleteFeedbackRecordsByUserFilters struct {
 	UserID   string  `form:"user_id"   validate:"required,no_null_bytes,min=1,max=255"`
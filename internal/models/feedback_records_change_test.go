@@ -61,3 +61,57 @@ func TestUpdateFeedbackRecordRequest_FieldsChangedFrom(t *testing.T) {
 		}
 	})
 }
+
+// TestNormalizeTags verifies tags are trimmed, lowercased, and de-duplicated in first-seen order,
+// that an empty input yields a non-nil empty set, and that a blank tag is rejected.
+func TestNormalizeTags(t *testing.T) {
+	got, ok := NormalizeTags([]string{" Bug", "urgent", "BUG", "ux "})
+	if !ok || !slices.Equal(got, []string{"bug", "urgent", "ux"}) {
+		t.Fatalf("NormalizeTags() = %v, %v; want [bug urgent ux], true", got, ok)
+	}
+
+	if got, ok := NormalizeTags(nil); !ok || got == nil || len(got) != 0 {
+		t.Fatalf("NormalizeTags(nil) = %#v, %v; want empty non-nil, true", got, ok)
+	}
+
+	if _, ok := NormalizeTags([]string{"bug", "  "}); ok {
+		t.Fatal("NormalizeTags() with a blank tag ok = true, want false")
+	}
+}
+
+// TestUpdateFeedbackRecordRequest_FieldsChangedFrom_Tags verifies a tag replacement is reported
+// only when the set differs from the stored one.
+func TestUpdateFeedbackRecordRequest_FieldsChangedFrom_Tags(t *testing.T) {
+	old := &FeedbackRecord{Tags: []string{"bug"}}
+
+	same := []string{"bug"}
+	if got := (&UpdateFeedbackRecordRequest{Tags: &same}).FieldsChangedFrom(old); slices.Contains(got, "tags") {
+		t.Fatalf("FieldsChangedFrom() = %v, want it to omit tags (idempotent re-send)", got)
+	}
+
+	cleared := []string{}
+	if got := (&UpdateFeedbackRecordRequest{Tags: &cleared}).FieldsChangedFrom(old); !slices.Contains(got, "tags") {
+		t.Fatalf("FieldsChangedFrom() = %v, want it to contain tags", got)
+	}
+}
+
+// TestUpdateFeedbackRecordRequest_FieldsChangedFrom_TenantAndSource verifies a tenant move and a
+// source correction are reported only when they change the stored values.
+func TestUpdateFeedbackRecordRequest_FieldsChangedFrom_TenantAndSource(t *testing.T) {
+	sourceID := "survey-1"
+	old := &FeedbackRecord{TenantID: "org-a", SourceType: "survey", SourceID: &sourceID}
+
+	sameTenant, sameType, sameID := "org-a", "survey", "survey-1"
+	unchanged := &UpdateFeedbackRecordRequest{TenantID: &sameTenant, SourceType: &sameType, SourceID: &sameID}
+
+	if got := unchanged.FieldsChangedFrom(old); len(got) != 0 {
+		t.Fatalf("FieldsChangedFrom() = %v, want none (idempotent re-send)", got)
+	}
+
+	newTenant, newName := "org-b", "Q3 survey"
+	moved := &UpdateFeedbackRecordRequest{TenantID: &newTenant, SourceName: &newName}
+
+	if got := moved.FieldsChangedFrom(old); !slices.Equal(got, []string{"tenant_id", "source_name"}) {
+		t.Fatalf("FieldsChangedFrom() = %v, want [tenant_id source_name]", got)
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/formbricks/hub/internal/huberrors"
+)
+
+// TestCheckTypedValues verifies each typed value is accepted only by its field types, that
+// value-less and text-only records always pass, and that the error names the offending field.
+func TestCheckTypedValues(t *testing.T) {
+	number := 9.0
+	boolean := true
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		fieldType FieldType
+		number    *float64
+		boolean   *bool
+		date      *time.Time
+		wantField string
+	}{
+		{name: "no typed value", fieldType: FieldTypeText},
+		{name: "number on nps", fieldType: FieldTypeNPS, number: &number},
+		{name: "number on rating", fieldType: FieldTypeRating, number: &number},
+		{name: "boolean on boolean", fieldType: FieldTypeBoolean, boolean: &boolean},
+		{name: "date on date", fieldType: FieldTypeDate, date: &date},
+		{name: "number on text", fieldType: FieldTypeText, number: &number, wantField: "value_number"},
+		{name: "boolean on categorical", fieldType: FieldTypeCategorical, boolean: &boolean, wantField: "value_boolean"},
+		{name: "date on number", fieldType: FieldTypeNumber, date: &date, wantField: "value_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTypedValues(tt.fieldType, tt.number, tt.boolean, tt.date)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("CheckTypedValues() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			var validationErr *huberrors.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.wantField {
+				t.Fatalf("CheckTypedValues() error = %v, want a validation error on %s", err, tt.wantField)
+			}
+		})
+	}
+}
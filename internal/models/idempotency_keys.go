@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Idempotency keys (the Idempotency-Key header on create requests).
+const (
+	// IdempotencyKeyTTL is how long a key is honored: a repeat within it returns the original
+	// record, a repeat after it creates a new one.
+	IdempotencyKeyTTL = 24 * time.Hour
+	// IdempotencyEndpointCreateFeedbackRecord scopes keys of POST /v1/feedback-records, so the
+	// same key sent to a future idempotent endpoint never returns a feedback record.
+	IdempotencyEndpointCreateFeedbackRecord = "POST /v1/feedback-records"
+)
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// SubjectErasureRequest is the body of POST /v1/gdpr/erase. Without tenant_id the subject is
+// erased in every tenant (the same right-to-erasure exception as DELETE /v1/feedback-records).
+type SubjectErasureRequest struct {
+	UserID   string  `json:"user_id"             validate:"required,no_null_bytes,min=1,max=255"`
+	TenantID *string `json:"tenant_id,omitempty" validate:"omitempty,no_null_bytes,min=1,max=255"`
+}
+
+// SubjectErasureCounts is the repository result of a subject erasure transaction.
+type SubjectErasureCounts struct {
+	DeletedByTenant                   []DeletedFeedbackRecordsByTenant
+	DeletedEmbeddings                 int64
+	DeletedTaxonomyClusterMemberships int64
+	DeletedWebhookDeadLetters         int64
+	// ValueTexts are the distinct value_text of the deleted records, used to evict in-process
+	// caches keyed by text. Never serialized.
+	ValueTexts []string
+}
+
+// SubjectErasureTenant is one tenant's share of an erasure.
+type SubjectErasureTenant struct {
+	TenantID               string `json:"tenant_id"`
+	DeletedFeedbackRecords int64  `json:"deleted_feedback_records"`
+}
+
+// SubjectErasureReport is the response of POST /v1/gdpr/erase: what was removed, for the caller's
+// erasure records. Warnings name the best-effort steps that ran after the database commit and did
+// not complete; the erasure itself is not rolled back for them.
+type SubjectErasureReport struct {
+	UserID                            string                 `json:"user_id"`
+	TenantID                          *string                `json:"tenant_id,omitempty"`
+	DeletedFeedbackRecords            int64                  `json:"deleted_feedback_records"`
+	Tenants                           []SubjectErasureTenant `json:"tenants"`
+	DeletedEmbeddings                 int64                  `json:"deleted_embeddings"`
+	DeletedTaxonomyClusterMemberships int64                  `json:"deleted_taxonomy_cluster_memberships"`
+	DeletedWebhookDeadLetters         int64                  `json:"deleted_webhook_dead_letters"`
+	CanceledEmbeddingJobs             int64                  `json:"canceled_embedding_jobs"`
+	EvictedQueryCacheEntries          int64                  `json:"evicted_query_cache_entries"`
+	Warnings                          []string               `json:"warnings,omitempty"`
+	ErasedAt                          time.Time              `json:"erased_at"`
+}
@@ -14,7 +14,8 @@ import (
 //   - running: Hub handed the run to the taxonomy service.
 //   - succeeded: the taxonomy service persisted artifacts and Hub activated the run.
 //   - failed: the run ended with a sanitized error and optional failure code.
-//   - canceled: reserved for future user/operator cancellation.
+//   - canceled: a user or operator canceled the run before it finished; the taxonomy
+//     service learns of it on its next heartbeat and its late result is rejected.
 //
 // Allowed transitions are pending -> running|failed|canceled and
 // running -> succeeded|failed|canceled. Terminal states must not be overwritten.
@@ -165,6 +166,14 @@ type TaxonomyCluster struct {
 	UpdatedAt  time.Time       `json:"updated_at"`
 }
 
+// TaxonomyNodeLevelDrift counts one run's nodes whose stored level differs from their depth in
+// the tree (the root is depth 0), as found by the fix-topic-levels maintenance command.
+type TaxonomyNodeLevelDrift struct {
+	RunID        uuid.UUID
+	TenantID     string
+	DriftedNodes int
+}
+
 // TaxonomyNode is a generated or edited node in a taxonomy tree.
 type TaxonomyNode struct {
 	ID            uuid.UUID        `json:"id"`
@@ -185,6 +194,23 @@ type TaxonomyNode struct {
 	Children      []TaxonomyNode   `json:"children,omitempty"`
 }
 
+// TaxonomyTreeSort orders the children of each taxonomy tree node.
+type TaxonomyTreeSort string
+
+const (
+	// TaxonomyTreeSortOrder keeps the taxonomy service's own order (sort_order), the default.
+	TaxonomyTreeSortOrder TaxonomyTreeSort = "sort_order"
+	// TaxonomyTreeSortLabel orders siblings alphabetically by label.
+	TaxonomyTreeSortLabel TaxonomyTreeSort = "label"
+)
+
+// TaxonomyTreeOptions shapes a taxonomy tree response. MaxDepth cuts the tree below that depth
+// (0 returns the root alone, 1 adds the top-level topics); nil returns every level.
+type TaxonomyTreeOptions struct {
+	MaxDepth *int             `form:"max_depth" validate:"omitempty,min=0,max=100"`
+	Sort     TaxonomyTreeSort `form:"sort"      validate:"omitempty,oneof=sort_order label"`
+}
+
 // TaxonomyTreeResponse returns a run and its taxonomy tree.
 type TaxonomyTreeResponse struct {
 	Run  TaxonomyRun   `json:"run"`
@@ -262,6 +288,30 @@ type RenameTaxonomyNodeRequest struct {
 	Label    string `json:"label"     validate:"required,no_null_bytes,min=1"`
 }
 
+// MoveTaxonomyNodeRequest moves a taxonomy node (with its subtree) under a new parent node.
+type MoveTaxonomyNodeRequest struct {
+	TenantID string    `json:"tenant_id" validate:"required,no_null_bytes,min=1,max=255"`
+	ActorID  string    `json:"actor_id"  validate:"required,no_null_bytes,min=1,max=255"`
+	ParentID uuid.UUID `json:"parent_id" validate:"required"`
+}
+
+// MergeTaxonomyNodeRequest merges a taxonomy node into another node of the same run: its feedback
+// records and children move to IntoNodeID and the node itself is soft-removed.
+type MergeTaxonomyNodeRequest struct {
+	TenantID   string    `json:"tenant_id"    validate:"required,no_null_bytes,min=1,max=255"`
+	ActorID    string    `json:"actor_id"     validate:"required,no_null_bytes,min=1,max=255"`
+	IntoNodeID uuid.UUID `json:"into_node_id" validate:"required"`
+}
+
+// MergeTaxonomyNodeResponse is the outcome of a node merge: the updated target node, its visible
+// child count after the merge, and how many children and feedback records moved over.
+type MergeTaxonomyNodeResponse struct {
+	Node          TaxonomyNode `json:"node"`
+	ChildCount    int          `json:"child_count"`
+	ChildrenMoved int          `json:"children_moved"`
+	RecordsMoved  int64        `json:"records_moved"`
+}
+
 // TaxonomyNodeRecordsFilters scopes taxonomy node feedback record drilldown.
 type TaxonomyNodeRecordsFilters struct {
 	TenantID string `form:"tenant_id" validate:"required,no_null_bytes,min=1,max=255"`
@@ -274,6 +324,44 @@ type RemoveTaxonomyNodeFilters struct {
 	ActorID  string `form:"actor_id"  validate:"required,no_null_bytes,min=1,max=255"`
 }
 
+// RemoveTaxonomyNodesRequest soft-removes several taxonomy nodes of one tenant at once
+// (DELETE /v1/taxonomy/nodes), at most 100 per request. DryRun reports the impact without
+// removing anything.
+type RemoveTaxonomyNodesRequest struct {
+	TenantID string      `json:"tenant_id"         validate:"required,no_null_bytes,min=1,max=255"`
+	ActorID  string      `json:"actor_id"          validate:"required,no_null_bytes,min=1,max=255"`
+	IDs      []uuid.UUID `json:"ids"               validate:"required,min=1,max=100,dive,required"`
+	DryRun   bool        `json:"dry_run,omitempty"`
+}
+
+// Per-node outcomes of a batch remove.
+const (
+	TaxonomyNodeRemoved   = "removed"   // the node was soft-removed
+	TaxonomyNodeRemovable = "removable" // dry run: the node would be soft-removed
+	TaxonomyNodeNotFound  = "not_found" // no visible node with this id in the tenant
+)
+
+// RemoveTaxonomyNodeResult is one requested node's outcome. DescendantsAffected counts the
+// visible descendants hidden along with it; FeedbackAffected the distinct feedback records
+// assigned anywhere in its subtree.
+type RemoveTaxonomyNodeResult struct {
+	ID                  uuid.UUID `json:"id"`
+	Status              string    `json:"status"`
+	DescendantsAffected int64     `json:"descendants_affected"`
+	FeedbackAffected    int64     `json:"feedback_affected"`
+}
+
+// RemoveTaxonomyNodesResponse reports a batch remove. The totals are de-duplicated across
+// overlapping subtrees (a requested node inside another requested node's subtree is counted once,
+// as removed rather than as a descendant).
+type RemoveTaxonomyNodesResponse struct {
+	DryRun              bool                       `json:"dry_run"`
+	Results             []RemoveTaxonomyNodeResult `json:"results"`
+	RemovedCount        int                        `json:"removed_count"`
+	DescendantsAffected int64                      `json:"descendants_affected"`
+	FeedbackAffected    int64                      `json:"feedback_affected"`
+}
+
 // TaxonomyNodeRecordsResponse contains feedback records for a taxonomy node.
 type TaxonomyNodeRecordsResponse struct {
 	Data  []FeedbackRecord `json:"data"`
@@ -293,3 +381,27 @@ type TaxonomyNodeRecordCount struct {
 type TaxonomyRecordCountsResponse struct {
 	Counts []TaxonomyNodeRecordCount `json:"counts"`
 }
+
+// RelatedTopicsFilters scopes a feedback record's related-topics lookup. Limit defaults to 5.
+type RelatedTopicsFilters struct {
+	TenantID string `form:"tenant_id" validate:"required,no_null_bytes,min=1,max=255"`
+	Limit    int    `form:"limit"     validate:"omitempty,min=1,max=20"`
+}
+
+// RelatedTopic is a topic of one of the tenant's active taxonomies, scored by the cosine
+// similarity between the record's embedding and the topic's centroid (the mean embedding of the
+// records clustered into it). Path holds the labels from the top-level topic down to this one.
+type RelatedTopic struct {
+	NodeID uuid.UUID `json:"node_id"`
+	RunID  uuid.UUID `json:"run_id"`
+	Label  string    `json:"label"`
+	Level  int       `json:"level"`
+	Path   []string  `json:"path"`
+	Score  float64   `json:"score"`
+}
+
+// RelatedTopicsResponse lists a feedback record's most similar topics, best first.
+type RelatedTopicsResponse struct {
+	Data  []RelatedTopic `json:"data"`
+	Limit int            `json:"limit"`
+}
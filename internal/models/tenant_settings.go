@@ -20,6 +20,16 @@ type EnrichmentSettings struct {
 	// unless it has explicitly switched emotions off. The deployment-level EMOTIONS_PROVIDER/MODEL
 	// gate still applies globally on top of this.
 	EmotionsEnabled *bool `json:"emotions_enabled,omitempty"`
+	// EmbeddingModel picks which configured embedding model (EMBEDDING_MODEL or
+	// EMBEDDING_SECONDARY_MODEL) the tenant's records are searched with by default, for
+	// differentiated tiers or a gradual per-customer rollout. Empty means the deployment's
+	// EMBEDDING_MODEL. Only configured models are accepted: they are the only ones with a client
+	// and with stored vectors.
+	EmbeddingModel string `json:"embedding_model,omitempty"`
+	// SearchMinScore is the similarity floor (0..1) applied to the tenant's semantic search and
+	// similar-feedback lookups when the caller omits min_score, so a tenant with noisier feedback
+	// can be tuned without a redeploy. Absent (nil) means the search default.
+	SearchMinScore *float64 `json:"search_min_score,omitempty"`
 }
 
 // SentimentEnrichmentEnabled reports whether sentiment enrichment is enabled for the tenant,
@@ -36,6 +46,25 @@ func (s EnrichmentSettings) EmotionsEnrichmentEnabled() bool {
 	return s.EmotionsEnabled == nil || *s.EmotionsEnabled
 }
 
+// EffectiveEmbeddingModel returns the tenant's embedding model, or fallback (EMBEDDING_MODEL)
+// when unset.
+func (s EnrichmentSettings) EffectiveEmbeddingModel(fallback string) string {
+	if s.EmbeddingModel == "" {
+		return fallback
+	}
+
+	return s.EmbeddingModel
+}
+
+// EffectiveSearchMinScore returns the tenant's search_min_score, or fallback when unset.
+func (s EnrichmentSettings) EffectiveSearchMinScore(fallback float64) float64 {
+	if s.SearchMinScore == nil {
+		return fallback
+	}
+
+	return *s.SearchMinScore
+}
+
 // TenantSettings is a tenant's persisted settings. It doubles as the API response
 // body for the settings endpoints. tenant_id is the natural key and is never
 // shared across tenants.
@@ -55,6 +84,12 @@ type UpdateTenantSettingsRequest struct {
 	// EmotionsEnabled toggles emotion enrichment for the tenant. As a full replace, an omitted
 	// member clears it back to the default (enabled).
 	EmotionsEnabled *bool `json:"emotions_enabled" validate:"omitempty"`
+	// EmbeddingModel selects a configured embedding model for the tenant. As a full replace, an
+	// omitted member clears it back to the default (EMBEDDING_MODEL).
+	EmbeddingModel string `json:"embedding_model" validate:"omitempty,no_null_bytes,max=255"`
+	// SearchMinScore sets the tenant's default search similarity floor. As a full replace, an
+	// omitted member clears it back to the search default.
+	SearchMinScore *float64 `json:"search_min_score" validate:"omitempty,min=0,max=1"`
 }
 
 // PatchTenantSettingsRequest is the body for PATCH /v1/tenants/{tenant_id}/settings.
@@ -71,4 +106,10 @@ type PatchTenantSettingsRequest struct {
 	// EmotionsEnabled toggles emotion enrichment: a concrete value sets it, an explicit null
 	// restores the default (enabled), an omitted member leaves it unchanged.
 	EmotionsEnabled Optional[bool] `json:"emotions_enabled"`
+	// EmbeddingModel selects a configured embedding model: a concrete value sets it, an explicit
+	// null restores the default (EMBEDDING_MODEL), an omitted member leaves it unchanged.
+	EmbeddingModel Optional[string] `json:"embedding_model"`
+	// SearchMinScore sets the default search similarity floor: a concrete value in [0, 1] sets it,
+	// an explicit null restores the search default, an omitted member leaves it unchanged.
+	SearchMinScore Optional[float64] `json:"search_min_score"`
 }
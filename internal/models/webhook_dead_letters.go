@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDeadLetter is a delivery that exhausted its attempts. Payload is the delivery job's
+// arguments (event id, type, timestamp, data, changed fields, tenant), from which a retry
+// rebuilds the identical Standard Webhooks payload — same webhook-id, so receivers deduplicate.
+// LastStatus is the endpoint's final HTTP status, absent when no response was received
+// (connection error, timeout). RetriedAt is set each time an operator re-enqueues it.
+type WebhookDeadLetter struct {
+	ID         uuid.UUID       `json:"id"`
+	WebhookID  uuid.UUID       `json:"webhook_id"`
+	TenantID   string          `json:"tenant_id"`
+	EventID    uuid.UUID       `json:"event_id"`
+	EventType  string          `json:"event_type"`
+	Payload    json.RawMessage `json:"payload"`
+	LastStatus *int            `json:"last_status,omitempty"`
+	Error      string          `json:"error"`
+	Attempts   int             `json:"attempts"`
+	RetriedAt  *time.Time      `json:"retried_at,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// CreateWebhookDeadLetter is what the dispatch worker records when a delivery fails for good.
+type CreateWebhookDeadLetter struct {
+	WebhookID  uuid.UUID
+	TenantID   string
+	EventID    uuid.UUID
+	EventType  string
+	Payload    json.RawMessage
+	LastStatus *int
+	Error      string
+	Attempts   int
+}
+
+// ListWebhookDeadLettersFilters represents query parameters for GET /v1/webhooks/{id}/dead-letters.
+type ListWebhookDeadLettersFilters struct {
+	Limit  int    `form:"limit"  validate:"omitempty,min=1,max=1000"`
+	Cursor string `form:"cursor" validate:"omitempty"` // keyset cursor; omit for first page, use next_cursor for subsequent pages
+}
+
+// ListWebhookDeadLettersResponse is the response for listing a webhook's dead letters, newest first.
+type ListWebhookDeadLettersResponse struct {
+	Data       []WebhookDeadLetter `json:"data"`
+	Limit      int                 `json:"limit"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook delivery attempt outcomes (webhook_deliveries.status).
+const (
+	WebhookDeliveryStatusSuccess     = "success"
+	WebhookDeliveryStatusRetry       = "retry"
+	WebhookDeliveryStatusFailedFinal = "failed_final"
+)
+
+// WebhookDisabledReasonDeliveryFailures is the disabled_reason of a webhook the dispatch worker
+// disabled after a delivery exhausted WEBHOOK_DELIVERY_MAX_ATTEMPTS; the failed attempts
+// themselves are in its deliveries.
+const WebhookDisabledReasonDeliveryFailures = "delivery_failures"
+
+// WebhookDelivery is one delivery attempt to a webhook endpoint. A failed attempt carries the
+// Error and, when the endpoint answered, its StatusCode and the start of its response body
+// (ResponseBodyTruncated); a successful one (any 2xx) carries neither. NextRetryAt is when the
+// next attempt is due, set only when Status is "retry".
+type WebhookDelivery struct {
+	ID                    uuid.UUID  `json:"id"`
+	WebhookID             uuid.UUID  `json:"webhook_id"`
+	TenantID              string     `json:"tenant_id"`
+	EventID               uuid.UUID  `json:"event_id"`
+	EventType             string     `json:"event_type"`
+	Attempt               int        `json:"attempt"`
+	Status                string     `json:"status"`
+	StatusCode            *int       `json:"status_code,omitempty"`
+	ResponseBodyTruncated *string    `json:"response_body_truncated,omitempty"`
+	Error                 *string    `json:"error,omitempty"`
+	DurationMs            int64      `json:"duration_ms"`
+	NextRetryAt           *time.Time `json:"next_retry_at,omitempty"`
+	CreatedAt             time.Time  `json:"created_at"`
+}
+
+// CreateWebhookDelivery is what the dispatch worker records for each attempt.
+type CreateWebhookDelivery struct {
+	WebhookID             uuid.UUID
+	TenantID              string
+	EventID               uuid.UUID
+	EventType             string
+	Attempt               int
+	Status                string
+	StatusCode            *int
+	ResponseBodyTruncated *string
+	Error                 *string
+	DurationMs            int64
+	NextRetryAt           *time.Time
+}
+
+// ListWebhookDeliveriesFilters represents query parameters for GET /v1/webhooks/{id}/deliveries.
+type ListWebhookDeliveriesFilters struct {
+	Limit  int    `form:"limit"  validate:"omitempty,min=1,max=1000"`
+	Cursor string `form:"cursor" validate:"omitempty"` // keyset cursor; omit for first page, use next_cursor for subsequent pages
+}
+
+// ListWebhookDeliveriesResponse is the response for listing a webhook's delivery attempts, newest first.
+type ListWebhookDeliveriesResponse struct {
+	Data       []WebhookDelivery `json:"data"`
+	Limit      int               `json:"limit"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
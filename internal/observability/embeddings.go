@@ -67,7 +67,7 @@ func NewEmbeddingMetrics(meter metric.Meter) (EmbeddingMetrics, error) {
 		outcomesName:          MetricNameEmbeddingOutcomes,
 		workerErrorsName:      MetricNameEmbeddingWorkerErrors,
 		durationName:          MetricNameEmbeddingDuration,
-		providerErrorsDesc:    "Total embedding provider errors (enqueue failures)",
+		providerErrorsDesc:    "Total embedding provider errors (enqueue failures, transient call retries)",
 		workerErrorsDesc:      "Total embedding worker errors (get record, provider, update)",
 		allowedProviderReason: AllowedEmbeddingProviderReason,
 		allowedWorkerReason:   AllowedEmbeddingWorkerReason,
@@ -24,6 +24,14 @@ type EventMetrics interface {
 	// queue (0 when empty) — the "how far behind are we" signal a depth count cannot give.
 	// Same bounded queue label as SetRiverQueueDepth.
 	SetRiverQueueOldestAge(queue string, ageSeconds float64)
+	// SetRiverQueuePriorityDepth records the backlog of one queue at one River priority (1..4),
+	// so bulk work parked at a low priority (e.g. an embedding backfill) is told apart from the
+	// interactive jobs that overtake it. Same bounded queue label; priority has four values.
+	SetRiverQueuePriorityDepth(queue string, priority, depth int)
+	// SetEmbeddingTenantDepths replaces the per-tenant embedding backlog with depths, which holds
+	// only the tenants with the deepest backlog (the poller's top N), so the tenant label stays
+	// bounded and a tenant that drained drops out of the gauge.
+	SetEmbeddingTenantDepths(depths map[string]int)
 	// RecordProviderPanic counts a recovered panic in one provider during the event fan-out, so a
 	// permanently-panicking provider is alertable instead of only visible in logs. The event-type
 	// label is normalized (bounded cardinality).
@@ -32,19 +40,29 @@ type EventMetrics interface {
 
 // eventMetrics implements EventMetrics.
 type eventMetrics struct {
-	eventsDiscarded   metric.Int64Counter
-	providerPanics    metric.Int64Counter
-	fanOutDuration    metric.Float64Histogram
-	channelDepth      atomic.Int64
-	channelDepthGauge metric.Float64ObservableGauge
-	riverQueueGauge   metric.Float64ObservableGauge
-	riverAgeGauge     metric.Float64ObservableGauge
+	eventsDiscarded    metric.Int64Counter
+	providerPanics     metric.Int64Counter
+	fanOutDuration     metric.Float64Histogram
+	channelDepth       atomic.Int64
+	channelDepthGauge  metric.Float64ObservableGauge
+	riverQueueGauge    metric.Float64ObservableGauge
+	riverAgeGauge      metric.Float64ObservableGauge
+	riverPriorityGauge metric.Float64ObservableGauge
+	tenantDepthGauge   metric.Float64ObservableGauge
 
 	// riverQueueDepths / riverQueueOldestAges hold the latest polled backlog and oldest-job age
 	// per queue name (a small fixed set from the poller), read by the observable-gauge callbacks.
-	riverQueueMu        sync.Mutex
-	riverQueueDepths    map[string]int64
-	riverQueueOldestAge map[string]float64
+	riverQueueMu          sync.Mutex
+	riverQueueDepths      map[string]int64
+	riverQueueOldestAge   map[string]float64
+	riverPriorityDepths   map[queuePriority]int64
+	embeddingTenantDepths map[string]int64
+}
+
+// queuePriority keys the per-priority backlog.
+type queuePriority struct {
+	queue    string
+	priority int
 }
 
 // NewEventMetrics creates EventMetrics and registers gauges. Returns (nil, nil) when meter is nil (metrics disabled).
@@ -80,11 +98,13 @@ func NewEventMetrics(meter metric.Meter) (EventMetrics, error) {
 	}
 
 	evtMetrics := &eventMetrics{
-		eventsDiscarded:     eventsDiscarded,
-		providerPanics:      providerPanics,
-		fanOutDuration:      fanOutDuration,
-		riverQueueDepths:    map[string]int64{},
-		riverQueueOldestAge: map[string]float64{},
+		eventsDiscarded:       eventsDiscarded,
+		providerPanics:        providerPanics,
+		fanOutDuration:        fanOutDuration,
+		riverQueueDepths:      map[string]int64{},
+		riverQueueOldestAge:   map[string]float64{},
+		riverPriorityDepths:   map[queuePriority]int64{},
+		embeddingTenantDepths: map[string]int64{},
 	}
 
 	channelDepthGauge, err := meter.Float64ObservableGauge(
@@ -143,6 +163,47 @@ func NewEventMetrics(meter metric.Meter) (EventMetrics, error) {
 
 	evtMetrics.riverAgeGauge = riverAgeGauge
 
+	riverPriorityGauge, err := meter.Float64ObservableGauge(
+		MetricNameRiverQueuePriorityDepth,
+		metric.WithDescription("Current River job queue depth per queue and job priority (available/retryable/scheduled)"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			evtMetrics.riverQueueMu.Lock()
+			defer evtMetrics.riverQueueMu.Unlock()
+
+			for key, depth := range evtMetrics.riverPriorityDepths {
+				o.Observe(float64(depth), metric.WithAttributes(
+					attribute.String(AttrQueue, key.queue), attribute.Int(AttrPriority, key.priority)))
+			}
+
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create river queue priority depth gauge: %w", err)
+	}
+
+	evtMetrics.riverPriorityGauge = riverPriorityGauge
+
+	tenantDepthGauge, err := meter.Float64ObservableGauge(
+		MetricNameEmbeddingTenantDepth,
+		metric.WithDescription("Current embedding queue depth of the tenants with the deepest backlog (available/retryable/scheduled)"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			evtMetrics.riverQueueMu.Lock()
+			defer evtMetrics.riverQueueMu.Unlock()
+
+			for tenantID, depth := range evtMetrics.embeddingTenantDepths {
+				o.Observe(float64(depth), metric.WithAttributes(attribute.String(AttrTenantID, tenantID)))
+			}
+
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create embedding tenant depth gauge: %w", err)
+	}
+
+	evtMetrics.tenantDepthGauge = tenantDepthGauge
+
 	return evtMetrics, nil
 }
 
@@ -178,6 +239,25 @@ func (e *eventMetrics) SetRiverQueueOldestAge(queue string, ageSeconds float64)
 	e.riverQueueOldestAge[queue] = ageSeconds
 }
 
+func (e *eventMetrics) SetRiverQueuePriorityDepth(queue string, priority, depth int) {
+	e.riverQueueMu.Lock()
+	defer e.riverQueueMu.Unlock()
+
+	e.riverPriorityDepths[queuePriority{queue: queue, priority: priority}] = int64(depth)
+}
+
+func (e *eventMetrics) SetEmbeddingTenantDepths(depths map[string]int) {
+	tenantDepths := make(map[string]int64, len(depths))
+	for tenantID, depth := range depths {
+		tenantDepths[tenantID] = int64(depth)
+	}
+
+	e.riverQueueMu.Lock()
+	defer e.riverQueueMu.Unlock()
+
+	e.embeddingTenantDepths = tenantDepths
+}
+
 func (e *eventMetrics) RecordProviderPanic(ctx context.Context, eventType string) {
 	eventType = NormalizeEventType(eventType)
 	e.providerPanics.Add(ctx, 1, metric.WithAttributes(attrEventType(eventType)))
@@ -3,9 +3,13 @@ package observability
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"strings"
 
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/formbricks/hub/internal/config"
 )
 
 // requestIDKey is the context key for the request ID (X-Request-ID).
@@ -25,6 +29,35 @@ func RequestIDFromContext(ctx context.Context) string {
 	return ""
 }
 
+// NewLogHandler returns the base slog handler for a process, writing to w at LOG_LEVEL level
+// (debug, info, warn, error; anything else is info) in LOG_FORMAT format: one JSON object per line
+// for config.LogFormatJSON, key=value text otherwise. Wrap it in a TraceContextHandler so
+// request_id, trace_id, and span_id are added as top-level fields.
+func NewLogHandler(w io.Writer, level, format string) slog.Handler {
+	var logLevel slog.Level
+
+	switch strings.ToLower(level) {
+	case "debug":
+		logLevel = slog.LevelDebug
+	case "info":
+		logLevel = slog.LevelInfo
+	case "warn":
+		logLevel = slog.LevelWarn
+	case "error":
+		logLevel = slog.LevelError
+	default:
+		logLevel = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{Level: logLevel}
+
+	if format == config.LogFormatJSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+
+	return slog.NewTextHandler(w, opts)
+}
+
 // TraceContextHandler wraps a slog.Handler and injects trace_id, span_id, and request_id
 // from the context into each log record when present.
 type TraceContextHandler struct {
@@ -0,0 +1,58 @@
+package observability
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/formbricks/hub/internal/config"
+)
+
+func TestNewLogHandler_JSONWithTraceContext(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(NewTraceContextHandler(NewLogHandler(&buf, "info", config.LogFormatJSON)))
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	ctx = context.WithValue(ctx, RequestIDKey, "req-1")
+
+	logger.DebugContext(ctx, "filtered out by level")
+	logger.InfoContext(ctx, "request handled", "status", 200)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("log output is not one JSON object: %v\n%s", err, buf.String())
+	}
+
+	want := map[string]any{
+		"msg":        "request handled",
+		"request_id": "req-1",
+		"trace_id":   sc.TraceID().String(),
+		"span_id":    sc.SpanID().String(),
+	}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("record[%q] = %v, want %v (top-level field)", key, record[key], value)
+		}
+	}
+}
+
+func TestNewLogHandler_TextByDefault(t *testing.T) {
+	var buf bytes.Buffer
+
+	slog.New(NewLogHandler(&buf, "debug", config.LogFormatText)).Debug("hello", "key", "value")
+
+	if got := buf.String(); !strings.Contains(got, "msg=hello") || !strings.Contains(got, "key=value") {
+		t.Fatalf("text output = %q, want key=value pairs", got)
+	}
+}
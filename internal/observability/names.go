@@ -12,6 +12,7 @@ const (
 	MetricNameEventChannelDepth         = "hub_event_channel_depth"
 	MetricNameRiverQueueDepth           = "hub_river_queue_depth"
 	MetricNameRiverQueueOldestAge       = "hub_river_queue_oldest_age_seconds"
+	MetricNameRiverQueuePriorityDepth   = "hub_river_queue_priority_depth"
 	MetricNameProviderPanics            = "hub_provider_panics_total"
 	MetricNameHNSWIterativeScanDegraded = "hub_hnsw_iterative_scan_degraded"
 	MetricNameEnrichmentOutputsCleared  = "hub_enrichment_outputs_cleared_total"
@@ -21,6 +22,8 @@ const (
 	MetricNameWebhookDisabled           = "hub_webhook_disabled_total"
 	MetricNameWebhookDispatchErrors     = "hub_webhook_dispatch_errors_total"
 	MetricNameWebhookDeliveryDuration   = "hub_webhook_delivery_duration_seconds"
+	MetricNameWebhookDeadLetters        = "hub_webhook_dead_letters_total"
+	MetricNameWebhookFanOutDropped      = "hub_webhook_fan_out_dropped_total"
 
 	// MetricNameEmbeddingJobsEnqueued and related embedding pipeline metrics.
 	MetricNameEmbeddingJobsEnqueued   = "hub_embedding_jobs_enqueued_total"
@@ -28,6 +31,7 @@ const (
 	MetricNameEmbeddingOutcomes       = "hub_embedding_outcomes_total"
 	MetricNameEmbeddingWorkerErrors   = "hub_embedding_worker_errors_total"
 	MetricNameEmbeddingDuration       = "hub_embedding_duration_seconds"
+	MetricNameEmbeddingTenantDepth    = "hub_embedding_queue_tenant_depth"
 
 	// MetricNameTranslationJobsEnqueued and related translation pipeline metrics.
 	MetricNameTranslationJobsEnqueued   = "hub_translation_jobs_enqueued_total"
@@ -65,6 +69,11 @@ const (
 	// AttrQueue labels the River queue-depth gauge; values come from the poller's fixed queue
 	// set, so cardinality is bounded.
 	AttrQueue = "queue"
+	// AttrPriority labels the per-priority queue-depth gauge with the River job priority (1..4).
+	AttrPriority = "priority"
+	// AttrTenantID labels the per-tenant embedding backlog gauge. Only the poller's top
+	// tenants are reported, and each poll replaces the set, so cardinality stays bounded.
+	AttrTenantID = "tenant_id"
 )
 
 // AllowedEventTypes returns event type strings allowed for metric attributes (bounded cardinality).
@@ -94,14 +103,20 @@ var allowedDisabledReasons = map[string]bool{
 
 // allowedDispatchReasons for hub_webhook_dispatch_errors_total.
 var allowedDispatchReasons = map[string]bool{
-	"get_webhook_failed": true,
-	"missing_tenant_id":  true,
-	"tenant_mismatch":    true,
+	"get_webhook_failed":        true,
+	"missing_tenant_id":         true,
+	"tenant_mismatch":           true,
+	"dead_letter_record_failed": true,
 }
 
 // allowedEmbeddingProviderReasons for hub_embedding_provider_errors_total.
+// transient_retry counts in-client retries of provider 5xx/network failures (not job failures);
+// rate_limited counts provider 429s and rate_limit_cooldown calls refused inside a Retry-After window.
 var allowedEmbeddingProviderReasons = map[string]bool{
-	"enqueue_failed": true,
+	"enqueue_failed":      true,
+	"transient_retry":     true,
+	"rate_limited":        true,
+	"rate_limit_cooldown": true,
 }
 
 // allowedEmbeddingOutcomeStatuses for hub_embedding_outcomes_total and hub_embedding_duration_seconds.
@@ -18,6 +18,8 @@ type WebhookMetrics interface {
 	RecordWebhookDisabled(ctx context.Context, reason string)
 	RecordDispatchError(ctx context.Context, reason string)
 	RecordWebhookDeliveryDuration(ctx context.Context, duration time.Duration, eventType, status string)
+	RecordDeadLetter(ctx context.Context, eventType string)
+	RecordFanOutCapped(ctx context.Context, eventType string, dropped int64)
 }
 
 // webhookMetrics implements WebhookMetrics.
@@ -28,6 +30,8 @@ type webhookMetrics struct {
 	disabled         metric.Int64Counter
 	dispatchErrors   metric.Int64Counter
 	deliveryDuration metric.Float64Histogram
+	deadLetters      metric.Int64Counter
+	fanOutDropped    metric.Int64Counter
 }
 
 // NewWebhookMetrics creates WebhookMetrics. Returns (nil, nil) when meter is nil (metrics disabled).
@@ -86,6 +90,22 @@ func NewWebhookMetrics(meter metric.Meter) (WebhookMetrics, error) {
 		return nil, fmt.Errorf("create webhook delivery duration histogram: %w", err)
 	}
 
+	deadLetters, err := meter.Int64Counter(
+		MetricNameWebhookDeadLetters,
+		metric.WithDescription("Total webhook deliveries recorded as dead letters after exhausting their attempts"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook dead letters counter: %w", err)
+	}
+
+	fanOutDropped, err := meter.Int64Counter(
+		MetricNameWebhookFanOutDropped,
+		metric.WithDescription("Total webhook deliveries not enqueued because an event matched more webhooks than WEBHOOK_MAX_FAN_OUT_PER_EVENT"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook fan-out dropped counter: %w", err)
+	}
+
 	return &webhookMetrics{
 		jobsEnqueued:     jobsEnqueued,
 		providerErrors:   providerErrors,
@@ -93,6 +113,8 @@ func NewWebhookMetrics(meter metric.Meter) (WebhookMetrics, error) {
 		disabled:         disabled,
 		dispatchErrors:   dispatchErrors,
 		deliveryDuration: deliveryDuration,
+		deadLetters:      deadLetters,
+		fanOutDropped:    fanOutDropped,
 	}, nil
 }
 
@@ -129,3 +151,13 @@ func (wm *webhookMetrics) RecordWebhookDeliveryDuration(ctx context.Context, dur
 	wm.deliveryDuration.Record(ctx, duration.Seconds(),
 		metric.WithAttributes(attrEventType(eventType), attribute.String(AttrStatus, status)))
 }
+
+func (wm *webhookMetrics) RecordDeadLetter(ctx context.Context, eventType string) {
+	eventType = NormalizeEventType(eventType)
+	wm.deadLetters.Add(ctx, 1, metric.WithAttributes(attrEventType(eventType)))
+}
+
+func (wm *webhookMetrics) RecordFanOutCapped(ctx context.Context, eventType string, dropped int64) {
+	eventType = NormalizeEventType(eventType)
+	wm.fanOutDropped.Add(ctx, dropped, metric.WithAttributes(attrEventType(eventType)))
+}
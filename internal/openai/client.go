@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -33,8 +34,19 @@ var (
 	ErrDimensionMismatch = errors.New("openai: embedding dimension mismatch")
 	// ErrNoCompletionInResponse is returned when a chat completion response contains no usable text.
 	ErrNoCompletionInResponse = errors.New("openai: no completion in response")
+	// ErrRateLimitCooldown is wrapped by the RateLimitError returned for a call made while the
+	// client is still inside a provider-requested Retry-After window (no request was sent).
+	ErrRateLimitCooldown = errors.New("openai: rate limit cool-down in effect")
 )
 
+// maxEmbeddingBatchInputs is the embeddings API's limit on inputs per request.
+const maxEmbeddingBatchInputs = 2048
+
+// maxRateLimitCooldown caps how long one 429's Retry-After hint can short-circuit the client, so
+// a bogus hint (or a far-future HTTP date) cannot block every call for hours. It matches the
+// worker's maximum rate-limit snooze.
+const maxRateLimitCooldown = 5 * time.Minute
+
 // Client calls the OpenAI embeddings API via the official SDK.
 type Client struct {
 	sdk        openaisdk.Client
@@ -45,6 +57,9 @@ type Client struct {
 	// temperatureUnsupported latches once the configured model rejects the temperature
 	// parameter (reasoning models do), so later calls omit it instead of failing.
 	temperatureUnsupported atomic.Bool
+	// throttledUntil is the unix-nano deadline of the latest provider Retry-After window; until
+	// it passes, calls fail fast with a RateLimitError instead of hitting the provider again.
+	throttledUntil atomic.Int64
 }
 
 // ClientOption configures the Client.
@@ -119,6 +134,10 @@ func (c *Client) CreateEmbedding(ctx context.Context, input string) ([]float32,
 		return nil, ErrInvalidDims
 	}
 
+	if err := c.checkRateLimitCooldown(); err != nil {
+		return nil, err
+	}
+
 	model := c.model
 
 	resp, err := c.sdk.Embeddings.New(ctx, openaisdk.EmbeddingNewParams{
@@ -129,14 +148,89 @@ func (c *Client) CreateEmbedding(ctx context.Context, input string) ([]float32,
 		Dimensions: param.NewOpt(int64(c.dimensions)),
 	})
 	if err != nil {
-		return nil, wrapOpenAIError("openai embedding", err)
+		return nil, c.observeRateLimit(wrapOpenAIError("openai embedding", err))
 	}
 
 	if len(resp.Data) == 0 {
 		return nil, ErrNoEmbeddingInResponse
 	}
 
-	emb := resp.Data[0].Embedding
+	return c.vector(resp.Data[0].Embedding)
+}
+
+// CreateEmbeddingsBatch returns one embedding per input, in input order, sending the inputs in as
+// few requests as the API's per-request limit (maxEmbeddingBatchInputs) allows. Every input must be
+// non-empty after trimming. The API reports no per-input errors, so a failed request fails the
+// whole call; the caller decides what to retry.
+func (c *Client) CreateEmbeddingsBatch(ctx context.Context, inputs []string) ([][]float32, error) {
+	if c.dimensions <= 0 {
+		return nil, ErrInvalidDims
+	}
+
+	trimmed := make([]string, len(inputs))
+	for i, input := range inputs {
+		trimmed[i] = strings.TrimSpace(input)
+		if trimmed[i] == "" {
+			return nil, ErrEmptyInput
+		}
+	}
+
+	out := make([][]float32, 0, len(trimmed))
+
+	for chunk := range slices.Chunk(trimmed, maxEmbeddingBatchInputs) {
+		vectors, err := c.embedChunk(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, vectors...)
+	}
+
+	return out, nil
+}
+
+// embedChunk embeds at most maxEmbeddingBatchInputs inputs in one request. The response items
+// carry their input's index; they are placed by it rather than trusted to arrive in order.
+func (c *Client) embedChunk(ctx context.Context, inputs []string) ([][]float32, error) {
+	if err := c.checkRateLimitCooldown(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sdk.Embeddings.New(ctx, openaisdk.EmbeddingNewParams{
+		Input: openaisdk.EmbeddingNewParamsInputUnion{
+			OfArrayOfStrings: inputs,
+		},
+		Model:      c.model,
+		Dimensions: param.NewOpt(int64(c.dimensions)),
+	})
+	if err != nil {
+		return nil, c.observeRateLimit(wrapOpenAIError("openai embedding batch", err))
+	}
+
+	if len(resp.Data) != len(inputs) {
+		return nil, fmt.Errorf("%w: got %d embeddings for %d inputs", ErrNoEmbeddingInResponse, len(resp.Data), len(inputs))
+	}
+
+	out := make([][]float32, len(inputs))
+
+	for _, item := range resp.Data {
+		if item.Index < 0 || item.Index >= int64(len(inputs)) || out[item.Index] != nil {
+			return nil, fmt.Errorf("%w: unexpected index %d", ErrNoEmbeddingInResponse, item.Index)
+		}
+
+		vector, err := c.vector(item.Embedding)
+		if err != nil {
+			return nil, err
+		}
+
+		out[item.Index] = vector
+	}
+
+	return out, nil
+}
+
+// vector checks one response embedding against the configured dimensions and converts it.
+func (c *Client) vector(emb []float64) ([]float32, error) {
 	if len(emb) != c.dimensions {
 		return nil, fmt.Errorf("%w: got %d, want %d", ErrDimensionMismatch, len(emb), c.dimensions)
 	}
@@ -180,7 +274,7 @@ func (c *Client) Translate(ctx context.Context, systemPrompt, userText string) (
 		},
 	})
 	if err != nil {
-		return "", wrapChatCompletionError(err)
+		return "", c.observeRateLimit(wrapChatCompletionError(err))
 	}
 
 	return completionText(resp)
@@ -215,7 +309,7 @@ func (c *Client) CompleteJSON(ctx context.Context, systemPrompt, userText string
 		},
 	})
 	if err != nil {
-		return "", wrapChatCompletionError(err)
+		return "", c.observeRateLimit(wrapChatCompletionError(err))
 	}
 
 	return completionText(resp)
@@ -230,6 +324,10 @@ func (c *Client) CompleteJSON(ctx context.Context, systemPrompt, userText string
 func (c *Client) createChatCompletion(
 	ctx context.Context, params openaisdk.ChatCompletionNewParams,
 ) (*openaisdk.ChatCompletion, error) {
+	if err := c.checkRateLimitCooldown(); err != nil {
+		return nil, err
+	}
+
 	if !c.temperatureUnsupported.Load() {
 		params.Temperature = param.NewOpt(0.0)
 	}
@@ -252,6 +350,45 @@ func (c *Client) createChatCompletion(
 	return resp, nil
 }
 
+// checkRateLimitCooldown returns a RateLimitError carrying the remaining wait while the client is
+// inside a provider Retry-After window. Every worker sharing this client then snoozes for what is
+// left of the window instead of spending a request (and the provider's patience) on a call that
+// would 429 again — the back-off the hint asks for, without sleeping inside the job attempt.
+func (c *Client) checkRateLimitCooldown() error {
+	until := c.throttledUntil.Load()
+	if until == 0 {
+		return nil
+	}
+
+	remaining := time.Until(time.Unix(0, until))
+	if remaining <= 0 {
+		return nil
+	}
+
+	return huberrors.NewRateLimitError(remaining, ErrRateLimitCooldown)
+}
+
+// observeRateLimit extends the client's cool-down window when err is a provider 429 with a
+// Retry-After hint, and returns err unchanged. The window only ever moves forward, so concurrent
+// 429s with different hints keep the longest. A 429 without a hint leaves the window alone: the
+// worker's default snooze already spaces out that job, and guessing a client-wide pause would
+// stall unrelated calls.
+func (c *Client) observeRateLimit(err error) error {
+	var rateLimitErr *huberrors.RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter <= 0 || errors.Is(err, ErrRateLimitCooldown) {
+		return err
+	}
+
+	until := time.Now().Add(min(rateLimitErr.RetryAfter, maxRateLimitCooldown)).UnixNano()
+
+	for {
+		current := c.throttledUntil.Load()
+		if current >= until || c.throttledUntil.CompareAndSwap(current, until) {
+			return err
+		}
+	}
+}
+
 // isUnsupportedTemperatureError reports whether err is the API rejecting the temperature
 // parameter (invalid_request_error with param "temperature", as returned by reasoning models).
 func isUnsupportedTemperatureError(err error) bool {
@@ -299,7 +436,8 @@ func wrapChatCompletionError(err error) error {
 }
 
 // wrapOpenAIError wraps an SDK error under op, mapping a 429 to a huberrors.RateLimitError
-// (carrying the Retry-After hint) so callers can snooze. Shared by the chat-completion and
+// (carrying the Retry-After hint) so callers can snooze, and a 5xx to
+// huberrors.ErrProviderUnavailable so callers may retry. Shared by the chat-completion and
 // embedding call paths — a throttled embedding backfill must snooze, not burn retry attempts.
 func wrapOpenAIError(op string, err error) error {
 	wrapped := fmt.Errorf("%s: %w", op, err)
@@ -309,6 +447,10 @@ func wrapOpenAIError(op string, err error) error {
 		return huberrors.NewRateLimitError(openaiRetryAfter(apiErr), wrapped)
 	}
 
+	if apiErr != nil && apiErr.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: %w", huberrors.ErrProviderUnavailable, wrapped)
+	}
+
 	return wrapped
 }
 
@@ -3,6 +3,7 @@ package openai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"sync/atomic"
@@ -106,6 +107,50 @@ func TestCreateEmbedding_UsesEnvironmentBaseURLWhenExplicitBaseURLIsUnset(t *tes
 	assert.Equal(t, int32(1), envHits.Load())
 }
 
+func TestCreateEmbeddingsBatch_SendsOneRequestAndOrdersByIndex(t *testing.T) {
+	var hits atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+
+		var req struct {
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request body: %v", err)
+			http.Error(w, "invalid request", http.StatusBadRequest)
+
+			return
+		}
+
+		assert.Equal(t, []string{"first", "second"}, req.Input)
+
+		// Answer out of order: the client must place vectors by index, not position.
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"object":"list","model":"test-model","data":[` +
+			`{"object":"embedding","index":1,"embedding":[3,4]},` +
+			`{"object":"embedding","index":0,"embedding":[1,2]}],` +
+			`"usage":{"prompt_tokens":2,"total_tokens":2}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("sk-test", WithBaseURL(server.URL+"/v1"), WithDimensions(2), WithModel("test-model"))
+
+	vectors, err := client.CreateEmbeddingsBatch(context.Background(), []string{" first ", "second"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 2}, {3, 4}}, vectors)
+	assert.Equal(t, int32(1), hits.Load())
+}
+
+func TestCreateEmbeddingsBatch_EmptyInputFailsWithoutRequest(t *testing.T) {
+	server, hits := newEmbeddingServer(t, []float64{1, 2})
+	client := NewClient("sk-test", WithBaseURL(server.URL+"/v1"), WithDimensions(2), WithModel("test-model"))
+
+	_, err := client.CreateEmbeddingsBatch(context.Background(), []string{"hello world", " "})
+	require.ErrorIs(t, err, ErrEmptyInput)
+	assert.Equal(t, int32(0), hits.Load())
+}
+
 // newChatCompletionServer drives the real SDK against a stub /v1/chat/completions endpoint so
 // the translation error paths exercise the SDK's own response decoding, not a hand-built error.
 func newChatCompletionServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
@@ -238,6 +283,17 @@ func TestTranslate_NonRateLimitErrorIsNotWrapped(t *testing.T) {
 	assert.NotErrorAs(t, err, &rateLimited, "a non-429 error must not be classified as rate-limited")
 }
 
+func TestTranslate_ServerErrorIsProviderUnavailable(t *testing.T) {
+	server := newChatCompletionServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client := NewClient("sk-test", WithBaseURL(server.URL+"/v1"), WithModel("test-model"))
+
+	_, err := client.Translate(context.Background(), "system prompt", "hello")
+	require.ErrorIs(t, err, huberrors.ErrProviderUnavailable, "a 5xx is transient and must be retryable")
+}
+
 func TestTranslate_Success(t *testing.T) {
 	server := newChatCompletionServer(t, func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -454,3 +510,68 @@ func TestCreateEmbedding_RateLimitReturnsRateLimitError(t *testing.T) {
 	require.ErrorAs(t, err, &rateLimited, "an embedding 429 must surface as a rate-limit error")
 	assert.Equal(t, 9*time.Second, rateLimited.RetryAfter)
 }
+
+func TestRateLimitCooldown_ShortCircuitsLaterCalls(t *testing.T) {
+	// A 429 with Retry-After opens a client-wide cool-down: calls inside it fail fast with the
+	// remaining wait instead of sending another request the provider would reject.
+	var calls atomic.Int32
+
+	server := newChatCompletionServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client := NewClient("sk-test", WithBaseURL(server.URL+"/v1"), WithModel("test-model"))
+
+	_, err := client.Translate(context.Background(), "system prompt", "hello")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrRateLimitCooldown, "the first 429 comes from the provider")
+
+	_, err = client.CompleteJSON(context.Background(), "system prompt", "hello", llm.Schema{Name: "s"})
+
+	var rateLimited *huberrors.RateLimitError
+	require.ErrorAs(t, err, &rateLimited)
+	require.ErrorIs(t, err, ErrRateLimitCooldown)
+	assert.InDelta(t, float64(30*time.Second), float64(rateLimited.RetryAfter), float64(5*time.Second),
+		"the short-circuit carries what is left of the window")
+	assert.Equal(t, int32(1), calls.Load(), "no request is sent during the cool-down")
+}
+
+func TestRateLimitCooldown_ExpiresAndIsCapped(t *testing.T) {
+	client := NewClient("sk-test")
+
+	require.NoError(t, client.checkRateLimitCooldown(), "a fresh client is not throttled")
+
+	err := client.observeRateLimit(huberrors.NewRateLimitError(time.Hour, errors.New("429")))
+	require.Error(t, err)
+
+	var rateLimited *huberrors.RateLimitError
+	require.ErrorAs(t, client.checkRateLimitCooldown(), &rateLimited)
+	assert.LessOrEqual(t, rateLimited.RetryAfter, maxRateLimitCooldown, "an absurd hint is capped")
+
+	_ = client.observeRateLimit(huberrors.NewRateLimitError(time.Second, errors.New("429")))
+	require.ErrorAs(t, client.checkRateLimitCooldown(), &rateLimited)
+	assert.Greater(t, rateLimited.RetryAfter, time.Minute, "a shorter hint never shortens the window")
+
+	client.throttledUntil.Store(time.Now().Add(-time.Second).UnixNano())
+	require.NoError(t, client.checkRateLimitCooldown(), "an elapsed window lets calls through")
+}
+
+func TestRateLimitCooldown_NoHintNoCooldown(t *testing.T) {
+	var calls atomic.Int32
+
+	server := newChatCompletionServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client := NewClient("sk-test", WithBaseURL(server.URL+"/v1"), WithModel("test-model"))
+
+	for range 2 {
+		_, err := client.Translate(context.Background(), "system prompt", "hello")
+		require.NotErrorIs(t, err, ErrRateLimitCooldown)
+	}
+
+	assert.Equal(t, int32(2), calls.Load(), "a 429 without a hint does not pause the client")
+}
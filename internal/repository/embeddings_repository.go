@@ -65,6 +65,20 @@ func (r *EmbeddingsRepository) IterativeScanDegraded() bool {
 	return r.iterativeScanUnavailable.Load()
 }
 
+// ColumnDimensions returns the declared dimension of embeddings.embedding (pgvector keeps it as
+// the column's type modifier). It is -1 when the column was declared without one.
+func (r *EmbeddingsRepository) ColumnDimensions(ctx context.Context) (int, error) {
+	var dims int
+	if err := r.db.QueryRow(ctx, `
+		SELECT atttypmod FROM pg_attribute
+		WHERE attrelid = 'embeddings'::regclass AND attname = 'embedding' AND NOT attisdropped`,
+	).Scan(&dims); err != nil {
+		return 0, fmt.Errorf("query embedding column dimensions: %w", err)
+	}
+
+	return dims, nil
+}
+
 // rollbackQuietly rolls back tx, logging (rather than returning) an unexpected rollback error.
 func rollbackQuietly(ctx context.Context, tx pgx.Tx, msg string) {
 	if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
@@ -174,7 +188,8 @@ func guardEmbeddingSourceCurrent(
 	// sibling guardValueTextCurrent. Crucial here: embeddings have no eager-clear or NULL-rows
 	// backfill, so a stale vector that lands last is otherwise permanent.
 	err := dbTx.QueryRow(ctx,
-		`SELECT field_label, value_text, value_text_translated FROM feedback_records WHERE id = $1 FOR UPDATE`, feedbackRecordID,
+		`SELECT field_label, value_text, value_text_translated FROM feedback_records
+		 WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, feedbackRecordID,
 	).Scan(&fieldLabel, &valueText, &valueTextTranslated)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -265,30 +280,10 @@ func (r *EmbeddingsRepository) ListFeedbackRecordIDsForBackfillByInputKind(
 	afterID uuid.UUID,
 	limit int,
 ) ([]uuid.UUID, error) {
-	query := `
-		SELECT fr.id FROM feedback_records fr
-		WHERE fr.value_text IS NOT NULL AND trim(fr.value_text) != ''
+	query := `SELECT fr.id FROM feedback_records fr WHERE ` + embeddingBackfillCondition(inputKind) + `
 		  AND fr.id > $2
-		  AND NOT EXISTS (
-		    SELECT 1 FROM embeddings e
-		    WHERE e.feedback_record_id = fr.id AND e.model = $1
-		  )
 		ORDER BY fr.id
-		LIMIT $3
-	`
-	if models.NormalizeEmbeddingInputKind(inputKind) == models.EmbeddingInputKindTaxonomyTranslated {
-		query = `
-			SELECT fr.id FROM feedback_records fr
-			WHERE COALESCE(NULLIF(btrim(fr.value_text_translated), ''), NULLIF(btrim(fr.value_text), '')) IS NOT NULL
-			  AND fr.id > $2
-			  AND NOT EXISTS (
-			    SELECT 1 FROM embeddings e
-			    WHERE e.feedback_record_id = fr.id AND e.model = $1
-			  )
-			ORDER BY fr.id
-			LIMIT $3
-		`
-	}
+		LIMIT $3`
 
 	rows, err := r.db.Query(ctx, query, model, afterID, limit)
 	if err != nil {
@@ -314,6 +309,110 @@ func (r *EmbeddingsRepository) ListFeedbackRecordIDsForBackfillByInputKind(
 	return ids, nil
 }
 
+// CountFeedbackRecordsForBackfillByInputKind counts the records
+// ListFeedbackRecordIDsForBackfillByInputKind would page through, so the backfill command can
+// report an ETA. It scans the same predicate once, before the backfill starts.
+func (r *EmbeddingsRepository) CountFeedbackRecordsForBackfillByInputKind(
+	ctx context.Context, model string, inputKind models.EmbeddingInputKind,
+) (int, error) {
+	query := `SELECT count(*) FROM feedback_records fr WHERE ` + embeddingBackfillCondition(inputKind)
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, model).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count feedback records for backfill: %w", err)
+	}
+
+	return count, nil
+}
+
+// embeddingBackfillCondition is the predicate (over feedback_records fr, with the model as $1) of
+// records eligible for inputKind that have no embedding row for the model yet. Taxonomy input
+// embeds the translation when there is one, so a record with only translated text qualifies too.
+func embeddingBackfillCondition(inputKind models.EmbeddingInputKind) string {
+	textCondition := `fr.value_text IS NOT NULL AND trim(fr.value_text) != ''`
+	if models.NormalizeEmbeddingInputKind(inputKind) == models.EmbeddingInputKindTaxonomyTranslated {
+		textCondition = `COALESCE(NULLIF(btrim(fr.value_text_translated), ''), NULLIF(btrim(fr.value_text), '')) IS NOT NULL`
+	}
+
+	return textCondition + `
+		  AND fr.deleted_at IS NULL
+		  AND NOT EXISTS (
+		    SELECT 1 FROM embeddings e
+		    WHERE e.feedback_record_id = fr.id AND e.model = $1
+		  )`
+}
+
+// ClearEmbeddingsForReembed takes one keyset page (fr.id > afterID, ordered by id, at most limit
+// rows) of the filter's tenant's text records matching filter, deletes their embedding rows for
+// model, and returns the page's IDs for the caller to enqueue. The page is selected and cleared in
+// one transaction under the tenant's write lock. Selection ignores whether a row exists, so a
+// re-run after a partial failure picks up every matching record again. Clearing before the jobs
+// run is the point: vectors computed before and after a provider change are not comparable, and
+// search must not mix them in one model's space — the records drop out of semantic search until
+// their job lands.
+func (r *EmbeddingsRepository) ClearEmbeddingsForReembed(
+	ctx context.Context, model string, filter *models.ReembedRequest, afterID uuid.UUID, limit int,
+) ([]uuid.UUID, error) {
+	conditions := []string{
+		"fr.tenant_id = $1", "fr.value_text IS NOT NULL", "trim(fr.value_text) != ''", "fr.deleted_at IS NULL", "fr.id > $2",
+	}
+	args := []any{filter.TenantID, afterID, limit}
+
+	if filter.SourceType != nil {
+		args = append(args, *filter.SourceType)
+		conditions = append(conditions, fmt.Sprintf("fr.source_type = $%d", len(args)))
+	}
+
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		conditions = append(conditions, fmt.Sprintf("fr.collected_at >= $%d", len(args)))
+	}
+
+	if filter.Until != nil {
+		args = append(args, *filter.Until)
+		conditions = append(conditions, fmt.Sprintf("fr.collected_at <= $%d", len(args)))
+	}
+
+	query := `
+		SELECT fr.id FROM feedback_records fr
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY fr.id
+		LIMIT $3`
+
+	var ids []uuid.UUID
+
+	err := withTenantWritePoolTx(ctx, r.db, []string{filter.TenantID}, func(dbTx tenantWriteTx) error {
+		rows, err := dbTx.Query(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("select reembed page: %w", err)
+		}
+
+		ids, err = pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+		if err != nil {
+			return fmt.Errorf("iterating reembed ids: %w", err)
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if _, err := dbTx.Exec(ctx, `
+			DELETE FROM embeddings e USING feedback_records fr
+			WHERE e.feedback_record_id = ANY($1) AND e.model = $2
+			  AND fr.id = e.feedback_record_id AND fr.tenant_id = $3`,
+			ids, model, filter.TenantID); err != nil {
+			return fmt.Errorf("clear embeddings for reembed: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 // ErrEmbeddingNotFound is returned when no embedding row exists for the given feedback record and model.
 var ErrEmbeddingNotFound = errors.New("embedding not found for feedback record and model")
 
@@ -344,7 +443,7 @@ func (r *EmbeddingsRepository) GetEmbeddingAndTenantByFeedbackRecordAndModel(
 	err := r.db.QueryRow(ctx,
 		`SELECT e.embedding, fr.tenant_id FROM embeddings e
 		 INNER JOIN feedback_records fr ON fr.id = e.feedback_record_id
-		 WHERE e.feedback_record_id = $1 AND e.model = $2`,
+		 WHERE e.feedback_record_id = $1 AND e.model = $2 AND fr.deleted_at IS NULL`,
 		feedbackRecordID, model,
 	).Scan(&vec, &tenantID)
 	if err != nil {
@@ -358,6 +457,26 @@ func (r *EmbeddingsRepository) GetEmbeddingAndTenantByFeedbackRecordAndModel(
 	return vec.Slice(), tenantID, nil
 }
 
+// GetTenantByFeedbackRecord returns the tenant that owns a feedback record, so a similar-feedback
+// lookup can resolve the tenant's embedding model before reading the source vector. A missing
+// (or soft-deleted) record is ErrEmbeddingNotFound, as it would be for the vector read that follows.
+func (r *EmbeddingsRepository) GetTenantByFeedbackRecord(ctx context.Context, feedbackRecordID uuid.UUID) (string, error) {
+	var tenantID string
+
+	err := r.db.QueryRow(ctx,
+		`SELECT tenant_id FROM feedback_records WHERE id = $1 AND deleted_at IS NULL`, feedbackRecordID,
+	).Scan(&tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrEmbeddingNotFound
+		}
+
+		return "", fmt.Errorf("get feedback record tenant: %w", err)
+	}
+
+	return tenantID, nil
+}
+
 // NearestFeedbackRecordsByEmbedding returns feedback record IDs and similarity scores (0..1) for the
 // nearest neighbors to queryEmbedding, filtered by model and tenant. Rows with score < minScore are
 // filtered in application code (not in WHERE) so pgvector's iterative index scan can run. The query
@@ -391,7 +510,7 @@ func (r *EmbeddingsRepository) NearestFeedbackRecordsByEmbedding(
 				COALESCE(fr.field_label, ''), fr.value_text
 			FROM embeddings e
 			INNER JOIN feedback_records fr ON fr.id = e.feedback_record_id
-			WHERE e.model = $2 AND fr.tenant_id = $3
+			WHERE e.model = $2 AND fr.tenant_id = $3 AND fr.deleted_at IS NULL
 			  AND e.model NOT LIKE 'taxonomy:%'
 			ORDER BY (e.embedding <=> $1), e.feedback_record_id
 			LIMIT $4`, queryVec, model, tenantID, fetchLimit)
@@ -401,7 +520,7 @@ func (r *EmbeddingsRepository) NearestFeedbackRecordsByEmbedding(
 				COALESCE(fr.field_label, ''), fr.value_text
 			FROM embeddings e
 			INNER JOIN feedback_records fr ON fr.id = e.feedback_record_id
-			WHERE e.model = $2 AND fr.tenant_id = $3 AND e.feedback_record_id != $4
+			WHERE e.model = $2 AND fr.tenant_id = $3 AND fr.deleted_at IS NULL AND e.feedback_record_id != $4
 			  AND e.model NOT LIKE 'taxonomy:%'
 			ORDER BY (e.embedding <=> $1), e.feedback_record_id
 			LIMIT $5`, queryVec, model, tenantID, *excludeID, fetchLimit)
@@ -498,7 +617,7 @@ func (r *EmbeddingsRepository) NearestFeedbackRecordsByEmbeddingAfterCursor(
 				COALESCE(fr.field_label, ''), fr.value_text
 			FROM embeddings e
 			INNER JOIN feedback_records fr ON fr.id = e.feedback_record_id
-			WHERE e.model = $2 AND fr.tenant_id = $3
+			WHERE e.model = $2 AND fr.tenant_id = $3 AND fr.deleted_at IS NULL
 			  AND e.model NOT LIKE 'taxonomy:%'
 			  AND ((e.embedding <=> $1), e.feedback_record_id) > ($4, $5)
 			ORDER BY (e.embedding <=> $1), e.feedback_record_id
@@ -509,7 +628,7 @@ func (r *EmbeddingsRepository) NearestFeedbackRecordsByEmbeddingAfterCursor(
 				COALESCE(fr.field_label, ''), fr.value_text
 			FROM embeddings e
 			INNER JOIN feedback_records fr ON fr.id = e.feedback_record_id
-			WHERE e.model = $2 AND fr.tenant_id = $3 AND e.feedback_record_id != $4
+			WHERE e.model = $2 AND fr.tenant_id = $3 AND fr.deleted_at IS NULL AND e.feedback_record_id != $4
 			  AND e.model NOT LIKE 'taxonomy:%'
 			  AND ((e.embedding <=> $1), e.feedback_record_id) > ($5, $6)
 			ORDER BY (e.embedding <=> $1), e.feedback_record_id
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+)
+
+const exportJobColumns = `id, tenant_id, format, filters, status, record_count, file_name, error,
+	started_at, finished_at, created_at, updated_at`
+
+// ExportJobsRepository handles data access for the export_jobs table.
+type ExportJobsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewExportJobsRepository creates a new export jobs repository.
+func NewExportJobsRepository(db *pgxpool.Pool) *ExportJobsRepository {
+	return &ExportJobsRepository{db: db}
+}
+
+// Create inserts a pending export job. Like every tenant-owned insert it is gated on the shared
+// tenant write lock, so a job cannot be created for a tenant whose data is being purged.
+func (r *ExportJobsRepository) Create(ctx context.Context, req *models.CreateExportJobRequest) (*models.ExportJob, error) {
+	filters, err := json.Marshal(req.ExportJobFilters)
+	if err != nil {
+		return nil, fmt.Errorf("marshal export filters: %w", err)
+	}
+
+	const lockKeyParam = 4 // $4, after the 3 inserted columns
+
+	query := `
+		INSERT INTO export_jobs (tenant_id, format, filters)
+		SELECT $1, $2, $3
+		WHERE ` + tenantWriteLockGate(lockKeyParam) + `
+		RETURNING ` + exportJobColumns
+
+	job, err := scanExportJob(r.db.QueryRow(ctx, query,
+		req.TenantID, string(req.Format), filters, TenantWriteLockKey(req.TenantID)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, huberrors.NewTenantWriteConflictError("tenant data purge in progress for this tenant; retry later")
+		}
+
+		return nil, fmt.Errorf("create export job: %w", err)
+	}
+
+	return job, nil
+}
+
+// Get returns the export job with the given id.
+func (r *ExportJobsRepository) Get(ctx context.Context, id uuid.UUID) (*models.ExportJob, error) {
+	job, err := scanExportJob(r.db.QueryRow(ctx, `SELECT `+exportJobColumns+` FROM export_jobs WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, huberrors.NewNotFoundError("export job", "export job not found")
+		}
+
+		return nil, fmt.Errorf("get export job: %w", err)
+	}
+
+	return job, nil
+}
+
+// MarkRunning moves a pending (or, on a retried attempt, running) job to running and returns it.
+// A job already completed or failed is returned unchanged with ok=false, so a duplicate delivery
+// does not redo finished work.
+func (r *ExportJobsRepository) MarkRunning(ctx context.Context, id uuid.UUID) (*models.ExportJob, bool, error) {
+	var (
+		job *models.ExportJob
+		ok  bool
+	)
+
+	err := r.withExportJobTenantLock(ctx, id, func(dbTx tenantWriteTx) error {
+		var err error
+
+		job, err = scanExportJob(dbTx.QueryRow(ctx, `
+			UPDATE export_jobs
+			SET status = 'running', started_at = COALESCE(started_at, NOW()), updated_at = NOW()
+			WHERE id = $1 AND status IN ('pending', 'running')
+			RETURNING `+exportJobColumns, id))
+		if err == nil {
+			ok = true
+
+			return nil
+		}
+
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("mark export job running: %w", err)
+		}
+
+		job, err = scanExportJob(dbTx.QueryRow(ctx, `SELECT `+exportJobColumns+` FROM export_jobs WHERE id = $1`, id))
+		if err != nil {
+			return fmt.Errorf("get export job: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return job, ok, nil
+}
+
+// Complete records a finished export's file and record count.
+func (r *ExportJobsRepository) Complete(ctx context.Context, id uuid.UUID, fileName string, recordCount int64) error {
+	return r.finish(ctx, id, models.ExportJobStatusCompleted, &fileName, recordCount, nil)
+}
+
+// Fail records a terminal export failure. message is shown to API clients, so callers pass a
+// sanitized summary rather than a raw driver or filesystem error.
+func (r *ExportJobsRepository) Fail(ctx context.Context, id uuid.UUID, message string) error {
+	return r.finish(ctx, id, models.ExportJobStatusFailed, nil, 0, &message)
+}
+
+func (r *ExportJobsRepository) finish(
+	ctx context.Context, id uuid.UUID, status models.ExportJobStatus, fileName *string, recordCount int64, message *string,
+) error {
+	return r.withExportJobTenantLock(ctx, id, func(dbTx tenantWriteTx) error {
+		tag, err := dbTx.Exec(ctx, `
+			UPDATE export_jobs
+			SET status = $2, file_name = $3, record_count = $4, error = $5, finished_at = NOW(), updated_at = NOW()
+			WHERE id = $1 AND status IN ('pending', 'running')`,
+			id, string(status), fileName, recordCount, message)
+		if err != nil {
+			return fmt.Errorf("finish export job: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return huberrors.NewConflictError("export job already finished")
+		}
+
+		return nil
+	})
+}
+
+// withExportJobTenantLock runs mutate in a tenant write transaction for the job's tenant, resolved
+// inside the transaction. A job deleted by a tenant purge is reported as not found.
+func (r *ExportJobsRepository) withExportJobTenantLock(
+	ctx context.Context, id uuid.UUID, mutate func(dbTx tenantWriteTx) error,
+) error {
+	return withTenantWritePoolTx(ctx, r.db, nil, func(dbTx tenantWriteTx) error {
+		var tenantID string
+
+		err := dbTx.QueryRow(ctx, `SELECT tenant_id FROM export_jobs WHERE id = $1`, id).Scan(&tenantID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return huberrors.NewNotFoundError("export job", "export job not found")
+			}
+
+			return fmt.Errorf("resolve export job tenant: %w", err)
+		}
+
+		if err := tryLockTenantsShared(ctx, dbTx, []string{tenantID}); err != nil {
+			return err
+		}
+
+		return mutate(dbTx)
+	})
+}
+
+func scanExportJob(row scanner) (*models.ExportJob, error) {
+	var (
+		job     models.ExportJob
+		filters []byte
+	)
+
+	if err := row.Scan(
+		&job.ID, &job.TenantID, &job.Format, &filters, &job.Status, &job.RecordCount, &job.FileName, &job.Error,
+		&job.StartedAt, &job.FinishedAt, &job.CreatedAt, &job.UpdatedAt,
+	); err != nil {
+		return nil, err //nolint:wrapcheck // callers wrap and check pgx.ErrNoRows
+	}
+
+	if err := json.Unmarshal(filters, &job.Filters); err != nil {
+		return nil, fmt.Errorf("decode export filters: %w", err)
+	}
+
+	return &job, nil
+}
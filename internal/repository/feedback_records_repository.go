@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pgvector/pgvector-go"
 
 	"github.com/formbricks/hub/internal/huberrors"
 	"github.com/formbricks/hub/internal/models"
@@ -47,7 +50,7 @@ const feedbackRecordColumns = `id, collected_at, created_at, updated_at,
 	metadata, language, user_id, tenant_id, submission_id,
 	value_text_translated, translation_lang_key,
 	sentiment, sentiment_score,
-	emotions`
+	emotions, tags, deleted_at`
 
 // scanFeedbackRecord materializes a FeedbackRecord from a row, in the exact column order of
 // feedbackRecordColumns above. It lives beside that const so the SELECT/RETURNING order and
@@ -86,6 +89,8 @@ func scanFeedbackRecord(row scanner) (*models.FeedbackRecord, error) {
 		&record.Sentiment,
 		&record.SentimentScore,
 		&emotions,
+		&record.Tags,
+		&record.DeletedAt,
 	); err != nil {
 		return nil, fmt.Errorf("scan feedback record: %w", err)
 	}
@@ -103,7 +108,140 @@ func scanFeedbackRecord(row scanner) (*models.FeedbackRecord, error) {
 }
 
 // Create inserts a new feedback record.
+//
+// With req.IdempotencyKey set the insert runs in a transaction with the key's lookup and storage
+// (see createIdempotent), and a repeated key returns the original record marked Replayed.
 func (r *FeedbackRecordsRepository) Create(ctx context.Context, req *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error) {
+	if req.IdempotencyKey == "" {
+		return createFeedbackRecord(ctx, r.db, req)
+	}
+
+	var record *models.FeedbackRecord
+
+	err := withTenantWritePoolTx(ctx, r.db, []string{req.TenantID}, func(dbTx tenantWriteTx) error {
+		var err error
+
+		record, err = createIdempotent(ctx, dbTx, req.IdempotencyKey, models.IdempotencyEndpointCreateFeedbackRecord,
+			req.TenantID, func() (*models.FeedbackRecord, error) {
+				return createFeedbackRecord(ctx, dbTx, req)
+			})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// CreateWithEmbedding creates a feedback record together with a caller-supplied (pre-computed)
+// embedding for model, in one transaction: either both rows land or neither does, so a record is
+// never visible without the vector its creator supplied. The tenant write lock is taken up front
+// for the whole transaction; the insert's own gate re-acquires it (shared locks are re-entrant).
+// An Idempotency-Key is honored as in Create.
+func (r *FeedbackRecordsRepository) CreateWithEmbedding(
+	ctx context.Context, req *models.CreateFeedbackRecordRequest, model string, embedding []float32,
+) (*models.FeedbackRecord, error) {
+	if len(embedding) != models.EmbeddingVectorDimensions {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrEmbeddingDimensionMismatch, len(embedding), models.EmbeddingVectorDimensions)
+	}
+
+	var record *models.FeedbackRecord
+
+	err := withTenantWritePoolTx(ctx, r.db, []string{req.TenantID}, func(dbTx tenantWriteTx) error {
+		create := func() (*models.FeedbackRecord, error) {
+			created, err := createFeedbackRecord(ctx, dbTx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			now := time.Now()
+
+			if _, err := dbTx.Exec(ctx, `
+				INSERT INTO embeddings (feedback_record_id, embedding, model, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, $4)`,
+				created.ID, pgvector.NewHalfVector(embedding), model, now,
+			); err != nil {
+				return nil, fmt.Errorf("insert supplied embedding: %w", err)
+			}
+
+			return created, nil
+		}
+
+		var err error
+		if req.IdempotencyKey == "" {
+			record, err = create()
+		} else {
+			record, err = createIdempotent(ctx, dbTx, req.IdempotencyKey, models.IdempotencyEndpointCreateFeedbackRecord,
+				req.TenantID, create)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// CreateBatch inserts reqs in one transaction that holds the write lock of every tenant in the
+// batch. Each insert runs under its own savepoint, so a record the database rejects (a duplicate
+// tenant_id, submission_id and field_id, say) is rolled back alone and reported at its index while
+// the others commit together. records and errs are index-aligned with reqs, with exactly one of
+// the two set per index; err is set only when the batch as a whole failed and nothing was written.
+// Idempotency keys are not supported here: a batch is retried by the caller as a whole.
+func (r *FeedbackRecordsRepository) CreateBatch(
+	ctx context.Context, reqs []*models.CreateFeedbackRecordRequest,
+) (records []*models.FeedbackRecord, errs []error, err error) {
+	tenantIDs := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		tenantIDs = append(tenantIDs, req.TenantID)
+	}
+
+	err = withTenantWritePoolTx(ctx, r.db, tenantIDs, func(dbTx tenantWriteTx) error {
+		records = make([]*models.FeedbackRecord, len(reqs))
+		errs = make([]error, len(reqs))
+
+		for i, req := range reqs {
+			if _, err := dbTx.Exec(ctx, `SAVEPOINT feedback_record_batch_item`); err != nil {
+				return fmt.Errorf("create batch savepoint: %w", err)
+			}
+
+			record, err := createFeedbackRecord(ctx, dbTx, req)
+			if err != nil {
+				// A broken connection fails the rollback too, which aborts the whole batch.
+				if _, rollbackErr := dbTx.Exec(ctx, `ROLLBACK TO SAVEPOINT feedback_record_batch_item`); rollbackErr != nil {
+					return fmt.Errorf("roll back batch item %d: %w", i, rollbackErr)
+				}
+
+				errs[i] = err
+
+				continue
+			}
+
+			if _, err := dbTx.Exec(ctx, `RELEASE SAVEPOINT feedback_record_batch_item`); err != nil {
+				return fmt.Errorf("release batch savepoint: %w", err)
+			}
+
+			records[i] = record
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return records, errs, nil
+}
+
+// createFeedbackRecord inserts one feedback record on querier (the pool, or a transaction that
+// already holds the tenant write lock).
+func createFeedbackRecord(
+	ctx context.Context, querier queryer, req *models.CreateFeedbackRecordRequest,
+) (*models.FeedbackRecord, error) {
 	collectedAt := time.Now()
 	if req.CollectedAt != nil {
 		collectedAt = *req.CollectedAt
@@ -113,24 +251,31 @@ func (r *FeedbackRecordsRepository) Create(ctx context.Context, req *models.Crea
 	// write lock in a single statement (held for this statement's implicit
 	// transaction): one round trip, same isolation against a tenant data purge.
 	// Zero rows means the lock was refused (purge in progress).
-	const lockKeyParam = 20 // $20, after the 19 inserted columns
+	const lockKeyParam = 21 // $21, after the 20 inserted columns
+
+	// tags is NOT NULL: bind an empty set for an untagged record rather than a nil slice, which
+	// the driver encodes as SQL NULL.
+	tags := req.Tags
+	if tags == nil {
+		tags = []string{}
+	}
 
 	query := `
 		INSERT INTO feedback_records (
 			collected_at, source_type, source_id, source_name,
 			field_id, field_label, field_type, field_group_id, field_group_label,
 			value_text, value_number, value_boolean, value_date,
-			metadata, language, user_id, tenant_id, submission_id, value_id
+			metadata, language, user_id, tenant_id, submission_id, value_id, tags
 		)
-		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 		WHERE ` + tenantWriteLockGate(lockKeyParam) + `
 		RETURNING ` + feedbackRecordColumns
 
-	record, err := scanFeedbackRecord(r.db.QueryRow(ctx, query,
+	record, err := scanFeedbackRecord(querier.QueryRow(ctx, query,
 		collectedAt, req.SourceType, req.SourceID, req.SourceName,
 		req.FieldID, req.FieldLabel, req.FieldType, req.FieldGroupID, req.FieldGroupLabel,
 		req.ValueText, req.ValueNumber, req.ValueBoolean, req.ValueDate,
-		req.Metadata, req.Language, req.UserID, req.TenantID, req.SubmissionID, req.ValueID,
+		req.Metadata, req.Language, req.UserID, req.TenantID, req.SubmissionID, req.ValueID, tags,
 		TenantWriteLockKey(req.TenantID),
 	))
 	if err != nil {
@@ -152,11 +297,14 @@ func (r *FeedbackRecordsRepository) Create(ctx context.Context, req *models.Crea
 // resolveFeedbackRecordTenant reads the tenant boundary of a feedback record
 // inside the current transaction so the caller can acquire the tenant write
 // lock before mutating. tenant_id is immutable on feedback records, so a
-// plain read is race-free once the lock is held.
+// plain read is race-free once the lock is held. A soft-deleted record is
+// NotFound: no by-ID write (update, delete, enrichment) touches it again.
 func resolveFeedbackRecordTenant(ctx context.Context, querier queryer, id uuid.UUID) (string, error) {
 	var tenantID string
 
-	err := querier.QueryRow(ctx, `SELECT tenant_id FROM feedback_records WHERE id = $1`, id).Scan(&tenantID)
+	err := querier.QueryRow(ctx,
+		`SELECT tenant_id FROM feedback_records WHERE id = $1 AND deleted_at IS NULL`, id,
+	).Scan(&tenantID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return "", huberrors.NewNotFoundError("feedback record", "feedback record not found")
@@ -187,11 +335,12 @@ func lockFeedbackRecordTenantShared(ctx context.Context, dbTx tenantWriteTx, id
 	return tenantID, nil
 }
 
-// GetByID retrieves a single feedback record by ID. Embedding is not selected (API/worker reads stay lean).
+// GetByID retrieves a single feedback record by ID; a soft-deleted record is NotFound. Embedding is
+// not selected (API/worker reads stay lean).
 func (r *FeedbackRecordsRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.FeedbackRecord, error) {
 	query := `SELECT ` + feedbackRecordColumns + `
 		FROM feedback_records
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	record, err := scanFeedbackRecord(r.db.QueryRow(ctx, query, id))
 	if err != nil {
@@ -235,7 +384,7 @@ func guardValueTextCurrent(
 	// (which does not take the per-record advisory lock) cannot change value_text between this
 	// re-read and the caller's UPDATE — closing the check-then-write window rather than narrowing it.
 	err := dbTx.QueryRow(ctx,
-		`SELECT value_text FROM feedback_records WHERE id = $1 FOR UPDATE`, feedbackRecordID,
+		`SELECT value_text FROM feedback_records WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, feedbackRecordID,
 	).Scan(&valueText)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -469,7 +618,7 @@ const translationBackfillSelectSQL = `
 	SELECT fr.id, COALESCE(NULLIF(ts.settings->>'target_language', ''), $1)
 	FROM feedback_records fr
 	LEFT JOIN tenant_settings ts ON ts.tenant_id = fr.tenant_id
-	WHERE fr.field_type = 'text'
+	WHERE fr.field_type = 'text' AND fr.deleted_at IS NULL
 		AND fr.value_text IS NOT NULL AND btrim(fr.value_text) <> ''
 		AND COALESCE(NULLIF(ts.settings->>'target_language', ''), $1) <> ''
 		AND fr.translation_lang_key IS DISTINCT FROM COALESCE(NULLIF(ts.settings->>'target_language', ''), $1)`
@@ -549,7 +698,7 @@ func scanTranslationBackfillTargets(rows pgx.Rows) ([]models.TranslationBackfill
 // never user input.
 const classifyBackfillEligibleSQL = `
 	SELECT id FROM feedback_records
-	WHERE field_type = 'text' AND value_text IS NOT NULL AND btrim(value_text) <> ''`
+	WHERE field_type = 'text' AND deleted_at IS NULL AND value_text IS NOT NULL AND btrim(value_text) <> ''`
 
 const sentimentBackfillSelectSQL = classifyBackfillEligibleSQL + `
 		AND sentiment IS NULL
@@ -590,6 +739,35 @@ func (r *FeedbackRecordsRepository) ListEmotionsBackfillTargets(
 	return scanBackfillTargetIDs(rows, "emotions")
 }
 
+// CountSentimentBackfillTargets counts the records ListSentimentBackfillTargets would page
+// through, so the backfill command can report an ETA.
+func (r *FeedbackRecordsRepository) CountSentimentBackfillTargets(ctx context.Context) (int, error) {
+	return r.countBackfillTargets(ctx, "sentiment",
+		`SELECT count(*) FROM (`+classifyBackfillEligibleSQL+` AND sentiment IS NULL) t`)
+}
+
+// CountEmotionsBackfillTargets counts the records ListEmotionsBackfillTargets would page through.
+func (r *FeedbackRecordsRepository) CountEmotionsBackfillTargets(ctx context.Context) (int, error) {
+	return r.countBackfillTargets(ctx, "emotions",
+		`SELECT count(*) FROM (`+classifyBackfillEligibleSQL+` AND emotions IS NULL) t`)
+}
+
+// CountTranslationBackfillTargets counts the records ListTranslationBackfillTargets would page
+// through for defaultLang.
+func (r *FeedbackRecordsRepository) CountTranslationBackfillTargets(ctx context.Context, defaultLang string) (int, error) {
+	return r.countBackfillTargets(ctx, "translation",
+		`SELECT count(*) FROM (`+translationBackfillSelectSQL+`) t`, defaultLang)
+}
+
+func (r *FeedbackRecordsRepository) countBackfillTargets(ctx context.Context, name, query string, args ...any) (int, error) {
+	var count int
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count %s backfill targets: %w", name, err)
+	}
+
+	return count, nil
+}
+
 // scanBackfillTargetIDs collects id rows and closes rows; name labels any error.
 func scanBackfillTargetIDs(rows pgx.Rows, name string) ([]uuid.UUID, error) {
 	defer rows.Close()
@@ -612,6 +790,18 @@ func scanBackfillTargetIDs(rows pgx.Rows, name string) ([]uuid.UUID, error) {
 	return ids, nil
 }
 
+// classifiedCondition matches records assigned to a topic: a membership in the active taxonomy
+// run of the record's field, in a cluster that is not the outlier bucket. Records of fields
+// without an active run are unclassified. The (tenant_id, feedback_record_id, run_id) membership
+// index serves the lookup.
+const classifiedCondition = `EXISTS (
+		SELECT 1 FROM taxonomy_cluster_memberships tcm
+		INNER JOIN taxonomy_active_runs tar ON tar.run_id = tcm.run_id
+		INNER JOIN taxonomy_clusters tc ON tc.id = tcm.cluster_id
+		WHERE tcm.tenant_id = feedback_records.tenant_id
+			AND tcm.feedback_record_id = feedback_records.id
+			AND NOT tc.is_outlier)`
+
 // buildFilterConditions builds WHERE clause conditions and arguments from filters.
 // Returns the WHERE clause (including " WHERE " prefix if conditions exist) and the args slice.
 func buildFilterConditions(filters *models.ListFeedbackRecordsFilters) (whereClause string, args []any) {
@@ -666,6 +856,18 @@ func buildFilterConditions(filters *models.ListFeedbackRecordsFilters) (whereCla
 		args = append(args, *filters.UserID)
 	}
 
+	// Tag filters use array containment so the GIN index on tags serves them: "all" (the default)
+	// requires every requested tag (@>), "any" at least one (&&).
+	if len(filters.Tags) > 0 {
+		op := "@>"
+		if filters.TagMatch == models.TagMatchAny {
+			op = "&&"
+		}
+
+		conditions = append(conditions, fmt.Sprintf("tags %s $%d", op, len(args)+1))
+		args = append(args, filters.Tags)
+	}
+
 	if filters.Since != nil {
 		conditions = append(conditions, fmt.Sprintf("collected_at >= $%d", len(args)+1))
 		args = append(args, *filters.Since)
@@ -676,6 +878,18 @@ func buildFilterConditions(filters *models.ListFeedbackRecordsFilters) (whereCla
 		args = append(args, *filters.Until)
 	}
 
+	if filters.Classified != nil {
+		if *filters.Classified {
+			conditions = append(conditions, classifiedCondition)
+		} else {
+			conditions = append(conditions, "NOT "+classifiedCondition)
+		}
+	}
+
+	if !filters.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
 	if len(conditions) > 0 {
 		whereClause = " WHERE " + strings.Join(conditions, " AND ")
 	}
@@ -707,6 +921,162 @@ func buildCountQuery(filters *models.ListFeedbackRecordsFilters) (string, []any)
 	return query, args
 }
 
+// Aggregate counts the tenant's live records by source_type, by field_type, and per UTC day of
+// collected_at, optionally within [since, until]. The three groupings come from one scan through
+// GROUPING SETS; GROUPING() tells the rows of each set apart.
+func (r *FeedbackRecordsRepository) Aggregate(
+	ctx context.Context, filters *models.FeedbackRecordStatsFilters,
+) (*models.FeedbackRecordStatsResponse, error) {
+	query, args := buildAggregateQuery(filters)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query feedback record stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &models.FeedbackRecordStatsResponse{
+		BySourceType: map[string]int64{},
+		ByFieldType:  map[string]int64{},
+		ByDay:        []models.FeedbackRecordDayCount{},
+	}
+
+	for rows.Next() {
+		var (
+			sourceType, fieldType                   *string
+			day                                     *time.Time
+			groupedSource, groupedField, groupedDay bool
+			count                                   int64
+		)
+
+		if err := rows.Scan(&sourceType, &fieldType, &day, &groupedSource, &groupedField, &groupedDay, &count); err != nil {
+			return nil, fmt.Errorf("scan feedback record stats: %w", err)
+		}
+
+		switch {
+		case !groupedSource:
+			stats.BySourceType[*sourceType] = count
+			stats.Total += count
+		case !groupedField:
+			stats.ByFieldType[*fieldType] = count
+		case !groupedDay:
+			stats.ByDay = append(stats.ByDay, models.FeedbackRecordDayCount{Day: day.UTC(), Count: count})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate feedback record stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// buildAggregateQuery constructs the GROUPING SETS query and args behind Aggregate.
+// Extracted for testability. A GROUPING() of 1 marks the column as rolled up in that row.
+func buildAggregateQuery(filters *models.FeedbackRecordStatsFilters) (string, []any) {
+	conditions := []string{"tenant_id = $1", "deleted_at IS NULL"}
+	args := []any{filters.TenantID}
+
+	if filters.Since != nil {
+		conditions = append(conditions, fmt.Sprintf("collected_at >= $%d", len(args)+1))
+		args = append(args, *filters.Since)
+	}
+
+	if filters.Until != nil {
+		conditions = append(conditions, fmt.Sprintf("collected_at <= $%d", len(args)+1))
+		args = append(args, *filters.Until)
+	}
+
+	query := `
+		SELECT source_type, field_type, date_trunc('day', collected_at, 'UTC') AS day,
+			GROUPING(source_type) = 1, GROUPING(field_type) = 1, GROUPING(date_trunc('day', collected_at, 'UTC')) = 1,
+			COUNT(*)
+		FROM feedback_records
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		GROUP BY GROUPING SETS ((source_type), (field_type), (date_trunc('day', collected_at, 'UTC')))
+		ORDER BY day`
+
+	return query, args
+}
+
+// ListTagCounts returns the tenant's tags with the number of records carrying each, most-used
+// first (ties by tag). It unnests every tagged record of the tenant — the GIN index cannot serve
+// an aggregate — so limit bounds the response, not the scan.
+func (r *FeedbackRecordsRepository) ListTagCounts(
+	ctx context.Context, tenantID string, limit int,
+) ([]models.FeedbackRecordTagCount, error) {
+	return r.queryTagCounts(ctx, `
+		SELECT t.tag, COUNT(*)
+		FROM feedback_records fr
+		CROSS JOIN LATERAL unnest(fr.tags) AS t(tag)
+		WHERE fr.tenant_id = $1 AND fr.deleted_at IS NULL
+		GROUP BY t.tag
+		ORDER BY COUNT(*) DESC, t.tag ASC
+		LIMIT $2`,
+		tenantID, limit,
+	)
+}
+
+// SampleTagCounts is ListTagCounts over a TABLESAMPLE SYSTEM of samplePercent of the table's
+// pages, with each count scaled by 100/samplePercent. Page sampling reads only the sampled pages,
+// so its cost tracks the sample rather than the tenant. The price is accuracy: a tag on few
+// records may be missed or over-counted, and a tenant with few records overall gets a noisy
+// answer. pg_stats is no alternative here: its most-common-elements are table-wide, not per tenant.
+func (r *FeedbackRecordsRepository) SampleTagCounts(
+	ctx context.Context, tenantID string, limit int, samplePercent float64,
+) ([]models.FeedbackRecordTagCount, error) {
+	return r.queryTagCounts(ctx, `
+		SELECT t.tag, round(COUNT(*) * 100.0 / $3::real)::bigint AS estimate
+		FROM feedback_records fr TABLESAMPLE SYSTEM ($3::real)
+		CROSS JOIN LATERAL unnest(fr.tags) AS t(tag)
+		WHERE fr.tenant_id = $1 AND fr.deleted_at IS NULL
+		GROUP BY t.tag
+		ORDER BY estimate DESC, t.tag ASC
+		LIMIT $2`,
+		tenantID, limit, samplePercent,
+	)
+}
+
+func (r *FeedbackRecordsRepository) queryTagCounts(
+	ctx context.Context, query string, args ...any,
+) ([]models.FeedbackRecordTagCount, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query feedback record tag counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []models.FeedbackRecordTagCount{}
+
+	for rows.Next() {
+		var count models.FeedbackRecordTagCount
+		if err := rows.Scan(&count.Tag, &count.Count); err != nil {
+			return nil, fmt.Errorf("scan feedback record tag count: %w", err)
+		}
+
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate feedback record tag counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// EstimateRowCount returns the planner's row estimate for feedback_records (pg_class.reltuples,
+// refreshed by VACUUM/ANALYZE). It is table-wide and free to read; -1 means never analyzed.
+func (r *FeedbackRecordsRepository) EstimateRowCount(ctx context.Context) (int64, error) {
+	var estimate int64
+	if err := r.db.QueryRow(ctx,
+		`SELECT reltuples::bigint FROM pg_class WHERE oid = 'feedback_records'::regclass`,
+	).Scan(&estimate); err != nil {
+		return 0, fmt.Errorf("query feedback records row estimate: %w", err)
+	}
+
+	return estimate, nil
+}
+
 const feedbackRecordsListSelect = `SELECT ` + feedbackRecordColumns + `
 		FROM feedback_records
 	`
@@ -860,6 +1230,45 @@ func buildUpdateQuery(
 		argCount++
 	}
 
+	// tags replaces the whole set; like value_id it is caller-supplied, not an enrichment source.
+	if req.Tags != nil {
+		tags := *req.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+
+		updates = append(updates, fmt.Sprintf("tags = $%d", argCount))
+		args = append(args, tags)
+		argCount++
+	}
+
+	// The source and tenant_id are plain caller-supplied fields too: a record moved to the right
+	// tenant or source keeps its content, so no enrichment output goes stale. (The caller locks
+	// the target tenant and checks the move, see Update.)
+	if req.SourceType != nil {
+		updates = append(updates, fmt.Sprintf("source_type = $%d", argCount))
+		args = append(args, *req.SourceType)
+		argCount++
+	}
+
+	if req.SourceID != nil {
+		updates = append(updates, fmt.Sprintf("source_id = $%d", argCount))
+		args = append(args, *req.SourceID)
+		argCount++
+	}
+
+	if req.SourceName != nil {
+		updates = append(updates, fmt.Sprintf("source_name = $%d", argCount))
+		args = append(args, *req.SourceName)
+		argCount++
+	}
+
+	if req.TenantID != nil {
+		updates = append(updates, fmt.Sprintf("tenant_id = $%d", argCount))
+		args = append(args, *req.TenantID)
+		argCount++
+	}
+
 	// Clear now-stale enrichment outputs, but only when the field they derive from ACTUALLY
 	// changes: the bare column on the RHS of an UPDATE ... SET is the pre-update value, so each
 	// CASE compares old vs new and clears only on a real change. This keeps a client re-sending an
@@ -952,11 +1361,25 @@ func joinOr(conds ...string) string {
 	return strings.Join(nonEmpty, " OR ")
 }
 
-// Update updates an existing feedback record. Only value fields, metadata, language, and user_id
-// can be updated. It returns both the updated row and the pre-update ("previous") row so the
+// errFeedbackRecordModified is returned by a conditional Update whose precondition failed.
+var errFeedbackRecordModified = huberrors.NewPreconditionFailedError(
+	"feedback record was modified since it was read; fetch it again and retry the update")
+
+// Update updates an existing feedback record (see models.UpdateFeedbackRecordRequest for the
+// updatable fields). It returns both the updated row and the pre-update ("previous") row so the
 // caller can compute the fields that ACTUALLY changed against state consistent with this write:
 // the previous snapshot is read FOR UPDATE inside the same transaction as the write, so a
 // concurrent Update cannot change the row between the read and the write and make the diff stale.
+// req.Precondition (optional) is checked against that same locked row, so a conditional update
+// cannot pass the check and then overwrite a write that committed in between.
+//
+// A tenant_id change also takes the target tenant's write lock, together with the current
+// tenant's and before the row lock, so a record cannot move into a tenant being purged, and is
+// refused with a conflict while the record is a member of topics in
+// its current tenant's taxonomy: memberships are tenant-scoped (their foreign key pins the
+// record's tenant), and moving them would leave the old taxonomy's topics pointing across
+// tenants. A move that collides with a record of the same submission and field in the target
+// tenant is a conflict too.
 func (r *FeedbackRecordsRepository) Update(
 	ctx context.Context, id uuid.UUID, req *models.UpdateFeedbackRecordRequest,
 ) (updated, previous *models.FeedbackRecord, err error) {
@@ -965,13 +1388,31 @@ func (r *FeedbackRecordsRepository) Update(
 		// No write happens, so no tenant write lock is needed; nothing changed, so the previous
 		// state is the current row.
 		record, getErr := r.GetByID(ctx, id)
+		if getErr != nil {
+			return nil, nil, getErr
+		}
 
-		return record, record, getErr
+		if req.Precondition != nil && !req.Precondition(record) {
+			return nil, nil, errFeedbackRecordModified
+		}
+
+		return record, record, nil
 	}
 
 	err = withTenantWritePoolTx(ctx, r.db, nil, func(dbTx tenantWriteTx) error {
-		tenantID, lockErr := lockFeedbackRecordTenantShared(ctx, dbTx, id)
-		if lockErr != nil {
+		tenantID, resolveErr := resolveFeedbackRecordTenant(ctx, dbTx, id)
+		if resolveErr != nil {
+			return resolveErr
+		}
+
+		// Lock the current tenant and, for a tenant move, the target tenant as well, before the
+		// row lock below, so the record cannot move into or out of a tenant that is being purged.
+		lockTenants := []string{tenantID}
+		if req.TenantID != nil {
+			lockTenants = append(lockTenants, *req.TenantID)
+		}
+
+		if lockErr := tryLockTenantsShared(ctx, dbTx, lockTenants); lockErr != nil {
 			return lockErr
 		}
 
@@ -980,7 +1421,7 @@ func (r *FeedbackRecordsRepository) Update(
 		// before the lock, which a concurrent Update could invalidate (dropping or inventing
 		// downstream webhook/enrichment triggers).
 		prev, prevErr := scanFeedbackRecord(dbTx.QueryRow(ctx,
-			`SELECT `+feedbackRecordColumns+` FROM feedback_records WHERE id = $1 AND tenant_id = $2 FOR UPDATE`,
+			`SELECT `+feedbackRecordColumns+` FROM feedback_records WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL FOR UPDATE`,
 			id, tenantID))
 		if prevErr != nil {
 			if errors.Is(prevErr, pgx.ErrNoRows) {
@@ -990,12 +1431,33 @@ func (r *FeedbackRecordsRepository) Update(
 			return fmt.Errorf("failed to read feedback record before update: %w", prevErr)
 		}
 
+		if req.Precondition != nil && !req.Precondition(prev) {
+			return errFeedbackRecordModified
+		}
+
+		// field_type is immutable, so a typed value is checked against the stored one.
+		if err := models.CheckTypedValues(prev.FieldType, req.ValueNumber, req.ValueBoolean, req.ValueDate); err != nil {
+			return err
+		}
+
+		if req.TenantID != nil && *req.TenantID != tenantID {
+			if err := checkFeedbackRecordTenantMove(ctx, dbTx, id, tenantID); err != nil {
+				return err
+			}
+		}
+
 		scanned, scanErr := scanFeedbackRecord(dbTx.QueryRow(ctx, query, append(args, tenantID)...))
 		if scanErr != nil {
 			if errors.Is(scanErr, pgx.ErrNoRows) {
 				return huberrors.NewNotFoundError("feedback record", "feedback record not found")
 			}
 
+			var pgErr *pgconn.PgError
+			if errors.As(scanErr, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+				return huberrors.NewConflictError(
+					"a feedback record with this submission_id and field_id already exists in the target tenant")
+			}
+
 			return fmt.Errorf("failed to update feedback record: %w", scanErr)
 		}
 
@@ -1011,7 +1473,29 @@ func (r *FeedbackRecordsRepository) Update(
 	return updated, previous, nil
 }
 
-// Delete removes a feedback record.
+// checkFeedbackRecordTenantMove refuses a tenant_id change while the record has taxonomy
+// memberships in its current tenant.
+func checkFeedbackRecordTenantMove(ctx context.Context, dbTx tenantWriteTx, id uuid.UUID, from string) error {
+	var classified bool
+	if err := dbTx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM taxonomy_cluster_memberships WHERE tenant_id = $1 AND feedback_record_id = $2
+		)`, from, id).Scan(&classified); err != nil {
+		return fmt.Errorf("check taxonomy memberships before tenant move: %w", err)
+	}
+
+	if classified {
+		return huberrors.NewConflictError(
+			"feedback record is assigned to topics of its current tenant's taxonomy and cannot move to another tenant")
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a feedback record: it stamps deleted_at, which hides the record from every
+// read (and its embedding from search) and refuses further writes to it, and leaves the row in
+// place until PurgeDeleted removes it after the retention window. Deleting an already deleted
+// record is NotFound.
 func (r *FeedbackRecordsRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return withTenantWritePoolTx(ctx, r.db, nil, func(dbTx tenantWriteTx) error {
 		tenantID, err := lockFeedbackRecordTenantShared(ctx, dbTx, id)
@@ -1019,7 +1503,9 @@ func (r *FeedbackRecordsRepository) Delete(ctx context.Context, id uuid.UUID) er
 			return err
 		}
 
-		result, err := dbTx.Exec(ctx, `DELETE FROM feedback_records WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+		result, err := dbTx.Exec(ctx, `
+			UPDATE feedback_records SET deleted_at = NOW()
+			WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`, id, tenantID)
 		if err != nil {
 			return fmt.Errorf("failed to delete feedback record: %w", err)
 		}
@@ -1032,9 +1518,82 @@ func (r *FeedbackRecordsRepository) Delete(ctx context.Context, id uuid.UUID) er
 	})
 }
 
+// PurgeDeleted hard-deletes records soft-deleted before cutoff, tenant by tenant and batchSize
+// rows per DELETE so a large purge never holds long row locks, and returns the total purged.
+// Embeddings, taxonomy memberships, and idempotency keys go with their record (ON DELETE
+// CASCADE). Each batch runs under the tenant's write lock; a tenant whose lock is refused (its
+// data purge is running) is skipped and picked up by the next run.
+func (r *FeedbackRecordsRepository) PurgeDeleted(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT DISTINCT tenant_id FROM feedback_records WHERE deleted_at < $1 ORDER BY tenant_id`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("list tenants with purgeable feedback records: %w", err)
+	}
+
+	tenantIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("list tenants with purgeable feedback records: %w", err)
+	}
+
+	var total int64
+
+	for _, tenantID := range tenantIDs {
+		purged, err := r.purgeDeletedForTenant(ctx, tenantID, cutoff, batchSize)
+		total += purged
+
+		if errors.Is(err, huberrors.ErrTenantWriteConflict) {
+			slog.InfoContext(ctx, "feedback record purge: tenant locked, retrying next run", "tenant_id", tenantID)
+
+			continue
+		}
+
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// purgeDeletedForTenant is PurgeDeleted for one tenant.
+func (r *FeedbackRecordsRepository) purgeDeletedForTenant(
+	ctx context.Context, tenantID string, cutoff time.Time, batchSize int,
+) (int64, error) {
+	var total int64
+
+	for {
+		var purged int64
+
+		err := withTenantWritePoolTx(ctx, r.db, []string{tenantID}, func(dbTx tenantWriteTx) error {
+			tag, err := dbTx.Exec(ctx, `
+				DELETE FROM feedback_records WHERE id IN (
+					SELECT id FROM feedback_records WHERE tenant_id = $2 AND deleted_at < $1 LIMIT $3
+				) AND tenant_id = $2`, cutoff, tenantID, batchSize)
+			if err != nil {
+				return fmt.Errorf("purge deleted feedback records: %w", err)
+			}
+
+			purged = tag.RowsAffected()
+
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+
+		total += purged
+
+		if purged < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
+
 // DeleteByUser deletes all feedback records matching user_id.
 // When tenant_id is provided, deletion is restricted to that tenant; otherwise all user records
 // are deleted across tenants (documented GDPR/right-to-erasure exception).
+// Unlike Delete this is a hard delete, and it takes the user's soft-deleted records too: an
+// erasure request is a legal obligation that a retention window must not postpone.
 // Every spanned tenant's write lock is acquired before deleting; if any tenant is under purge
 // the whole request fails with a retryable conflict. The delete is scoped to the locked tenants,
 // so records appearing in new tenants mid-transaction are never touched without a lock.
@@ -1064,60 +1623,202 @@ func (r *FeedbackRecordsRepository) DeleteByUser(
 			return err
 		}
 
-		rows, err := dbTx.Query(ctx, `
-			DELETE FROM feedback_records
-			WHERE user_id = $1 AND tenant_id = ANY($2)
-			RETURNING id, tenant_id`, filters.UserID, tenantIDs)
+		if groups, err = deleteUserFeedbackInTenants(ctx, dbTx, filters.UserID, tenantIDs); err != nil {
+			return err
+		}
+
+		// Drift guard: a record for this user may have been written into a tenant
+		// not in the locked snapshot (a new tenant for the all-tenant erase, or a
+		// concurrent insert into an already-locked tenant). Re-check the in-scope
+		// set; if anything survives, fail with a retryable conflict so the whole
+		// erase rolls back and the caller retries, rather than reporting a
+		// partial erasure as complete.
+		if err := ensureNoResidualUserFeedback(ctx, dbTx, filters); err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// EraseSubject is DeleteByUser extended to everything else Hub keeps about the subject (GDPR
+// erasure): in the same transaction, and under the same tenant locks and drift guard, it deletes
+// the records' embeddings and taxonomy cluster memberships explicitly (so the counts are exact
+// rather than hidden in cascades) and the webhook dead letters whose payload carries a record of
+// the user. Dead letters outlive the records they describe, so their tenants are locked too even
+// when the user has no feedback left there. The distinct value_text of the deleted records is
+// returned for evicting in-process caches keyed by it.
+func (r *FeedbackRecordsRepository) EraseSubject(
+	ctx context.Context, filters *models.DeleteFeedbackRecordsByUserFilters,
+) (*models.SubjectErasureCounts, error) {
+	counts := &models.SubjectErasureCounts{DeletedByTenant: make([]models.DeletedFeedbackRecordsByTenant, 0)}
+
+	err := withTenantWritePoolTx(ctx, r.db, nil, func(dbTx tenantWriteTx) error {
+		tenantIDs, err := listUserFeedbackTenants(ctx, dbTx, filters)
 		if err != nil {
-			return fmt.Errorf("failed to delete feedback records by user: %w", err)
+			return err
 		}
-		defer rows.Close()
 
-		groupIndexByTenant := make(map[string]int)
+		deadLetterTenantIDs, err := listUserDeadLetterTenants(ctx, dbTx, filters)
+		if err != nil {
+			return err
+		}
 
-		for rows.Next() {
-			var (
-				id       uuid.UUID
-				tenantID string
-			)
+		lockTenantIDs := append(slices.Clone(tenantIDs), deadLetterTenantIDs...)
+		if len(lockTenantIDs) == 0 {
+			// Nothing to erase; keep the endpoint idempotent without taking locks.
+			return nil
+		}
 
-			if err := rows.Scan(&id, &tenantID); err != nil {
-				return fmt.Errorf("failed to scan deleted feedback record id: %w", err)
-			}
+		if err := tryLockTenantsShared(ctx, dbTx, lockTenantIDs); err != nil {
+			return err
+		}
 
-			groupIndex, ok := groupIndexByTenant[tenantID]
-			if !ok {
-				groupIndex = len(groups)
-				groupIndexByTenant[tenantID] = groupIndex
-				groups = append(groups, models.DeletedFeedbackRecordsByTenant{TenantID: tenantID})
-			}
+		if counts.ValueTexts, err = listUserFeedbackTexts(ctx, dbTx, filters.UserID, tenantIDs); err != nil {
+			return err
+		}
 
-			groups[groupIndex].IDs = append(groups[groupIndex].IDs, id)
+		embeddingsTag, err := dbTx.Exec(ctx, `
+			DELETE FROM embeddings e
+			USING feedback_records fr
+			WHERE e.feedback_record_id = fr.id
+				AND fr.user_id = $1
+				AND fr.tenant_id = ANY($2)`, filters.UserID, tenantIDs)
+		if err != nil {
+			return fmt.Errorf("delete user embeddings: %w", err)
 		}
 
-		if err := rows.Err(); err != nil {
-			return fmt.Errorf("error iterating delete feedback records by user result: %w", err)
+		membershipsTag, err := dbTx.Exec(ctx, `
+			DELETE FROM taxonomy_cluster_memberships m
+			USING feedback_records fr
+			WHERE m.feedback_record_id = fr.id
+				AND m.tenant_id = fr.tenant_id
+				AND fr.user_id = $1
+				AND fr.tenant_id = ANY($2)`, filters.UserID, tenantIDs)
+		if err != nil {
+			return fmt.Errorf("delete user taxonomy cluster memberships: %w", err)
 		}
 
-		// Drift guard: a record for this user may have been written into a tenant
-		// not in the locked snapshot (a new tenant for the all-tenant erase, or a
-		// concurrent insert into an already-locked tenant). Re-check the in-scope
-		// set; if anything survives, fail with a retryable conflict so the whole
-		// erase rolls back and the caller retries, rather than reporting a
-		// partial erasure as complete.
-		if err := ensureNoResidualUserFeedback(ctx, dbTx, filters); err != nil {
+		if counts.DeletedByTenant, err = deleteUserFeedbackInTenants(ctx, dbTx, filters.UserID, tenantIDs); err != nil {
 			return err
 		}
 
-		return nil
+		deadLettersTag, err := dbTx.Exec(ctx, `
+			DELETE FROM webhook_dead_letters
+			WHERE payload->'data'->>'user_id' = $1
+				AND tenant_id = ANY($2)`, filters.UserID, deadLetterTenantIDs)
+		if err != nil {
+			return fmt.Errorf("delete user webhook dead letters: %w", err)
+		}
+
+		counts.DeletedEmbeddings = embeddingsTag.RowsAffected()
+		counts.DeletedTaxonomyClusterMemberships = membershipsTag.RowsAffected()
+		counts.DeletedWebhookDeadLetters = deadLettersTag.RowsAffected()
+
+		return ensureNoResidualUserFeedback(ctx, dbTx, filters)
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	return counts, nil
+}
+
+// deleteUserFeedbackInTenants deletes the user's feedback records in the (already locked) tenants
+// and returns their IDs grouped by tenant.
+func deleteUserFeedbackInTenants(
+	ctx context.Context, dbTx tenantWriteTx, userID string, tenantIDs []string,
+) ([]models.DeletedFeedbackRecordsByTenant, error) {
+	groups := make([]models.DeletedFeedbackRecordsByTenant, 0)
+
+	rows, err := dbTx.Query(ctx, `
+		DELETE FROM feedback_records
+		WHERE user_id = $1 AND tenant_id = ANY($2)
+		RETURNING id, tenant_id`, userID, tenantIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete feedback records by user: %w", err)
+	}
+	defer rows.Close()
+
+	groupIndexByTenant := make(map[string]int)
+
+	for rows.Next() {
+		var (
+			id       uuid.UUID
+			tenantID string
+		)
+
+		if err := rows.Scan(&id, &tenantID); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted feedback record id: %w", err)
+		}
+
+		groupIndex, ok := groupIndexByTenant[tenantID]
+		if !ok {
+			groupIndex = len(groups)
+			groupIndexByTenant[tenantID] = groupIndex
+			groups = append(groups, models.DeletedFeedbackRecordsByTenant{TenantID: tenantID})
+		}
+
+		groups[groupIndex].IDs = append(groups[groupIndex].IDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating delete feedback records by user result: %w", err)
+	}
+
 	return groups, nil
 }
 
+// listUserFeedbackTexts returns the distinct, trimmed, non-empty value_text of the user's records.
+func listUserFeedbackTexts(ctx context.Context, dbTx tenantWriteTx, userID string, tenantIDs []string) ([]string, error) {
+	rows, err := dbTx.Query(ctx, `
+		SELECT DISTINCT btrim(value_text)
+		FROM feedback_records
+		WHERE user_id = $1 AND tenant_id = ANY($2) AND btrim(value_text) <> ''`, userID, tenantIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list user feedback texts: %w", err)
+	}
+
+	texts, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("scan user feedback texts: %w", err)
+	}
+
+	return texts, nil
+}
+
+// listUserDeadLetterTenants returns the tenants holding webhook dead letters whose payload carries
+// a record of the user (optionally restricted to one tenant).
+func listUserDeadLetterTenants(
+	ctx context.Context, dbTx tenantWriteTx, filters *models.DeleteFeedbackRecordsByUserFilters,
+) ([]string, error) {
+	query := `SELECT DISTINCT tenant_id FROM webhook_dead_letters WHERE payload->'data'->>'user_id' = $1`
+	args := []any{filters.UserID}
+
+	if filters.TenantID != nil {
+		query += ` AND tenant_id = $2`
+
+		args = append(args, *filters.TenantID)
+	}
+
+	rows, err := dbTx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list tenants for user webhook dead letters: %w", err)
+	}
+
+	tenantIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("scan tenant id: %w", err)
+	}
+
+	return tenantIDs, nil
+}
+
 // ensureNoResidualUserFeedback returns a retryable tenant write conflict if any
 // in-scope feedback record for the user still exists after DeleteByUser's delete.
 func ensureNoResidualUserFeedback(
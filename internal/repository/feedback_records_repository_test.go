@@ -101,8 +101,8 @@ func TestBuildCountQuery(t *testing.T) {
 
 	t.Run("no filters", func(t *testing.T) {
 		query, args := buildCountQuery(&models.ListFeedbackRecordsFilters{})
-		if query != "SELECT COUNT(*) FROM feedback_records" {
-			t.Fatalf("query = %q, want SELECT COUNT(*) FROM feedback_records", query)
+		if query != "SELECT COUNT(*) FROM feedback_records WHERE deleted_at IS NULL" {
+			t.Fatalf("query = %q, want SELECT COUNT(*) FROM feedback_records WHERE deleted_at IS NULL", query)
 		}
 
 		if len(args) != 0 {
@@ -206,6 +206,145 @@ func TestBuildUpdateQuery_ValueID(t *testing.T) {
 	}
 }
 
+// TestBuildAggregateQuery verifies the stats query always scopes to the tenant's live records and
+// binds the optional collected_at range after the tenant.
+func TestBuildAggregateQuery(t *testing.T) {
+	query, args := buildAggregateQuery(&models.FeedbackRecordStatsFilters{TenantID: "t1"})
+
+	if !strings.Contains(query, "WHERE tenant_id = $1 AND deleted_at IS NULL\n") ||
+		!strings.Contains(query, "GROUP BY GROUPING SETS") {
+		t.Fatalf("query = %q, want tenant-scoped live records grouped by GROUPING SETS", query)
+	}
+
+	if len(args) != 1 || args[0] != "t1" {
+		t.Fatalf("args = %v, want [tenant]", args)
+	}
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	query, args = buildAggregateQuery(&models.FeedbackRecordStatsFilters{TenantID: "t1", Since: &since, Until: &until})
+
+	if !strings.Contains(query, "collected_at >= $2 AND collected_at <= $3") {
+		t.Fatalf("query = %q, want the range bound to $2 and $3", query)
+	}
+
+	if len(args) != 3 {
+		t.Fatalf("args = %v, want [tenant, since, until]", args)
+	}
+}
+
+// TestBuildFilterConditions_Tags verifies the tag filter maps to array containment: "all" (the
+// default) is @>, "any" is &&, and the tag set is bound as one array argument.
+func TestBuildFilterConditions_Tags(t *testing.T) {
+	tenant := "t1"
+	tags := []string{"bug", "urgent"}
+
+	for _, tc := range []struct {
+		match string
+		want  string
+	}{
+		{match: "", want: "tags @> $2"},
+		{match: models.TagMatchAll, want: "tags @> $2"},
+		{match: models.TagMatchAny, want: "tags && $2"},
+	} {
+		where, args := buildFilterConditions(&models.ListFeedbackRecordsFilters{
+			TenantID: &tenant, Tags: tags, TagMatch: tc.match,
+		})
+
+		if !strings.Contains(where, tc.want) {
+			t.Fatalf("tag_match %q: where = %q, want it to contain %q", tc.match, where, tc.want)
+		}
+
+		if len(args) != 2 {
+			t.Fatalf("tag_match %q: args = %v, want [tenant, tags]", tc.match, args)
+		}
+	}
+}
+
+// TestBuildFilterConditions_Classified verifies classified=true/false emits the topic-membership
+// subquery (negated for false) without binding an argument, so later placeholders stay in place.
+func TestBuildFilterConditions_Classified(t *testing.T) {
+	tenant := "t1"
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, classified := range []bool{true, false} {
+		where, args := buildFilterConditions(&models.ListFeedbackRecordsFilters{
+			TenantID: &tenant, Since: &since, Classified: &classified,
+		})
+
+		if !strings.Contains(where, "collected_at >= $2") || len(args) != 2 {
+			t.Fatalf("classified=%v: where = %q, args = %v, want [tenant, since]", classified, where, args)
+		}
+
+		negated := strings.Contains(where, "NOT EXISTS (")
+		if !strings.Contains(where, "taxonomy_cluster_memberships") || negated == classified {
+			t.Fatalf("classified=%v: where = %q", classified, where)
+		}
+	}
+}
+
+// TestBuildFilterConditions_IncludeDeleted verifies soft-deleted records are excluded unless the
+// caller asks for them, without binding an argument either way.
+func TestBuildFilterConditions_IncludeDeleted(t *testing.T) {
+	tenant := "t1"
+
+	where, args := buildFilterConditions(&models.ListFeedbackRecordsFilters{TenantID: &tenant})
+	if !strings.Contains(where, "deleted_at IS NULL") || len(args) != 1 {
+		t.Fatalf("default: where = %q, args = %v, want deleted records excluded", where, args)
+	}
+
+	where, args = buildFilterConditions(&models.ListFeedbackRecordsFilters{TenantID: &tenant, IncludeDeleted: true})
+	if strings.Contains(where, "deleted_at") || len(args) != 1 {
+		t.Fatalf("include_deleted: where = %q, args = %v, want no deleted_at condition", where, args)
+	}
+}
+
+// TestBuildUpdateQuery_Tags verifies a tag replacement is a direct assignment and that an empty
+// set binds a non-nil slice (the column is NOT NULL; a nil slice would encode as SQL NULL).
+func TestBuildUpdateQuery_Tags(t *testing.T) {
+	var empty []string
+
+	query, args, hasUpdates := buildUpdateQuery(&models.UpdateFeedbackRecordRequest{Tags: &empty}, uuid.New(), time.Now())
+	if !hasUpdates {
+		t.Fatal("buildUpdateQuery hasUpdates = false, want true")
+	}
+
+	if !strings.Contains(query, "tags = $1") {
+		t.Fatalf("query missing direct tags assignment\nquery: %s", query)
+	}
+
+	if got, ok := args[0].([]string); !ok || got == nil {
+		t.Fatalf("args[0] = %#v, want a non-nil []string", args[0])
+	}
+}
+
+// TestBuildUpdateQuery_TenantAndSource verifies a tenant move and a source correction are direct
+// assignments that clear no enrichment output (the content did not change), and that the row is
+// still matched on its current tenant, bound after the new one.
+func TestBuildUpdateQuery_TenantAndSource(t *testing.T) {
+	tenantID, sourceType := "org-b", "survey"
+
+	query, args, hasUpdates := buildUpdateQuery(
+		&models.UpdateFeedbackRecordRequest{TenantID: &tenantID, SourceType: &sourceType}, uuid.New(), time.Now())
+	if !hasUpdates {
+		t.Fatal("buildUpdateQuery hasUpdates = false, want true")
+	}
+
+	for _, want := range []string{"source_type = $1", "tenant_id = $2", "WHERE id = $4 AND tenant_id = $5"} {
+		if !strings.Contains(query, want) {
+			t.Fatalf("query missing %q\nquery: %s", want, query)
+		}
+	}
+
+	if strings.Contains(query, "CASE WHEN") {
+		t.Fatalf("a tenant or source change must not clear enrichment outputs\nquery: %s", query)
+	}
+
+	if args[1] != tenantID {
+		t.Fatalf("args[1] = %#v, want %q", args[1], tenantID)
+	}
+}
+
 // TestBuildFilterConditions_PlaceholdersMatchArgs locks that every generated $N placeholder maps to
 // its argument's 1-based position for any combination of filters. The placeholder is derived from
 // len(args)+1 at each append precisely so the order of filters can't desync it — this guards
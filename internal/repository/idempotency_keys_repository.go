@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/formbricks/hub/internal/models"
+)
+
+// IdempotencyKeyHash is the stored form of an Idempotency-Key: the hex SHA-256 of the raw value,
+// so keys derived from client data never sit in the table verbatim.
+func IdempotencyKeyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// createIdempotent runs create for a request carrying an Idempotency-Key, on a transaction that
+// already holds the tenant write lock. Keys are scoped to the tenant: a key stored for tenantID and
+// endpoint within models.IdempotencyKeyTTL returns its record (marked Replayed) without calling
+// create; otherwise create runs and the key is stored with the new record, replacing an expired
+// row. Another tenant's use of the same key is a different key. Concurrent requests with the same
+// key serialize on a per-key advisory lock, so exactly one of them creates.
+//
+// A key whose record was soft-deleted, or moved to another tenant, counts as expired, since only
+// include_deleted reads may return a deleted record and no read crosses tenants.
+func createIdempotent(
+	ctx context.Context, dbTx tenantWriteTx, key, endpoint, tenantID string,
+	create func() (*models.FeedbackRecord, error),
+) (*models.FeedbackRecord, error) {
+	keyHash := IdempotencyKeyHash(key)
+
+	if _, err := dbTx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`,
+		"idempotency_key|"+tenantID+"|"+endpoint+"|"+keyHash); err != nil {
+		return nil, fmt.Errorf("lock idempotency key: %w", err)
+	}
+
+	existing, err := scanFeedbackRecord(dbTx.QueryRow(ctx, `
+		SELECT `+feedbackRecordColumns+`
+		FROM feedback_records
+		WHERE id = (
+			SELECT feedback_record_id FROM idempotency_keys
+			WHERE tenant_id = $1 AND key_hash = $2 AND endpoint = $3
+				AND created_at > NOW() - make_interval(secs => $4)
+		) AND tenant_id = $1 AND deleted_at IS NULL`,
+		tenantID, keyHash, endpoint, models.IdempotencyKeyTTL.Seconds()))
+	if err == nil {
+		existing.Replayed = true
+
+		return existing, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("look up idempotency key: %w", err)
+	}
+
+	record, err := create()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dbTx.Exec(ctx, `
+		INSERT INTO idempotency_keys (tenant_id, key_hash, endpoint, feedback_record_id, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (tenant_id, key_hash, endpoint)
+		DO UPDATE SET feedback_record_id = EXCLUDED.feedback_record_id, created_at = EXCLUDED.created_at`,
+		tenantID, keyHash, endpoint, record.ID); err != nil {
+		return nil, fmt.Errorf("store idempotency key: %w", err)
+	}
+
+	return record, nil
+}
+
+// IdempotencyKeysRepository maintains the idempotency_keys table outside the create path.
+type IdempotencyKeysRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewIdempotencyKeysRepository creates a new idempotency keys repository.
+func NewIdempotencyKeysRepository(db *pgxpool.Pool) *IdempotencyKeysRepository {
+	return &IdempotencyKeysRepository{db: db}
+}
+
+// DeleteExpired deletes keys created before cutoff, batchSize rows per DELETE so a large cleanup
+// never holds long row locks, and returns the total deleted. A concurrent create only ever writes
+// rows newer than any cutoff in the past, so the two never contend.
+func (r *IdempotencyKeysRepository) DeleteExpired(ctx context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	var total int64
+
+	for {
+		tag, err := r.db.Exec(ctx, `
+			DELETE FROM idempotency_keys WHERE (tenant_id, key_hash, endpoint) IN (
+				SELECT tenant_id, key_hash, endpoint FROM idempotency_keys WHERE created_at < $1 LIMIT $2
+			)`, cutoff, batchSize)
+		if err != nil {
+			return total, fmt.Errorf("delete expired idempotency keys: %w", err)
+		}
+
+		deleted := tag.RowsAffected()
+		total += deleted
+
+		if deleted < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
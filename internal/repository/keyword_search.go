@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/formbricks/hub/internal/models"
+)
+
+// likeEscaper escapes the LIKE wildcards (and the escape character itself) in a search term, so a
+// term matches literally under Postgres' default backslash ESCAPE.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// KeywordSearchFeedbackRecords returns the tenant's text records whose value_text contains any of
+// terms (case-insensitive substring match, ILIKE), best first: by how many of the terms they
+// contain, then newest. Score is the fraction of terms matched, in (0, 1].
+//
+// It is the keyword half of hybrid search and lives with the nearest-neighbor queries it is fused
+// with. The substring match has no index behind it, so it scans the tenant's text records; callers
+// bound the terms and the limit (service.HybridSearch).
+func (r *EmbeddingsRepository) KeywordSearchFeedbackRecords(
+	ctx context.Context, tenantID string, terms []string, limit int,
+) ([]models.FeedbackRecordWithScore, error) {
+	if len(terms) == 0 {
+		return []models.FeedbackRecordWithScore{}, nil
+	}
+
+	patterns := make([]string, len(terms))
+	for i, term := range terms {
+		patterns[i] = "%" + likeEscaper.Replace(term) + "%"
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, COALESCE(field_label, ''), value_text, hits
+		FROM (
+			SELECT fr.id, fr.field_label, fr.value_text, fr.collected_at,
+				(SELECT COUNT(*) FROM unnest($2::text[]) AS p(pattern) WHERE fr.value_text ILIKE p.pattern) AS hits
+			FROM feedback_records fr
+			WHERE fr.tenant_id = $1 AND fr.deleted_at IS NULL AND fr.field_type = $3
+				AND fr.value_text ILIKE ANY($2::text[])
+		) matched
+		ORDER BY hits DESC, collected_at DESC, id
+		LIMIT $4`, tenantID, patterns, models.FieldTypeText, limit)
+	if err != nil {
+		return nil, fmt.Errorf("keyword search feedback records: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.FeedbackRecordWithScore{}
+
+	for rows.Next() {
+		var (
+			row  models.FeedbackRecordWithScore
+			hits int
+		)
+
+		if err := rows.Scan(&row.FeedbackRecordID, &row.FieldLabel, &row.ValueText, &hits); err != nil {
+			return nil, fmt.Errorf("scan keyword search result: %w", err)
+		}
+
+		row.Score = float64(hits) / float64(len(terms))
+		results = append(results, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate keyword search results: %w", err)
+	}
+
+	return results, nil
+}
@@ -22,6 +22,7 @@ import (
 const (
 	defaultTaxonomyRunsLimit       = 20
 	defaultTaxonomyNodeRecordLimit = 50
+	defaultRelatedTopicsLimit      = 5
 )
 
 var (
@@ -67,7 +68,7 @@ func (r *TaxonomyRepository) ListFieldOptions(
 			COUNT(e.feedback_record_id)::int
 		FROM feedback_records fr
 		LEFT JOIN embeddings e ON e.feedback_record_id = fr.id AND e.model = $2
-		WHERE fr.tenant_id = $1
+		WHERE fr.tenant_id = $1 AND fr.deleted_at IS NULL
 		  AND COALESCE(NULLIF(btrim(fr.value_text_translated), ''), NULLIF(btrim(fr.value_text), '')) IS NOT NULL
 		GROUP BY fr.tenant_id, fr.source_type, COALESCE(NULLIF(btrim(fr.source_id), ''), ''), fr.field_id
 		ORDER BY fr.source_type, COALESCE(NULLIF(btrim(fr.source_id), ''), ''), fr.field_id`,
@@ -124,7 +125,7 @@ func (r *TaxonomyRepository) CountScopeInput(
 				COUNT(e.feedback_record_id)::int
 			FROM feedback_records fr
 			LEFT JOIN embeddings e ON e.feedback_record_id = fr.id AND e.model = $2
-			WHERE fr.tenant_id = $1
+			WHERE fr.tenant_id = $1 AND fr.deleted_at IS NULL
 			  AND COALESCE(NULLIF(btrim(fr.value_text_translated), ''), NULLIF(btrim(fr.value_text), '')) IS NOT NULL`,
 			scope.TenantID, embeddingModel,
 		).Scan(&recordCount, &embeddingCount)
@@ -142,7 +143,7 @@ func (r *TaxonomyRepository) CountScopeInput(
 			MAX(fr.field_label) FILTER (WHERE fr.field_label IS NOT NULL AND btrim(fr.field_label) <> '')
 		FROM feedback_records fr
 		LEFT JOIN embeddings e ON e.feedback_record_id = fr.id AND e.model = $5
-		WHERE fr.tenant_id = $1
+		WHERE fr.tenant_id = $1 AND fr.deleted_at IS NULL
 		  AND fr.source_type = $2
 		  AND NULLIF(btrim(fr.source_id), '') IS NOT DISTINCT FROM NULLIF(btrim($3), '')
 		  AND fr.field_id = $4
@@ -329,6 +330,46 @@ func (r *TaxonomyRepository) MarkRunFailed(
 	return run, nil
 }
 
+// CancelRun transitions a pending or running taxonomy run to canceled. Canceling frees the
+// scope's in-progress guard in CreateRunIfAvailable immediately, so a new run can start without
+// waiting for the taxonomy service to notice. A run that already reached a terminal state is a
+// conflict; an unknown id (or another tenant's run) is not found.
+func (r *TaxonomyRepository) CancelRun(
+	ctx context.Context,
+	runID uuid.UUID,
+	tenantID string,
+) (*models.TaxonomyRun, error) {
+	var run *models.TaxonomyRun
+
+	err := withTenantWritePoolTx(ctx, r.db, []string{tenantID}, func(dbTx tenantWriteTx) error {
+		updated, err := queryTaxonomyRun(ctx, dbTx, `
+			WITH taxonomy_runs AS (
+				UPDATE taxonomy_runs
+				SET status = 'canceled', finished_at = NOW(), updated_at = NOW()
+				WHERE id = $1 AND tenant_id = $2 AND status IN ('pending', 'running')
+				RETURNING *
+			)`+taxonomyRunSelect+` FROM taxonomy_runs`,
+			runID, tenantID,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return r.transitionError(ctx, dbTx, runID, tenantID, models.TaxonomyRunStatusCanceled)
+			}
+
+			return fmt.Errorf("cancel taxonomy run: %w", err)
+		}
+
+		run = updated
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
 // Heartbeat bumps updated_at to NOW() for a run still in a non-terminal state (pending/running),
 // keeping it out of the stuck-run reaper's reach for another timeout window. The taxonomy service
 // calls this periodically while a generation is in flight; updated_at is the liveness signal the
@@ -928,7 +969,12 @@ func (r *TaxonomyRepository) CountNodeRecords(
 		)
 		SELECT subtree.ancestor_id, COUNT(DISTINCT tcm.feedback_record_id)
 		FROM subtree
-		LEFT JOIN taxonomy_cluster_memberships tcm
+		LEFT JOIN (
+			-- Soft-deleted records keep their memberships until purged but no longer count.
+			taxonomy_cluster_memberships tcm
+			INNER JOIN feedback_records fr
+				ON fr.id = tcm.feedback_record_id AND fr.tenant_id = tcm.tenant_id AND fr.deleted_at IS NULL
+		)
 			ON tcm.run_id = $1
 			AND tcm.tenant_id = $2
 			AND tcm.cluster_id = subtree.cluster_id
@@ -958,6 +1004,104 @@ func (r *TaxonomyRepository) CountNodeRecords(
 	return counts, nil
 }
 
+// ListRelatedTopics returns the visible topics of the tenant's active taxonomies whose centroid is
+// closest to the record's embedding under embeddingModel, best first. Nodes carry no vector of
+// their own, so a topic's centroid is the mean of its cluster members' embeddings, computed per
+// request: a tenant has a handful of active runs with tens of topics each, and this keeps the
+// result in step with node moves, removals and member deletions without a stored copy to refresh.
+// Only nodes backed by a cluster are scored; a branch created by hand has no members of its own.
+// Returns a not-found error when the record is not the tenant's and ErrEmbeddingNotFound when it
+// has no embedding under embeddingModel yet.
+func (r *TaxonomyRepository) ListRelatedTopics(
+	ctx context.Context,
+	feedbackRecordID uuid.UUID,
+	tenantID string,
+	embeddingModel string,
+	limit int,
+) ([]models.RelatedTopic, int, error) {
+	if limit <= 0 {
+		limit = defaultRelatedTopicsLimit
+	}
+
+	var embedded bool
+
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM embeddings e WHERE e.feedback_record_id = fr.id AND e.model = $3
+		)
+		FROM feedback_records fr
+		WHERE fr.id = $1 AND fr.tenant_id = $2 AND fr.deleted_at IS NULL`,
+		feedbackRecordID, tenantID, embeddingModel,
+	).Scan(&embedded)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, huberrors.NewNotFoundError("feedback record", "feedback record not found")
+		}
+
+		return nil, 0, fmt.Errorf("check related topics source embedding: %w", err)
+	}
+
+	if !embedded {
+		return nil, 0, ErrEmbeddingNotFound
+	}
+
+	rows, err := r.db.Query(ctx, `
+		WITH RECURSIVE visible_nodes AS (
+			-- The root stands for the whole field scope, so paths start at the top-level topics.
+			SELECT tn.id, tn.run_id, tn.cluster_id, tn.label, tn.level, ARRAY[]::text[] AS path
+			FROM taxonomy_active_runs ar
+			INNER JOIN taxonomy_nodes tn ON tn.run_id = ar.run_id
+			WHERE ar.tenant_id = $2 AND tn.parent_id IS NULL AND tn.removed_at IS NULL
+			UNION ALL
+			SELECT child.id, child.run_id, child.cluster_id, child.label, child.level, parent.path || child.label
+			FROM visible_nodes parent
+			INNER JOIN taxonomy_nodes child ON child.parent_id = parent.id AND child.run_id = parent.run_id
+			WHERE child.removed_at IS NULL
+		),
+		source AS (
+			SELECT embedding FROM embeddings WHERE feedback_record_id = $1 AND model = $3
+		),
+		centroids AS (
+			SELECT vn.id, vn.run_id, vn.label, vn.level, vn.path, AVG(e.embedding) AS centroid
+			FROM visible_nodes vn
+			INNER JOIN taxonomy_cluster_memberships tcm
+				ON tcm.run_id = vn.run_id AND tcm.cluster_id = vn.cluster_id AND tcm.tenant_id = $2
+			-- A soft-deleted member no longer pulls its topic's centroid.
+			INNER JOIN feedback_records fr
+				ON fr.id = tcm.feedback_record_id AND fr.tenant_id = tcm.tenant_id AND fr.deleted_at IS NULL
+			INNER JOIN embeddings e ON e.feedback_record_id = tcm.feedback_record_id AND e.model = $3
+			GROUP BY vn.id, vn.run_id, vn.label, vn.level, vn.path
+		)
+		SELECT c.id, c.run_id, c.label, c.level, c.path, 1 - (c.centroid <=> s.embedding)
+		FROM centroids c
+		CROSS JOIN source s
+		ORDER BY c.centroid <=> s.embedding, c.id
+		LIMIT $4`,
+		feedbackRecordID, tenantID, embeddingModel, limit,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list related topics: %w", err)
+	}
+	defer rows.Close()
+
+	topics := make([]models.RelatedTopic, 0, limit)
+
+	for rows.Next() {
+		var topic models.RelatedTopic
+		if err := rows.Scan(&topic.NodeID, &topic.RunID, &topic.Label, &topic.Level, &topic.Path, &topic.Score); err != nil {
+			return nil, 0, fmt.Errorf("scan related topic: %w", err)
+		}
+
+		topics = append(topics, topic)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate related topics: %w", err)
+	}
+
+	return topics, limit, nil
+}
+
 // RenameNode updates a taxonomy node label and records an edit event.
 func (r *TaxonomyRepository) RenameNode(
 	ctx context.Context,
@@ -1037,6 +1181,693 @@ func (r *TaxonomyRepository) RemoveNode(
 	return updated, nil
 }
 
+// RemoveNodes soft-removes several of a tenant's taxonomy nodes in one transaction, exactly as
+// RemoveNode does for one (an edit event per node), and reports each node's impact: the visible
+// descendants hidden with it and the feedback records assigned in its subtree. Ids that are not a
+// visible node of the tenant — unknown, already removed, or another tenant's — are reported
+// not_found rather than failing the batch. The trees of every run involved are locked (in run id
+// order, after the tenant lock) before the impact is measured, so a concurrent move cannot change
+// a subtree between the count and the removal. With dryRun nothing is written.
+func (r *TaxonomyRepository) RemoveNodes(
+	ctx context.Context,
+	nodeIDs []uuid.UUID,
+	tenantID string,
+	actorID string,
+	dryRun bool,
+) (*models.RemoveTaxonomyNodesResponse, error) {
+	var result *models.RemoveTaxonomyNodesResponse
+
+	err := withTenantWritePoolTx(ctx, r.db, []string{tenantID}, func(dbTx tenantWriteTx) error {
+		rows, err := dbTx.Query(ctx, `
+			SELECT DISTINCT n.run_id
+			FROM taxonomy_nodes n
+			JOIN taxonomy_runs r ON r.id = n.run_id AND r.tenant_id = $2
+			WHERE n.id = ANY($1)
+			ORDER BY n.run_id`,
+			nodeIDs, tenantID,
+		)
+		if err != nil {
+			return fmt.Errorf("resolve taxonomy node runs: %w", err)
+		}
+
+		var runIDs []uuid.UUID
+
+		for rows.Next() {
+			var runID uuid.UUID
+			if err := rows.Scan(&runID); err != nil {
+				rows.Close()
+
+				return fmt.Errorf("scan taxonomy node run: %w", err)
+			}
+
+			runIDs = append(runIDs, runID)
+		}
+
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("iterate taxonomy node runs: %w", err)
+		}
+
+		for _, runID := range runIDs {
+			if _, err := dbTx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`,
+				taxonomyTreeEditLockKey(runID)); err != nil {
+				return fmt.Errorf("lock taxonomy run tree: %w", err)
+			}
+		}
+
+		result, err = queryTaxonomyRemovalImpact(ctx, dbTx, nodeIDs, tenantID, dryRun)
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			return nil
+		}
+
+		for _, res := range result.Results {
+			if res.Status != models.TaxonomyNodeRemoved {
+				continue
+			}
+
+			_, run, err := getNodeForUpdate(ctx, dbTx, res.ID, tenantID)
+			if err != nil {
+				return err
+			}
+
+			if _, err := dbTx.Exec(ctx, `
+				UPDATE taxonomy_nodes
+				SET removed_at = NOW(), removed_by = $2, updated_at = NOW()
+				WHERE id = $1`,
+				res.ID, actorID,
+			); err != nil {
+				return fmt.Errorf("remove taxonomy node: %w", err)
+			}
+
+			if err := insertNodeEvent(ctx, dbTx, run, res.ID, "soft_remove", actorID,
+				map[string]any{"removed_at": nil},
+				map[string]string{"removed_by": actorID}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// queryTaxonomyRemovalImpact measures what removing nodeIDs hides, per node and in total. One
+// recursive walk collects every requested node's visible subtree; the grouping set () adds a
+// total row (root_id NULL) whose DISTINCT counts de-duplicate overlapping subtrees.
+func queryTaxonomyRemovalImpact(
+	ctx context.Context, dbTx tenantWriteTx, nodeIDs []uuid.UUID, tenantID string, dryRun bool,
+) (*models.RemoveTaxonomyNodesResponse, error) {
+	rows, err := dbTx.Query(ctx, `
+		WITH RECURSIVE subtree AS (
+			SELECT n.id AS root_id, n.id, n.run_id, n.cluster_id
+			FROM taxonomy_nodes n
+			JOIN taxonomy_runs r ON r.id = n.run_id AND r.tenant_id = $2
+			WHERE n.id = ANY($1) AND n.removed_at IS NULL
+			UNION ALL
+			SELECT s.root_id, child.id, child.run_id, child.cluster_id
+			FROM subtree s
+			JOIN taxonomy_nodes child ON child.parent_id = s.id AND child.run_id = s.run_id
+			WHERE child.removed_at IS NULL
+		)
+		SELECT s.root_id, COUNT(DISTINCT s.id), COUNT(DISTINCT tcm.feedback_record_id)
+		FROM subtree s
+		LEFT JOIN (
+			-- Soft-deleted records keep their memberships until purged but are not affected.
+			taxonomy_cluster_memberships tcm
+			INNER JOIN feedback_records fr
+				ON fr.id = tcm.feedback_record_id AND fr.tenant_id = tcm.tenant_id AND fr.deleted_at IS NULL
+		)
+			ON tcm.run_id = s.run_id
+			AND tcm.tenant_id = $2
+			AND tcm.cluster_id = s.cluster_id
+		GROUP BY GROUPING SETS ((s.root_id), ())`,
+		nodeIDs, tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("measure taxonomy node removal: %w", err)
+	}
+	defer rows.Close()
+
+	type impact struct{ nodes, feedback int64 }
+
+	perNode := make(map[uuid.UUID]impact, len(nodeIDs))
+
+	var total impact
+
+	for rows.Next() {
+		var (
+			rootID *uuid.UUID
+			counts impact
+		)
+
+		if err := rows.Scan(&rootID, &counts.nodes, &counts.feedback); err != nil {
+			return nil, fmt.Errorf("scan taxonomy node removal impact: %w", err)
+		}
+
+		if rootID == nil {
+			total = counts
+		} else {
+			perNode[*rootID] = counts
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate taxonomy node removal impact: %w", err)
+	}
+
+	found := models.TaxonomyNodeRemoved
+	if dryRun {
+		found = models.TaxonomyNodeRemovable
+	}
+
+	result := &models.RemoveTaxonomyNodesResponse{
+		DryRun:           dryRun,
+		Results:          make([]models.RemoveTaxonomyNodeResult, 0, len(nodeIDs)),
+		FeedbackAffected: total.feedback,
+	}
+
+	for _, id := range nodeIDs {
+		counts, ok := perNode[id]
+		if !ok {
+			result.Results = append(result.Results, models.RemoveTaxonomyNodeResult{ID: id, Status: models.TaxonomyNodeNotFound})
+
+			continue
+		}
+
+		result.RemovedCount++
+		result.Results = append(result.Results, models.RemoveTaxonomyNodeResult{
+			ID: id, Status: found, DescendantsAffected: counts.nodes - 1, FeedbackAffected: counts.feedback,
+		})
+	}
+
+	// The total's distinct nodes include every requested node found; the rest are descendants.
+	result.DescendantsAffected = total.nodes - int64(result.RemovedCount)
+
+	return result, nil
+}
+
+// MoveNode re-parents a visible taxonomy node under another visible, non-leaf node of the same
+// run, shifting the level of the node and its whole subtree (removed descendants included, so
+// their levels stay consistent if restored) and appending it after its new siblings. Moves of one
+// run are serialized on a run-scoped advisory lock, taken after the tenant lock and before any row
+// lock per the lock-order convention: two concurrent moves could otherwise each pass the cycle
+// check and together form a cycle. A move that would put any subtree node deeper than maxLevel is
+// rejected. Moving a node to its current parent is a no-op and records no event.
+func (r *TaxonomyRepository) MoveNode(
+	ctx context.Context,
+	nodeID uuid.UUID,
+	tenantID string,
+	actorID string,
+	parentID uuid.UUID,
+	maxLevel int,
+) (*models.TaxonomyNode, error) {
+	var moved *models.TaxonomyNode
+
+	err := withTenantWritePoolTx(ctx, r.db, []string{tenantID}, func(dbTx tenantWriteTx) error {
+		if err := lockTaxonomyNodeRunTree(ctx, dbTx, nodeID, tenantID); err != nil {
+			return err
+		}
+
+		node, run, err := getNodeForUpdate(ctx, dbTx, nodeID, tenantID)
+		if err != nil {
+			return err
+		}
+
+		if node.ParentID == nil {
+			return huberrors.NewValidationError("node_id", "the root node cannot be moved")
+		}
+
+		if *node.ParentID == parentID {
+			moved = node
+
+			return nil
+		}
+
+		parent, err := queryTaxonomyNode(ctx, dbTx, taxonomyNodeSelect+`
+			FROM taxonomy_nodes
+			WHERE id = $1 AND run_id = $2 AND removed_at IS NULL
+			FOR UPDATE`,
+			parentID, node.RunID,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return huberrors.NewValidationError("parent_id", "must be a visible node of the same taxonomy run")
+			}
+
+			return fmt.Errorf("lock taxonomy parent node: %w", err)
+		}
+
+		if parent.NodeType == models.TaxonomyNodeTypeLeaf {
+			return huberrors.NewValidationError("parent_id", "a leaf node cannot have children")
+		}
+
+		var (
+			parentInSubtree bool
+			subtreeMaxLevel int
+		)
+
+		if err := dbTx.QueryRow(ctx, `
+			WITH RECURSIVE subtree AS (
+				SELECT id, level FROM taxonomy_nodes WHERE id = $1
+				UNION ALL
+				SELECT child.id, child.level
+				FROM taxonomy_nodes child
+				JOIN subtree ON child.parent_id = subtree.id AND child.run_id = $2
+			)
+			SELECT COALESCE(bool_or(id = $3), false), MAX(level) FROM subtree`,
+			nodeID, node.RunID, parentID,
+		).Scan(&parentInSubtree, &subtreeMaxLevel); err != nil {
+			return fmt.Errorf("inspect taxonomy node subtree: %w", err)
+		}
+
+		if parentInSubtree {
+			return huberrors.NewValidationError("parent_id", "must not be the node itself or one of its descendants")
+		}
+
+		levelShift := parent.Level + 1 - node.Level
+		if subtreeMaxLevel+levelShift > maxLevel {
+			return huberrors.NewValidationError("parent_id",
+				fmt.Sprintf("move would place nodes at level %d; the maximum is %d", subtreeMaxLevel+levelShift, maxLevel))
+		}
+
+		if levelShift != 0 {
+			if _, err := dbTx.Exec(ctx, `
+				WITH RECURSIVE subtree AS (
+					SELECT id FROM taxonomy_nodes WHERE id = $1
+					UNION ALL
+					SELECT child.id
+					FROM taxonomy_nodes child
+					JOIN subtree ON child.parent_id = subtree.id AND child.run_id = $2
+				)
+				UPDATE taxonomy_nodes
+				SET level = level + $3, updated_at = NOW()
+				WHERE run_id = $2 AND id IN (SELECT id FROM subtree)`,
+				nodeID, node.RunID, levelShift,
+			); err != nil {
+				return fmt.Errorf("shift taxonomy subtree levels: %w", err)
+			}
+		}
+
+		moved, err = queryTaxonomyNode(ctx, dbTx, `
+			WITH taxonomy_nodes AS (
+				UPDATE taxonomy_nodes
+				SET parent_id = $2,
+					sort_order = (
+						SELECT COALESCE(MAX(sort_order) + 1, 0)
+						FROM taxonomy_nodes
+						WHERE run_id = $3 AND parent_id = $2
+					),
+					updated_at = NOW()
+				WHERE id = $1
+				RETURNING *
+			)`+taxonomyNodeSelect+` FROM taxonomy_nodes`,
+			nodeID, parentID, node.RunID,
+		)
+		if err != nil {
+			return fmt.Errorf("move taxonomy node: %w", err)
+		}
+
+		return insertNodeEvent(ctx, dbTx, run, nodeID, "move", actorID,
+			map[string]any{"parent_id": node.ParentID, "level": node.Level},
+			map[string]any{"parent_id": parentID, "level": moved.Level})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+}
+
+// MergeNode merges a visible taxonomy node into another visible node of the same run, to
+// consolidate near-duplicate topics. The node's feedback records move with its cluster
+// memberships: into the target's cluster, or, when the target has no cluster of its own, the
+// target adopts the node's cluster. Its children are re-parented under the target (appended after
+// the target's own children, subtree levels shifted to match), and the node is soft-removed with a
+// merge event. Merges take the same run-scoped tree-edit lock as MoveNode, so a concurrent move
+// cannot combine with one into a cycle. Merging a node into itself or one of its descendants, the
+// root, children into a leaf, or children past maxLevel is rejected; a target outside the node's
+// run (another tenant's included) is a validation error.
+func (r *TaxonomyRepository) MergeNode(
+	ctx context.Context,
+	nodeID uuid.UUID,
+	tenantID string,
+	actorID string,
+	intoID uuid.UUID,
+	maxLevel int,
+) (*models.MergeTaxonomyNodeResponse, error) {
+	result := &models.MergeTaxonomyNodeResponse{}
+
+	err := withTenantWritePoolTx(ctx, r.db, []string{tenantID}, func(dbTx tenantWriteTx) error {
+		if err := lockTaxonomyNodeRunTree(ctx, dbTx, nodeID, tenantID); err != nil {
+			return err
+		}
+
+		node, run, err := getNodeForUpdate(ctx, dbTx, nodeID, tenantID)
+		if err != nil {
+			return err
+		}
+
+		if node.ParentID == nil {
+			return huberrors.NewValidationError("node_id", "the root node cannot be merged")
+		}
+
+		into, err := queryTaxonomyNode(ctx, dbTx, taxonomyNodeSelect+`
+			FROM taxonomy_nodes
+			WHERE id = $1 AND run_id = $2 AND removed_at IS NULL
+			FOR UPDATE`,
+			intoID, node.RunID,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return huberrors.NewValidationError("into_node_id", "must be a visible node of the same taxonomy run")
+			}
+
+			return fmt.Errorf("lock taxonomy merge target node: %w", err)
+		}
+
+		var (
+			intoInSubtree      bool
+			descendantMaxLevel int
+			childCount         int
+		)
+
+		if err := dbTx.QueryRow(ctx, `
+			WITH RECURSIVE subtree AS (
+				SELECT id, level FROM taxonomy_nodes WHERE id = $1
+				UNION ALL
+				SELECT child.id, child.level
+				FROM taxonomy_nodes child
+				JOIN subtree ON child.parent_id = subtree.id AND child.run_id = $2
+			)
+			SELECT COALESCE(bool_or(id = $3), false),
+				COALESCE(MAX(level) FILTER (WHERE id <> $1), 0),
+				(SELECT COUNT(*) FROM taxonomy_nodes WHERE run_id = $2 AND parent_id = $1)
+			FROM subtree`,
+			nodeID, node.RunID, intoID,
+		).Scan(&intoInSubtree, &descendantMaxLevel, &childCount); err != nil {
+			return fmt.Errorf("inspect taxonomy node subtree: %w", err)
+		}
+
+		if intoInSubtree {
+			return huberrors.NewValidationError("into_node_id", "must not be the node itself or one of its descendants")
+		}
+
+		// Children land one level below the target, where they sat one level below the node.
+		levelShift := into.Level - node.Level
+
+		if childCount > 0 {
+			if into.NodeType == models.TaxonomyNodeTypeLeaf {
+				return huberrors.NewValidationError("into_node_id", "a leaf node cannot have children")
+			}
+
+			if descendantMaxLevel+levelShift > maxLevel {
+				return huberrors.NewValidationError("into_node_id",
+					fmt.Sprintf("merge would place nodes at level %d; the maximum is %d",
+						descendantMaxLevel+levelShift, maxLevel))
+			}
+
+			if err := mergeTaxonomyNodeChildren(ctx, dbTx, node, intoID, levelShift); err != nil {
+				return err
+			}
+		}
+
+		recordsMoved, err := mergeTaxonomyNodeMemberships(ctx, dbTx, node, into)
+		if err != nil {
+			return err
+		}
+
+		// The merged node owns nothing any more: its records follow the target either way.
+		if _, err := dbTx.Exec(ctx, `
+			UPDATE taxonomy_nodes
+			SET removed_at = NOW(), removed_by = $2, cluster_id = NULL, updated_at = NOW()
+			WHERE id = $1`,
+			nodeID, actorID,
+		); err != nil {
+			return fmt.Errorf("remove merged taxonomy node: %w", err)
+		}
+
+		// A target without a cluster adopts the merged node's, which carries its records.
+		updated, err := queryTaxonomyNode(ctx, dbTx, `
+			WITH taxonomy_nodes AS (
+				UPDATE taxonomy_nodes
+				SET cluster_id = COALESCE(cluster_id, $2), updated_at = NOW()
+				WHERE id = $1
+				RETURNING *
+			)`+taxonomyNodeSelect+` FROM taxonomy_nodes`,
+			intoID, node.ClusterID,
+		)
+		if err != nil {
+			return fmt.Errorf("update taxonomy merge target node: %w", err)
+		}
+
+		var visibleChildren int
+		if err := dbTx.QueryRow(ctx, `
+			SELECT COUNT(*) FROM taxonomy_nodes
+			WHERE run_id = $1 AND parent_id = $2 AND removed_at IS NULL`,
+			node.RunID, intoID,
+		).Scan(&visibleChildren); err != nil {
+			return fmt.Errorf("count taxonomy merge target children: %w", err)
+		}
+
+		result.Node = *updated
+		result.ChildCount = visibleChildren
+		result.ChildrenMoved = childCount
+		result.RecordsMoved = recordsMoved
+
+		return insertNodeEvent(ctx, dbTx, run, nodeID, "merge", actorID,
+			map[string]any{"parent_id": node.ParentID, "cluster_id": node.ClusterID},
+			map[string]any{"into_node_id": intoID, "children_moved": childCount, "records_moved": recordsMoved})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// mergeTaxonomyNodeChildren re-parents every child of node (removed ones too, so a restore stays
+// consistent) under intoID, after the target's existing children, shifting their subtrees' levels.
+func mergeTaxonomyNodeChildren(
+	ctx context.Context, dbTx tenantWriteTx, node *models.TaxonomyNode, intoID uuid.UUID, levelShift int,
+) error {
+	if levelShift != 0 {
+		if _, err := dbTx.Exec(ctx, `
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM taxonomy_nodes WHERE run_id = $2 AND parent_id = $1
+				UNION ALL
+				SELECT child.id
+				FROM taxonomy_nodes child
+				JOIN subtree ON child.parent_id = subtree.id AND child.run_id = $2
+			)
+			UPDATE taxonomy_nodes
+			SET level = level + $3, updated_at = NOW()
+			WHERE run_id = $2 AND id IN (SELECT id FROM subtree)`,
+			node.ID, node.RunID, levelShift,
+		); err != nil {
+			return fmt.Errorf("shift merged taxonomy subtree levels: %w", err)
+		}
+	}
+
+	if _, err := dbTx.Exec(ctx, `
+		UPDATE taxonomy_nodes
+		SET parent_id = $2,
+			sort_order = sort_order + (
+				SELECT COALESCE(MAX(sort_order) + 1, 0)
+				FROM taxonomy_nodes
+				WHERE run_id = $3 AND parent_id = $2
+			),
+			updated_at = NOW()
+		WHERE run_id = $3 AND parent_id = $1`,
+		node.ID, intoID, node.RunID,
+	); err != nil {
+		return fmt.Errorf("re-parent merged taxonomy children: %w", err)
+	}
+
+	return nil
+}
+
+// mergeTaxonomyNodeMemberships moves node's feedback records to into and returns how many moved.
+// When into has a cluster, node's memberships are reassigned to it and both cluster sizes follow;
+// otherwise into adopts node's cluster (set by the caller) and the records come along unchanged.
+func mergeTaxonomyNodeMemberships(
+	ctx context.Context, dbTx tenantWriteTx, node, into *models.TaxonomyNode,
+) (int64, error) {
+	if node.ClusterID == nil {
+		return 0, nil
+	}
+
+	if into.ClusterID != nil && *into.ClusterID == *node.ClusterID {
+		// Both nodes already share the cluster: nothing moves.
+		return 0, nil
+	}
+
+	if into.ClusterID == nil {
+		var count int64
+		if err := dbTx.QueryRow(ctx, `
+			SELECT COUNT(*) FROM taxonomy_cluster_memberships WHERE run_id = $1 AND cluster_id = $2`,
+			node.RunID, *node.ClusterID,
+		).Scan(&count); err != nil {
+			return 0, fmt.Errorf("count merged taxonomy memberships: %w", err)
+		}
+
+		return count, nil
+	}
+
+	tag, err := dbTx.Exec(ctx, `
+		UPDATE taxonomy_cluster_memberships
+		SET cluster_id = $3
+		WHERE run_id = $1 AND cluster_id = $2`,
+		node.RunID, *node.ClusterID, *into.ClusterID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("reassign merged taxonomy memberships: %w", err)
+	}
+
+	moved := tag.RowsAffected()
+
+	if _, err := dbTx.Exec(ctx, `
+		UPDATE taxonomy_clusters
+		SET size = CASE WHEN id = $3 THEN size + $4 ELSE 0 END, updated_at = NOW()
+		WHERE run_id = $1 AND id IN ($2, $3)`,
+		node.RunID, *node.ClusterID, *into.ClusterID, moved,
+	); err != nil {
+		return 0, fmt.Errorf("update merged taxonomy cluster sizes: %w", err)
+	}
+
+	return moved, nil
+}
+
+// taxonomyNodeDepthCTE computes every node's depth by walking down from the roots. Nodes not
+// reachable from a root cannot exist (the tree-shape check requires a parent for every non-root,
+// and a single parent per node rules out a reachable cycle), so the walk covers the whole table.
+const taxonomyNodeDepthCTE = `
+	WITH RECURSIVE tree AS (
+		SELECT id, run_id, 0 AS depth FROM taxonomy_nodes WHERE parent_id IS NULL
+		UNION ALL
+		SELECT child.id, child.run_id, tree.depth + 1
+		FROM taxonomy_nodes child
+		JOIN tree ON child.parent_id = tree.id AND child.run_id = tree.run_id
+	)`
+
+// ListNodeLevelDrift returns, per run, how many nodes have a level that differs from their depth
+// in the tree. Levels drift only through writes that bypass MoveNode (imports, direct SQL); the
+// result drives the fix-topic-levels command and is read-only.
+func (r *TaxonomyRepository) ListNodeLevelDrift(ctx context.Context) ([]models.TaxonomyNodeLevelDrift, error) {
+	rows, err := r.db.Query(ctx, taxonomyNodeDepthCTE+`
+		SELECT n.run_id, runs.tenant_id, COUNT(*)
+		FROM tree
+		JOIN taxonomy_nodes n ON n.id = tree.id
+		JOIN taxonomy_runs runs ON runs.id = n.run_id
+		WHERE n.level <> tree.depth
+		GROUP BY n.run_id, runs.tenant_id
+		ORDER BY n.run_id`)
+	if err != nil {
+		return nil, fmt.Errorf("list taxonomy node level drift: %w", err)
+	}
+	defer rows.Close()
+
+	drift := []models.TaxonomyNodeLevelDrift{}
+
+	for rows.Next() {
+		var d models.TaxonomyNodeLevelDrift
+		if err := rows.Scan(&d.RunID, &d.TenantID, &d.DriftedNodes); err != nil {
+			return nil, fmt.Errorf("scan taxonomy node level drift: %w", err)
+		}
+
+		drift = append(drift, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate taxonomy node level drift: %w", err)
+	}
+
+	return drift, nil
+}
+
+// RepairNodeLevels sets every node of one run to its depth in the tree and returns how many
+// nodes changed. It runs under the tenant write lock and the run's tree-edit lock, like MoveNode,
+// so it neither races a purge nor interleaves with a concurrent move shifting the same levels.
+func (r *TaxonomyRepository) RepairNodeLevels(ctx context.Context, runID uuid.UUID, tenantID string) (int64, error) {
+	var fixed int64
+
+	err := withTenantWritePoolTx(ctx, r.db, []string{tenantID}, func(dbTx tenantWriteTx) error {
+		if _, err := dbTx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`,
+			taxonomyTreeEditLockKey(runID)); err != nil {
+			return fmt.Errorf("lock taxonomy run tree: %w", err)
+		}
+
+		tag, err := dbTx.Exec(ctx, `
+			WITH RECURSIVE tree AS (
+				SELECT id, 0 AS depth FROM taxonomy_nodes WHERE run_id = $1 AND parent_id IS NULL
+				UNION ALL
+				SELECT child.id, tree.depth + 1
+				FROM taxonomy_nodes child
+				JOIN tree ON child.parent_id = tree.id AND child.run_id = $1
+			)
+			UPDATE taxonomy_nodes n
+			SET level = tree.depth, updated_at = NOW()
+			FROM tree
+			WHERE n.id = tree.id AND n.run_id = $1 AND n.level <> tree.depth`,
+			runID,
+		)
+		if err != nil {
+			return fmt.Errorf("repair taxonomy node levels: %w", err)
+		}
+
+		fixed = tag.RowsAffected()
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return fixed, nil
+}
+
+// taxonomyTreeEditLockKey is the advisory lock key serializing structural edits of one run's tree.
+func taxonomyTreeEditLockKey(runID uuid.UUID) string {
+	return "taxonomy_tree_edit|" + runID.String()
+}
+
+// lockTaxonomyNodeRunTree resolves the run of a tenant's node and takes that run's tree-edit
+// advisory lock, which serializes structural edits (moves, merges) of one tree. It runs after the
+// tenant lock and before any row lock, per the lock-order convention.
+func lockTaxonomyNodeRunTree(ctx context.Context, dbTx tenantWriteTx, nodeID uuid.UUID, tenantID string) error {
+	var runID uuid.UUID
+
+	err := dbTx.QueryRow(ctx, `
+		SELECT n.run_id
+		FROM taxonomy_nodes n
+		JOIN taxonomy_runs r ON r.id = n.run_id AND r.tenant_id = $2
+		WHERE n.id = $1`,
+		nodeID, tenantID,
+	).Scan(&runID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return huberrors.NewNotFoundError("taxonomy_node", "taxonomy node not found")
+		}
+
+		return fmt.Errorf("resolve taxonomy node run: %w", err)
+	}
+
+	if _, err := dbTx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`,
+		taxonomyTreeEditLockKey(runID)); err != nil {
+		return fmt.Errorf("lock taxonomy run tree: %w", err)
+	}
+
+	return nil
+}
+
 // ListNodeRecords returns feedback records assigned to a visible taxonomy node or descendants.
 func (r *TaxonomyRepository) ListNodeRecords(
 	ctx context.Context,
@@ -1066,12 +1897,12 @@ func (r *TaxonomyRepository) ListNodeRecords(
 			fr.metadata, fr.language, fr.user_id, fr.tenant_id, fr.submission_id,
 			fr.value_text_translated, fr.translation_lang_key,
 			fr.sentiment, fr.sentiment_score,
-			fr.emotions
+			fr.emotions, fr.tags, fr.deleted_at
 		FROM visible_nodes vn
 		INNER JOIN taxonomy_runs tr ON tr.id = vn.run_id
 		INNER JOIN taxonomy_cluster_memberships tcm ON tcm.run_id = vn.run_id AND tcm.cluster_id = vn.cluster_id
 		INNER JOIN feedback_records fr ON fr.id = tcm.feedback_record_id AND fr.tenant_id = tcm.tenant_id
-		WHERE tr.tenant_id = $2
+		WHERE tr.tenant_id = $2 AND fr.deleted_at IS NULL
 		ORDER BY fr.collected_at DESC, fr.id ASC
 		LIMIT $3`,
 		nodeID, tenantID, limit,
@@ -1117,7 +1948,7 @@ func (r *TaxonomyRepository) queryRunInputRows(
 				e.embedding
 			FROM feedback_records fr
 			INNER JOIN embeddings e ON e.feedback_record_id = fr.id AND e.model = $3
-			WHERE fr.tenant_id = $1
+			WHERE fr.tenant_id = $1 AND fr.deleted_at IS NULL
 			  AND COALESCE(NULLIF(btrim(fr.value_text_translated), ''), NULLIF(btrim(fr.value_text), '')) IS NOT NULL
 			ORDER BY fr.collected_at DESC, fr.id ASC
 			LIMIT $2`,
@@ -1141,7 +1972,7 @@ func (r *TaxonomyRepository) queryRunInputRows(
 			e.embedding
 		FROM feedback_records fr
 		INNER JOIN embeddings e ON e.feedback_record_id = fr.id AND e.model = $6
-		WHERE fr.tenant_id = $1
+		WHERE fr.tenant_id = $1 AND fr.deleted_at IS NULL
 		  AND fr.source_type = $2
 		  AND NULLIF(btrim(fr.source_id), '') IS NOT DISTINCT FROM NULLIF(btrim($3), '')
 		  AND fr.field_id = $4
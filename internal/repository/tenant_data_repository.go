@@ -175,6 +175,14 @@ func deleteTenantDataInTx(
 		return nil, fmt.Errorf("delete tenant settings: %w", err)
 	}
 
+	// export_jobs rows are tenant-owned (they snapshot the tenant's filters). Not counted either;
+	// the export files under EXPORT_DIR are outside the database and this transaction.
+	if _, err = exec.Exec(ctx, `
+		DELETE FROM export_jobs
+		WHERE tenant_id = $1`, tenantID); err != nil {
+		return nil, fmt.Errorf("delete tenant export jobs: %w", err)
+	}
+
 	return &models.TenantDataDeleteCounts{
 		DeletedFeedbackRecords:            feedbackRecordsTag.RowsAffected(),
 		DeletedEmbeddings:                 embeddingTag.RowsAffected(),
@@ -57,7 +57,7 @@ func purgeLockTags() []pgconn.CommandTag {
 	}
 }
 
-// tenantDeleteTags returns command tags for the ten DELETE statements
+// tenantDeleteTags returns command tags for the eleven DELETE statements
 // deleteTenantDataInTx issues, in execution order, each with a distinct row
 // count so tests can assert the per-table count mapping (see
 // assertTenantDeleteCounts).
@@ -73,6 +73,7 @@ func tenantDeleteTags() []pgconn.CommandTag {
 		pgconn.NewCommandTag("DELETE 3"),  // feedback_records
 		pgconn.NewCommandTag("DELETE 1"),  // webhooks
 		pgconn.NewCommandTag("DELETE 99"), // tenant_settings (count not surfaced)
+		pgconn.NewCommandTag("DELETE 98"), // export_jobs (count not surfaced)
 	}
 }
 
@@ -127,8 +128,8 @@ func TestTenantDataRepository_DeleteByTenant(t *testing.T) {
 			t.Fatal("deferred rollback was not called")
 		}
 
-		if len(transaction.queries) != 13 {
-			t.Fatalf("queries = %d, want 13 (3 lock statements + 10 deletes)", len(transaction.queries))
+		if len(transaction.queries) != 14 {
+			t.Fatalf("queries = %d, want 14 (3 lock statements + 11 deletes)", len(transaction.queries))
 		}
 
 		assertQueryContains(t, transaction.queries[0], "set_config('lock_timeout', $1, true)")
@@ -251,8 +252,8 @@ func TestDeleteTenantDataInTx(t *testing.T) {
 
 		assertTenantDeleteCounts(t, counts)
 
-		if len(exec.queries) != 10 {
-			t.Fatalf("queries = %d, want 10", len(exec.queries))
+		if len(exec.queries) != 11 {
+			t.Fatalf("queries = %d, want 11", len(exec.queries))
 		}
 
 		// Children before parents, with taxonomy_runs deleted after the
@@ -268,6 +269,7 @@ func TestDeleteTenantDataInTx(t *testing.T) {
 		assertQueryContains(t, exec.queries[7], "DELETE FROM feedback_records")
 		assertQueryContains(t, exec.queries[8], "DELETE FROM webhooks")
 		assertQueryContains(t, exec.queries[9], "DELETE FROM tenant_settings")
+		assertQueryContains(t, exec.queries[10], "DELETE FROM export_jobs")
 
 		// taxonomy_nodes and taxonomy_clusters have no tenant_id column, so they
 		// must be scoped through their run via a taxonomy_runs subquery.
@@ -364,7 +366,7 @@ func TestDeleteTenantDataInTx(t *testing.T) {
 	})
 
 	t.Run("stops after tenant settings delete error", func(t *testing.T) {
-		// tenant_settings is the tenth (final) delete.
+		// tenant_settings is the tenth delete.
 		exec := &fakeTenantDataExecutor{tags: tenantDeleteTags(), errAtQuery: 10}
 
 		counts, err := deleteTenantDataInTx(context.Background(), exec, "org-123")
@@ -382,6 +384,22 @@ func TestDeleteTenantDataInTx(t *testing.T) {
 
 		assertQueryContains(t, exec.queries[9], "DELETE FROM tenant_settings")
 	})
+
+	t.Run("stops after export jobs delete error", func(t *testing.T) {
+		// export_jobs is the eleventh (final) delete.
+		exec := &fakeTenantDataExecutor{tags: tenantDeleteTags(), errAtQuery: 11}
+
+		counts, err := deleteTenantDataInTx(context.Background(), exec, "org-123")
+		if err == nil {
+			t.Fatal("deleteTenantDataInTx() error = nil, want error")
+		}
+
+		if counts != nil {
+			t.Fatalf("counts = %+v, want nil", counts)
+		}
+
+		assertQueryContains(t, exec.queries[10], "DELETE FROM export_jobs")
+	})
 }
 
 func assertQueryContains(t *testing.T, query, want string) {
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+)
+
+const webhookDeadLetterColumns = `id, webhook_id, tenant_id, event_id, event_type, payload, last_status, error, attempts,
+	retried_at, created_at`
+
+// CreateDeadLetter records a permanently failed delivery. Like Create it is gated on the tenant
+// write lock in a single statement, so a tenant purge never races a dead letter back in; a
+// refused lock is a tenant write conflict. A webhook deleted meanwhile is a not-found error.
+func (r *WebhooksRepository) CreateDeadLetter(ctx context.Context, req *models.CreateWebhookDeadLetter) error {
+	const lockKeyParam = 9 // $9, after the 8 inserted columns
+
+	query := `
+		INSERT INTO webhook_dead_letters (
+			webhook_id, tenant_id, event_id, event_type, payload, last_status, error, attempts
+		)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8
+		WHERE EXISTS (SELECT 1 FROM webhooks WHERE id = $1)
+			AND ` + tenantWriteLockGate(lockKeyParam)
+
+	tag, err := r.db.Exec(ctx, query,
+		req.WebhookID, req.TenantID, req.EventID, req.EventType, req.Payload, req.LastStatus, req.Error, req.Attempts,
+		TenantWriteLockKey(req.TenantID),
+	)
+	if err != nil {
+		return fmt.Errorf("create webhook dead letter: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		if _, err := r.GetByID(ctx, req.WebhookID); err != nil {
+			return err
+		}
+
+		return huberrors.NewTenantWriteConflictError("tenant data purge in progress for this tenant; retry later")
+	}
+
+	return nil
+}
+
+// ListDeadLetters returns one page of a webhook's dead letters, newest first (created_at DESC,
+// id ASC). A nil cursorCreatedAt returns the first page; otherwise the page starts after the
+// (cursorCreatedAt, cursorID) keyset. Fetches limit+1 as sentinel to determine hasMore.
+func (r *WebhooksRepository) ListDeadLetters(
+	ctx context.Context, webhookID uuid.UUID, limit int, cursorCreatedAt *time.Time, cursorID uuid.UUID,
+) ([]models.WebhookDeadLetter, bool, error) {
+	query := `SELECT ` + webhookDeadLetterColumns + ` FROM webhook_dead_letters WHERE webhook_id = $1`
+	args := []any{webhookID}
+
+	if cursorCreatedAt != nil {
+		query += " AND (created_at < $2 OR (created_at = $2 AND id > $3))"
+
+		args = append(args, *cursorCreatedAt, cursorID)
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id ASC LIMIT $%d", len(args)+1)
+
+	args = append(args, limit+1)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("list webhook dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	deadLetters := []models.WebhookDeadLetter{}
+
+	for rows.Next() {
+		deadLetter, err := scanWebhookDeadLetter(rows)
+		if err != nil {
+			return nil, false, fmt.Errorf("scan webhook dead letter: %w", err)
+		}
+
+		deadLetters = append(deadLetters, *deadLetter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("iterate webhook dead letters: %w", err)
+	}
+
+	hasMore := len(deadLetters) > limit
+	if hasMore {
+		deadLetters = deadLetters[:limit]
+	}
+
+	return deadLetters, hasMore, nil
+}
+
+// GetDeadLetter returns one of a webhook's dead letters; one belonging to another webhook is not found.
+func (r *WebhooksRepository) GetDeadLetter(ctx context.Context, webhookID, id uuid.UUID) (*models.WebhookDeadLetter, error) {
+	deadLetter, err := scanWebhookDeadLetter(r.db.QueryRow(ctx,
+		`SELECT `+webhookDeadLetterColumns+` FROM webhook_dead_letters WHERE id = $1 AND webhook_id = $2`, id, webhookID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, huberrors.NewNotFoundError("webhook dead letter", "webhook dead letter not found")
+		}
+
+		return nil, fmt.Errorf("get webhook dead letter: %w", err)
+	}
+
+	return deadLetter, nil
+}
+
+// MarkDeadLetterRetried stamps retried_at on a dead letter of the tenant that was re-enqueued.
+// Like the other tenant-owned writes it holds the tenant's shared write lock, so it conflicts with
+// a purge of the tenant instead of racing it; a dead letter gone meanwhile is not found.
+func (r *WebhooksRepository) MarkDeadLetterRetried(
+	ctx context.Context, tenantID string, id uuid.UUID, at time.Time,
+) error {
+	return withTenantWritePoolTx(ctx, r.db, []string{tenantID}, func(dbTx tenantWriteTx) error {
+		tag, err := dbTx.Exec(ctx,
+			`UPDATE webhook_dead_letters SET retried_at = $2 WHERE id = $1 AND tenant_id = $3`, id, at, tenantID)
+		if err != nil {
+			return fmt.Errorf("mark webhook dead letter retried: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return huberrors.NewNotFoundError("webhook dead letter", "webhook dead letter not found")
+		}
+
+		return nil
+	})
+}
+
+func scanWebhookDeadLetter(row scanner) (*models.WebhookDeadLetter, error) {
+	var d models.WebhookDeadLetter
+
+	if err := row.Scan(
+		&d.ID, &d.WebhookID, &d.TenantID, &d.EventID, &d.EventType, &d.Payload, &d.LastStatus, &d.Error, &d.Attempts,
+		&d.RetriedAt, &d.CreatedAt,
+	); err != nil {
+		return nil, err //nolint:wrapcheck // callers wrap and check pgx.ErrNoRows
+	}
+
+	return &d, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+)
+
+// webhookDeliveriesKept is how many of a webhook's latest delivery attempts are kept; older ones
+// are trimmed as new ones are recorded.
+const webhookDeliveriesKept = 200
+
+const webhookDeliveryColumns = `id, webhook_id, tenant_id, event_id, event_type, attempt, status, status_code,
+	response_body_truncated, error, duration_ms, next_retry_at, created_at`
+
+// CreateDelivery records one delivery attempt and trims the webhook's attempts to the latest
+// webhookDeliveriesKept, in one statement. Like CreateDeadLetter it is gated on the tenant write
+// lock, so a tenant purge never races an attempt back in; a refused lock is a tenant write
+// conflict. A webhook deleted meanwhile is a not-found error.
+func (r *WebhooksRepository) CreateDelivery(ctx context.Context, req *models.CreateWebhookDelivery) error {
+	const lockKeyParam = 12 // $12, after the 11 inserted columns
+
+	// The trim runs on the snapshot from before the insert, so it keeps one row fewer than the
+	// limit ($13) to make room for the new one.
+	query := `
+		WITH inserted AS (
+			INSERT INTO webhook_deliveries (
+				webhook_id, tenant_id, event_id, event_type, attempt, status, status_code,
+				response_body_truncated, error, duration_ms, next_retry_at
+			)
+			SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+			WHERE EXISTS (SELECT 1 FROM webhooks WHERE id = $1)
+				AND ` + tenantWriteLockGate(lockKeyParam) + `
+			RETURNING id
+		), trimmed AS (
+			DELETE FROM webhook_deliveries
+			WHERE id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE webhook_id = $1 AND EXISTS (SELECT 1 FROM inserted)
+				ORDER BY created_at DESC, id DESC
+				OFFSET $13
+			)
+		)
+		SELECT COUNT(*) FROM inserted`
+
+	var inserted int
+
+	err := r.db.QueryRow(ctx, query,
+		req.WebhookID, req.TenantID, req.EventID, req.EventType, req.Attempt, req.Status, req.StatusCode,
+		req.ResponseBodyTruncated, req.Error, req.DurationMs, req.NextRetryAt,
+		TenantWriteLockKey(req.TenantID), webhookDeliveriesKept-1,
+	).Scan(&inserted)
+	if err != nil {
+		return fmt.Errorf("create webhook delivery: %w", err)
+	}
+
+	if inserted == 0 {
+		if _, err := r.GetByID(ctx, req.WebhookID); err != nil {
+			return err
+		}
+
+		return huberrors.NewTenantWriteConflictError("tenant data purge in progress for this tenant; retry later")
+	}
+
+	return nil
+}
+
+// ListDeliveries returns one page of a webhook's delivery attempts, newest first (created_at DESC,
+// id ASC). A nil cursorCreatedAt returns the first page; otherwise the page starts after the
+// (cursorCreatedAt, cursorID) keyset. Fetches limit+1 as sentinel to determine hasMore.
+func (r *WebhooksRepository) ListDeliveries(
+	ctx context.Context, webhookID uuid.UUID, limit int, cursorCreatedAt *time.Time, cursorID uuid.UUID,
+) ([]models.WebhookDelivery, bool, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE webhook_id = $1`
+	args := []any{webhookID}
+
+	if cursorCreatedAt != nil {
+		query += " AND (created_at < $2 OR (created_at = $2 AND id > $3))"
+
+		args = append(args, *cursorCreatedAt, cursorID)
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id ASC LIMIT $%d", len(args)+1)
+
+	args = append(args, limit+1)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+
+	for rows.Next() {
+		var d models.WebhookDelivery
+
+		if err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.TenantID, &d.EventID, &d.EventType, &d.Attempt, &d.Status, &d.StatusCode,
+			&d.ResponseBodyTruncated, &d.Error, &d.DurationMs, &d.NextRetryAt, &d.CreatedAt,
+		); err != nil {
+			return nil, false, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("iterate webhook deliveries: %w", err)
+	}
+
+	hasMore := len(deliveries) > limit
+	if hasMore {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, hasMore, nil
+}
@@ -495,7 +495,9 @@ func listEnabledForEventTypeAndTenantQuery(eventType string, tenantID *string) (
 		args = append(args, *tenantID)
 	}
 
-	query += ` ORDER BY id`
+	// Creation order: when an event matches more webhooks than the fan-out cap, the provider
+	// keeps the oldest ones, so which subscriptions fire is stable across events.
+	query += ` ORDER BY created_at, id`
 
 	return query, args
 }
@@ -48,7 +48,7 @@ func TestListEnabledForEventTypeAndTenantQuery(t *testing.T) {
 			assert.Contains(t, query, "event_types IS NULL OR event_types = '{}' OR event_types @> ARRAY[$1]::VARCHAR(64)[]")
 			assert.Contains(t, query, tt.wantTenantClause)
 			assert.NotContains(t, query, tt.rejectTenantClause)
-			assert.True(t, strings.HasSuffix(strings.TrimSpace(query), "ORDER BY id"))
+			assert.True(t, strings.HasSuffix(strings.TrimSpace(query), "ORDER BY created_at, id"))
 		})
 	}
 }
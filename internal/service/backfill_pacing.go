@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrBackfillInterrupted is returned by a backfill whose context was cancelled between pages
+// (SIGINT/SIGTERM in the backfill commands). The page in flight is always finished first, so the
+// jobs counted as enqueued are really in the queue; re-running the command resumes the scan, since
+// every target query excludes records that no longer need the work.
+var ErrBackfillInterrupted = errors.New("backfill interrupted")
+
+// BackfillPacing throttles the keyset-paged backfills so a large fan-out can run against a
+// production database without exhausting the pool or spiking replication lag. The zero value is
+// the historical behavior: one insert at a time, as fast as Postgres answers, no progress reports.
+type BackfillPacing struct {
+	// Concurrency is how many job inserts of a page run at once; <= 1 inserts sequentially.
+	Concurrency int
+	// Rate caps job inserts per second across all of them; <= 0 is unlimited.
+	Rate float64
+	// Total is the number of targets counted up front, only used for the ETA; 0 omits it.
+	Total int
+	// Progress, when set, is called after every page.
+	Progress func(BackfillProgress)
+}
+
+// BackfillProgress is a backfill's running tally, reported after every page.
+type BackfillProgress struct {
+	Name     string
+	Enqueued int
+	Skipped  int
+	Total    int
+	Elapsed  time.Duration
+}
+
+// Throughput is the targets handled (enqueued or skipped as duplicates) per second so far.
+func (p BackfillProgress) Throughput() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(p.Enqueued+p.Skipped) / p.Elapsed.Seconds()
+}
+
+// ETA estimates the time left at the current throughput, or false when no Total was counted or
+// nothing has been handled yet. Total is a snapshot taken before the scan, so records written
+// during the run can outlast it — the estimate is a guide, not a deadline.
+func (p BackfillProgress) ETA() (time.Duration, bool) {
+	throughput := p.Throughput()
+	if p.Total <= 0 || throughput <= 0 {
+		return 0, false
+	}
+
+	remaining := max(p.Total-p.Enqueued-p.Skipped, 0)
+
+	return time.Duration(float64(remaining) / throughput * float64(time.Second)), true
+}
+
+// insertLimiter spaces inserts evenly at a fixed rate across all workers of a backfill. A nil
+// limiter never waits. Slots are handed out in order, so a burst of workers queues up behind the
+// schedule instead of all firing at once after an idle spell.
+type insertLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newInsertLimiter(rate float64) *insertLimiter {
+	if rate <= 0 {
+		return nil
+	}
+
+	return &insertLimiter{interval: time.Duration(float64(time.Second) / rate)}
+}
+
+// wait blocks until the caller's slot comes up or ctx is done.
+func (l *insertLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("wait for insert slot: %w", context.Cause(ctx))
+	case <-timer.C:
+		return nil
+	}
+}
+
+// insertPaced runs insertOne for every item with at most concurrency inserts in flight, each
+// first taking a slot from limiter; insertOne reports whether River skipped the job as a
+// duplicate. It returns once every started insert has finished. On the first error no further
+// inserts start and that error is returned along with the counts so far.
+func insertPaced[T any](
+	ctx context.Context,
+	items []T,
+	concurrency int,
+	limiter *insertLimiter,
+	insertOne func(ctx context.Context, item T) (bool, error),
+) (int, int, error) {
+	var inserted, skipped atomic.Int64
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(concurrency, 1))
+
+	for _, item := range items {
+		if groupCtx.Err() != nil {
+			break
+		}
+
+		group.Go(func() error {
+			if err := limiter.wait(groupCtx); err != nil {
+				return err
+			}
+
+			duplicate, err := insertOne(groupCtx, item)
+			if err != nil {
+				return err
+			}
+
+			if duplicate {
+				skipped.Add(1)
+			} else {
+				inserted.Add(1)
+			}
+
+			return nil
+		})
+	}
+
+	err := group.Wait()
+
+	return int(inserted.Load()), int(skipped.Load()), err //nolint:wrapcheck // insertOne wraps its own errors
+}
+
+// LogBackfillProgress is the backfill commands' BackfillPacing.Progress: one log line per page
+// with the running tally, throughput and, when a total was counted, the ETA.
+func LogBackfillProgress(p BackfillProgress) {
+	attrs := []any{
+		"enqueued", p.Enqueued, "skipped", p.Skipped,
+		"per_second", strconv.FormatFloat(p.Throughput(), 'f', 1, 64),
+	}
+
+	if p.Total > 0 {
+		attrs = append(attrs, "total", p.Total)
+	}
+
+	if eta, ok := p.ETA(); ok {
+		attrs = append(attrs, "eta", eta.Round(time.Second).String())
+	}
+
+	slog.Info(p.Name+" backfill progress", attrs...)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPageIDs(n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.Must(uuid.NewV7())
+	}
+
+	return ids
+}
+
+func TestBackfillPaged_BoundsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+
+	var progress []BackfillProgress
+
+	enqueued, err := backfillPaged(t.Context(), BackfillPacing{
+		Concurrency: 3,
+		Total:       12,
+		Progress:    func(p BackfillProgress) { progress = append(progress, p) },
+	}, "test", 20,
+		func(afterID uuid.UUID) ([]uuid.UUID, error) { return newPageIDs(12), nil },
+		func(id uuid.UUID) uuid.UUID { return id },
+		func(context.Context, uuid.UUID) (bool, error) {
+			n := inFlight.Add(1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+
+			return false, nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, 12, enqueued)
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	require.Len(t, progress, 1)
+	assert.Equal(t, 12, progress[0].Enqueued)
+
+	eta, ok := progress[0].ETA()
+	assert.True(t, ok)
+	assert.Zero(t, eta, "nothing is left once the counted total is handled")
+}
+
+func TestBackfillPaged_RateLimitsInserts(t *testing.T) {
+	started := time.Now()
+
+	enqueued, err := backfillPaged(t.Context(), BackfillPacing{Concurrency: 4, Rate: 100}, "test", 20,
+		func(afterID uuid.UUID) ([]uuid.UUID, error) { return newPageIDs(6), nil },
+		func(id uuid.UUID) uuid.UUID { return id },
+		func(context.Context, uuid.UUID) (bool, error) { return false, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 6, enqueued)
+	// Six slots at 10ms spacing: the last one opens 50ms after the first.
+	assert.GreaterOrEqual(t, time.Since(started), 50*time.Millisecond)
+}
+
+func TestBackfillPaged_InterruptFinishesPageInFlight(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		inserted int
+		pages    int
+	)
+
+	enqueued, err := backfillPaged(ctx, BackfillPacing{Concurrency: 2}, "test", 4,
+		func(afterID uuid.UUID) ([]uuid.UUID, error) {
+			pages++
+
+			return newPageIDs(4), nil
+		},
+		func(id uuid.UUID) uuid.UUID { return id },
+		func(insertCtx context.Context, _ uuid.UUID) (bool, error) {
+			// The signal arrives mid-page; the page's inserts must not see it.
+			cancel()
+
+			if insertCtx.Err() != nil {
+				return false, insertCtx.Err()
+			}
+
+			mu.Lock()
+			inserted++
+			mu.Unlock()
+
+			return false, nil
+		})
+	require.ErrorIs(t, err, ErrBackfillInterrupted)
+	assert.Equal(t, 1, pages, "no page is fetched after the interrupt")
+	assert.Equal(t, 4, inserted)
+	assert.Equal(t, 4, enqueued)
+}
+
+func TestBackfillProgress_ETA(t *testing.T) {
+	p := BackfillProgress{Enqueued: 90, Skipped: 10, Total: 400, Elapsed: 10 * time.Second}
+	assert.InDelta(t, 10.0, p.Throughput(), 0.001)
+
+	eta, ok := p.ETA()
+	require.True(t, ok)
+	assert.Equal(t, 30*time.Second, eta)
+
+	_, ok = BackfillProgress{Enqueued: 5, Elapsed: time.Second}.ETA()
+	assert.False(t, ok, "no ETA without a counted total")
+}
@@ -91,47 +91,51 @@ func (s *FeedbackRecordsService) backfillClassifyPaged(
 ) (int, error) {
 	hash := "backfill:" + runID
 
-	return backfillPaged(name, classifyBackfillPageSize, fetchPage,
+	return backfillPaged(ctx, s.backfillPacing, name, classifyBackfillPageSize, fetchPage,
 		func(id uuid.UUID) uuid.UUID { return id },
-		func(ids []uuid.UUID) (int, int, error) {
-			inserted, skipped := 0, 0
-
-			for _, id := range ids {
-				res, insErr := inserter.Insert(ctx, buildArgs(id, hash), opts)
-				if insErr != nil {
-					return inserted, skipped, fmt.Errorf("enqueue %s backfill job for %s: %w", name, id, insErr)
-				}
-
-				if res != nil && res.UniqueSkippedAsDuplicate {
-					skipped++
-
-					continue
-				}
-
-				inserted++
+		func(ctx context.Context, id uuid.UUID) (bool, error) {
+			res, err := inserter.Insert(ctx, buildArgs(id, hash), opts)
+			if err != nil {
+				return false, fmt.Errorf("enqueue %s backfill job for %s: %w", name, id, err)
 			}
 
-			return inserted, skipped, nil
+			return res != nil && res.UniqueSkippedAsDuplicate, nil
 		})
 }
 
-// backfillPaged is the one keyset-paged backfill loop shared by the classify and translation
-// backfills: stream pages, insert each, count duplicate-skips, advance the cursor by the last
-// item seen (so a fully-deduped page cannot livelock), and stop on the first short page.
+// backfillPaged is the one keyset-paged backfill loop shared by the classify, translation and
+// embedding backfills: stream pages, insert each item through insertOne under pacing (bounded
+// concurrency, rate limit, progress reports), count duplicate-skips, advance the cursor by the
+// last item seen (so a fully-deduped page cannot livelock), and stop on the first short page.
+// Cancelling ctx stops the loop between pages with ErrBackfillInterrupted; the page in flight is
+// inserted under a context that ignores the cancellation, so it is never left half-enqueued.
 func backfillPaged[T any](
+	ctx context.Context,
+	pacing BackfillPacing,
 	name string,
 	pageSize int,
 	fetchPage func(afterID uuid.UUID) ([]T, error),
 	cursorID func(item T) uuid.UUID,
-	insertPage func(items []T) (inserted, skipped int, err error),
+	insertOne func(ctx context.Context, item T) (duplicate bool, err error),
 ) (int, error) {
 	enqueued := 0
 	skipped := 0
 	afterID := uuid.Nil
+	limiter := newInsertLimiter(pacing.Rate)
+	pageCtx := context.WithoutCancel(ctx)
+	started := time.Now()
 
 	for {
+		if ctx.Err() != nil {
+			return enqueued, fmt.Errorf("%s backfill: %w", name, ErrBackfillInterrupted)
+		}
+
 		items, err := fetchPage(afterID)
 		if err != nil {
+			if ctx.Err() != nil {
+				return enqueued, fmt.Errorf("%s backfill: %w", name, ErrBackfillInterrupted)
+			}
+
 			return enqueued, err
 		}
 
@@ -139,10 +143,16 @@ func backfillPaged[T any](
 			break
 		}
 
-		inserted, duplicates, err := insertPage(items)
+		inserted, duplicates, err := insertPaced(pageCtx, items, pacing.Concurrency, limiter, insertOne)
 		enqueued += inserted
 		skipped += duplicates
 
+		if pacing.Progress != nil {
+			pacing.Progress(BackfillProgress{
+				Name: name, Enqueued: enqueued, Skipped: skipped, Total: pacing.Total, Elapsed: time.Since(started),
+			})
+		}
+
 		if err != nil {
 			return enqueued, err
 		}
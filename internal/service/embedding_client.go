@@ -1,6 +1,14 @@
 package service
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrEmbeddingBatchMismatch is returned when a batch embedding call returns a different number of
+// vectors than it was given inputs.
+var ErrEmbeddingBatchMismatch = errors.New("embedding batch returned a mismatched number of vectors")
 
 // EmbeddingClient generates embedding vectors for text.
 // CreateEmbedding is for embedding documents (e.g. feedback records) for storage.
@@ -9,3 +17,39 @@ type EmbeddingClient interface {
 	CreateEmbedding(ctx context.Context, input string) ([]float32, error)
 	CreateEmbeddingForQuery(ctx context.Context, input string) ([]float32, error)
 }
+
+// BatchEmbeddingClient is implemented by embedding clients that can embed many documents in one
+// provider request (openai.Client, googleai.Client, and the retry wrapper around them).
+type BatchEmbeddingClient interface {
+	CreateEmbeddingsBatch(ctx context.Context, inputs []string) ([][]float32, error)
+}
+
+// CreateEmbeddings embeds inputs as documents, one vector per input in input order: in batched
+// requests when client implements BatchEmbeddingClient, otherwise one CreateEmbedding call each.
+func CreateEmbeddings(ctx context.Context, client EmbeddingClient, inputs []string) ([][]float32, error) {
+	if batcher, ok := client.(BatchEmbeddingClient); ok {
+		vectors, err := batcher.CreateEmbeddingsBatch(ctx, inputs)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // the provider clients wrap their own errors
+		}
+
+		if len(vectors) != len(inputs) {
+			return nil, fmt.Errorf("%w: got %d vectors for %d inputs", ErrEmbeddingBatchMismatch, len(vectors), len(inputs))
+		}
+
+		return vectors, nil
+	}
+
+	vectors := make([][]float32, len(inputs))
+
+	for i, input := range inputs {
+		vector, err := client.CreateEmbedding(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("embed input %d of %d: %w", i+1, len(inputs), err)
+		}
+
+		vectors[i] = vector
+	}
+
+	return vectors, nil
+}
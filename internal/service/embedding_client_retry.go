@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/openai"
+)
+
+// Backoff bounds between in-client embedding retries. Short on purpose: this loop only absorbs
+// blips (a reset connection, a one-off 502) inside one job attempt; anything longer-lived is
+// River's to retry and the rate-limit snooze's to wait out.
+const (
+	embeddingRetryBaseDelay = 250 * time.Millisecond
+	embeddingRetryMaxDelay  = 2 * time.Second
+)
+
+// hub_embedding_provider_errors_total reasons recorded by the retry wrapper: one per in-client
+// retry, one per provider 429, and one per call the client short-circuited inside a provider
+// Retry-After window (see openai.ErrRateLimitCooldown) without sending a request.
+const (
+	embeddingRetryReason             = "transient_retry"
+	embeddingRateLimitedReason       = "rate_limited"
+	embeddingRateLimitCooldownReason = "rate_limit_cooldown"
+)
+
+// EmbeddingRetryMetrics counts in-client retries of transient embedding failures and rate-limit hits.
+type EmbeddingRetryMetrics interface {
+	RecordProviderError(ctx context.Context, reason string)
+}
+
+// EmbeddingRetryConfig configures WithEmbeddingRetry.
+type EmbeddingRetryConfig struct {
+	// AttemptTimeout bounds each provider call, so a hung connection fails fast and is retried
+	// instead of holding the worker until the job timeout. 0 = no per-attempt bound.
+	AttemptTimeout time.Duration
+	// MaxRetries is how many times a transient failure is retried (0 = call once).
+	MaxRetries int
+	// Metrics may be nil (metrics disabled).
+	Metrics EmbeddingRetryMetrics
+}
+
+// retryingEmbeddingClient wraps an EmbeddingClient with a per-attempt timeout and a short,
+// bounded retry of transient failures. The provider SDKs' own retry loops stay disabled (see
+// openai.NewClient): they slept the full Retry-After and masked 429s as deadline errors. This
+// loop never retries a rate limit — those surface immediately so the worker can snooze — and
+// never sleeps past the caller's context.
+type retryingEmbeddingClient struct {
+	next EmbeddingClient
+	cfg  EmbeddingRetryConfig
+}
+
+// WithEmbeddingRetry wraps client with cfg's per-attempt timeout and transient-error retry.
+// It returns client unchanged when cfg enables neither.
+func WithEmbeddingRetry(client EmbeddingClient, cfg EmbeddingRetryConfig) EmbeddingClient {
+	if cfg.AttemptTimeout <= 0 && cfg.MaxRetries <= 0 {
+		return client
+	}
+
+	return &retryingEmbeddingClient{next: client, cfg: cfg}
+}
+
+// CreateEmbedding implements EmbeddingClient.
+func (c *retryingEmbeddingClient) CreateEmbedding(ctx context.Context, input string) ([]float32, error) {
+	return retryEmbeddingCall(ctx, c, func(ctx context.Context) ([]float32, error) {
+		return c.next.CreateEmbedding(ctx, input)
+	})
+}
+
+// CreateEmbeddingForQuery implements EmbeddingClient.
+func (c *retryingEmbeddingClient) CreateEmbeddingForQuery(ctx context.Context, input string) ([]float32, error) {
+	return retryEmbeddingCall(ctx, c, func(ctx context.Context) ([]float32, error) {
+		return c.next.CreateEmbeddingForQuery(ctx, input)
+	})
+}
+
+// CreateEmbeddingsBatch implements BatchEmbeddingClient, retrying the whole batch as one call. A
+// wrapped client without batch support is called once per input (see CreateEmbeddings).
+func (c *retryingEmbeddingClient) CreateEmbeddingsBatch(ctx context.Context, inputs []string) ([][]float32, error) {
+	return retryEmbeddingCall(ctx, c, func(ctx context.Context) ([][]float32, error) {
+		return CreateEmbeddings(ctx, c.next, inputs)
+	})
+}
+
+func retryEmbeddingCall[T any](ctx context.Context, c *retryingEmbeddingClient, call func(context.Context) (T, error)) (T, error) {
+	for attempt := 0; ; attempt++ {
+		result, err := attemptEmbeddingCall(ctx, c.cfg.AttemptTimeout, call)
+		c.recordRateLimit(ctx, err)
+
+		if err == nil || attempt >= c.cfg.MaxRetries || !isTransientEmbeddingError(ctx, err) {
+			return result, err
+		}
+
+		if c.cfg.Metrics != nil {
+			c.cfg.Metrics.RecordProviderError(ctx, embeddingRetryReason)
+		}
+
+		timer := time.NewTimer(embeddingRetryDelayWithJitter(attempt))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			var zero T
+
+			return zero, err // the provider error says more than the caller's cancellation
+		case <-timer.C:
+		}
+	}
+}
+
+func attemptEmbeddingCall[T any](ctx context.Context, timeout time.Duration, call func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return call(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return call(attemptCtx)
+}
+
+// recordRateLimit counts err when it is a rate limit, split by whether the provider returned the
+// 429 or the client refused the call during an earlier 429's Retry-After window.
+func (c *retryingEmbeddingClient) recordRateLimit(ctx context.Context, err error) {
+	var rateLimitErr *huberrors.RateLimitError
+	if c.cfg.Metrics == nil || !errors.As(err, &rateLimitErr) {
+		return
+	}
+
+	if errors.Is(err, openai.ErrRateLimitCooldown) {
+		c.cfg.Metrics.RecordProviderError(ctx, embeddingRateLimitCooldownReason)
+
+		return
+	}
+
+	c.cfg.Metrics.RecordProviderError(ctx, embeddingRateLimitedReason)
+}
+
+// isTransientEmbeddingError reports whether err is worth retrying within the same job attempt:
+// a provider 5xx, a network-level failure, or this loop's own per-attempt timeout. A cancelled
+// or expired caller context is never transient — there is no budget left to retry in.
+func isTransientEmbeddingError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var rateLimitErr *huberrors.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return false
+	}
+
+	if errors.Is(err, huberrors.ErrProviderUnavailable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
+
+// embeddingRetryDelayWithJitter returns a random delay between half and all of
+// embeddingRetryDelay(attempt). Workers sharing a provider hit the same blip together; without the
+// jitter they would all retry in the same instant and could cause the next one.
+func embeddingRetryDelayWithJitter(attempt int) time.Duration {
+	delay := embeddingRetryDelay(attempt)
+	half := delay / 2
+
+	//nolint:gosec // G404: jitter for backoff is not security-sensitive
+	return half + time.Duration(rand.Int64N(int64(delay-half)+1))
+}
+
+// embeddingRetryDelay returns the capped exponential backoff before retry attempt+1.
+func embeddingRetryDelay(attempt int) time.Duration {
+	delay := embeddingRetryBaseDelay << attempt
+	if delay <= 0 || delay > embeddingRetryMaxDelay {
+		return embeddingRetryMaxDelay
+	}
+
+	return delay
+}
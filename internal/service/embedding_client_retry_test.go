@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/openai"
+)
+
+type countingRetryMetrics struct{ reasons []string }
+
+func (m *countingRetryMetrics) RecordProviderError(_ context.Context, reason string) {
+	m.reasons = append(m.reasons, reason)
+}
+
+func TestWithEmbeddingRetry_RetriesTransientThenSucceeds(t *testing.T) {
+	calls := 0
+	next := &mockEmbeddingClient{createFunc: func(context.Context, string) ([]float32, error) {
+		calls++
+		if calls == 1 {
+			return nil, fmt.Errorf("%w: 502 bad gateway", huberrors.ErrProviderUnavailable)
+		}
+
+		return []float32{0.5}, nil
+	}}
+	metrics := &countingRetryMetrics{}
+	client := WithEmbeddingRetry(next, EmbeddingRetryConfig{MaxRetries: 2, Metrics: metrics})
+
+	vector, err := client.CreateEmbedding(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("CreateEmbedding() error = %v", err)
+	}
+
+	if calls != 2 || len(vector) != 1 {
+		t.Fatalf("calls = %d, vector = %v; want 2 calls and the second call's vector", calls, vector)
+	}
+
+	if len(metrics.reasons) != 1 || metrics.reasons[0] != embeddingRetryReason {
+		t.Fatalf("recorded reasons = %v, want one %q", metrics.reasons, embeddingRetryReason)
+	}
+}
+
+func TestWithEmbeddingRetry_DoesNotRetryRateLimit(t *testing.T) {
+	calls := 0
+	next := &mockEmbeddingClient{createFunc: func(context.Context, string) ([]float32, error) {
+		calls++
+
+		return nil, huberrors.NewRateLimitError(time.Second, errors.New("429"))
+	}}
+	client := WithEmbeddingRetry(next, EmbeddingRetryConfig{MaxRetries: 3})
+
+	_, err := client.CreateEmbedding(context.Background(), "hello")
+
+	var rateLimitErr *huberrors.RateLimitError
+	if !errors.As(err, &rateLimitErr) || calls != 1 {
+		t.Fatalf("err = %v after %d calls, want the rate-limit error after 1 call", err, calls)
+	}
+}
+
+func TestWithEmbeddingRetry_StopsAfterMaxRetries(t *testing.T) {
+	calls := 0
+	next := &mockEmbeddingClient{createQueryFunc: func(context.Context, string) ([]float32, error) {
+		calls++
+
+		return nil, huberrors.ErrProviderUnavailable
+	}}
+	client := WithEmbeddingRetry(next, EmbeddingRetryConfig{MaxRetries: 1})
+
+	_, err := client.CreateEmbeddingForQuery(context.Background(), "hello")
+	if !errors.Is(err, huberrors.ErrProviderUnavailable) || calls != 2 {
+		t.Fatalf("err = %v after %d calls, want ErrProviderUnavailable after 2 calls", err, calls)
+	}
+}
+
+func TestWithEmbeddingRetry_AttemptTimeoutRetriesHungCall(t *testing.T) {
+	calls := 0
+	next := &mockEmbeddingClient{createFunc: func(ctx context.Context, _ string) ([]float32, error) {
+		calls++
+		if calls == 1 {
+			<-ctx.Done()
+
+			return nil, ctx.Err()
+		}
+
+		return []float32{0.5}, nil
+	}}
+	client := WithEmbeddingRetry(next, EmbeddingRetryConfig{AttemptTimeout: 10 * time.Millisecond, MaxRetries: 1})
+
+	if _, err := client.CreateEmbedding(context.Background(), "hello"); err != nil || calls != 2 {
+		t.Fatalf("err = %v after %d calls, want success on the second call", err, calls)
+	}
+}
+
+func TestWithEmbeddingRetry_CancelledCallerIsNotRetried(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	next := &mockEmbeddingClient{createFunc: func(context.Context, string) ([]float32, error) {
+		calls++
+		cancel()
+
+		return nil, huberrors.ErrProviderUnavailable
+	}}
+	client := WithEmbeddingRetry(next, EmbeddingRetryConfig{MaxRetries: 3})
+
+	if _, err := client.CreateEmbedding(ctx, "hello"); err == nil || calls != 1 {
+		t.Fatalf("err = %v after %d calls, want an error after 1 call", err, calls)
+	}
+}
+
+func TestEmbeddingRetryDelay(t *testing.T) {
+	want := []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, time.Second, 2 * time.Second, 2 * time.Second}
+	for attempt, expected := range want {
+		if got := embeddingRetryDelay(attempt); got != expected {
+			t.Errorf("embeddingRetryDelay(%d) = %s, want %s", attempt, got, expected)
+		}
+	}
+
+	if got := embeddingRetryDelay(100); got != embeddingRetryMaxDelay {
+		t.Errorf("embeddingRetryDelay(100) = %s, want the cap", got)
+	}
+}
+
+func TestEmbeddingRetryDelayWithJitter(t *testing.T) {
+	for attempt := range 5 {
+		upper := embeddingRetryDelay(attempt)
+
+		for range 100 {
+			if got := embeddingRetryDelayWithJitter(attempt); got < upper/2 || got > upper {
+				t.Fatalf("embeddingRetryDelayWithJitter(%d) = %s, want within [%s, %s]", attempt, got, upper/2, upper)
+			}
+		}
+	}
+}
+
+func TestWithEmbeddingRetry_CountsRateLimits(t *testing.T) {
+	errs := []error{
+		huberrors.NewRateLimitError(time.Second, errors.New("429")),
+		huberrors.NewRateLimitError(time.Second, openai.ErrRateLimitCooldown),
+	}
+	next := &mockEmbeddingClient{createFunc: func(context.Context, string) ([]float32, error) {
+		err := errs[0]
+		errs = errs[1:]
+
+		return nil, err
+	}}
+	metrics := &countingRetryMetrics{}
+	client := WithEmbeddingRetry(next, EmbeddingRetryConfig{MaxRetries: 2, Metrics: metrics})
+
+	_, _ = client.CreateEmbedding(context.Background(), "hello")
+	_, _ = client.CreateEmbedding(context.Background(), "hello")
+
+	want := []string{embeddingRateLimitedReason, embeddingRateLimitCooldownReason}
+	if fmt.Sprint(metrics.reasons) != fmt.Sprint(want) {
+		t.Fatalf("recorded reasons = %v, want %v", metrics.reasons, want)
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/formbricks/hub/internal/huberrors"
+)
+
+// batchingEmbeddingClient is an EmbeddingClient that also embeds in batches.
+type batchingEmbeddingClient struct {
+	mockEmbeddingClient
+
+	batchFunc func(ctx context.Context, inputs []string) ([][]float32, error)
+}
+
+func (m *batchingEmbeddingClient) CreateEmbeddingsBatch(ctx context.Context, inputs []string) ([][]float32, error) {
+	return m.batchFunc(ctx, inputs)
+}
+
+func TestCreateEmbeddings_UsesBatchWhenSupported(t *testing.T) {
+	singleCalls := 0
+	client := &batchingEmbeddingClient{
+		mockEmbeddingClient: mockEmbeddingClient{createFunc: func(context.Context, string) ([]float32, error) {
+			singleCalls++
+
+			return []float32{0}, nil
+		}},
+		batchFunc: func(_ context.Context, inputs []string) ([][]float32, error) {
+			return [][]float32{{1}, {2}}[:len(inputs)], nil
+		},
+	}
+
+	vectors, err := CreateEmbeddings(context.Background(), client, []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+
+	if singleCalls != 0 || len(vectors) != 2 || vectors[1][0] != 2 {
+		t.Fatalf("single calls = %d, vectors = %v; want the batch's vectors and no single calls", singleCalls, vectors)
+	}
+}
+
+func TestCreateEmbeddings_RejectsMismatchedBatch(t *testing.T) {
+	client := &batchingEmbeddingClient{batchFunc: func(context.Context, []string) ([][]float32, error) {
+		return [][]float32{{1}}, nil
+	}}
+
+	_, err := CreateEmbeddings(context.Background(), client, []string{"a", "b"})
+	if !errors.Is(err, ErrEmbeddingBatchMismatch) {
+		t.Fatalf("CreateEmbeddings() error = %v, want ErrEmbeddingBatchMismatch", err)
+	}
+}
+
+func TestCreateEmbeddings_FallsBackToOneCallPerInput(t *testing.T) {
+	var seen []string
+
+	client := &mockEmbeddingClient{createFunc: func(_ context.Context, input string) ([]float32, error) {
+		seen = append(seen, input)
+
+		return []float32{float32(len(seen))}, nil
+	}}
+
+	vectors, err := CreateEmbeddings(context.Background(), client, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+
+	if len(seen) != 3 || seen[2] != "c" || len(vectors) != 3 || vectors[2][0] != 3 {
+		t.Fatalf("seen = %v, vectors = %v; want one call per input in order", seen, vectors)
+	}
+}
+
+func TestWithEmbeddingRetry_BatchRetriesTransient(t *testing.T) {
+	calls := 0
+	next := &batchingEmbeddingClient{batchFunc: func(_ context.Context, inputs []string) ([][]float32, error) {
+		calls++
+		if calls == 1 {
+			return nil, huberrors.ErrProviderUnavailable
+		}
+
+		return make([][]float32, len(inputs)), nil
+	}}
+	client := WithEmbeddingRetry(next, EmbeddingRetryConfig{MaxRetries: 2})
+
+	vectors, err := CreateEmbeddings(context.Background(), client, []string{"a", "b"})
+	if err != nil || calls != 2 || len(vectors) != 2 {
+		t.Fatalf("err = %v, calls = %d, vectors = %d; want success on the second batch call", err, calls, len(vectors))
+	}
+}
@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	feedbackEmbeddingKind = "feedback_embedding"
+	feedbackEmbeddingKind      = "feedback_embedding"
+	feedbackEmbeddingBatchKind = "feedback_embedding_batch"
 	// EmbeddingsQueueName is the River queue used for feedback embedding jobs.
 	EmbeddingsQueueName = "embeddings"
 )
@@ -28,9 +29,37 @@ type FeedbackEmbeddingArgs struct {
 	InputKind models.EmbeddingInputKind `json:"input_kind,omitempty"`
 	// ValueTextHash is a hash of the input (trimmed value_text, or "empty"/"backfill") for dedupe semantics.
 	ValueTextHash string `json:"value_text_hash" river:"unique"`
+	// TenantID is the record's tenant, so the backlog poller can attribute queued jobs
+	// (EmbeddingTenantBacklog). Empty when the enqueuer does not know it (backfills, legacy
+	// jobs); not part of the dedupe key.
+	TenantID string `json:"tenant_id,omitempty"`
 }
 
 // Kind returns the River job kind.
 func (FeedbackEmbeddingArgs) Kind() string { return feedbackEmbeddingKind }
 
 var _ river.JobArgs = FeedbackEmbeddingArgs{}
+
+// MaxEmbeddingBatchSize caps FeedbackEmbeddingBatchArgs.FeedbackRecordIDs. It matches the largest
+// provider request (OpenAI's 2048 inputs); providers with lower limits split the batch themselves.
+const MaxEmbeddingBatchSize = 2048
+
+// FeedbackEmbeddingBatchArgs is the job payload for embedding several feedback records with one
+// provider call, enqueued by the embedding backfill in batch mode and run by
+// FeedbackEmbeddingBatchWorker. Records the batch cannot finish are re-enqueued one by one as
+// FeedbackEmbeddingArgs, so a bad record never costs the rest of the batch a retry. Unique by
+// all fields but TenantID: re-running a backfill over the same pages dedupes against
+// still-pending batches.
+type FeedbackEmbeddingBatchArgs struct {
+	FeedbackRecordIDs []uuid.UUID               `json:"feedback_record_ids"  river:"unique"`
+	Model             string                    `json:"model"                river:"unique"`
+	InputKind         models.EmbeddingInputKind `json:"input_kind,omitempty" river:"unique"`
+	// TenantID is set when every record belongs to one tenant (batch creates group by tenant),
+	// as on FeedbackEmbeddingArgs.
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+// Kind returns the River job kind.
+func (FeedbackEmbeddingBatchArgs) Kind() string { return feedbackEmbeddingBatchKind }
+
+var _ river.JobArgs = FeedbackEmbeddingBatchArgs{}
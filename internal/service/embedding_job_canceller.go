@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	"github.com/formbricks/hub/internal/datatypes"
+)
+
+// embeddingCancelPageSize bounds each listing of a deleted batch's pending embedding jobs; a
+// delete-by-user can remove thousands of records at once.
+const embeddingCancelPageSize = 500
+
+// RiverJobCanceller lists and cancels River jobs (satisfied by *river.Client).
+type RiverJobCanceller interface {
+	JobList(ctx context.Context, params *river.JobListParams) (*river.JobListResult, error)
+	JobCancel(ctx context.Context, jobID int64) (*rivertype.JobRow, error)
+}
+
+// EmbeddingJobCanceller implements eventPublisher by cancelling the not-yet-finished
+// feedback_embedding jobs (every model, taxonomy included) of records named in a
+// FeedbackRecordDeleted event. Without it those jobs still run, find the record gone, and add
+// skipped outcomes and log noise after every delete; the stored vectors themselves need no
+// cleanup, since embeddings rows cascade with the record.
+//
+// A job already running is only marked for cancellation: River cancels its context, and if it
+// finishes first its not-found path still skips it. Cancelling is best-effort — a failure is
+// logged and the worker's not-found skip remains the backstop.
+type EmbeddingJobCanceller struct {
+	client RiverJobCanceller
+}
+
+// NewEmbeddingJobCanceller creates a canceller backed by client.
+func NewEmbeddingJobCanceller(client RiverJobCanceller) *EmbeddingJobCanceller {
+	return &EmbeddingJobCanceller{client: client}
+}
+
+// PublishEvent cancels the deleted records' pending embedding jobs.
+func (c *EmbeddingJobCanceller) PublishEvent(ctx context.Context, event Event) {
+	if event.Type != datatypes.FeedbackRecordDeleted {
+		return
+	}
+
+	ids := deletedEventIDs(event)
+	if len(ids) == 0 {
+		return
+	}
+
+	cancelled, err := c.CancelJobs(ctx, ids)
+	if err != nil {
+		slog.Warn("embedding: cancel jobs of deleted records failed",
+			"event_id", event.ID, "records", len(ids), "cancelled", cancelled, "error", err)
+
+		return
+	}
+
+	if cancelled > 0 {
+		slog.Info("embedding: cancelled jobs of deleted records",
+			"event_id", event.ID, "records", len(ids), "cancelled", cancelled)
+	}
+}
+
+// CancelJobs cancels the not-yet-finished embedding jobs of the given records and returns how many
+// it cancelled; on error the count so far is returned with it. Subject erasure calls it directly
+// to report the count.
+func (c *EmbeddingJobCanceller) CancelJobs(ctx context.Context, ids []uuid.UUID) (int, error) {
+	recordIDs := make([]string, len(ids))
+	for i, id := range ids {
+		recordIDs[i] = id.String()
+	}
+
+	params := river.NewJobListParams().
+		Kinds(feedbackEmbeddingKind).
+		States(rivertype.JobStateAvailable, rivertype.JobStateScheduled, rivertype.JobStateRetryable, rivertype.JobStateRunning).
+		Where("args->>'feedback_record_id' = ANY(@record_ids::text[])", river.NamedArgs{"record_ids": recordIDs}).
+		First(embeddingCancelPageSize)
+
+	cancelled := 0
+
+	for {
+		result, err := c.client.JobList(ctx, params)
+		if err != nil {
+			return cancelled, fmt.Errorf("list embedding jobs: %w", err)
+		}
+
+		for _, job := range result.Jobs {
+			if _, err := c.client.JobCancel(ctx, job.ID); err != nil {
+				if errors.Is(err, rivertype.ErrNotFound) {
+					continue // finished and cleaned up between the listing and the cancel
+				}
+
+				return cancelled, fmt.Errorf("cancel embedding job %d: %w", job.ID, err)
+			}
+
+			cancelled++
+		}
+
+		if len(result.Jobs) < embeddingCancelPageSize {
+			return cancelled, nil
+		}
+
+		params = params.After(result.LastCursor)
+	}
+}
+
+// Ensure EmbeddingJobCanceller implements eventPublisher.
+var _ eventPublisher = (*EmbeddingJobCanceller)(nil)
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/formbricks/hub/internal/datatypes"
+	"github.com/formbricks/hub/internal/models"
+)
+
+type fakeJobCanceller struct {
+	jobs      []*rivertype.JobRow
+	listCalls int
+	cancelled []int64
+	cancelErr map[int64]error
+}
+
+func (f *fakeJobCanceller) JobList(context.Context, *river.JobListParams) (*river.JobListResult, error) {
+	f.listCalls++
+
+	return &river.JobListResult{Jobs: f.jobs}, nil
+}
+
+func (f *fakeJobCanceller) JobCancel(_ context.Context, jobID int64) (*rivertype.JobRow, error) {
+	if err := f.cancelErr[jobID]; err != nil {
+		return nil, err
+	}
+
+	f.cancelled = append(f.cancelled, jobID)
+
+	return &rivertype.JobRow{ID: jobID, State: rivertype.JobStateCancelled}, nil
+}
+
+func TestEmbeddingJobCanceller_CancelsJobsOfDeletedRecords(t *testing.T) {
+	client := &fakeJobCanceller{
+		jobs:      []*rivertype.JobRow{{ID: 1}, {ID: 2}, {ID: 3}},
+		cancelErr: map[int64]error{2: rivertype.ErrNotFound},
+	}
+
+	NewEmbeddingJobCanceller(client).PublishEvent(context.Background(), Event{
+		Type: datatypes.FeedbackRecordDeleted,
+		Data: models.DeletedIDsEventData{TenantID: "org-1", IDs: []uuid.UUID{uuid.New()}},
+	})
+
+	assert.Equal(t, []int64{1, 3}, client.cancelled, "a job that finished meanwhile is skipped, not an error")
+}
+
+func TestEmbeddingJobCanceller_StopsOnCancelError(t *testing.T) {
+	client := &fakeJobCanceller{
+		jobs:      []*rivertype.JobRow{{ID: 1}, {ID: 2}},
+		cancelErr: map[int64]error{1: errors.New("db down")},
+	}
+
+	NewEmbeddingJobCanceller(client).PublishEvent(context.Background(), Event{
+		Type: datatypes.FeedbackRecordDeleted,
+		Data: &models.DeletedIDsEventData{IDs: []uuid.UUID{uuid.New()}},
+	})
+
+	assert.Empty(t, client.cancelled)
+}
+
+func TestEmbeddingJobCanceller_IgnoresOtherEvents(t *testing.T) {
+	client := &fakeJobCanceller{jobs: []*rivertype.JobRow{{ID: 1}}}
+	canceller := NewEmbeddingJobCanceller(client)
+
+	canceller.PublishEvent(context.Background(), Event{Type: datatypes.FeedbackRecordUpdated, Data: &models.FeedbackRecord{}})
+	canceller.PublishEvent(context.Background(), Event{Type: datatypes.FeedbackRecordDeleted, Data: models.DeletedIDsEventData{}})
+
+	assert.Zero(t, client.listCalls, "no listing without deleted ids")
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/formbricks/hub/internal/models"
+)
+
+// embeddingProbeInput is the fixed text embedded by ProbeEmbeddingClient: short, so the probe
+// costs a handful of tokens per deploy.
+const embeddingProbeInput = "formbricks hub embedding probe"
+
+// embeddingProbeTimeout bounds the whole probe (including the retry wrapper's attempts), so a
+// provider that hangs delays startup by at most this long.
+const embeddingProbeTimeout = 30 * time.Second
+
+// ErrEmbeddingProbeDimensions is returned by ProbeEmbeddingClient when the provider answers with
+// vectors the embeddings column cannot store.
+var ErrEmbeddingProbeDimensions = errors.New("embedding probe: unexpected vector dimensions")
+
+// ErrEmbeddingColumnDimensions is returned by CheckEmbeddingColumnDimensions when the embeddings
+// column was migrated to another size than models.EmbeddingVectorDimensions.
+var ErrEmbeddingColumnDimensions = errors.New("embeddings.embedding column dimensions do not match EmbeddingVectorDimensions")
+
+// EmbeddingColumnReader reads the declared dimension of the embeddings column.
+type EmbeddingColumnReader interface {
+	ColumnDimensions(ctx context.Context) (int, error)
+}
+
+// CheckEmbeddingColumnDimensions fails when the embeddings column cannot hold the vectors the
+// providers are asked for (models.EmbeddingVectorDimensions), so a schema out of step with the
+// build stops startup instead of failing every embedding write. Unlike the provider probe it
+// costs one catalog query and is always run while embeddings are enabled.
+func CheckEmbeddingColumnDimensions(ctx context.Context, column EmbeddingColumnReader) error {
+	dims, err := column.ColumnDimensions(ctx)
+	if err != nil {
+		return fmt.Errorf("check embedding column: %w", err)
+	}
+
+	if dims != models.EmbeddingVectorDimensions {
+		return fmt.Errorf("%w: column has %d, want %d", ErrEmbeddingColumnDimensions, dims, models.EmbeddingVectorDimensions)
+	}
+
+	return nil
+}
+
+// ProbeEmbeddingClient embeds a tiny fixed string through client and checks the vector fits
+// models.EmbeddingVectorDimensions. It runs at startup (EMBEDDING_STARTUP_PROBE) so a bad API
+// key, a wrong base URL, or a model with other dimensions surfaces on deploy instead of as
+// background jobs failing one by one.
+func ProbeEmbeddingClient(ctx context.Context, client EmbeddingClient) error {
+	ctx, cancel := context.WithTimeout(ctx, embeddingProbeTimeout)
+	defer cancel()
+
+	embedding, err := client.CreateEmbedding(ctx, embeddingProbeInput)
+	if err != nil {
+		return fmt.Errorf("embedding probe: %w", err)
+	}
+
+	if len(embedding) != models.EmbeddingVectorDimensions {
+		return fmt.Errorf("%w: got %d, want %d", ErrEmbeddingProbeDimensions, len(embedding), models.EmbeddingVectorDimensions)
+	}
+
+	return nil
+}
+
+// RunEmbeddingStartupProbe probes client and logs the outcome. A failure is returned only when
+// strict (EMBEDDING_REQUIRED), failing startup; otherwise it is logged and startup continues with
+// embedding jobs that will fail the same way.
+func RunEmbeddingStartupProbe(ctx context.Context, client EmbeddingClient, provider, model string, strict bool) error {
+	start := time.Now()
+
+	if err := ProbeEmbeddingClient(ctx, client); err != nil {
+		if strict {
+			return err
+		}
+
+		slog.ErrorContext(ctx, "embedding: startup probe failed", "provider", provider, "model", model, "error", err)
+
+		return nil
+	}
+
+	slog.InfoContext(ctx, "embedding: startup probe succeeded",
+		"provider", provider, "model", model, "duration_ms", time.Since(start).Milliseconds())
+
+	return nil
+}
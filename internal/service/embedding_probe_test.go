@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/models"
+)
+
+func TestProbeEmbeddingClient(t *testing.T) {
+	errUnauthorized := errors.New("401 invalid api key")
+
+	tests := map[string]struct {
+		create  func(ctx context.Context, input string) ([]float32, error)
+		wantErr error
+	}{
+		"matching dimensions": {
+			create: func(context.Context, string) ([]float32, error) {
+				return make([]float32, models.EmbeddingVectorDimensions), nil
+			},
+		},
+		"provider error": {
+			create:  func(context.Context, string) ([]float32, error) { return nil, errUnauthorized },
+			wantErr: errUnauthorized,
+		},
+		"dimension mismatch": {
+			create:  func(context.Context, string) ([]float32, error) { return make([]float32, 1536), nil },
+			wantErr: ErrEmbeddingProbeDimensions,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ProbeEmbeddingClient(t.Context(), &mockEmbeddingClient{createFunc: tc.create})
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestRunEmbeddingStartupProbe_FailsOnlyWhenStrict(t *testing.T) {
+	client := &mockEmbeddingClient{createFunc: func(context.Context, string) ([]float32, error) {
+		return []float32{0.1}, nil
+	}}
+
+	require.NoError(t, RunEmbeddingStartupProbe(t.Context(), client, "openai", "m", false), "lenient mode only logs")
+	assert.ErrorIs(t, RunEmbeddingStartupProbe(t.Context(), client, "openai", "m", true), ErrEmbeddingProbeDimensions)
+}
+
+type stubEmbeddingColumn struct {
+	dims int
+	err  error
+}
+
+func (s stubEmbeddingColumn) ColumnDimensions(context.Context) (int, error) { return s.dims, s.err }
+
+func TestCheckEmbeddingColumnDimensions(t *testing.T) {
+	require.NoError(t, CheckEmbeddingColumnDimensions(t.Context(), stubEmbeddingColumn{dims: models.EmbeddingVectorDimensions}))
+	require.ErrorIs(t, CheckEmbeddingColumnDimensions(t.Context(), stubEmbeddingColumn{dims: 1536}), ErrEmbeddingColumnDimensions)
+
+	errDown := errors.New("db down")
+	require.ErrorIs(t, CheckEmbeddingColumnDimensions(t.Context(), stubEmbeddingColumn{err: errDown}), errDown)
+}
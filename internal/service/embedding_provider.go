@@ -26,6 +26,9 @@ type EmbeddingProvider struct {
 	docPrefix   string // model-specific prefix for document embedding; OpenAI and Google use ""
 	metrics     observability.EmbeddingMetrics
 	inputKind   models.EmbeddingInputKind
+	secondary   bool
+	// tenantBacklog, set by SetTenantBacklog, demotes the jobs of tenants with a deep queue.
+	tenantBacklog *EmbeddingTenantBacklog
 }
 
 // NewEmbeddingProvider creates a provider that enqueues feedback_embedding jobs.
@@ -65,6 +68,30 @@ func NewEmbeddingProviderForInputKind(
 	}
 }
 
+// NewSecondaryEmbeddingProvider creates the raw-text provider for EMBEDDING_SECONDARY_MODEL. It
+// differs from the primary provider only in never skipping records embedded inline on create:
+// the inline path stores the primary model's vector, so the secondary still needs its job.
+func NewSecondaryEmbeddingProvider(
+	inserter RiverJobInserter,
+	model string,
+	queueName string,
+	maxAttempts int,
+	docPrefix string,
+	metrics observability.EmbeddingMetrics,
+) *EmbeddingProvider {
+	p := NewEmbeddingProvider(inserter, model, queueName, maxAttempts, docPrefix, metrics)
+	p.secondary = true
+
+	return p
+}
+
+// SetTenantBacklog makes the provider insert the jobs of tenants the backlog reports as
+// backlogged at a lower priority, so other tenants' new records are embedded first. nil (the
+// default) inserts every job at River's default priority.
+func (p *EmbeddingProvider) SetTenantBacklog(backlog *EmbeddingTenantBacklog) {
+	p.tenantBacklog = backlog
+}
+
 // PublishEvent enqueues a feedback_embedding job when the event is FeedbackRecordCreated (with non-empty value_text)
 // or FeedbackRecordUpdated (with value_text in ChangedFields). On update, the job is enqueued even when value_text
 // is now empty so the worker can clear the embedding for text fields.
@@ -90,6 +117,30 @@ func (p *EmbeddingProvider) PublishEvent(ctx context.Context, event Event) {
 		return
 	}
 
+	// The record was imported with skip_embedding=true; the embedding backfill picks it up later.
+	if event.Type == datatypes.FeedbackRecordCreated && record.SkipEmbedding {
+		slog.Debug("embedding: skip, skip_embedding on create", "event_id", event.ID, "feedback_record_id", record.ID)
+
+		return
+	}
+
+	// The create request already stored the raw embedding inline (sync_embedding=true); a job
+	// would only re-embed identical text. Other input kinds are still enqueued.
+	if event.Type == datatypes.FeedbackRecordCreated && record.EmbeddedInline &&
+		p.inputKind == models.EmbeddingInputKindRaw && !p.secondary {
+		slog.Debug("embedding: skip, embedded inline on create", "event_id", event.ID, "feedback_record_id", record.ID)
+
+		return
+	}
+
+	// The batch create already enqueued this record's job together with the rest of its batch.
+	if event.Type == datatypes.FeedbackRecordCreated && record.EmbeddingEnqueued &&
+		p.inputKind == models.EmbeddingInputKindRaw && !p.secondary {
+		slog.Debug("embedding: skip, enqueued with its batch", "event_id", event.ID, "feedback_record_id", record.ID)
+
+		return
+	}
+
 	// Build the embedding input once and reuse it for both the create-time empty check and the
 	// dedupe hash; it was otherwise computed twice on the create path.
 	input := BuildEmbeddingInputForKind(record, p.inputKind, p.docPrefix)
@@ -111,6 +162,7 @@ func (p *EmbeddingProvider) PublishEvent(ctx context.Context, event Event) {
 	opts := &river.InsertOpts{
 		Queue:       p.queueName,
 		MaxAttempts: p.maxAttempts,
+		Priority:    p.tenantBacklog.priority(record.TenantID),
 	}
 
 	_, err := p.inserter.Insert(ctx, FeedbackEmbeddingArgs{
@@ -119,6 +171,7 @@ func (p *EmbeddingProvider) PublishEvent(ctx context.Context, event Event) {
 		Model:            p.model,
 		InputKind:        p.inputKind,
 		ValueTextHash:    valueTextHash,
+		TenantID:         record.TenantID,
 	}, opts)
 	if err != nil {
 		if p.metrics != nil {
@@ -144,6 +197,81 @@ func (p *EmbeddingProvider) PublishEvent(ctx context.Context, event Event) {
 	}
 }
 
+// EnqueueBatch enqueues the raw embeddings of a batch create's records as FeedbackEmbeddingBatchArgs
+// jobs of up to MaxEmbeddingBatchSize records, so the worker embeds them with one provider call
+// instead of one per record. Each job holds one tenant's records, so it is attributed and
+// prioritized like that tenant's per-record jobs. Records this provider would not embed on create
+// (no text) are left out, and each record enqueued is marked EmbeddingEnqueued.
+// A failed insert leaves its records unmarked, so their created events enqueue them one by one.
+// Only the primary raw-text provider takes batches; any other returns without enqueueing.
+func (p *EmbeddingProvider) EnqueueBatch(ctx context.Context, records []*models.FeedbackRecord) {
+	if p.inputKind != models.EmbeddingInputKindRaw || p.secondary {
+		return
+	}
+
+	var tenants []string
+
+	pending := make(map[string][]*models.FeedbackRecord)
+
+	for _, record := range records {
+		if BuildEmbeddingInputForKind(record, p.inputKind, p.docPrefix) == "" {
+			continue
+		}
+
+		if _, ok := pending[record.TenantID]; !ok {
+			tenants = append(tenants, record.TenantID)
+		}
+
+		pending[record.TenantID] = append(pending[record.TenantID], record)
+	}
+
+	for _, tenantID := range tenants {
+		p.enqueueTenantBatches(ctx, tenantID, pending[tenantID])
+	}
+}
+
+// enqueueTenantBatches enqueues one tenant's records for EnqueueBatch.
+func (p *EmbeddingProvider) enqueueTenantBatches(ctx context.Context, tenantID string, records []*models.FeedbackRecord) {
+	opts := &river.InsertOpts{
+		Queue:       p.queueName,
+		MaxAttempts: p.maxAttempts,
+		Priority:    p.tenantBacklog.priority(tenantID),
+	}
+
+	for batch := range slices.Chunk(records, MaxEmbeddingBatchSize) {
+		ids := make([]uuid.UUID, len(batch))
+		for i, record := range batch {
+			ids[i] = record.ID
+		}
+
+		if _, err := p.inserter.Insert(ctx, FeedbackEmbeddingBatchArgs{
+			FeedbackRecordIDs: ids,
+			Model:             p.model,
+			InputKind:         p.inputKind,
+			TenantID:          tenantID,
+		}, opts); err != nil {
+			if p.metrics != nil {
+				p.metrics.RecordProviderError(ctx, "enqueue_failed")
+			}
+
+			slog.Error("embedding: batch enqueue failed, falling back to per-record jobs",
+				"batch_size", len(batch), "error", err)
+
+			continue
+		}
+
+		for _, record := range batch {
+			record.EmbeddingEnqueued = true
+		}
+
+		slog.Info("embedding: batch job enqueued", "batch_size", len(batch))
+
+		if p.metrics != nil {
+			p.metrics.RecordJobsEnqueued(ctx, 1)
+		}
+	}
+}
+
 func (p *EmbeddingProvider) hasEmbeddingRelevantChange(changedFields []string) bool {
 	return slices.Contains(changedFields, "value_text") || slices.Contains(changedFields, "field_label")
 }
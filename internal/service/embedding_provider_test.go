@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -21,8 +22,9 @@ type mockEmbeddingInserter struct {
 }
 
 type insertCall struct {
-	args FeedbackEmbeddingArgs
-	opts *river.InsertOpts
+	args      FeedbackEmbeddingArgs
+	batchArgs FeedbackEmbeddingBatchArgs
+	opts      *river.InsertOpts
 }
 
 func (m *mockEmbeddingInserter) Insert(
@@ -32,7 +34,8 @@ func (m *mockEmbeddingInserter) Insert(
 ) (*rivertype.JobInsertResult, error) {
 	embeddingArgs, ok := args.(FeedbackEmbeddingArgs)
 	if !ok {
-		m.insertCalls = append(m.insertCalls, insertCall{opts: opts})
+		batchArgs, _ := args.(FeedbackEmbeddingBatchArgs)
+		m.insertCalls = append(m.insertCalls, insertCall{batchArgs: batchArgs, opts: opts})
 
 		return nil, m.insertErr
 	}
@@ -260,3 +263,153 @@ func TestTaxonomyEmbeddingModel(t *testing.T) {
 	assert.Equal(t, "custom-taxonomy-model", TaxonomyEmbeddingModel("text-embedding", " custom-taxonomy-model "))
 	assert.Empty(t, TaxonomyEmbeddingModel(" ", ""))
 }
+
+func TestEmbeddingProvider_SkipsRecordEmbeddedInline(t *testing.T) {
+	inserter := &mockEmbeddingInserter{}
+	provider := NewEmbeddingProvider(inserter, "embedding-model", EmbeddingsQueueName, 3, "", nil)
+
+	text := "hello"
+	provider.PublishEvent(context.Background(), Event{
+		ID:   uuid.Must(uuid.NewV7()),
+		Type: datatypes.FeedbackRecordCreated,
+		Data: &models.FeedbackRecord{ID: uuid.Must(uuid.NewV7()), ValueText: &text, EmbeddedInline: true},
+	})
+
+	if len(inserter.insertCalls) != 0 {
+		t.Fatalf("insert calls = %d, want 0 for an inline-embedded record", len(inserter.insertCalls))
+	}
+}
+
+func TestSecondaryEmbeddingProvider_EnqueuesRecordEmbeddedInline(t *testing.T) {
+	inserter := &mockEmbeddingInserter{}
+	provider := NewSecondaryEmbeddingProvider(inserter, "candidate-model", EmbeddingsQueueName, 3, "", nil)
+
+	text := "hello"
+	provider.PublishEvent(context.Background(), Event{
+		ID:   uuid.Must(uuid.NewV7()),
+		Type: datatypes.FeedbackRecordCreated,
+		Data: &models.FeedbackRecord{ID: uuid.Must(uuid.NewV7()), ValueText: &text, EmbeddedInline: true},
+	})
+
+	if len(inserter.insertCalls) != 1 {
+		t.Fatalf("insert calls = %d, want 1: the inline path stores only the primary model", len(inserter.insertCalls))
+	}
+
+	if got := inserter.insertCalls[0].args.Model; got != "candidate-model" {
+		t.Fatalf("job model = %q, want candidate-model", got)
+	}
+}
+
+func TestEmbeddingProviders_SkipRecordCreatedWithSkipEmbedding(t *testing.T) {
+	inserter := &mockEmbeddingInserter{}
+	providers := []*EmbeddingProvider{
+		NewEmbeddingProvider(inserter, "embedding-model", EmbeddingsQueueName, 3, "", nil),
+		NewSecondaryEmbeddingProvider(inserter, "candidate-model", EmbeddingsQueueName, 3, "", nil),
+	}
+
+	text := "hello"
+	for _, provider := range providers {
+		provider.PublishEvent(context.Background(), Event{
+			ID:   uuid.Must(uuid.NewV7()),
+			Type: datatypes.FeedbackRecordCreated,
+			Data: &models.FeedbackRecord{ID: uuid.Must(uuid.NewV7()), ValueText: &text, SkipEmbedding: true},
+		})
+	}
+
+	if len(inserter.insertCalls) != 0 {
+		t.Fatalf("insert calls = %d, want 0 for a record created with skip_embedding", len(inserter.insertCalls))
+	}
+}
+
+func TestEmbeddingProvider_EnqueueBatch(t *testing.T) {
+	text := "hello"
+	newRecords := func() []*models.FeedbackRecord {
+		return []*models.FeedbackRecord{
+			{ID: uuid.Must(uuid.NewV7()), TenantID: "basic", FieldType: models.FieldTypeText, ValueText: &text},
+			{ID: uuid.Must(uuid.NewV7()), TenantID: "basic", FieldType: models.FieldTypeText},
+			{ID: uuid.Must(uuid.NewV7()), TenantID: "premium", FieldType: models.FieldTypeText, ValueText: &text},
+		}
+	}
+
+	t.Run("enqueues the records with text and the created events skip them", func(t *testing.T) {
+		inserter := &mockEmbeddingInserter{}
+		provider := NewEmbeddingProvider(inserter, "embedding-model", EmbeddingsQueueName, 3, "", nil)
+
+		records := newRecords()
+		provider.EnqueueBatch(context.Background(), records)
+
+		require.Len(t, inserter.insertCalls, 2)
+		assert.Equal(t, EmbeddingsQueueName, inserter.insertCalls[0].opts.Queue)
+		assert.True(t, records[0].EmbeddingEnqueued)
+		assert.False(t, records[1].EmbeddingEnqueued, "a record without text is not embedded on create")
+		assert.True(t, records[2].EmbeddingEnqueued, "every tenant gets the primary model, whatever its embedding_model")
+
+		provider.PublishEvent(context.Background(), Event{
+			ID: uuid.Must(uuid.NewV7()), Type: datatypes.FeedbackRecordCreated, Data: records[0],
+		})
+		assert.Len(t, inserter.insertCalls, 2, "the created event of an enqueued record adds no job")
+	})
+
+	t.Run("one job per tenant, each at its tenant's priority", func(t *testing.T) {
+		inserter := &mockEmbeddingInserter{}
+		provider := NewEmbeddingProvider(inserter, "embedding-model", EmbeddingsQueueName, 3, "", nil)
+		backlog := NewEmbeddingTenantBacklog(100)
+		backlog.Update(map[string]int{"premium": 100})
+		provider.SetTenantBacklog(backlog)
+
+		records := newRecords()
+		provider.EnqueueBatch(context.Background(), records)
+
+		require.Len(t, inserter.insertCalls, 2)
+		assert.Equal(t, "basic", inserter.insertCalls[0].batchArgs.TenantID)
+		assert.Equal(t, []uuid.UUID{records[0].ID}, inserter.insertCalls[0].batchArgs.FeedbackRecordIDs)
+		assert.Equal(t, 0, inserter.insertCalls[0].opts.Priority)
+		assert.Equal(t, "premium", inserter.insertCalls[1].batchArgs.TenantID)
+		assert.Equal(t, []uuid.UUID{records[2].ID}, inserter.insertCalls[1].batchArgs.FeedbackRecordIDs)
+		assert.Equal(t, embeddingBackloggedTenantPriority, inserter.insertCalls[1].opts.Priority)
+	})
+
+	t.Run("a failed insert leaves the records to their created events", func(t *testing.T) {
+		inserter := &mockEmbeddingInserter{insertErr: errors.New("queue unavailable")}
+		provider := NewEmbeddingProvider(inserter, "embedding-model", EmbeddingsQueueName, 3, "", nil)
+
+		records := newRecords()
+		provider.EnqueueBatch(context.Background(), records)
+
+		assert.False(t, records[0].EmbeddingEnqueued)
+	})
+
+	t.Run("the secondary provider takes no batches", func(t *testing.T) {
+		inserter := &mockEmbeddingInserter{}
+		provider := NewSecondaryEmbeddingProvider(inserter, "candidate-model", EmbeddingsQueueName, 3, "", nil)
+
+		provider.EnqueueBatch(context.Background(), newRecords())
+
+		assert.Empty(t, inserter.insertCalls)
+	})
+}
+
+func TestEmbeddingProvider_DemotesBackloggedTenant(t *testing.T) {
+	inserter := &mockEmbeddingInserter{}
+	provider := NewEmbeddingProvider(inserter, "embedding-model", EmbeddingsQueueName, 3, "", nil)
+	backlog := NewEmbeddingTenantBacklog(100)
+	backlog.Update(map[string]int{"importing": 250, "quiet": 3})
+	provider.SetTenantBacklog(backlog)
+
+	text := "hello"
+	for _, tenantID := range []string{"importing", "quiet"} {
+		provider.PublishEvent(context.Background(), Event{
+			ID:   uuid.Must(uuid.NewV7()),
+			Type: datatypes.FeedbackRecordCreated,
+			Data: &models.FeedbackRecord{
+				ID: uuid.Must(uuid.NewV7()), TenantID: tenantID, FieldType: models.FieldTypeText, ValueText: &text,
+			},
+		})
+	}
+
+	require.Len(t, inserter.insertCalls, 2)
+	assert.Equal(t, "importing", inserter.insertCalls[0].args.TenantID)
+	assert.Equal(t, embeddingBackloggedTenantPriority, inserter.insertCalls[0].opts.Priority)
+	assert.Equal(t, "quiet", inserter.insertCalls[1].args.TenantID)
+	assert.Equal(t, 0, inserter.insertCalls[1].opts.Priority, "other tenants keep River's default priority")
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"sync"
+)
+
+const (
+	// EmbeddingTenantBacklogTopN bounds how many tenants the embedding backlog poll reports: the
+	// deepest ones, which are the only ones EmbeddingTenantBacklog can demote and the only ones
+	// worth a metric series.
+	EmbeddingTenantBacklogTopN = 20
+
+	// embeddingBackloggedTenantPriority is the River priority of a backlogged tenant's
+	// event-driven embedding jobs: behind every other tenant's (1), ahead of bulk backfills and
+	// re-embeds (EMBEDDING_BULK_JOB_PRIORITY, 4 by default).
+	embeddingBackloggedTenantPriority = 2
+)
+
+// EmbeddingTenantBacklog tracks which tenants have a deep embedding queue, so EmbeddingProvider
+// can insert their new jobs behind everyone else's. Without it the queue is FIFO across tenants:
+// one tenant's import of 100k records (event-driven, so not demoted as bulk work) delays every
+// other tenant's fresh feedback by the whole import. Demoted jobs still run in order and as soon
+// as the other tenants' jobs are done, so a busy tenant slows down only while others are waiting.
+//
+// The set is refreshed from the queue by the API's backlog poller (Update); jobs are attributed
+// by FeedbackEmbeddingArgs.TenantID / FeedbackEmbeddingBatchArgs.TenantID. A nil
+// *EmbeddingTenantBacklog demotes no one. Safe for concurrent use.
+type EmbeddingTenantBacklog struct {
+	threshold int
+
+	mu         sync.RWMutex
+	backlogged map[string]struct{}
+}
+
+// NewEmbeddingTenantBacklog returns a tracker that treats a tenant with at least threshold queued
+// embedding jobs as backlogged (EMBEDDING_TENANT_BACKLOG_THRESHOLD). Returns nil when threshold
+// is not positive, which disables the demotion.
+func NewEmbeddingTenantBacklog(threshold int) *EmbeddingTenantBacklog {
+	if threshold <= 0 {
+		return nil
+	}
+
+	return &EmbeddingTenantBacklog{threshold: threshold, backlogged: map[string]struct{}{}}
+}
+
+// Update replaces the backlogged set from the latest queued-job count per tenant. Tenants missing
+// from depths count as drained.
+func (b *EmbeddingTenantBacklog) Update(depths map[string]int) {
+	backlogged := make(map[string]struct{})
+
+	for tenantID, depth := range depths {
+		if tenantID != "" && depth >= b.threshold {
+			backlogged[tenantID] = struct{}{}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.backlogged = backlogged
+}
+
+// IsBacklogged reports whether the tenant had at least the threshold of queued jobs at the last
+// Update. Always false on a nil tracker.
+func (b *EmbeddingTenantBacklog) IsBacklogged(tenantID string) bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	_, ok := b.backlogged[tenantID]
+
+	return ok
+}
+
+// priority returns the River priority for a new event-driven embedding job of the tenant: 0
+// (River's default, 1) unless the tenant is backlogged.
+func (b *EmbeddingTenantBacklog) priority(tenantID string) int {
+	if b.IsBacklogged(tenantID) {
+		return embeddingBackloggedTenantPriority
+	}
+
+	return 0
+}
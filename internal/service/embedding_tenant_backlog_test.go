@@ -0,0 +1,29 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddingTenantBacklog(t *testing.T) {
+	t.Run("a tenant at the threshold is backlogged until it drains", func(t *testing.T) {
+		backlog := NewEmbeddingTenantBacklog(100)
+
+		backlog.Update(map[string]int{"importing": 100, "quiet": 99})
+		assert.True(t, backlog.IsBacklogged("importing"))
+		assert.False(t, backlog.IsBacklogged("quiet"))
+		assert.False(t, backlog.IsBacklogged("unknown"))
+
+		backlog.Update(map[string]int{"quiet": 5})
+		assert.False(t, backlog.IsBacklogged("importing"), "a tenant missing from the poll has drained")
+	})
+
+	t.Run("a non-positive threshold disables it", func(t *testing.T) {
+		backlog := NewEmbeddingTenantBacklog(0)
+
+		assert.Nil(t, backlog)
+		assert.False(t, backlog.IsBacklogged("importing"))
+		assert.Equal(t, 0, backlog.priority("importing"))
+	})
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+)
+
+const (
+	feedbackExportKind = "feedback_export"
+	// ExportsQueueName is the River queue for feedback export jobs. It is kept separate so a long
+	// export never occupies a worker slot that webhook delivery or enrichment needs.
+	ExportsQueueName = "exports"
+)
+
+// FeedbackExportArgs runs one export_jobs row. The row, not the args, carries the tenant,
+// format, and filter snapshot, so the args stay a stable id and a retry re-reads the same job.
+type FeedbackExportArgs struct {
+	ExportJobID uuid.UUID `json:"export_job_id" river:"unique"`
+}
+
+// Kind returns the River job kind.
+func (FeedbackExportArgs) Kind() string { return feedbackExportKind }
+
+var _ river.JobArgs = FeedbackExportArgs{}
@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/pkg/cursor"
+)
+
+// ErrExportNotConfigured is returned when exports are requested while EXPORT_DIR is unset.
+var ErrExportNotConfigured = errors.New("feedback export not configured")
+
+// exportPageSize is how many records an export reads per keyset page; the file is written page by
+// page, so memory stays bounded by one page however large the export is.
+const exportPageSize = 1000
+
+// exportFailedMessage is the client-visible error of a failed export. The cause is logged by the
+// worker instead: filesystem and driver errors name paths and hosts.
+const exportFailedMessage = "export failed; create a new export to retry"
+
+// ExportJobsRepository defines data access for export_jobs.
+type ExportJobsRepository interface {
+	Create(ctx context.Context, req *models.CreateExportJobRequest) (*models.ExportJob, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.ExportJob, error)
+	MarkRunning(ctx context.Context, id uuid.UUID) (*models.ExportJob, bool, error)
+	Complete(ctx context.Context, id uuid.UUID, fileName string, recordCount int64) error
+	Fail(ctx context.Context, id uuid.UUID, message string) error
+}
+
+// ExportRecordsRepository is the feedback-record paging an export reads through.
+type ExportRecordsRepository interface {
+	List(ctx context.Context, filters *models.ListFeedbackRecordsFilters) ([]models.FeedbackRecord, bool, error)
+	ListAfterCursor(
+		ctx context.Context, filters *models.ListFeedbackRecordsFilters, cursorCollectedAt time.Time, cursorID uuid.UUID,
+	) ([]models.FeedbackRecord, bool, error)
+}
+
+// ExportService runs asynchronous feedback exports: the API creates the job and enqueues it, the
+// worker streams the matching records into a file under dir, and the API serves the finished file.
+type ExportService struct {
+	repo        ExportJobsRepository
+	records     ExportRecordsRepository
+	dir         string
+	inserter    RiverJobInserter
+	maxAttempts int
+}
+
+// NewExportService creates an export service. dir is EXPORT_DIR; empty disables exports. The River
+// inserter is set separately (SetInserter) because the API's River client is created later.
+func NewExportService(repo ExportJobsRepository, records ExportRecordsRepository, dir string, maxAttempts int) *ExportService {
+	return &ExportService{repo: repo, records: records, dir: dir, maxAttempts: maxAttempts}
+}
+
+// SetInserter sets the River inserter export jobs are enqueued with.
+func (s *ExportService) SetInserter(inserter RiverJobInserter) {
+	s.inserter = inserter
+}
+
+// CreateExport validates and persists an export job and enqueues it. If the enqueue fails the row
+// is marked failed rather than left pending forever.
+func (s *ExportService) CreateExport(ctx context.Context, req *models.CreateExportJobRequest) (*models.ExportJob, error) {
+	if s.dir == "" || s.inserter == nil {
+		return nil, ErrExportNotConfigured
+	}
+
+	tenantID, err := normalizeRequiredTenantIDValue(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.TenantID = tenantID
+
+	if len(req.Tags) > 0 {
+		if req.Tags, err = normalizeTags(req.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Since != nil && req.Until != nil && req.Since.After(*req.Until) {
+		return nil, huberrors.NewValidationError("since", "must not be after until")
+	}
+
+	job, err := s.repo.Create(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("create export job: %w", err)
+	}
+
+	if _, err := s.inserter.Insert(ctx, FeedbackExportArgs{ExportJobID: job.ID}, &river.InsertOpts{
+		Queue:       ExportsQueueName,
+		MaxAttempts: s.maxAttempts,
+	}); err != nil {
+		if failErr := s.repo.Fail(ctx, job.ID, exportFailedMessage); failErr != nil {
+			err = errors.Join(err, failErr)
+		}
+
+		return nil, fmt.Errorf("enqueue export job: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetExport returns an export job.
+func (s *ExportService) GetExport(ctx context.Context, id uuid.UUID) (*models.ExportJob, error) {
+	job, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get export job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ExportFile returns a completed export job and the absolute path of its file. A job that has not
+// completed is a conflict: the client polls the job until its status is completed.
+func (s *ExportService) ExportFile(ctx context.Context, id uuid.UUID) (*models.ExportJob, string, error) {
+	if s.dir == "" {
+		return nil, "", ErrExportNotConfigured
+	}
+
+	job, err := s.GetExport(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if job.Status != models.ExportJobStatusCompleted || job.FileName == nil {
+		return nil, "", huberrors.NewConflictError("export job is " + string(job.Status) + ", not completed")
+	}
+
+	return job, filepath.Join(s.dir, *job.FileName), nil
+}
+
+// RunExport writes the job's file. It is the worker's body: finished jobs are skipped, the file is
+// written to a temporary name and renamed into place only once complete (a crashed attempt never
+// leaves a truncated file behind a completed row), and the row is marked failed only when
+// finalAttempt is set — earlier failures are left to River's retry. A job removed by a tenant
+// purge mid-run ends without error, and the file it just wrote is deleted again.
+func (s *ExportService) RunExport(ctx context.Context, id uuid.UUID, finalAttempt bool) error {
+	if s.dir == "" {
+		return ErrExportNotConfigured
+	}
+
+	job, ok, err := s.repo.MarkRunning(ctx, id)
+	if errors.Is(err, huberrors.ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("start export job: %w", err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	fileName, count, err := s.writeExportFile(ctx, job)
+	if err != nil {
+		if finalAttempt {
+			if failErr := s.repo.Fail(context.WithoutCancel(ctx), id, exportFailedMessage); failErr != nil {
+				err = errors.Join(err, failErr)
+			}
+		}
+
+		return fmt.Errorf("write export %s: %w", id, err)
+	}
+
+	if err := s.repo.Complete(ctx, id, fileName, count); err != nil {
+		if errors.Is(err, huberrors.ErrNotFound) {
+			_ = os.Remove(filepath.Join(s.dir, fileName))
+
+			return nil
+		}
+
+		return fmt.Errorf("complete export job: %w", err)
+	}
+
+	return nil
+}
+
+// writeExportFile streams the job's records into <dir>/<tenant dir>/<job id>.<format> and returns
+// the file name relative to dir plus the number of records written.
+func (s *ExportService) writeExportFile(ctx context.Context, job *models.ExportJob) (string, int64, error) {
+	tenantDir := ExportTenantDir(s.dir, job.TenantID)
+	if err := os.MkdirAll(tenantDir, 0o750); err != nil {
+		return "", 0, fmt.Errorf("create export dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(tenantDir, job.ID.String()+"-*.tmp")
+	if err != nil {
+		return "", 0, fmt.Errorf("create export file: %w", err)
+	}
+
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }() // no-op after the rename
+
+	writer := newExportWriter(job.Format, tmp)
+
+	count, err := streamFeedbackRecords(ctx, s.records, job.Filters.ListFilters(job.TenantID, exportPageSize),
+		writer.write, writer.flush)
+
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("close export file: %w", closeErr)
+	}
+
+	if err != nil {
+		return "", 0, err
+	}
+
+	finalName := job.ID.String() + "." + string(job.Format)
+	if err := os.Rename(tmpName, filepath.Join(tenantDir, finalName)); err != nil {
+		return "", 0, fmt.Errorf("publish export file: %w", err)
+	}
+
+	return filepath.Join(filepath.Base(tenantDir), finalName), count, nil
+}
+
+// streamFeedbackRecords pages through filters' selection in list order (collected_at DESC, id
+// ASC), starting after filters.Cursor when one is set, and hands each record to write. pageDone
+// runs after every page, the empty last one included, so a caller can flush as it goes. filters'
+// Limit is the page size.
+func streamFeedbackRecords(
+	ctx context.Context,
+	records ExportRecordsRepository,
+	filters *models.ListFeedbackRecordsFilters,
+	write func(*models.FeedbackRecord) error,
+	pageDone func() error,
+) (int64, error) {
+	var (
+		count   int64
+		page    []models.FeedbackRecord
+		hasMore bool
+		err     error
+	)
+
+	if cursorStr := strings.TrimSpace(filters.Cursor); cursorStr != "" {
+		collectedAt, id, decErr := cursor.Decode(cursorStr)
+		if decErr != nil {
+			return 0, fmt.Errorf("decode cursor: %w", decErr)
+		}
+
+		page, hasMore, err = records.ListAfterCursor(ctx, filters, collectedAt, id)
+	} else {
+		page, hasMore, err = records.List(ctx, filters)
+	}
+
+	for {
+		if err != nil {
+			return count, fmt.Errorf("list export records: %w", err)
+		}
+
+		for i := range page {
+			if err := write(&page[i]); err != nil {
+				return count, err
+			}
+
+			count++
+		}
+
+		if err := pageDone(); err != nil {
+			return count, err
+		}
+
+		if !hasMore || len(page) == 0 {
+			return count, nil
+		}
+
+		last := page[len(page)-1]
+		page, hasMore, err = records.ListAfterCursor(ctx, filters, last.CollectedAt, last.ID)
+	}
+}
+
+// ExportTenantDir is the directory under dir holding one tenant's export files. It is named by a
+// hash of the tenant id — tenant ids are free-form and must never become path components — and
+// lets a tenant purge remove every export file of the tenant at once.
+func ExportTenantDir(dir, tenantID string) string {
+	sum := sha256.Sum256([]byte(tenantID))
+
+	return filepath.Join(dir, hex.EncodeToString(sum[:16]))
+}
+
+// exportWriter encodes records in one export format. flush may be called after every page; a CSV
+// gets its header row on the first record or, for an empty export, on the first flush.
+type exportWriter struct {
+	write func(*models.FeedbackRecord) error
+	flush func() error
+}
+
+func newExportWriter(format models.ExportFormat, w io.Writer) exportWriter {
+	if format == models.ExportFormatJSONL {
+		encoder := json.NewEncoder(w)
+
+		return exportWriter{
+			write: func(record *models.FeedbackRecord) error {
+				if err := encoder.Encode(record); err != nil {
+					return fmt.Errorf("write export record: %w", err)
+				}
+
+				return nil
+			},
+			flush: func() error { return nil },
+		}
+	}
+
+	csvWriter := csv.NewWriter(w)
+	headerWritten := false
+
+	return exportWriter{
+		write: func(record *models.FeedbackRecord) error {
+			if !headerWritten {
+				if err := csvWriter.Write(exportCSVHeader); err != nil {
+					return fmt.Errorf("write export header: %w", err)
+				}
+
+				headerWritten = true
+			}
+
+			if err := csvWriter.Write(exportCSVRow(record)); err != nil {
+				return fmt.Errorf("write export record: %w", err)
+			}
+
+			return nil
+		},
+		flush: func() error {
+			if !headerWritten {
+				if err := csvWriter.Write(exportCSVHeader); err != nil {
+					return fmt.Errorf("write export header: %w", err)
+				}
+
+				headerWritten = true
+			}
+
+			csvWriter.Flush()
+
+			if err := csvWriter.Error(); err != nil {
+				return fmt.Errorf("flush export file: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// exportCSVHeader is the CSV column order. Metadata and enrichment outputs are JSONL-only: CSV is
+// for spreadsheets, and nested JSON does not survive them.
+var exportCSVHeader = []string{
+	"id", "tenant_id", "collected_at", "created_at", "updated_at", "submission_id",
+	"source_type", "source_id", "source_name", "field_id", "field_label", "field_type",
+	"field_group_id", "field_group_label", "value_text", "value_id", "value_number",
+	"value_boolean", "value_date", "language", "user_id", "tags",
+}
+
+func exportCSVRow(r *models.FeedbackRecord) []string {
+	row := []string{
+		r.ID.String(), r.TenantID,
+		r.CollectedAt.UTC().Format(time.RFC3339Nano),
+		r.CreatedAt.UTC().Format(time.RFC3339Nano),
+		r.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		csvText(r.SubmissionID),
+		csvText(r.SourceType), csvTextPtr(r.SourceID), csvTextPtr(r.SourceName),
+		csvText(r.FieldID), csvTextPtr(r.FieldLabel), string(r.FieldType),
+		csvTextPtr(r.FieldGroupID), csvTextPtr(r.FieldGroupLabel),
+		csvTextPtr(r.ValueText), csvTextPtr(r.ValueID),
+		"", "", "",
+		csvTextPtr(r.Language), csvTextPtr(r.UserID),
+		csvText(strings.Join(r.Tags, ";")),
+	}
+
+	if r.ValueNumber != nil {
+		row[16] = strconv.FormatFloat(*r.ValueNumber, 'f', -1, 64)
+	}
+
+	if r.ValueBoolean != nil {
+		row[17] = strconv.FormatBool(*r.ValueBoolean)
+	}
+
+	if r.ValueDate != nil {
+		row[18] = r.ValueDate.UTC().Format(time.RFC3339Nano)
+	}
+
+	return row
+}
+
+// csvText neutralizes spreadsheet formula injection: a client-supplied cell starting with a
+// formula trigger is prefixed with a quote so spreadsheet apps show it as text instead of
+// evaluating it (OWASP "CSV injection"). Numbers and timestamps are formatted by Hub and skip this.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
+
+func csvTextPtr(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return csvText(*s)
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+)
+
+// fakeExportJobsRepo keeps export jobs in memory.
+type fakeExportJobsRepo struct {
+	jobs      map[uuid.UUID]*models.ExportJob
+	failedMsg string
+}
+
+func newFakeExportJobsRepo() *fakeExportJobsRepo {
+	return &fakeExportJobsRepo{jobs: map[uuid.UUID]*models.ExportJob{}}
+}
+
+func (f *fakeExportJobsRepo) Create(_ context.Context, req *models.CreateExportJobRequest) (*models.ExportJob, error) {
+	job := &models.ExportJob{
+		ID: uuid.New(), TenantID: req.TenantID, Format: req.Format, Filters: req.ExportJobFilters,
+		Status: models.ExportJobStatusPending,
+	}
+	f.jobs[job.ID] = job
+
+	return job, nil
+}
+
+func (f *fakeExportJobsRepo) Get(_ context.Context, id uuid.UUID) (*models.ExportJob, error) {
+	job, ok := f.jobs[id]
+	if !ok {
+		return nil, huberrors.NewNotFoundError("export job", "export job not found")
+	}
+
+	return job, nil
+}
+
+func (f *fakeExportJobsRepo) MarkRunning(ctx context.Context, id uuid.UUID) (*models.ExportJob, bool, error) {
+	job, err := f.Get(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if job.Status != models.ExportJobStatusPending && job.Status != models.ExportJobStatusRunning {
+		return job, false, nil
+	}
+
+	job.Status = models.ExportJobStatusRunning
+
+	return job, true, nil
+}
+
+func (f *fakeExportJobsRepo) Complete(ctx context.Context, id uuid.UUID, fileName string, recordCount int64) error {
+	job, err := f.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	job.Status = models.ExportJobStatusCompleted
+	job.FileName = &fileName
+	job.RecordCount = recordCount
+
+	return nil
+}
+
+func (f *fakeExportJobsRepo) Fail(_ context.Context, id uuid.UUID, message string) error {
+	f.jobs[id].Status = models.ExportJobStatusFailed
+	f.failedMsg = message
+
+	return nil
+}
+
+// fakeExportRecords serves pages of records; err, when set, fails the first List, and onList runs
+// before it (e.g. to simulate a purge while the export is running).
+type fakeExportRecords struct {
+	pages  [][]models.FeedbackRecord
+	calls  int
+	err    error
+	onList func()
+}
+
+func (f *fakeExportRecords) List(context.Context, *models.ListFeedbackRecordsFilters) ([]models.FeedbackRecord, bool, error) {
+	if f.onList != nil {
+		f.onList()
+	}
+
+	if f.err != nil {
+		return nil, false, f.err
+	}
+
+	return f.next()
+}
+
+func (f *fakeExportRecords) ListAfterCursor(
+	context.Context, *models.ListFeedbackRecordsFilters, time.Time, uuid.UUID,
+) ([]models.FeedbackRecord, bool, error) {
+	return f.next()
+}
+
+func (f *fakeExportRecords) next() ([]models.FeedbackRecord, bool, error) {
+	if f.calls >= len(f.pages) {
+		return nil, false, nil
+	}
+
+	page := f.pages[f.calls]
+	f.calls++
+
+	return page, f.calls < len(f.pages), nil
+}
+
+func exportTestRecord(fieldID, text string) models.FeedbackRecord {
+	return models.FeedbackRecord{
+		ID: uuid.New(), TenantID: "org-1", SourceType: "survey", FieldID: fieldID, FieldType: models.FieldTypeText,
+		ValueText: &text, CollectedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestExportService_CreateExport_NotConfigured(t *testing.T) {
+	svc := NewExportService(newFakeExportJobsRepo(), &fakeExportRecords{}, "", 3)
+	svc.SetInserter(&recordingInserter{})
+
+	_, err := svc.CreateExport(context.Background(), &models.CreateExportJobRequest{TenantID: "org-1", Format: "csv"})
+	if !errors.Is(err, ErrExportNotConfigured) {
+		t.Fatalf("err = %v, want ErrExportNotConfigured", err)
+	}
+}
+
+func TestExportService_CreateExport_EnqueuesJob(t *testing.T) {
+	repo := newFakeExportJobsRepo()
+	inserter := &recordingInserter{}
+	svc := NewExportService(repo, &fakeExportRecords{}, t.TempDir(), 3)
+	svc.SetInserter(inserter)
+
+	job, err := svc.CreateExport(context.Background(), &models.CreateExportJobRequest{TenantID: " org-1 ", Format: "jsonl"})
+	if err != nil {
+		t.Fatalf("CreateExport: %v", err)
+	}
+
+	if job.TenantID != "org-1" {
+		t.Fatalf("tenant = %q, want trimmed org-1", job.TenantID)
+	}
+
+	if len(inserter.args) != 1 {
+		t.Fatalf("enqueued %d jobs, want 1", len(inserter.args))
+	}
+
+	args, ok := inserter.args[0].(FeedbackExportArgs)
+	if !ok || args.ExportJobID != job.ID {
+		t.Fatalf("enqueued args = %#v, want FeedbackExportArgs for %s", inserter.args[0], job.ID)
+	}
+
+	if inserter.opts[0].Queue != ExportsQueueName || inserter.opts[0].MaxAttempts != 3 {
+		t.Fatalf("insert opts = %+v, want queue %q and 3 attempts", inserter.opts[0], ExportsQueueName)
+	}
+}
+
+func TestExportService_CreateExport_RejectsInvertedRange(t *testing.T) {
+	svc := NewExportService(newFakeExportJobsRepo(), &fakeExportRecords{}, t.TempDir(), 3)
+	svc.SetInserter(&recordingInserter{})
+
+	since := time.Now()
+	until := since.Add(-time.Hour)
+
+	_, err := svc.CreateExport(context.Background(), &models.CreateExportJobRequest{
+		TenantID: "org-1", Format: "csv", ExportJobFilters: models.ExportJobFilters{Since: &since, Until: &until},
+	})
+	if !errors.Is(err, huberrors.ErrValidation) {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+}
+
+func TestExportService_CreateExport_EnqueueFailureFailsJob(t *testing.T) {
+	repo := newFakeExportJobsRepo()
+	svc := NewExportService(repo, &fakeExportRecords{}, t.TempDir(), 3)
+	svc.SetInserter(&recordingInserter{err: errors.New("queue down")})
+
+	if _, err := svc.CreateExport(context.Background(), &models.CreateExportJobRequest{TenantID: "org-1", Format: "csv"}); err == nil {
+		t.Fatal("CreateExport succeeded, want enqueue error")
+	}
+
+	for _, job := range repo.jobs {
+		if job.Status != models.ExportJobStatusFailed {
+			t.Fatalf("job status = %s, want failed", job.Status)
+		}
+	}
+}
+
+func TestExportService_RunExport_WritesCSVAcrossPages(t *testing.T) {
+	dir := t.TempDir()
+	repo := newFakeExportJobsRepo()
+	records := &fakeExportRecords{pages: [][]models.FeedbackRecord{
+		{exportTestRecord("q1", "great"), exportTestRecord("q2", "=HYPERLINK(\"x\")")},
+		{exportTestRecord("q3", "ok")},
+	}}
+	svc := NewExportService(repo, records, dir, 3)
+
+	job, _ := repo.Create(context.Background(), &models.CreateExportJobRequest{TenantID: "org-1", Format: "csv"})
+
+	if err := svc.RunExport(context.Background(), job.ID, false); err != nil {
+		t.Fatalf("RunExport: %v", err)
+	}
+
+	if job.Status != models.ExportJobStatusCompleted || job.RecordCount != 3 {
+		t.Fatalf("job = %s with %d records, want completed with 3", job.Status, job.RecordCount)
+	}
+
+	_, path, err := svc.ExportFile(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("ExportFile: %v", err)
+	}
+
+	if filepath.Dir(path) != ExportTenantDir(dir, "org-1") {
+		t.Fatalf("export path %q is not in the tenant dir", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open export: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+
+	if len(rows) != 4 || rows[0][0] != "id" {
+		t.Fatalf("csv has %d rows (header %v), want header + 3", len(rows), rows[0])
+	}
+
+	if got := rows[2][14]; got != "'=HYPERLINK(\"x\")" {
+		t.Fatalf("value_text = %q, want formula neutralized", got)
+	}
+}
+
+func TestExportService_RunExport_WritesJSONL(t *testing.T) {
+	repo := newFakeExportJobsRepo()
+	records := &fakeExportRecords{pages: [][]models.FeedbackRecord{{exportTestRecord("q1", "a"), exportTestRecord("q2", "b")}}}
+	svc := NewExportService(repo, records, t.TempDir(), 3)
+
+	job, _ := repo.Create(context.Background(), &models.CreateExportJobRequest{TenantID: "org-1", Format: "jsonl"})
+
+	if err := svc.RunExport(context.Background(), job.ID, false); err != nil {
+		t.Fatalf("RunExport: %v", err)
+	}
+
+	_, path, err := svc.ExportFile(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("ExportFile: %v", err)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // test temp dir
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"field_id":"q1"`) {
+		t.Fatalf("jsonl = %q, want 2 record lines", data)
+	}
+}
+
+func TestExportService_RunExport_FailsOnlyOnFinalAttempt(t *testing.T) {
+	dir := t.TempDir()
+	repo := newFakeExportJobsRepo()
+	svc := NewExportService(repo, &fakeExportRecords{err: errors.New("db down")}, dir, 3)
+
+	job, _ := repo.Create(context.Background(), &models.CreateExportJobRequest{TenantID: "org-1", Format: "csv"})
+
+	if err := svc.RunExport(context.Background(), job.ID, false); err == nil {
+		t.Fatal("RunExport succeeded, want error")
+	}
+
+	if job.Status != models.ExportJobStatusRunning {
+		t.Fatalf("status after non-final attempt = %s, want running", job.Status)
+	}
+
+	if err := svc.RunExport(context.Background(), job.ID, true); err == nil {
+		t.Fatal("RunExport succeeded, want error")
+	}
+
+	if job.Status != models.ExportJobStatusFailed || repo.failedMsg != exportFailedMessage {
+		t.Fatalf("status after final attempt = %s (%q), want failed", job.Status, repo.failedMsg)
+	}
+
+	entries, _ := os.ReadDir(ExportTenantDir(dir, "org-1"))
+	if len(entries) != 0 {
+		t.Fatalf("failed export left %d files behind", len(entries))
+	}
+}
+
+func TestExportService_RunExport_PurgedMidRunRemovesFile(t *testing.T) {
+	dir := t.TempDir()
+	repo := newFakeExportJobsRepo()
+	records := &fakeExportRecords{pages: [][]models.FeedbackRecord{{exportTestRecord("q1", "a")}}}
+	svc := NewExportService(repo, records, dir, 3)
+
+	job, _ := repo.Create(context.Background(), &models.CreateExportJobRequest{TenantID: "org-1", Format: "csv"})
+	records.onList = func() { delete(repo.jobs, job.ID) }
+
+	if err := svc.RunExport(context.Background(), job.ID, false); err != nil {
+		t.Fatalf("RunExport: %v, want nil for a purged job", err)
+	}
+
+	entries, _ := os.ReadDir(ExportTenantDir(dir, "org-1"))
+	if len(entries) != 0 {
+		t.Fatalf("purged export left %d files behind", len(entries))
+	}
+
+	// A redelivered job whose row is gone is a no-op.
+	if err := svc.RunExport(context.Background(), job.ID, false); err != nil {
+		t.Fatalf("RunExport (redelivery): %v", err)
+	}
+}
+
+func TestExportService_ExportFile_PendingIsConflict(t *testing.T) {
+	repo := newFakeExportJobsRepo()
+	svc := NewExportService(repo, &fakeExportRecords{}, t.TempDir(), 3)
+
+	job, _ := repo.Create(context.Background(), &models.CreateExportJobRequest{TenantID: "org-1", Format: "csv"})
+
+	if _, _, err := svc.ExportFile(context.Background(), job.ID); !errors.Is(err, huberrors.ErrConflict) {
+		t.Fatalf("err = %v, want conflict", err)
+	}
+}
+
+func TestCSVText(t *testing.T) {
+	cases := map[string]string{
+		"":        "",
+		"hello":   "hello",
+		"=1+1":    "'=1+1",
+		"+1":      "'+1",
+		"-1":      "'-1",
+		"@SUM(1)": "'@SUM(1)",
+		"a=b":     "a=b",
+	}
+
+	for in, want := range cases {
+		if got := csvText(in); got != want {
+			t.Errorf("csvText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
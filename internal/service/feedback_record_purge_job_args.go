@@ -0,0 +1,15 @@
+package service
+
+import "github.com/riverqueue/river"
+
+const feedbackRecordPurgeKind = "feedback_record_purge"
+
+// FeedbackRecordPurgeArgs hard-deletes feedback records soft-deleted longer ago than
+// FEEDBACK_RETENTION_DAYS. The worker process schedules it as a periodic job; it carries no
+// arguments since every run sweeps the whole table.
+type FeedbackRecordPurgeArgs struct{}
+
+// Kind returns the River job kind.
+func (FeedbackRecordPurgeArgs) Kind() string { return feedbackRecordPurgeKind }
+
+var _ river.JobArgs = FeedbackRecordPurgeArgs{}
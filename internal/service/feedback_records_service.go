@@ -5,7 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"math"
+	"slices"
 	"strings"
 	"time"
 
@@ -44,6 +47,9 @@ const uniqueByPeriodEmbedding = 24 * time.Hour
 // FeedbackRecordsRepository defines the interface for feedback records data access.
 type FeedbackRecordsRepository interface { //nolint:interfacebloat // one cohesive feedback-record data-access boundary.
 	Create(ctx context.Context, req *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error)
+	CreateWithEmbedding(
+		ctx context.Context, req *models.CreateFeedbackRecordRequest, model string, embedding []float32,
+	) (*models.FeedbackRecord, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.FeedbackRecord, error)
 	List(ctx context.Context, filters *models.ListFeedbackRecordsFilters) ([]models.FeedbackRecord, bool, error)
 	ListAfterCursor(
@@ -67,6 +73,15 @@ type FeedbackRecordsRepository interface { //nolint:interfacebloat // one cohesi
 	ListSentimentBackfillTargets(ctx context.Context, afterID uuid.UUID, limit int) ([]uuid.UUID, error)
 	ListEmotionsBackfillTargets(ctx context.Context, afterID uuid.UUID, limit int) ([]uuid.UUID, error)
 	Count(ctx context.Context, filters *models.ListFeedbackRecordsFilters) (int, error)
+	ListTagCounts(ctx context.Context, tenantID string, limit int) ([]models.FeedbackRecordTagCount, error)
+	SampleTagCounts(
+		ctx context.Context, tenantID string, limit int, samplePercent float64,
+	) ([]models.FeedbackRecordTagCount, error)
+	EstimateRowCount(ctx context.Context) (int64, error)
+	Aggregate(ctx context.Context, filters *models.FeedbackRecordStatsFilters) (*models.FeedbackRecordStatsResponse, error)
+	CreateBatch(
+		ctx context.Context, reqs []*models.CreateFeedbackRecordRequest,
+	) (records []*models.FeedbackRecord, errs []error, err error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteByUser(ctx context.Context, filters *models.DeleteFeedbackRecordsByUserFilters) ([]models.DeletedFeedbackRecordsByTenant, error)
 }
@@ -87,6 +102,9 @@ type EmbeddingsRepository interface {
 	ListFeedbackRecordIDsForBackfillByInputKind(
 		ctx context.Context, model string, inputKind models.EmbeddingInputKind, afterID uuid.UUID, limit int,
 	) ([]uuid.UUID, error)
+	ClearEmbeddingsForReembed(
+		ctx context.Context, model string, filter *models.ReembedRequest, afterID uuid.UUID, limit int,
+	) ([]uuid.UUID, error)
 }
 
 // EnrichmentClearMetrics records enrichment outputs nulled by an edit's eager-clear, labeled by
@@ -106,8 +124,35 @@ type FeedbackRecordsService struct {
 	embeddingInserter      RiverJobInserter
 	embeddingQueueName     string
 	embeddingMaxAttempts   int
+	embeddingBulkPriority  int
+	embeddingBatchSize     int
 	translationDefaultLang string
 	clearMetrics           EnrichmentClearMetrics
+	syncEmbedder           *SyncEmbedder
+	facets                 FacetSettings
+	rejectImpreciseNumbers bool
+	backfillPacing         BackfillPacing
+	batchMaxRecords        int
+	batchEmbeddings        BatchEmbeddingEnqueuer
+}
+
+// DefaultFeedbackRecordBatchMaxRecords is the most records one batch create takes when
+// SetBatchMaxRecords was not called (FEEDBACK_BATCH_MAX_RECORDS).
+const DefaultFeedbackRecordBatchMaxRecords = 500
+
+// BatchEmbeddingEnqueuer enqueues the raw embeddings of a batch create's records together. It marks
+// each record it enqueued with EmbeddingEnqueued, so the per-record job for its created event is
+// skipped; records it leaves unmarked are embedded from their events.
+type BatchEmbeddingEnqueuer interface {
+	EnqueueBatch(ctx context.Context, records []*models.FeedbackRecord)
+}
+
+// FacetSettings tunes the facet endpoints (FACET_* config). SamplePercent is the share of table
+// pages an approximate facet samples; an exact facet over a table estimated above
+// ApproximateRowThreshold rows suggests approximate mode (0 never suggests).
+type FacetSettings struct {
+	SamplePercent           float64
+	ApproximateRowThreshold int64
 }
 
 // NewFeedbackRecordsService creates a new feedback records service.
@@ -146,21 +191,174 @@ func (s *FeedbackRecordsService) SetEmbeddingInserter(inserter RiverJobInserter)
 	s.embeddingInserter = inserter
 }
 
+// SetEmbeddingBulkPriority sets the River priority (1..4, 1 first) of the jobs BackfillEmbeddings
+// and ReembedFeedbackRecords enqueue (EMBEDDING_BULK_JOB_PRIORITY). Event-driven embedding jobs
+// keep River's default of 1, so at a lower bulk priority the workers always take newly written
+// feedback first and work through a bulk run in the gaps. Unset (0) is River's default.
+func (s *FeedbackRecordsService) SetEmbeddingBulkPriority(priority int) {
+	s.embeddingBulkPriority = priority
+}
+
+// SetEmbeddingBatchSize makes BackfillEmbeddings enqueue one FeedbackEmbeddingBatchArgs job per
+// size records (the backfill command's -batch-size), so each job embeds its records with one
+// provider call. <= 1 keeps one job per record; the size is capped at MaxEmbeddingBatchSize.
+func (s *FeedbackRecordsService) SetEmbeddingBatchSize(size int) {
+	s.embeddingBatchSize = min(size, MaxEmbeddingBatchSize)
+}
+
+// bulkEmbeddingInsertOpts is the insert config shared by the embedding backfill and re-embed runs.
+func (s *FeedbackRecordsService) bulkEmbeddingInsertOpts() *river.InsertOpts {
+	return &river.InsertOpts{
+		Queue:       s.embeddingQueueName,
+		MaxAttempts: s.embeddingMaxAttempts,
+		Priority:    s.embeddingBulkPriority,
+		UniqueOpts:  river.UniqueOpts{ByArgs: true, ByPeriod: uniqueByPeriodEmbedding},
+	}
+}
+
+// SetBackfillPacing throttles the backfills and re-embeds this service runs (the backfill
+// commands' -concurrency and -rate flags). Unset, they insert one job at a time, unthrottled.
+func (s *FeedbackRecordsService) SetBackfillPacing(pacing BackfillPacing) {
+	s.backfillPacing = pacing
+}
+
 // SetTaxonomyEmbeddingModel sets the model key used for taxonomy-specific translated embeddings.
 func (s *FeedbackRecordsService) SetTaxonomyEmbeddingModel(model string) {
 	s.taxonomyEmbeddingModel = strings.TrimSpace(model)
 }
 
+// SetFacets configures approximate facet counting. Unset, approximate facets sample
+// defaultFacetSamplePercent of the table and exact ones never suggest approximate mode.
+func (s *FeedbackRecordsService) SetFacets(settings FacetSettings) {
+	s.facets = settings
+}
+
 // SetEnrichmentClearMetrics enables the eager-clear counter. Wire it on the API service instance
 // (the eager-clear fires on UpdateFeedbackRecord); leaving it unset disables the metric.
 func (s *FeedbackRecordsService) SetEnrichmentClearMetrics(m EnrichmentClearMetrics) {
 	s.clearMetrics = m
 }
 
-// CreateFeedbackRecord creates a new feedback record.
+// SetRejectImpreciseValueNumber makes creates and updates whose value_number lies beyond float64's
+// exact integer range fail validation instead of being stored rounded with a warning
+// (REJECT_IMPRECISE_VALUE_NUMBER).
+func (s *FeedbackRecordsService) SetRejectImpreciseValueNumber(reject bool) {
+	s.rejectImpreciseNumbers = reject
+}
+
+// SetSyncEmbedder enables CreateFeedbackRecordWithSyncEmbedding's inline path. Wire it on the API
+// service instance only when embeddings are enabled; leaving it unset makes sync requests behave
+// exactly like CreateFeedbackRecord (async embedding via the queue).
+func (s *FeedbackRecordsService) SetSyncEmbedder(e *SyncEmbedder) {
+	s.syncEmbedder = e
+}
+
+// SetBatchMaxRecords caps how many records one CreateFeedbackRecordsBatch call takes
+// (FEEDBACK_BATCH_MAX_RECORDS). Unset (0), the cap is DefaultFeedbackRecordBatchMaxRecords.
+func (s *FeedbackRecordsService) SetBatchMaxRecords(maxRecords int) {
+	s.batchMaxRecords = maxRecords
+}
+
+// SetBatchEmbeddingEnqueuer makes batch creates enqueue their records' raw embeddings together
+// (the primary EmbeddingProvider). Unset, each record is embedded from its created event.
+func (s *FeedbackRecordsService) SetBatchEmbeddingEnqueuer(enqueuer BatchEmbeddingEnqueuer) {
+	s.batchEmbeddings = enqueuer
+}
+
+// createEmbedding is how a create handles the new record's embedding.
+type createEmbedding int
+
+const (
+	createEmbeddingAsync createEmbedding = iota
+	createEmbeddingSync
+	createEmbeddingSkip
+)
+
+// CreateFeedbackRecord creates a new feedback record. Its embedding (when enabled) is computed
+// asynchronously by the embeddings queue.
 func (s *FeedbackRecordsService) CreateFeedbackRecord(
 	ctx context.Context, req *models.CreateFeedbackRecordRequest,
 ) (*models.FeedbackRecord, error) {
+	return s.createFeedbackRecord(ctx, req, createEmbeddingAsync)
+}
+
+// CreateFeedbackRecordWithSyncEmbedding creates a new feedback record and, when a SyncEmbedder is
+// configured, computes and stores its raw embedding before returning so the record is immediately
+// searchable. The record is created regardless of the embedding outcome: an inline failure
+// (timeout, no free slot, provider error, write race) is logged and the record falls back to the
+// normal async job, so the call only fails when the create itself fails.
+func (s *FeedbackRecordsService) CreateFeedbackRecordWithSyncEmbedding(
+	ctx context.Context, req *models.CreateFeedbackRecordRequest,
+) (*models.FeedbackRecord, error) {
+	if s.syncEmbedder == nil {
+		return s.createFeedbackRecord(ctx, req, createEmbeddingAsync)
+	}
+
+	return s.createFeedbackRecord(ctx, req, createEmbeddingSync)
+}
+
+// CreateFeedbackRecordWithoutEmbedding creates a new feedback record without enqueueing its
+// embedding jobs (skip_embedding=true). It is meant for large historical imports: the live
+// embeddings queue stays free for new feedback, and the imported records are embedded later by
+// the embedding backfill, which picks up every record that has no embedding. Other enrichments
+// and webhooks see the created event as usual, and a later value_text edit embeds the record
+// normally.
+func (s *FeedbackRecordsService) CreateFeedbackRecordWithoutEmbedding(
+	ctx context.Context, req *models.CreateFeedbackRecordRequest,
+) (*models.FeedbackRecord, error) {
+	if len(req.Embedding) > 0 {
+		return nil, huberrors.NewValidationError("embedding", "cannot be combined with skip_embedding=true")
+	}
+
+	return s.createFeedbackRecord(ctx, req, createEmbeddingSkip)
+}
+
+func (s *FeedbackRecordsService) createFeedbackRecord(
+	ctx context.Context, req *models.CreateFeedbackRecordRequest, embedding createEmbedding,
+) (*models.FeedbackRecord, error) {
+	normalizedReq, err := s.normalizeCreateRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(normalizedReq.Embedding) > 0 {
+		return s.createFeedbackRecordWithSuppliedEmbedding(ctx, normalizedReq)
+	}
+
+	record, err := s.repo.Create(ctx, normalizedReq)
+	if err != nil {
+		return nil, fmt.Errorf("create feedback record: %w", err)
+	}
+
+	// A replayed create wrote nothing: its embedding and event belong to the original request.
+	if record.Replayed {
+		return record, nil
+	}
+
+	switch embedding {
+	case createEmbeddingSync:
+		record.EmbeddedInline = s.embedInline(ctx, record)
+	case createEmbeddingSkip:
+		record.SkipEmbedding = true
+	case createEmbeddingAsync:
+		// The embedding provider enqueues the job from the created event.
+	}
+
+	// Published after the inline attempt so EmbeddingProvider sees EmbeddedInline and skips the
+	// now-redundant job; on fallback the flag is false and the job is enqueued as usual.
+	if s.publisher != nil {
+		s.publisher.PublishEvent(ctx, datatypes.FeedbackRecordCreated, record)
+	}
+
+	return record, nil
+}
+
+// normalizeCreateRequest returns a normalized copy of a create request (trimmed tenant_id, tags and
+// Idempotency-Key) after the checks struct validation cannot express: typed values matching
+// field_type and value_number precision.
+func (s *FeedbackRecordsService) normalizeCreateRequest(
+	ctx context.Context, req *models.CreateFeedbackRecordRequest,
+) (*models.CreateFeedbackRecordRequest, error) {
 	normalizedTenantID, err := normalizeRequiredTenantIDValue(req.TenantID)
 	if err != nil {
 		return nil, err
@@ -169,11 +367,155 @@ func (s *FeedbackRecordsService) CreateFeedbackRecord(
 	normalizedReq := *req
 	normalizedReq.TenantID = normalizedTenantID
 
-	record, err := s.repo.Create(ctx, &normalizedReq)
+	if normalizedReq.Tags, err = normalizeTags(req.Tags); err != nil {
+		return nil, err
+	}
+
+	// Validated like an identifier (trimmed, at most 255 characters); blank means no key.
+	if normalizedReq.IdempotencyKey, err = normalizeOptionalIdentifier("Idempotency-Key", req.IdempotencyKey); err != nil {
+		return nil, err
+	}
+
+	if err := models.CheckTypedValues(req.FieldType, req.ValueNumber, req.ValueBoolean, req.ValueDate); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkValueNumberPrecision(ctx, req.ValueNumber,
+		"tenant_id", normalizedTenantID, "field_id", req.FieldID); err != nil {
+		return nil, err
+	}
+
+	return &normalizedReq, nil
+}
+
+// CreateFeedbackRecordsBatch creates the records of a batch (POST /v1/feedback-records/batch) in
+// one transaction and sets each item's Record or Err. A record that fails normalization or is
+// rejected by the database fails alone; the returned error is for the batch as a whole (too many
+// records, a tenant under purge, a database failure), in which case no record was created.
+// Records may not carry an embedding, and a batch has no Idempotency-Key.
+//
+// The created records' raw embeddings are enqueued together, as one batch job, when a batch
+// enqueuer is set; a record it did not take is embedded from its created event as usual.
+func (s *FeedbackRecordsService) CreateFeedbackRecordsBatch(ctx context.Context, items []models.FeedbackRecordBatchItem) error {
+	return s.createFeedbackRecordsBatch(ctx, items, createEmbeddingAsync)
+}
+
+// CreateFeedbackRecordsBatchWithoutEmbedding is CreateFeedbackRecordsBatch with skip_embedding=true:
+// no embedding job is enqueued for the created records, which the embedding backfill picks up
+// later (see CreateFeedbackRecordWithoutEmbedding).
+func (s *FeedbackRecordsService) CreateFeedbackRecordsBatchWithoutEmbedding(
+	ctx context.Context, items []models.FeedbackRecordBatchItem,
+) error {
+	return s.createFeedbackRecordsBatch(ctx, items, createEmbeddingSkip)
+}
+
+func (s *FeedbackRecordsService) createFeedbackRecordsBatch(
+	ctx context.Context, items []models.FeedbackRecordBatchItem, embedding createEmbedding,
+) error {
+	if len(items) == 0 {
+		return huberrors.NewValidationError("records", "must contain at least one record")
+	}
+
+	maxRecords := s.batchMaxRecords
+	if maxRecords <= 0 {
+		maxRecords = DefaultFeedbackRecordBatchMaxRecords
+	}
+
+	if len(items) > maxRecords {
+		return huberrors.NewValidationError("records", fmt.Sprintf(
+			"must contain at most %d records (got %d); split the import into several batches", maxRecords, len(items)))
+	}
+
+	indexes := make([]int, 0, len(items))
+	reqs := make([]*models.CreateFeedbackRecordRequest, 0, len(items))
+
+	for i := range items {
+		item := &items[i]
+		if item.Err != nil {
+			continue
+		}
+
+		if len(item.Request.Embedding) > 0 {
+			item.Err = huberrors.NewValidationError("embedding",
+				"is not supported on batch create; create the record on its own to supply an embedding")
+
+			continue
+		}
+
+		req, err := s.normalizeCreateRequest(ctx, item.Request)
+		if err != nil {
+			item.Err = err
+
+			continue
+		}
+
+		indexes = append(indexes, i)
+		reqs = append(reqs, req)
+	}
+
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	records, errs, err := s.repo.CreateBatch(ctx, reqs)
 	if err != nil {
-		return nil, fmt.Errorf("create feedback record: %w", err)
+		return fmt.Errorf("create feedback record batch: %w", err)
+	}
+
+	created := make([]*models.FeedbackRecord, 0, len(records))
+
+	for j, i := range indexes {
+		items[i].Record, items[i].Err = records[j], errs[j]
+		if records[j] != nil {
+			created = append(created, records[j])
+		}
+	}
+
+	switch {
+	case embedding == createEmbeddingSkip:
+		for _, record := range created {
+			record.SkipEmbedding = true
+		}
+	case s.batchEmbeddings != nil:
+		s.batchEmbeddings.EnqueueBatch(ctx, created)
 	}
 
+	if s.publisher != nil {
+		for _, record := range created {
+			s.publisher.PublishEvent(ctx, datatypes.FeedbackRecordCreated, record)
+		}
+	}
+
+	return nil
+}
+
+// createFeedbackRecordWithSuppliedEmbedding stores a record with its caller-computed embedding in
+// one transaction and skips the embedding job (the vector is already there; re-embedding would pay
+// the provider for a vector the client already has). The vector is stored under the configured
+// model, so it is only accepted when embeddings are enabled and must match the column dimension.
+func (s *FeedbackRecordsService) createFeedbackRecordWithSuppliedEmbedding(
+	ctx context.Context, req *models.CreateFeedbackRecordRequest,
+) (*models.FeedbackRecord, error) {
+	if s.embeddingModel == "" {
+		return nil, huberrors.NewValidationError("embedding", "embeddings are not enabled on this deployment; omit embedding")
+	}
+
+	if len(req.Embedding) != models.EmbeddingVectorDimensions {
+		return nil, huberrors.NewValidationError("embedding", fmt.Sprintf(
+			"must have exactly %d dimensions (got %d)", models.EmbeddingVectorDimensions, len(req.Embedding)))
+	}
+
+	record, err := s.repo.CreateWithEmbedding(ctx, req, s.embeddingModel, req.Embedding)
+	if err != nil {
+		return nil, fmt.Errorf("create feedback record with embedding: %w", err)
+	}
+
+	if record.Replayed {
+		return record, nil
+	}
+
+	record.EmbeddedInline = true
+
 	if s.publisher != nil {
 		s.publisher.PublishEvent(ctx, datatypes.FeedbackRecordCreated, record)
 	}
@@ -181,6 +523,41 @@ func (s *FeedbackRecordsService) CreateFeedbackRecord(
 	return record, nil
 }
 
+// embedInline computes and stores the record's raw embedding on the request path and reports
+// whether it was stored. Every failure is non-fatal (the async job takes over), so errors are
+// only logged. The write goes through SetEmbedding with the same supersession guard the worker
+// uses, so an edit racing the create can never be overwritten by the inline vector.
+func (s *FeedbackRecordsService) embedInline(ctx context.Context, record *models.FeedbackRecord) bool {
+	log := slog.With("feedback_record_id", record.ID)
+
+	input, vector, err := s.syncEmbedder.embed(ctx, record)
+	if err != nil {
+		log.Warn("sync embedding: falling back to async job", "error", err)
+
+		return false
+	}
+
+	if input == "" {
+		return false
+	}
+
+	stillCurrent := func(fieldLabel, valueText, valueTextTranslated *string) bool {
+		return BuildEmbeddingInputFromValues(
+			fieldLabel, valueText, valueTextTranslated, models.EmbeddingInputKindRaw, s.syncEmbedder.docPrefix,
+		) == input
+	}
+
+	if err := s.SetEmbedding(ctx, record.ID, s.embeddingModel, vector, stillCurrent); err != nil {
+		log.Warn("sync embedding: store failed, falling back to async job", "error", err)
+
+		return false
+	}
+
+	log.Debug("sync embedding: stored inline")
+
+	return true
+}
+
 // GetFeedbackRecord retrieves a single feedback record by ID.
 func (s *FeedbackRecordsService) GetFeedbackRecord(ctx context.Context, id uuid.UUID) (*models.FeedbackRecord, error) {
 	record, err := s.repo.GetByID(ctx, id)
@@ -301,6 +678,10 @@ func (s *FeedbackRecordsService) ListFeedbackRecords(
 		filters.Limit = 100
 	}
 
+	if err := normalizeTagFilters(filters); err != nil {
+		return nil, err
+	}
+
 	cursorStr := strings.TrimSpace(filters.Cursor)
 
 	var (
@@ -340,6 +721,47 @@ func (s *FeedbackRecordsService) ListFeedbackRecords(
 	}, nil
 }
 
+// StreamFeedbackRecords writes every record matching filters to w as format (the same encodings as
+// the asynchronous export files), reading one keyset page at a time so memory stays bounded by a
+// page however large the selection. filters.Cursor resumes after that record; filters.Limit is
+// ignored. pageDone runs after each page is encoded — the handler flushes there. Nothing is
+// written before the first page has been read, so errors up to that point can still be answered
+// as an ordinary error response. filters.TenantID is required: an export never spans tenants. It
+// returns the number of records written.
+func (s *FeedbackRecordsService) StreamFeedbackRecords(
+	ctx context.Context,
+	filters *models.ListFeedbackRecordsFilters,
+	format models.ExportFormat,
+	w io.Writer,
+	pageDone func() error,
+) (int64, error) {
+	if filters == nil {
+		filters = &models.ListFeedbackRecordsFilters{}
+	}
+
+	tenantID, err := normalizeRequiredTenantID(filters.TenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	filters.TenantID = &tenantID
+
+	if err := normalizeTagFilters(filters); err != nil {
+		return 0, err
+	}
+
+	filters.Limit = exportPageSize
+	writer := newExportWriter(format, w)
+
+	return streamFeedbackRecords(ctx, s.repo, filters, writer.write, func() error {
+		if err := writer.flush(); err != nil {
+			return err
+		}
+
+		return pageDone()
+	})
+}
+
 // CountFeedbackRecords returns the count of feedback records matching the given filters.
 func (s *FeedbackRecordsService) CountFeedbackRecords(
 	ctx context.Context, filters *models.ListFeedbackRecordsFilters,
@@ -348,6 +770,10 @@ func (s *FeedbackRecordsService) CountFeedbackRecords(
 		filters = &models.ListFeedbackRecordsFilters{}
 	}
 
+	if err := normalizeTagFilters(filters); err != nil {
+		return 0, err
+	}
+
 	count, err := s.repo.Count(ctx, filters)
 	if err != nil {
 		return 0, fmt.Errorf("count feedback records: %w", err)
@@ -356,6 +782,139 @@ func (s *FeedbackRecordsService) CountFeedbackRecords(
 	return count, nil
 }
 
+// defaultTagFacetLimit is the tag facet's page size when the caller sets none.
+const defaultTagFacetLimit = 100
+
+// defaultFacetSamplePercent is the approximate facets' sample size when SetFacets was not called.
+const defaultFacetSamplePercent = 1.0
+
+// ListFeedbackRecordTags returns the tenant's tags with per-tag record counts, most-used first.
+// With filters.Approximate the counts are estimated from a sample of the table. An exact answer
+// is flagged ApproximateSuggested when the table's row estimate exceeds the configured threshold;
+// the hint is best-effort, so a failed estimate is logged and the facet still served.
+func (s *FeedbackRecordsService) ListFeedbackRecordTags(
+	ctx context.Context, filters *models.ListFeedbackRecordTagsFilters,
+) (*models.ListFeedbackRecordTagsResponse, error) {
+	tenantID, err := normalizeRequiredTenantIDValue(filters.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = defaultTagFacetLimit
+	}
+
+	if filters.Approximate {
+		samplePercent := s.facets.SamplePercent
+		if samplePercent <= 0 {
+			samplePercent = defaultFacetSamplePercent
+		}
+
+		counts, err := s.repo.SampleTagCounts(ctx, tenantID, limit, samplePercent)
+		if err != nil {
+			return nil, fmt.Errorf("sample feedback record tags: %w", err)
+		}
+
+		return &models.ListFeedbackRecordTagsResponse{Data: counts, Approximate: true}, nil
+	}
+
+	counts, err := s.repo.ListTagCounts(ctx, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list feedback record tags: %w", err)
+	}
+
+	resp := &models.ListFeedbackRecordTagsResponse{Data: counts}
+
+	if threshold := s.facets.ApproximateRowThreshold; threshold > 0 {
+		estimate, err := s.repo.EstimateRowCount(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "list feedback record tags: row estimate failed", "error", err)
+		} else {
+			resp.ApproximateSuggested = estimate > threshold
+		}
+	}
+
+	return resp, nil
+}
+
+// GetFeedbackRecordStats returns the tenant's record counts by source_type, by field_type, and
+// per UTC day over the filters' collected_at range, for dashboards that chart volume without
+// listing records.
+func (s *FeedbackRecordsService) GetFeedbackRecordStats(
+	ctx context.Context, filters *models.FeedbackRecordStatsFilters,
+) (*models.FeedbackRecordStatsResponse, error) {
+	tenantID, err := normalizeRequiredTenantIDValue(filters.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if filters.Since != nil && filters.Until != nil && filters.Since.After(*filters.Until) {
+		return nil, huberrors.NewValidationError("since", "must not be after until")
+	}
+
+	normalized := *filters
+	normalized.TenantID = tenantID
+
+	stats, err := s.repo.Aggregate(ctx, &normalized)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate feedback records: %w", err)
+	}
+
+	return stats, nil
+}
+
+// maxExactValueNumber is the largest magnitude below which every integer survives the float64
+// value_number column unchanged (2^53-1, JavaScript's Number.MAX_SAFE_INTEGER).
+const maxExactValueNumber = 1<<53 - 1
+
+// checkValueNumberPrecision flags a value_number that may already have been rounded: beyond 2^53-1
+// neighbouring integers share a float64, so 9007199254740993 arrives as ...992. Only the decoded
+// value is seen here, so a long decimal fraction that rounded is not detectable; the API docs say
+// as much. logAttrs identify the write in the warning.
+func (s *FeedbackRecordsService) checkValueNumberPrecision(ctx context.Context, value *float64, logAttrs ...any) error {
+	if value == nil || math.Abs(*value) <= maxExactValueNumber {
+		return nil
+	}
+
+	if s.rejectImpreciseNumbers {
+		return huberrors.NewValidationError("value_number",
+			"exceeds 2^53-1 in magnitude and cannot be stored exactly; send exact large numbers as value_text")
+	}
+
+	slog.WarnContext(ctx, "feedback record value_number exceeds exact float64 integer range; stored rounded",
+		append(logAttrs, "value_number", *value)...)
+
+	return nil
+}
+
+// normalizeTags canonicalizes caller-supplied tags (models.NormalizeTags), rejecting blank ones.
+func normalizeTags(tags []string) ([]string, error) {
+	normalized, ok := models.NormalizeTags(tags)
+	if !ok {
+		return nil, huberrors.NewValidationError("tags", "tags must not be blank")
+	}
+
+	return normalized, nil
+}
+
+// normalizeTagFilters puts the tag filter in the same canonical form tags are stored in, so
+// ?tag=Bug matches records tagged "bug".
+func normalizeTagFilters(filters *models.ListFeedbackRecordsFilters) error {
+	if len(filters.Tags) == 0 {
+		return nil
+	}
+
+	normalized, ok := models.NormalizeTags(filters.Tags)
+	if !ok {
+		return huberrors.NewValidationError("tag", "tag must not be blank")
+	}
+
+	filters.Tags = normalized
+
+	return nil
+}
+
 // UpdateFeedbackRecord updates an existing feedback record.
 func (s *FeedbackRecordsService) UpdateFeedbackRecord(
 	ctx context.Context, id uuid.UUID, req *models.UpdateFeedbackRecordRequest,
@@ -364,6 +923,32 @@ func (s *FeedbackRecordsService) UpdateFeedbackRecord(
 	// event carries the fields that ACTUALLY changed: an integration idempotently re-PATCHing the
 	// same values must not re-fire webhooks or re-run every LLM enrichment, and the diff is
 	// computed against state consistent with the write (no pre-lock snapshot race).
+	if req.Tags != nil {
+		tags, err := normalizeTags(*req.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		normalizedReq := *req
+		normalizedReq.Tags = &tags
+		req = &normalizedReq
+	}
+
+	if req.TenantID != nil {
+		tenantID, err := normalizeRequiredTenantIDValue(*req.TenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		normalizedReq := *req
+		normalizedReq.TenantID = &tenantID
+		req = &normalizedReq
+	}
+
+	if err := s.checkValueNumberPrecision(ctx, req.ValueNumber, "feedback_record_id", id); err != nil {
+		return nil, err
+	}
+
 	record, previous, err := s.repo.Update(ctx, id, req)
 	if err != nil {
 		return nil, fmt.Errorf("update feedback record: %w", err)
@@ -374,14 +959,7 @@ func (s *FeedbackRecordsService) UpdateFeedbackRecord(
 	// would re-trigger webhooks and enrichment for free — including while the tenant is under a
 	// data purge.
 	if s.publisher != nil {
-		changed := req.ChangedFields()
-		if previous != nil {
-			changed = req.FieldsChangedFrom(previous)
-		}
-
-		if len(changed) > 0 {
-			s.publisher.PublishEventWithChangedFields(ctx, datatypes.FeedbackRecordUpdated, record, changed)
-		}
+		s.publishUpdate(ctx, req, previous, record)
 	}
 
 	// Make the eager-clear observable: which enrichment outputs this edit nulled (derived from
@@ -401,6 +979,34 @@ func (s *FeedbackRecordsService) UpdateFeedbackRecord(
 	return record, nil
 }
 
+// publishUpdate publishes the events of an applied update: "updated" with the fields that
+// changed, or none for a no-op. A tenant move is a delete in the old tenant and a create in the
+// new one instead: an "updated" event routes to the new tenant only, so the old tenant's webhooks
+// would keep a record that left it. The create also re-runs enrichment under the new tenant's
+// settings.
+func (s *FeedbackRecordsService) publishUpdate(
+	ctx context.Context, req *models.UpdateFeedbackRecordRequest, previous, record *models.FeedbackRecord,
+) {
+	if previous != nil && previous.TenantID != record.TenantID {
+		s.publisher.PublishEvent(ctx, datatypes.FeedbackRecordDeleted, models.DeletedIDsEventData{
+			TenantID: previous.TenantID,
+			IDs:      []uuid.UUID{record.ID},
+		})
+		s.publisher.PublishEvent(ctx, datatypes.FeedbackRecordCreated, record)
+
+		return
+	}
+
+	changed := req.ChangedFields()
+	if previous != nil {
+		changed = req.FieldsChangedFrom(previous)
+	}
+
+	if len(changed) > 0 {
+		s.publisher.PublishEventWithChangedFields(ctx, datatypes.FeedbackRecordUpdated, record, changed)
+	}
+}
+
 // clearedEnrichmentFields lists the enrichment outputs the update's eager-clear nulled — present
 // on the pre-update row, absent on the updated one.
 func clearedEnrichmentFields(previous, updated *models.FeedbackRecord) []string {
@@ -425,8 +1031,9 @@ func clearedEnrichmentFields(previous, updated *models.FeedbackRecord) []string
 	return cleared
 }
 
-// DeleteFeedbackRecord deletes a feedback record by ID.
-// Publishes FeedbackRecordDeleted with tenant-aware deleted IDs for webhook isolation.
+// DeleteFeedbackRecord soft-deletes a feedback record by ID; the worker purges it after the
+// retention window (FEEDBACK_RETENTION_DAYS). Subscribers see the delete at once: publishes
+// FeedbackRecordDeleted with tenant-aware deleted IDs for webhook isolation.
 func (s *FeedbackRecordsService) DeleteFeedbackRecord(ctx context.Context, id uuid.UUID) error {
 	record, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -547,29 +1154,27 @@ func (s *FeedbackRecordsService) BackfillEmbeddingsWithInputKind(
 	}
 
 	inputKind = models.NormalizeEmbeddingInputKind(inputKind)
+	opts := s.bulkEmbeddingInsertOpts()
 
-	opts := &river.InsertOpts{
-		Queue:       s.embeddingQueueName,
-		MaxAttempts: s.embeddingMaxAttempts,
-		UniqueOpts:  river.UniqueOpts{ByArgs: true, ByPeriod: uniqueByPeriodEmbedding},
+	if s.embeddingBatchSize > 1 {
+		return s.backfillEmbeddingBatches(ctx, model, inputKind, opts)
 	}
 
-	enqueued := 0
-	skipped := 0
-	afterID := uuid.Nil
-
-	for {
-		ids, err := s.embeddingsRepo.ListFeedbackRecordIDsForBackfillByInputKind(
-			ctx, model, inputKind, afterID, embeddingBackfillPageSize)
-		if err != nil {
-			return enqueued, fmt.Errorf("list ids for embedding backfill: %w", err)
-		}
-
-		if len(ids) == 0 {
-			break
-		}
+	// The query excludes already-embedded records, so the keyset cursor always moves forward; a
+	// duplicate skipped by the unique insert (a still-pending job from an earlier run) is not an
+	// enqueue and is counted apart.
+	return backfillPaged(ctx, s.backfillPacing, "embedding", embeddingBackfillPageSize,
+		func(afterID uuid.UUID) ([]uuid.UUID, error) {
+			ids, err := s.embeddingsRepo.ListFeedbackRecordIDsForBackfillByInputKind(
+				ctx, model, inputKind, afterID, embeddingBackfillPageSize)
+			if err != nil {
+				return nil, fmt.Errorf("list ids for embedding backfill: %w", err)
+			}
 
-		for _, id := range ids {
+			return ids, nil
+		},
+		func(id uuid.UUID) uuid.UUID { return id },
+		func(ctx context.Context, id uuid.UUID) (bool, error) {
 			res, err := s.embeddingInserter.Insert(ctx, FeedbackEmbeddingArgs{
 				FeedbackRecordID: id,
 				Model:            model,
@@ -577,35 +1182,98 @@ func (s *FeedbackRecordsService) BackfillEmbeddingsWithInputKind(
 				ValueTextHash:    "backfill:" + string(inputKind),
 			}, opts)
 			if err != nil {
-				return enqueued, fmt.Errorf("enqueue embedding job for %s: %w", id, err)
+				return false, fmt.Errorf("enqueue embedding job for %s: %w", id, err)
 			}
 
-			// A duplicate skipped by the unique insert (a still-pending job from an earlier
-			// run) is not an enqueue — count it truthfully.
-			if res != nil && res.UniqueSkippedAsDuplicate {
-				skipped++
+			return res != nil && res.UniqueSkippedAsDuplicate, nil
+		})
+}
 
-				continue
+// backfillEmbeddingBatches is BackfillEmbeddingsWithInputKind in batch mode: each page of target
+// ids is split into batches of embeddingBatchSize, one FeedbackEmbeddingBatchArgs job each. The
+// count returned (and reported as progress) is of batch jobs, not records.
+func (s *FeedbackRecordsService) backfillEmbeddingBatches(
+	ctx context.Context, model string, inputKind models.EmbeddingInputKind, opts *river.InsertOpts,
+) (int, error) {
+	// Every full page splits into the same number of batches, so that count is backfillPaged's page
+	// size: a short last page still stops the loop, or at worst costs one empty page query.
+	batchesPerPage := (embeddingBackfillPageSize + s.embeddingBatchSize - 1) / s.embeddingBatchSize
+
+	return backfillPaged(ctx, s.backfillPacing, "embedding", batchesPerPage,
+		func(afterID uuid.UUID) ([][]uuid.UUID, error) {
+			ids, err := s.embeddingsRepo.ListFeedbackRecordIDsForBackfillByInputKind(
+				ctx, model, inputKind, afterID, embeddingBackfillPageSize)
+			if err != nil {
+				return nil, fmt.Errorf("list ids for embedding backfill: %w", err)
 			}
 
-			enqueued++
-		}
+			return slices.Collect(slices.Chunk(ids, s.embeddingBatchSize)), nil
+		},
+		func(batch []uuid.UUID) uuid.UUID { return batch[len(batch)-1] },
+		func(ctx context.Context, batch []uuid.UUID) (bool, error) {
+			res, err := s.embeddingInserter.Insert(ctx, FeedbackEmbeddingBatchArgs{
+				FeedbackRecordIDs: batch,
+				Model:             model,
+				InputKind:         inputKind,
+			}, opts)
+			if err != nil {
+				return false, fmt.Errorf("enqueue embedding batch job for %d records: %w", len(batch), err)
+			}
 
-		// Advance the keyset cursor past the last id seen; the query excludes
-		// already-embedded records, so the cursor always moves forward.
-		afterID = ids[len(ids)-1]
+			return res != nil && res.UniqueSkippedAsDuplicate, nil
+		})
+}
 
-		if len(ids) < embeddingBackfillPageSize {
-			break
-		}
+// ReembedFeedbackRecords clears the current model's embeddings of the tenant's text records matching
+// req and enqueues a fresh embedding job for each (POST /v1/admin/reembed) — the runtime, filterable
+// counterpart of cmd/backfill-embeddings, for re-embedding after a provider change that kept the
+// model name. Pages are cleared then enqueued in turn, so a failure part-way leaves at most one
+// page cleared without jobs; re-running the same request picks it up again. Each call is its own
+// run (a fresh dedupe hash), so a repeat within the unique period is not skipped as a duplicate of
+// the jobs that already completed. Returns the number of jobs enqueued.
+func (s *FeedbackRecordsService) ReembedFeedbackRecords(ctx context.Context, req *models.ReembedRequest) (int, error) {
+	if s.embeddingInserter == nil || s.embeddingQueueName == "" || s.embeddingModel == "" {
+		return 0, ErrEmbeddingBackfillNotConfigured
+	}
+
+	tenantID, err := normalizeRequiredTenantIDValue(req.TenantID)
+	if err != nil {
+		return 0, err
 	}
 
-	if skipped > 0 {
-		slog.Info("embedding backfill: duplicate jobs skipped by unique insert",
-			"skipped", skipped, "enqueued", enqueued)
+	if req.Since != nil && req.Until != nil && req.Since.After(*req.Until) {
+		return 0, huberrors.NewValidationError("since", "must not be after until")
 	}
 
-	return enqueued, nil
+	filter := *req
+	filter.TenantID = tenantID
+	opts := s.bulkEmbeddingInsertOpts()
+	hash := "reembed:" + uuid.NewString()
+
+	return backfillPaged(ctx, s.backfillPacing, "reembed", embeddingBackfillPageSize,
+		func(afterID uuid.UUID) ([]uuid.UUID, error) {
+			ids, err := s.embeddingsRepo.ClearEmbeddingsForReembed(ctx, s.embeddingModel, &filter, afterID, embeddingBackfillPageSize)
+			if err != nil {
+				return nil, fmt.Errorf("clear embeddings for reembed: %w", err)
+			}
+
+			return ids, nil
+		},
+		func(id uuid.UUID) uuid.UUID { return id },
+		func(ctx context.Context, id uuid.UUID) (bool, error) {
+			res, err := s.embeddingInserter.Insert(ctx, FeedbackEmbeddingArgs{
+				FeedbackRecordID: id,
+				Model:            s.embeddingModel,
+				InputKind:        models.EmbeddingInputKindRaw,
+				ValueTextHash:    hash,
+				TenantID:         tenantID,
+			}, opts)
+			if err != nil {
+				return false, fmt.Errorf("enqueue reembed job for %s: %w", id, err)
+			}
+
+			return res != nil && res.UniqueSkippedAsDuplicate, nil
+		})
 }
 
 // BackfillTranslations enqueues a translation job for every feedback record that needs
@@ -622,7 +1290,7 @@ const translationBackfillPageSize = 500
 // BackfillTranslations enqueues a translation job for every feedback record (across all
 // tenants) that needs (re)translation, streaming the targets in keyset pages. Used by the
 // one-off global backfill command. runID discriminates this run's jobs from earlier runs'
-// (see enqueueTranslationBackfillJobs). Returns the number of jobs enqueued.
+// (see enqueueTranslationBackfillJob). Returns the number of jobs enqueued.
 func (s *FeedbackRecordsService) BackfillTranslations(
 	ctx context.Context, inserter RiverJobInserter, queueName string, maxAttempts int, runID string,
 ) (int, error) {
@@ -642,7 +1310,7 @@ func (s *FeedbackRecordsService) BackfillTranslations(
 // tenant that needs (re)translation, streaming in keyset pages so a large tenant is never
 // fully materialized. It is the bulk work behind a settings-change re-translation
 // (TenantTranslationBackfillArgs). runID discriminates this run's jobs from earlier runs'
-// (see enqueueTranslationBackfillJobs). Returns the number of jobs enqueued.
+// (see enqueueTranslationBackfillJob). Returns the number of jobs enqueued.
 func (s *FeedbackRecordsService) BackfillTranslationsForTenant(
 	ctx context.Context, inserter RiverJobInserter, queueName string, maxAttempts int, tenantID, runID string,
 ) (int, error) {
@@ -690,10 +1358,10 @@ func (s *FeedbackRecordsService) backfillTranslationsPaged(
 	runID string,
 	fetchPage func(afterID uuid.UUID) ([]models.TranslationBackfillTarget, error),
 ) (int, error) {
-	return backfillPaged("translation", translationBackfillPageSize, fetchPage,
+	return backfillPaged(ctx, s.backfillPacing, "translation", translationBackfillPageSize, fetchPage,
 		func(target models.TranslationBackfillTarget) uuid.UUID { return target.FeedbackRecordID },
-		func(targets []models.TranslationBackfillTarget) (int, int, error) {
-			return enqueueTranslationBackfillJobs(ctx, inserter, opts, runID, targets)
+		func(ctx context.Context, target models.TranslationBackfillTarget) (bool, error) {
+			return enqueueTranslationBackfillJob(ctx, inserter, opts, runID, target)
 		})
 }
 
@@ -708,35 +1376,24 @@ func translationBackfillInsertOpts(queueName string, maxAttempts int) *river.Ins
 	}
 }
 
-// enqueueTranslationBackfillJobs inserts one FeedbackTranslationArgs per target. The
-// "backfill:<runID>" hash marks these jobs distinct from event-driven ones AND from earlier
-// runs' jobs: River's unique states include completed, so a constant marker would let a
-// completed job from a previous run (e.g. before the tenant's target flip-flopped back the
-// same day) silently swallow this run's re-translation. Within one run the marker is stable,
-// so a rescued/retried fan-out still dedupes its own re-inserted pages. Unique-skipped
-// duplicates are counted separately — never as enqueued. Stops on the first insert error.
-func enqueueTranslationBackfillJobs(
+// enqueueTranslationBackfillJob inserts the FeedbackTranslationArgs for one target and reports
+// whether River skipped it as a duplicate. The "backfill:<runID>" hash marks these jobs distinct
+// from event-driven ones AND from earlier runs' jobs: River's unique states include completed, so
+// a constant marker would let a completed job from a previous run (e.g. before the tenant's target
+// flip-flopped back the same day) silently swallow this run's re-translation. Within one run the
+// marker is stable, so a rescued/retried fan-out still dedupes its own re-inserted pages.
+func enqueueTranslationBackfillJob(
 	ctx context.Context, inserter RiverJobInserter, opts *river.InsertOpts,
-	runID string, targets []models.TranslationBackfillTarget,
-) (enqueued, skipped int, err error) {
-	for _, target := range targets {
-		res, err := inserter.Insert(ctx, FeedbackTranslationArgs{
-			FeedbackRecordID: target.FeedbackRecordID,
-			TargetLang:       target.TargetLang,
-			ValueTextHash:    "backfill:" + runID,
-		}, opts)
-		if err != nil {
-			return enqueued, skipped, fmt.Errorf("enqueue translation job for %s: %w", target.FeedbackRecordID, err)
-		}
-
-		if res != nil && res.UniqueSkippedAsDuplicate {
-			skipped++
-
-			continue
-		}
-
-		enqueued++
+	runID string, target models.TranslationBackfillTarget,
+) (bool, error) {
+	res, err := inserter.Insert(ctx, FeedbackTranslationArgs{
+		FeedbackRecordID: target.FeedbackRecordID,
+		TargetLang:       target.TargetLang,
+		ValueTextHash:    "backfill:" + runID,
+	}, opts)
+	if err != nil {
+		return false, fmt.Errorf("enqueue translation job for %s: %w", target.FeedbackRecordID, err)
 	}
 
-	return enqueued, skipped, nil
+	return res != nil && res.UniqueSkippedAsDuplicate, nil
 }
@@ -1,8 +1,12 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"errors"
+	"io"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -12,12 +16,17 @@ import (
 	"github.com/formbricks/hub/internal/datatypes"
 	"github.com/formbricks/hub/internal/huberrors"
 	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/pkg/cursor"
 )
 
 type mockFeedbackRecordsRepo struct {
 	record                     *models.FeedbackRecord
 	previousRecord             *models.FeedbackRecord // pre-update row Update returns; falls back to record when nil
 	createReq                  *models.CreateFeedbackRecordRequest
+	createEmbeddingModel       string
+	createEmbedding            []float32
+	batchReqs                  []*models.CreateFeedbackRecordRequest
+	batchItemErrs              map[string]error // by submission_id
 	deleteByUserGroups         []models.DeletedFeedbackRecordsByTenant
 	deletedID                  uuid.UUID
 	deleteByUserFilters        *models.DeleteFeedbackRecordsByUserFilters
@@ -34,12 +43,28 @@ type mockFeedbackRecordsRepo struct {
 	countResult int
 	countCalled bool
 
+	// listPages, when set, are served in order by List and ListAfterCursor; afterCursorCalls
+	// counts the ListAfterCursor calls.
+	listPages        [][]models.FeedbackRecord
+	afterCursorCalls int
+
 	setSentimentCalled bool
 	setSentimentLabel  *models.SentimentValue
 	setSentimentScore  *float64
 
 	setEmotionsCalled bool
 	setEmotionsLabels []models.EmotionValue
+
+	updateReq       *models.UpdateFeedbackRecordRequest
+	tagCountsTenant string
+	tagCountsLimit  int
+
+	tagSamplePercent  float64
+	rowEstimate       int64
+	rowEstimateErr    error
+	rowEstimateCalled bool
+
+	aggregateFilters *models.FeedbackRecordStatsFilters
 }
 
 func (m *mockFeedbackRecordsRepo) Create(
@@ -55,6 +80,35 @@ func (m *mockFeedbackRecordsRepo) Create(
 	return &models.FeedbackRecord{TenantID: req.TenantID}, nil
 }
 
+func (m *mockFeedbackRecordsRepo) CreateWithEmbedding(
+	ctx context.Context, req *models.CreateFeedbackRecordRequest, model string, embedding []float32,
+) (*models.FeedbackRecord, error) {
+	m.createEmbeddingModel = model
+	m.createEmbedding = embedding
+
+	return m.Create(ctx, req)
+}
+
+func (m *mockFeedbackRecordsRepo) CreateBatch(
+	_ context.Context, reqs []*models.CreateFeedbackRecordRequest,
+) (records []*models.FeedbackRecord, errs []error, err error) {
+	m.batchReqs = reqs
+	records = make([]*models.FeedbackRecord, len(reqs))
+	errs = make([]error, len(reqs))
+
+	for i, req := range reqs {
+		if itemErr := m.batchItemErrs[req.SubmissionID]; itemErr != nil {
+			errs[i] = itemErr
+
+			continue
+		}
+
+		records[i] = &models.FeedbackRecord{ID: uuid.New(), TenantID: req.TenantID, SubmissionID: req.SubmissionID}
+	}
+
+	return records, errs, nil
+}
+
 func (m *mockFeedbackRecordsRepo) GetByID(_ context.Context, _ uuid.UUID) (*models.FeedbackRecord, error) {
 	return m.record, nil
 }
@@ -62,18 +116,37 @@ func (m *mockFeedbackRecordsRepo) GetByID(_ context.Context, _ uuid.UUID) (*mode
 func (m *mockFeedbackRecordsRepo) List(
 	_ context.Context, _ *models.ListFeedbackRecordsFilters,
 ) ([]models.FeedbackRecord, bool, error) {
-	return nil, false, errors.New("not implemented")
+	return m.nextListPage()
 }
 
 func (m *mockFeedbackRecordsRepo) ListAfterCursor(
 	_ context.Context, _ *models.ListFeedbackRecordsFilters, _ time.Time, _ uuid.UUID,
 ) ([]models.FeedbackRecord, bool, error) {
-	return nil, false, errors.New("not implemented")
+	m.afterCursorCalls++
+
+	return m.nextListPage()
+}
+
+func (m *mockFeedbackRecordsRepo) nextListPage() ([]models.FeedbackRecord, bool, error) {
+	if m.listPages == nil {
+		return nil, false, errors.New("not implemented")
+	}
+
+	if len(m.listPages) == 0 {
+		return nil, false, nil
+	}
+
+	page := m.listPages[0]
+	m.listPages = m.listPages[1:]
+
+	return page, len(m.listPages) > 0, nil
 }
 
 func (m *mockFeedbackRecordsRepo) Update(
-	_ context.Context, _ uuid.UUID, _ *models.UpdateFeedbackRecordRequest,
+	_ context.Context, _ uuid.UUID, req *models.UpdateFeedbackRecordRequest,
 ) (*models.FeedbackRecord, *models.FeedbackRecord, error) {
+	m.updateReq = req
+
 	if m.record != nil {
 		previous := m.record
 		if m.previousRecord != nil {
@@ -193,6 +266,39 @@ func (m *mockFeedbackRecordsRepo) Count(
 	return m.countResult, m.countErr
 }
 
+func (m *mockFeedbackRecordsRepo) ListTagCounts(
+	_ context.Context, tenantID string, limit int,
+) ([]models.FeedbackRecordTagCount, error) {
+	m.tagCountsTenant = tenantID
+	m.tagCountsLimit = limit
+
+	return []models.FeedbackRecordTagCount{{Tag: "bug", Count: 3}}, nil
+}
+
+func (m *mockFeedbackRecordsRepo) SampleTagCounts(
+	_ context.Context, tenantID string, limit int, samplePercent float64,
+) ([]models.FeedbackRecordTagCount, error) {
+	m.tagCountsTenant = tenantID
+	m.tagCountsLimit = limit
+	m.tagSamplePercent = samplePercent
+
+	return []models.FeedbackRecordTagCount{{Tag: "bug", Count: 300}}, nil
+}
+
+func (m *mockFeedbackRecordsRepo) Aggregate(
+	_ context.Context, filters *models.FeedbackRecordStatsFilters,
+) (*models.FeedbackRecordStatsResponse, error) {
+	m.aggregateFilters = filters
+
+	return &models.FeedbackRecordStatsResponse{Total: 4, BySourceType: map[string]int64{"formbricks": 4}}, nil
+}
+
+func (m *mockFeedbackRecordsRepo) EstimateRowCount(_ context.Context) (int64, error) {
+	m.rowEstimateCalled = true
+
+	return m.rowEstimate, m.rowEstimateErr
+}
+
 // TestFeedbackRecordsService_CountFeedbackRecords locks the count behaviour:
 // the service layer passes filters through to the repo and propagates its result or error.
 func TestFeedbackRecordsService_CountFeedbackRecords(t *testing.T) {
@@ -314,6 +420,543 @@ func TestFeedbackRecordsService_CreateFeedbackRecord_NormalizesTenantID(t *testi
 	}
 }
 
+// TestFeedbackRecordsService_CreateFeedbackRecord_NormalizesTags locks that tags reach the repo in
+// canonical form (so facets and filters see one label per spelling) and that blank tags are
+// rejected as a validation error rather than stored.
+// TestFeedbackRecordsService_CreateFeedbackRecord_RejectsMismatchedTypedValue verifies a typed
+// value the field type cannot carry is a validation error and never reaches the repository.
+func TestFeedbackRecordsService_CreateFeedbackRecord_RejectsMismatchedTypedValue(t *testing.T) {
+	repo := &mockFeedbackRecordsRepo{}
+	svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+	yes := true
+
+	_, err := svc.CreateFeedbackRecord(context.Background(), &models.CreateFeedbackRecordRequest{
+		SourceType: "formbricks", FieldID: "field-1", FieldType: models.FieldTypeText,
+		TenantID: "org-123", SubmissionID: "submission-1", ValueBoolean: &yes,
+	})
+	if !errors.Is(err, huberrors.ErrValidation) {
+		t.Fatalf("CreateFeedbackRecord() error = %v, want validation error", err)
+	}
+
+	if repo.createReq != nil {
+		t.Fatal("repo.Create was called for a mismatched typed value")
+	}
+}
+
+func TestFeedbackRecordsService_CreateFeedbackRecord_NormalizesTags(t *testing.T) {
+	newReq := func(tags []string) *models.CreateFeedbackRecordRequest {
+		return &models.CreateFeedbackRecordRequest{
+			SourceType: "formbricks", FieldID: "field-1", FieldType: models.FieldTypeText,
+			TenantID: "org-123", SubmissionID: "submission-1", Tags: tags,
+		}
+	}
+
+	t.Run("normalizes", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+		if _, err := svc.CreateFeedbackRecord(context.Background(), newReq([]string{" Bug", "urgent", "bug"})); err != nil {
+			t.Fatalf("CreateFeedbackRecord() error = %v", err)
+		}
+
+		if got, want := repo.createReq.Tags, []string{"bug", "urgent"}; !slices.Equal(got, want) {
+			t.Fatalf("repo tags = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("rejects blank", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+		_, err := svc.CreateFeedbackRecord(context.Background(), newReq([]string{"bug", "   "}))
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("CreateFeedbackRecord() error = %v, want validation error", err)
+		}
+
+		if repo.createReq != nil {
+			t.Fatal("repo.Create called for a request with a blank tag")
+		}
+	})
+}
+
+// TestFeedbackRecordsService_ValueNumberPrecision locks the 2^53-1 boundary: beyond it a
+// value_number is stored with a warning by default and rejected when configured to.
+func TestFeedbackRecordsService_ValueNumberPrecision(t *testing.T) {
+	newReq := func(value float64) *models.CreateFeedbackRecordRequest {
+		return &models.CreateFeedbackRecordRequest{
+			SourceType: "formbricks", FieldID: "field-1", FieldType: models.FieldTypeNumber,
+			TenantID: "org-123", SubmissionID: "submission-1", ValueNumber: &value,
+		}
+	}
+
+	t.Run("stores by default", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+		if _, err := svc.CreateFeedbackRecord(context.Background(), newReq(1<<60)); err != nil {
+			t.Fatalf("CreateFeedbackRecord() error = %v", err)
+		}
+
+		if repo.createReq == nil {
+			t.Fatal("repo.Create not called; an imprecise value_number is only a warning by default")
+		}
+	})
+
+	t.Run("rejects when configured", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+		svc.SetRejectImpreciseValueNumber(true)
+
+		if _, err := svc.CreateFeedbackRecord(context.Background(), newReq(maxExactValueNumber)); err != nil {
+			t.Fatalf("CreateFeedbackRecord(2^53-1) error = %v, want accepted", err)
+		}
+
+		_, err := svc.CreateFeedbackRecord(context.Background(), newReq(-(maxExactValueNumber + 1)))
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("CreateFeedbackRecord(-2^53) error = %v, want validation error", err)
+		}
+
+		value := float64(1 << 60)
+
+		_, err = svc.UpdateFeedbackRecord(context.Background(), uuid.New(), &models.UpdateFeedbackRecordRequest{ValueNumber: &value})
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("UpdateFeedbackRecord() error = %v, want validation error", err)
+		}
+	})
+}
+
+// TestFeedbackRecordsService_UpdateFeedbackRecord_NormalizesTags locks that a tag replacement is
+// canonicalized before the write, so the stored set and the changed-field diff use one form.
+func TestFeedbackRecordsService_UpdateFeedbackRecord_NormalizesTags(t *testing.T) {
+	repo := &mockFeedbackRecordsRepo{record: &models.FeedbackRecord{ID: uuid.New(), TenantID: "org-123"}}
+	svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+	tags := []string{"Urgent ", "urgent"}
+	if _, err := svc.UpdateFeedbackRecord(context.Background(), repo.record.ID, &models.UpdateFeedbackRecordRequest{Tags: &tags}); err != nil {
+		t.Fatalf("UpdateFeedbackRecord() error = %v", err)
+	}
+
+	if repo.updateReq == nil || repo.updateReq.Tags == nil || !slices.Equal(*repo.updateReq.Tags, []string{"urgent"}) {
+		t.Fatalf("repo update tags = %v, want [urgent]", repo.updateReq)
+	}
+}
+
+// TestFeedbackRecordsService_ListFeedbackRecordTags locks the facet's tenant normalization and
+// default page size.
+func TestFeedbackRecordsService_ListFeedbackRecordTags(t *testing.T) {
+	repo := &mockFeedbackRecordsRepo{}
+	svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+	resp, err := svc.ListFeedbackRecordTags(context.Background(), &models.ListFeedbackRecordTagsFilters{TenantID: " org-123 "})
+	if err != nil {
+		t.Fatalf("ListFeedbackRecordTags() error = %v", err)
+	}
+
+	if repo.tagCountsTenant != "org-123" || repo.tagCountsLimit != defaultTagFacetLimit {
+		t.Fatalf("repo called with (%q, %d), want (org-123, %d)", repo.tagCountsTenant, repo.tagCountsLimit, defaultTagFacetLimit)
+	}
+
+	if len(resp.Data) != 1 || resp.Data[0].Tag != "bug" {
+		t.Fatalf("Data = %v, want the repo's counts", resp.Data)
+	}
+}
+
+func TestFeedbackRecordsService_GetFeedbackRecordStats(t *testing.T) {
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("normalizes tenant and forwards the range", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+		resp, err := svc.GetFeedbackRecordStats(context.Background(),
+			&models.FeedbackRecordStatsFilters{TenantID: " org-123 ", Since: &since, Until: &until})
+		if err != nil {
+			t.Fatalf("GetFeedbackRecordStats() error = %v", err)
+		}
+
+		if repo.aggregateFilters == nil || repo.aggregateFilters.TenantID != "org-123" ||
+			!repo.aggregateFilters.Since.Equal(since) || !repo.aggregateFilters.Until.Equal(until) {
+			t.Fatalf("repo called with %+v, want trimmed tenant and the range", repo.aggregateFilters)
+		}
+
+		if resp.Total != 4 {
+			t.Fatalf("Total = %d, want the repo's stats", resp.Total)
+		}
+	})
+
+	t.Run("rejects since after until", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+		_, err := svc.GetFeedbackRecordStats(context.Background(),
+			&models.FeedbackRecordStatsFilters{TenantID: "org-123", Since: &until, Until: &since})
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("GetFeedbackRecordStats() error = %v, want validation error", err)
+		}
+
+		if repo.aggregateFilters != nil {
+			t.Fatal("repo was called for an inverted range")
+		}
+	})
+}
+
+// TestFeedbackRecordsService_ListFeedbackRecordTags_Approximate locks the sampled path: the
+// configured sample percent reaches the repo and the response is marked approximate.
+func TestFeedbackRecordsService_ListFeedbackRecordTags_Approximate(t *testing.T) {
+	repo := &mockFeedbackRecordsRepo{}
+	svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+	svc.SetFacets(FacetSettings{SamplePercent: 5, ApproximateRowThreshold: 10})
+
+	resp, err := svc.ListFeedbackRecordTags(context.Background(),
+		&models.ListFeedbackRecordTagsFilters{TenantID: "org-123", Approximate: true})
+	if err != nil {
+		t.Fatalf("ListFeedbackRecordTags() error = %v", err)
+	}
+
+	if repo.tagSamplePercent != 5 || !resp.Approximate || resp.ApproximateSuggested {
+		t.Fatalf("sample percent %v, response %+v; want 5, approximate and no suggestion", repo.tagSamplePercent, resp)
+	}
+
+	if repo.rowEstimateCalled {
+		t.Fatal("an approximate request must not read the row estimate")
+	}
+}
+
+// TestFeedbackRecordsService_ListFeedbackRecordTags_SuggestsApproximate locks the row-estimate
+// hint on exact requests, including that a failed estimate does not fail the facet.
+func TestFeedbackRecordsService_ListFeedbackRecordTags_SuggestsApproximate(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int64
+		estimate  int64
+		err       error
+		want      bool
+	}{
+		{name: "above threshold", threshold: 1000, estimate: 1001, want: true},
+		{name: "at threshold", threshold: 1000, estimate: 1000, want: false},
+		{name: "threshold disabled", threshold: 0, estimate: 1 << 40, want: false},
+		{name: "estimate fails", threshold: 1000, err: errors.New("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockFeedbackRecordsRepo{rowEstimate: tt.estimate, rowEstimateErr: tt.err}
+			svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+			svc.SetFacets(FacetSettings{SamplePercent: 1, ApproximateRowThreshold: tt.threshold})
+
+			resp, err := svc.ListFeedbackRecordTags(context.Background(),
+				&models.ListFeedbackRecordTagsFilters{TenantID: "org-123"})
+			if err != nil {
+				t.Fatalf("ListFeedbackRecordTags() error = %v", err)
+			}
+
+			if resp.Approximate || resp.ApproximateSuggested != tt.want {
+				t.Fatalf("response %+v, want exact with approximate_suggested=%v", resp, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeedbackRecordsService_CreateFeedbackRecordWithoutEmbedding(t *testing.T) {
+	newRequest := func() *models.CreateFeedbackRecordRequest {
+		return &models.CreateFeedbackRecordRequest{
+			SourceType:   "formbricks",
+			FieldID:      "field-1",
+			FieldType:    models.FieldTypeText,
+			TenantID:     "org-123",
+			SubmissionID: "submission-1",
+		}
+	}
+
+	t.Run("creates the record and marks it for the providers to skip", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		publisher := &capturePublisher{}
+		svc := NewFeedbackRecordsService(repo, nil, "embedding-model", publisher, nil, "", 0, "")
+
+		record, err := svc.CreateFeedbackRecordWithoutEmbedding(context.Background(), newRequest())
+		if err != nil {
+			t.Fatalf("CreateFeedbackRecordWithoutEmbedding() error = %v", err)
+		}
+
+		if !record.SkipEmbedding || record.EmbeddedInline {
+			t.Fatalf("SkipEmbedding = %v, EmbeddedInline = %v; want true, false", record.SkipEmbedding, record.EmbeddedInline)
+		}
+
+		if publisher.callCount != 1 {
+			t.Fatalf("publish calls = %d, want 1: other subscribers still see the created event", publisher.callCount)
+		}
+	})
+
+	t.Run("a supplied embedding is a validation error", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "embedding-model", &capturePublisher{}, nil, "", 0, "")
+
+		req := newRequest()
+		req.Embedding = make([]float32, models.EmbeddingVectorDimensions)
+
+		_, err := svc.CreateFeedbackRecordWithoutEmbedding(context.Background(), req)
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("CreateFeedbackRecordWithoutEmbedding() error = %v, want validation error", err)
+		}
+
+		if repo.createReq != nil || repo.createEmbedding != nil {
+			t.Fatal("record was created despite the conflicting options")
+		}
+	})
+}
+
+// stubBatchEmbeddingEnqueuer marks every record it is given as enqueued.
+type stubBatchEmbeddingEnqueuer struct {
+	records []*models.FeedbackRecord
+}
+
+func (s *stubBatchEmbeddingEnqueuer) EnqueueBatch(_ context.Context, records []*models.FeedbackRecord) {
+	s.records = records
+	for _, record := range records {
+		record.EmbeddingEnqueued = true
+	}
+}
+
+func TestFeedbackRecordsService_CreateFeedbackRecordsBatch(t *testing.T) {
+	newItem := func(submissionID string) models.FeedbackRecordBatchItem {
+		return models.FeedbackRecordBatchItem{Request: &models.CreateFeedbackRecordRequest{
+			SourceType:   "formbricks",
+			FieldID:      "field-1",
+			FieldType:    models.FieldTypeText,
+			TenantID:     " org-123 ",
+			SubmissionID: submissionID,
+		}}
+	}
+
+	t.Run("failed items fail alone and the rest are created together", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{batchItemErrs: map[string]error{
+			"duplicate": huberrors.NewConflictError("a feedback record with this tenant_id, submission_id, and field_id already exists"),
+		}}
+		publisher := &capturePublisher{}
+		enqueuer := &stubBatchEmbeddingEnqueuer{}
+		svc := NewFeedbackRecordsService(repo, nil, "embedding-model", publisher, nil, "", 0, "")
+		svc.SetBatchEmbeddingEnqueuer(enqueuer)
+
+		invalid := newItem("invalid")
+		invalid.Err = huberrors.NewValidationError("field_type", "is required")
+
+		embedded := newItem("embedded")
+		embedded.Request.Embedding = make([]float32, models.EmbeddingVectorDimensions)
+
+		items := []models.FeedbackRecordBatchItem{newItem("first"), invalid, newItem("duplicate"), embedded, newItem("last")}
+
+		if err := svc.CreateFeedbackRecordsBatch(context.Background(), items); err != nil {
+			t.Fatalf("CreateFeedbackRecordsBatch() error = %v", err)
+		}
+
+		if len(repo.batchReqs) != 3 {
+			t.Fatalf("repository got %d records, want 3 (the invalid and embedded items never reach it)", len(repo.batchReqs))
+		}
+
+		if repo.batchReqs[0].TenantID != "org-123" {
+			t.Fatalf("tenant_id = %q, want it normalized", repo.batchReqs[0].TenantID)
+		}
+
+		for i, wantCreated := range []bool{true, false, false, false, true} {
+			if created := items[i].Record != nil && items[i].Err == nil; created != wantCreated {
+				t.Errorf("items[%d]: record = %v, err = %v; want created = %v", i, items[i].Record, items[i].Err, wantCreated)
+			}
+		}
+
+		if !errors.Is(items[2].Err, huberrors.ErrConflict) || !errors.Is(items[3].Err, huberrors.ErrValidation) {
+			t.Fatalf("item errors = %v, %v; want conflict and validation", items[2].Err, items[3].Err)
+		}
+
+		if len(enqueuer.records) != 2 || !items[0].Record.EmbeddingEnqueued {
+			t.Fatalf("batch enqueuer got %d records, want the 2 created", len(enqueuer.records))
+		}
+
+		if publisher.callCount != 2 {
+			t.Fatalf("publish calls = %d, want one created event per created record", publisher.callCount)
+		}
+	})
+
+	t.Run("skip_embedding enqueues no embeddings", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		publisher := &capturePublisher{}
+		enqueuer := &stubBatchEmbeddingEnqueuer{}
+		svc := NewFeedbackRecordsService(repo, nil, "embedding-model", publisher, nil, "", 0, "")
+		svc.SetBatchEmbeddingEnqueuer(enqueuer)
+
+		items := []models.FeedbackRecordBatchItem{newItem("a"), newItem("b")}
+
+		if err := svc.CreateFeedbackRecordsBatchWithoutEmbedding(context.Background(), items); err != nil {
+			t.Fatalf("CreateFeedbackRecordsBatchWithoutEmbedding() error = %v", err)
+		}
+
+		if len(enqueuer.records) != 0 {
+			t.Fatalf("batch enqueuer got %d records, want none", len(enqueuer.records))
+		}
+
+		for i, item := range items {
+			if item.Record == nil || !item.Record.SkipEmbedding {
+				t.Errorf("items[%d].Record = %+v, want created with SkipEmbedding", i, item.Record)
+			}
+		}
+
+		if publisher.callCount != 2 {
+			t.Fatalf("publish calls = %d, want one created event per created record", publisher.callCount)
+		}
+	})
+
+	t.Run("a batch over the limit creates nothing", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+		svc.SetBatchMaxRecords(2)
+
+		items := []models.FeedbackRecordBatchItem{newItem("a"), newItem("b"), newItem("c")}
+
+		err := svc.CreateFeedbackRecordsBatch(context.Background(), items)
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("CreateFeedbackRecordsBatch() error = %v, want validation error", err)
+		}
+
+		if repo.batchReqs != nil {
+			t.Fatal("records were created despite the oversized batch")
+		}
+	})
+}
+
+func TestFeedbackRecordsService_CreateFeedbackRecord_IdempotencyKey(t *testing.T) {
+	newRequest := func(key string) *models.CreateFeedbackRecordRequest {
+		return &models.CreateFeedbackRecordRequest{
+			SourceType:     "formbricks",
+			FieldID:        "field-1",
+			FieldType:      models.FieldTypeText,
+			TenantID:       "org-123",
+			SubmissionID:   "submission-1",
+			IdempotencyKey: key,
+		}
+	}
+
+	t.Run("the key is trimmed and passed to the repository", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+		if _, err := svc.CreateFeedbackRecord(context.Background(), newRequest("  row-42  ")); err != nil {
+			t.Fatalf("CreateFeedbackRecord() error = %v", err)
+		}
+
+		if repo.createReq.IdempotencyKey != "row-42" {
+			t.Fatalf("IdempotencyKey = %q, want %q", repo.createReq.IdempotencyKey, "row-42")
+		}
+	})
+
+	t.Run("an overlong key is a validation error", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+		_, err := svc.CreateFeedbackRecord(context.Background(), newRequest(strings.Repeat("k", 256)))
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("CreateFeedbackRecord() error = %v, want validation error", err)
+		}
+
+		if repo.createReq != nil {
+			t.Fatal("record was created with an invalid key")
+		}
+	})
+
+	t.Run("a replayed record publishes no event", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{record: &models.FeedbackRecord{TenantID: "org-123", Replayed: true}}
+		publisher := &capturePublisher{}
+		svc := NewFeedbackRecordsService(repo, nil, "embedding-model", publisher, nil, "", 0, "")
+
+		for _, create := range []func(context.Context, *models.CreateFeedbackRecordRequest) (*models.FeedbackRecord, error){
+			svc.CreateFeedbackRecord, svc.CreateFeedbackRecordWithoutEmbedding,
+		} {
+			record, err := create(context.Background(), newRequest("row-42"))
+			if err != nil {
+				t.Fatalf("create error = %v", err)
+			}
+
+			if !record.Replayed || record.SkipEmbedding {
+				t.Fatalf("Replayed = %v, SkipEmbedding = %v; want true, false", record.Replayed, record.SkipEmbedding)
+			}
+		}
+
+		req := newRequest("row-42")
+		req.Embedding = make([]float32, models.EmbeddingVectorDimensions)
+
+		record, err := svc.CreateFeedbackRecord(context.Background(), req)
+		if err != nil {
+			t.Fatalf("create with embedding error = %v", err)
+		}
+
+		if record.EmbeddedInline {
+			t.Fatal("a replayed record must not be marked as embedded by this request")
+		}
+
+		if publisher.callCount != 0 {
+			t.Fatalf("publish calls = %d, want 0: the original create already published", publisher.callCount)
+		}
+	})
+}
+
+func TestFeedbackRecordsService_CreateFeedbackRecord_SuppliedEmbedding(t *testing.T) {
+	newRequest := func(dims int) *models.CreateFeedbackRecordRequest {
+		return &models.CreateFeedbackRecordRequest{
+			SourceType:   "formbricks",
+			FieldID:      "field-1",
+			FieldType:    models.FieldTypeText,
+			TenantID:     "org-123",
+			SubmissionID: "submission-1",
+			Embedding:    make([]float32, dims),
+		}
+	}
+
+	t.Run("stores the vector with the record and marks it embedded", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		publisher := &capturePublisher{}
+		svc := NewFeedbackRecordsService(repo, nil, "embedding-model", publisher, nil, "", 0, "")
+
+		record, err := svc.CreateFeedbackRecord(context.Background(), newRequest(models.EmbeddingVectorDimensions))
+		if err != nil {
+			t.Fatalf("CreateFeedbackRecord() error = %v", err)
+		}
+
+		if repo.createEmbeddingModel != "embedding-model" || len(repo.createEmbedding) != models.EmbeddingVectorDimensions {
+			t.Fatalf("CreateWithEmbedding got model %q with %d dims", repo.createEmbeddingModel, len(repo.createEmbedding))
+		}
+
+		if !record.EmbeddedInline {
+			t.Fatal("EmbeddedInline = false, want true so no embedding job is enqueued")
+		}
+
+		if publisher.callCount != 1 {
+			t.Fatalf("publish calls = %d, want 1", publisher.callCount)
+		}
+	})
+
+	t.Run("wrong dimension is a validation error", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{}
+		svc := NewFeedbackRecordsService(repo, nil, "embedding-model", &capturePublisher{}, nil, "", 0, "")
+
+		_, err := svc.CreateFeedbackRecord(context.Background(), newRequest(3))
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("CreateFeedbackRecord() error = %v, want validation error", err)
+		}
+
+		if repo.createReq != nil {
+			t.Fatal("record was created despite the invalid embedding")
+		}
+	})
+
+	t.Run("embeddings disabled is a validation error", func(t *testing.T) {
+		svc := NewFeedbackRecordsService(&mockFeedbackRecordsRepo{}, nil, "", &capturePublisher{}, nil, "", 0, "")
+
+		_, err := svc.CreateFeedbackRecord(context.Background(), newRequest(models.EmbeddingVectorDimensions))
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("CreateFeedbackRecord() error = %v, want validation error", err)
+		}
+	})
+}
+
 func TestFeedbackRecordsService_DeleteFeedbackRecordsByUser_PublishesTenantAwareDeletedEventsByTenant(t *testing.T) {
 	ctx := context.Background()
 	tenantA := "org-123"
@@ -703,6 +1346,37 @@ func TestFeedbackRecordsService_BackfillTranslations(t *testing.T) {
 	}
 }
 
+func TestFeedbackRecordsService_BackfillEmbeddings_Batched(t *testing.T) {
+	embeddingsRepo := &captureEmbeddingsRepo{backfillIDs: make([]uuid.UUID, embeddingBackfillPageSize+5)}
+	for i := range embeddingsRepo.backfillIDs {
+		embeddingsRepo.backfillIDs[i] = uuid.Must(uuid.NewV7())
+	}
+
+	inserter := &captureBatchInserter{}
+	svc := NewFeedbackRecordsService(&mockFeedbackRecordsRepo{}, embeddingsRepo, "m", nil, inserter, EmbeddingsQueueName, 3, "")
+	svc.SetEmbeddingBatchSize(200)
+
+	enqueued, err := svc.BackfillEmbeddings(context.Background(), "m")
+	if err != nil {
+		t.Fatalf("BackfillEmbeddings() error = %v", err)
+	}
+
+	// 505 ids: a full page of 500 (200+200+100) and a last page of 5.
+	if enqueued != 4 || len(inserter.batches) != 4 {
+		t.Fatalf("enqueued = %d, batches = %d, want 4 and 4", enqueued, len(inserter.batches))
+	}
+
+	total := 0
+	for _, batch := range inserter.batches {
+		total += len(batch.FeedbackRecordIDs)
+	}
+
+	if total != len(embeddingsRepo.backfillIDs) || len(inserter.batches[0].FeedbackRecordIDs) != 200 {
+		t.Fatalf("batched %d ids (first batch %d), want all %d in batches of up to 200",
+			total, len(inserter.batches[0].FeedbackRecordIDs), len(embeddingsRepo.backfillIDs))
+	}
+}
+
 func TestFeedbackRecordsService_BackfillTranslations_RepoError(t *testing.T) {
 	repo := &mockFeedbackRecordsRepo{translationBackfillErr: errors.New("boom")}
 	svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
@@ -921,6 +1595,42 @@ func TestFeedbackRecordsService_UpdateFeedbackRecord_RealChangePublishesChangedF
 	}
 }
 
+// TestFeedbackRecordsService_UpdateFeedbackRecord_TenantMovePublishesDeleteAndCreate locks that a
+// tenant move tells both tenants: a delete for the old tenant (so its webhooks drop the record) and
+// a create for the new one — never an "updated", which would reach only the new tenant.
+func TestFeedbackRecordsService_UpdateFeedbackRecord_TenantMovePublishesDeleteAndCreate(t *testing.T) {
+	id := uuid.Must(uuid.NewV7())
+	previous := &models.FeedbackRecord{ID: id, TenantID: "tenant-a", FieldType: models.FieldTypeText}
+	moved := &models.FeedbackRecord{ID: id, TenantID: "tenant-b", FieldType: models.FieldTypeText}
+	repo := &mockFeedbackRecordsRepo{record: moved, previousRecord: previous}
+	publisher := &capturePublisher{}
+	svc := NewFeedbackRecordsService(repo, nil, "", publisher, nil, "", 0, "")
+
+	target := "tenant-b"
+
+	_, err := svc.UpdateFeedbackRecord(context.Background(), id, &models.UpdateFeedbackRecordRequest{
+		TenantID: &target,
+	})
+	if err != nil {
+		t.Fatalf("UpdateFeedbackRecord() error = %v", err)
+	}
+
+	if publisher.callCount != 2 {
+		t.Fatalf("published %d events, want 2 (deleted + created)", publisher.callCount)
+	}
+
+	assertDeletedEventDataAt(t, publisher, 0, datatypes.FeedbackRecordDeleted, "tenant-a", []uuid.UUID{id})
+
+	created := publisher.events[1]
+	if created.eventType != datatypes.FeedbackRecordCreated {
+		t.Fatalf("second event type = %s, want %s", created.eventType, datatypes.FeedbackRecordCreated)
+	}
+
+	if record, ok := created.data.(*models.FeedbackRecord); !ok || record.TenantID != "tenant-b" {
+		t.Fatalf("created event data = %#v, want the record in tenant-b", created.data)
+	}
+}
+
 type mockClearMetrics struct{ cleared []string }
 
 func (m *mockClearMetrics) RecordOutputCleared(_ context.Context, output string) {
@@ -976,3 +1686,152 @@ func TestFeedbackRecordsService_BackfillTranslations_CountsUniqueSkipsSeparately
 		t.Fatalf("enqueued = %d, want 1 (the duplicate is skipped, not counted)", enqueued)
 	}
 }
+
+func TestFeedbackRecordsService_ReembedFeedbackRecords(t *testing.T) {
+	tenantID := "org-123"
+	embeddingsRepo := &captureEmbeddingsRepo{reembedIDs: []uuid.UUID{uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())}}
+	inserter := &mockEmbeddingInserter{}
+	svc := NewFeedbackRecordsService(&mockFeedbackRecordsRepo{}, embeddingsRepo, "model-a", nil, inserter, EmbeddingsQueueName, 3, "")
+	svc.SetEmbeddingBulkPriority(4)
+
+	enqueued, err := svc.ReembedFeedbackRecords(context.Background(), &models.ReembedRequest{TenantID: tenantID})
+	if err != nil {
+		t.Fatalf("ReembedFeedbackRecords() error = %v", err)
+	}
+
+	if enqueued != 2 || len(inserter.insertCalls) != 2 || embeddingsRepo.model != "model-a" {
+		t.Fatalf("enqueued = %d (%d inserts, cleared model %q), want 2 for model-a",
+			enqueued, len(inserter.insertCalls), embeddingsRepo.model)
+	}
+
+	args := inserter.insertCalls[0].args
+	if args.Model != "model-a" || args.InputKind != models.EmbeddingInputKindRaw || !strings.HasPrefix(args.ValueTextHash, "reembed:") {
+		t.Fatalf("job args = %+v, want a raw model-a job with a reembed run hash", args)
+	}
+
+	if priority := inserter.insertCalls[0].opts.Priority; priority != 4 {
+		t.Fatalf("job priority = %d, want the bulk priority 4 so event-driven embeddings run first", priority)
+	}
+}
+
+func TestFeedbackRecordsService_ReembedFeedbackRecords_Rejects(t *testing.T) {
+	since := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(-time.Hour)
+	tenantID := "org-123"
+
+	tests := map[string]*models.ReembedRequest{
+		"without tenant_id": {},
+		"since after until": {TenantID: tenantID, Since: &since, Until: &until},
+	}
+
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc := NewFeedbackRecordsService(
+				&mockFeedbackRecordsRepo{}, &captureEmbeddingsRepo{}, "model-a", nil, &mockEmbeddingInserter{}, EmbeddingsQueueName, 3, "")
+
+			if _, err := svc.ReembedFeedbackRecords(context.Background(), req); !errors.Is(err, huberrors.ErrValidation) {
+				t.Fatalf("ReembedFeedbackRecords() error = %v, want a validation error", err)
+			}
+		})
+	}
+
+	t.Run("embeddings not configured", func(t *testing.T) {
+		svc := NewFeedbackRecordsService(&mockFeedbackRecordsRepo{}, nil, "", nil, nil, "", 0, "")
+
+		_, err := svc.ReembedFeedbackRecords(context.Background(), &models.ReembedRequest{TenantID: tenantID})
+		if !errors.Is(err, ErrEmbeddingBackfillNotConfigured) {
+			t.Fatalf("ReembedFeedbackRecords() error = %v, want ErrEmbeddingBackfillNotConfigured", err)
+		}
+	})
+}
+
+// TestFeedbackRecordsService_StreamFeedbackRecords locks the streamed export: every page is
+// written with pageDone after each, an empty CSV is its header row once, and a cursor resumes.
+func TestFeedbackRecordsService_StreamFeedbackRecords(t *testing.T) {
+	tenantID := "org-1"
+
+	t.Run("writes every page and calls pageDone after each", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{listPages: [][]models.FeedbackRecord{
+			{exportTestRecord("q1", "great"), exportTestRecord("q2", "fine")},
+			{exportTestRecord("q3", "ok")},
+		}}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+		var (
+			out   bytes.Buffer
+			pages int
+		)
+
+		count, err := svc.StreamFeedbackRecords(context.Background(), &models.ListFeedbackRecordsFilters{TenantID: &tenantID},
+			models.ExportFormatJSONL, &out, func() error {
+				pages++
+
+				return nil
+			})
+		if err != nil {
+			t.Fatalf("StreamFeedbackRecords() error = %v", err)
+		}
+
+		if count != 3 || pages != 2 {
+			t.Fatalf("count = %d over %d pages, want 3 over 2", count, pages)
+		}
+
+		if lines := strings.Count(out.String(), "\n"); lines != 3 {
+			t.Fatalf("jsonl has %d lines, want one per record", lines)
+		}
+	})
+
+	t.Run("empty CSV export is the header row once", func(t *testing.T) {
+		svc := NewFeedbackRecordsService(&mockFeedbackRecordsRepo{listPages: [][]models.FeedbackRecord{}}, nil, "", nil, nil, "", 0, "")
+
+		var out bytes.Buffer
+
+		if _, err := svc.StreamFeedbackRecords(context.Background(), &models.ListFeedbackRecordsFilters{TenantID: &tenantID},
+			models.ExportFormatCSV, &out, func() error { return nil }); err != nil {
+			t.Fatalf("StreamFeedbackRecords() error = %v", err)
+		}
+
+		rows, err := csv.NewReader(&out).ReadAll()
+		if err != nil {
+			t.Fatalf("read csv: %v", err)
+		}
+
+		if len(rows) != 1 || rows[0][0] != "id" {
+			t.Fatalf("csv rows = %v, want the header alone", rows)
+		}
+	})
+
+	t.Run("cursor resumes after the given record", func(t *testing.T) {
+		repo := &mockFeedbackRecordsRepo{listPages: [][]models.FeedbackRecord{{exportTestRecord("q1", "great")}}}
+		svc := NewFeedbackRecordsService(repo, nil, "", nil, nil, "", 0, "")
+
+		next, err := cursor.Encode(time.Now(), uuid.New())
+		if err != nil {
+			t.Fatalf("encode cursor: %v", err)
+		}
+
+		count, err := svc.StreamFeedbackRecords(context.Background(),
+			&models.ListFeedbackRecordsFilters{TenantID: &tenantID, Cursor: next},
+			models.ExportFormatJSONL, io.Discard, func() error { return nil })
+		if err != nil {
+			t.Fatalf("StreamFeedbackRecords() error = %v", err)
+		}
+
+		if count != 1 || repo.afterCursorCalls != 1 {
+			t.Fatalf("count = %d with %d ListAfterCursor calls, want 1 and 1", count, repo.afterCursorCalls)
+		}
+	})
+
+	t.Run("requires tenant_id", func(t *testing.T) {
+		svc := NewFeedbackRecordsService(&mockFeedbackRecordsRepo{}, nil, "", nil, nil, "", 0, "")
+		blank := "   "
+
+		for _, filters := range []*models.ListFeedbackRecordsFilters{{}, {TenantID: &blank}} {
+			_, err := svc.StreamFeedbackRecords(context.Background(), filters,
+				models.ExportFormatCSV, io.Discard, func() error { return nil })
+			if !errors.Is(err, huberrors.ErrValidation) {
+				t.Fatalf("StreamFeedbackRecords() error = %v, want a validation error", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+)
+
+// Hybrid search bounds. Each half contributes at most hybridSearchCandidates records to the
+// fusion, so a record ranked below that in both is not returned even if the fused list is short.
+const (
+	hybridSearchCandidates = 100
+	// rrfK is the reciprocal rank fusion constant: a record at rank r contributes weight/(rrfK+r).
+	// 60 is the value from the original RRF paper and the common default; it keeps the top few
+	// ranks of either list from drowning out agreement between the two.
+	rrfK = 60
+	// maxKeywordTerms caps the terms of a query the keyword half matches on (the first ones win);
+	// each is a substring scan of the tenant's text records.
+	maxKeywordTerms = 10
+	// DefaultHybridKeywordWeight weighs the keyword and vector rankings equally.
+	DefaultHybridKeywordWeight = 0.5
+)
+
+// KeywordSearchRepository is the keyword half of hybrid search (satisfied by
+// *repository.EmbeddingsRepository).
+type KeywordSearchRepository interface {
+	KeywordSearchFeedbackRecords(
+		ctx context.Context, tenantID string, terms []string, limit int,
+	) ([]models.FeedbackRecordWithScore, error)
+}
+
+// HybridSearchHit is one hybrid search result. Record.Score is the fused score. KeywordRank and
+// VectorRank are the record's 1-based rank in each half, nil when that half did not return it;
+// VectorScore is its cosine similarity to the query, nil likewise.
+type HybridSearchHit struct {
+	Record      models.FeedbackRecordWithScore
+	KeywordRank *int
+	VectorRank  *int
+	VectorScore *float64
+}
+
+// HybridSearchResult is the fused result of a hybrid search, best first.
+type HybridSearchResult struct {
+	Hits          []HybridSearchHit
+	Model         string
+	KeywordWeight float64
+}
+
+// HybridSearch runs a keyword (substring) search and a semantic search for query in the tenant's
+// feedback and merges the two rankings with reciprocal rank fusion: a record scores
+// keywordWeight/(60+keyword rank) + (1-keywordWeight)/(60+vector rank), counting only the halves
+// that returned it, so records both halves agree on rise to the top. This helps queries carrying
+// rare keywords (a product name, an error code) that the embedding alone ranks poorly.
+//
+// The semantic half takes no score floor and no cursor: both halves contribute their top
+// candidates and the fused list is cut to limit, so there is no next page. model selects the
+// embedding model as for SemanticSearch. keywordWeight is in [0, 1]; 0 is pure semantic ranking
+// and 1 pure keyword ranking.
+func (s *SearchService) HybridSearch(
+	ctx context.Context, query, tenantID, model string, limit int, keywordWeight float64,
+) (HybridSearchResult, error) {
+	out := HybridSearchResult{KeywordWeight: keywordWeight}
+
+	if s.keywordRepo == nil {
+		return out, ErrKeywordSearchNotConfigured
+	}
+
+	if tenantID == "" {
+		return out, ErrMissingTenantID
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return out, ErrEmptyQuery
+	}
+
+	if s.maxQueryLen > 0 && utf8.RuneCountInString(query) > s.maxQueryLen {
+		return out, huberrors.NewValidationError("query", fmt.Sprintf("must be at most %d characters", s.maxQueryLen))
+	}
+
+	semantic, err := s.searchByText(ctx, "query", query, tenantID, model, hybridSearchCandidates, 0, "")
+	if err != nil {
+		return out, err
+	}
+
+	keyword, err := s.keywordRepo.KeywordSearchFeedbackRecords(ctx, tenantID, keywordTerms(query), hybridSearchCandidates)
+	if err != nil {
+		s.logger.Error("hybrid search: keyword search failed", "error", err)
+
+		return out, fmt.Errorf("keyword search: %w", err)
+	}
+
+	out.Model = semantic.Model
+	out.Hits = fuseRankings(keyword, semantic.Results, keywordWeight, limit)
+
+	return out, nil
+}
+
+// keywordTerms splits a query into its distinct (case-insensitively) whitespace-separated terms,
+// at most maxKeywordTerms of them.
+func keywordTerms(query string) []string {
+	var terms []string
+
+	seen := make(map[string]bool)
+
+	for _, field := range strings.Fields(query) {
+		key := strings.ToLower(field)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		terms = append(terms, field)
+
+		if len(terms) == maxKeywordTerms {
+			break
+		}
+	}
+
+	return terms
+}
+
+// fuseRankings merges the keyword and vector rankings with reciprocal rank fusion, deduplicated by
+// record, and returns the best limit hits (ties broken by record id, so the order is stable).
+func fuseRankings(
+	keyword, vector []models.FeedbackRecordWithScore, keywordWeight float64, limit int,
+) []HybridSearchHit {
+	hits := make(map[uuid.UUID]*HybridSearchHit, len(keyword)+len(vector))
+
+	hitFor := func(record models.FeedbackRecordWithScore) *HybridSearchHit {
+		hit, ok := hits[record.FeedbackRecordID]
+		if !ok {
+			hit = &HybridSearchHit{Record: record}
+			hit.Record.Score = 0
+			hit.Record.Distance = 0
+			hits[record.FeedbackRecordID] = hit
+		}
+
+		return hit
+	}
+
+	for i, record := range keyword {
+		hit := hitFor(record)
+		rank := i + 1
+		hit.KeywordRank = &rank
+		hit.Record.Score += keywordWeight / float64(rrfK+rank)
+	}
+
+	for i, record := range vector {
+		hit := hitFor(record)
+		rank := i + 1
+		score := record.Score
+		hit.VectorRank = &rank
+		hit.VectorScore = &score
+		hit.Record.Score += (1 - keywordWeight) / float64(rrfK+rank)
+	}
+
+	fused := make([]HybridSearchHit, 0, len(hits))
+	for _, hit := range hits {
+		fused = append(fused, *hit)
+	}
+
+	slices.SortFunc(fused, func(a, b HybridSearchHit) int {
+		if c := cmp.Compare(b.Record.Score, a.Record.Score); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Record.FeedbackRecordID.String(), b.Record.FeedbackRecordID.String())
+	})
+
+	return fused[:min(limit, len(fused))]
+}
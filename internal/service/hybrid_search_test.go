@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/models"
+)
+
+type mockKeywordRepo struct {
+	searchFunc func(ctx context.Context, tenantID string, terms []string, limit int) ([]models.FeedbackRecordWithScore, error)
+}
+
+func (m *mockKeywordRepo) KeywordSearchFeedbackRecords(
+	ctx context.Context, tenantID string, terms []string, limit int,
+) ([]models.FeedbackRecordWithScore, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(ctx, tenantID, terms, limit)
+	}
+
+	return nil, nil
+}
+
+func TestSearchService_HybridSearch(t *testing.T) {
+	idA := uuid.MustParse("018e1234-5678-9abc-def0-00000000000a")
+	idB := uuid.MustParse("018e1234-5678-9abc-def0-00000000000b")
+	idC := uuid.MustParse("018e1234-5678-9abc-def0-00000000000c")
+
+	newService := func(keyword, vector []models.FeedbackRecordWithScore) *SearchService {
+		return NewSearchService(SearchServiceParams{
+			EmbeddingClient: &mockEmbeddingClient{},
+			EmbeddingsRepo: &mockEmbeddingsRepoForSearch{
+				nearestFunc: func(
+					_ context.Context, _ string, _ []float32, _ string, limit int, _ *uuid.UUID, minScore float64,
+				) ([]models.FeedbackRecordWithScore, bool, error) {
+					assert.Equal(t, hybridSearchCandidates, limit)
+					assert.Zero(t, minScore, "the vector half takes no score floor")
+
+					return vector, false, nil
+				},
+			},
+			KeywordRepo: &mockKeywordRepo{
+				searchFunc: func(_ context.Context, tenantID string, terms []string, limit int) ([]models.FeedbackRecordWithScore, error) {
+					assert.Equal(t, "env-1", tenantID)
+					assert.Equal(t, []string{"ERR-42", "checkout"}, terms, "duplicate terms are dropped")
+					assert.Equal(t, hybridSearchCandidates, limit)
+
+					return keyword, nil
+				},
+			},
+			Model: "test-model",
+		})
+	}
+
+	keyword := []models.FeedbackRecordWithScore{{FeedbackRecordID: idA, Score: 1}, {FeedbackRecordID: idB, Score: 0.5}}
+	vector := []models.FeedbackRecordWithScore{{FeedbackRecordID: idC, Score: 0.9}, {FeedbackRecordID: idB, Score: 0.8}}
+
+	t.Run("fuses both rankings and deduplicates", func(t *testing.T) {
+		res, err := newService(keyword, vector).HybridSearch(t.Context(), "ERR-42 checkout err-42", "env-1", "", 10, 0.5)
+		require.NoError(t, err)
+		require.Len(t, res.Hits, 3)
+		assert.Equal(t, "test-model", res.Model)
+
+		// B is returned by both halves and wins; A and C each rank first in one half only.
+		top := res.Hits[0]
+		assert.Equal(t, idB, top.Record.FeedbackRecordID)
+		assert.InDelta(t, 0.5/62+0.5/62, top.Record.Score, 1e-12)
+		require.NotNil(t, top.KeywordRank)
+		require.NotNil(t, top.VectorRank)
+		require.NotNil(t, top.VectorScore)
+		assert.Equal(t, 2, *top.KeywordRank)
+		assert.Equal(t, 2, *top.VectorRank)
+		assert.InDelta(t, 0.8, *top.VectorScore, 1e-12)
+
+		assert.Equal(t, idA, res.Hits[1].Record.FeedbackRecordID, "equal scores are ordered by id")
+		assert.Nil(t, res.Hits[1].VectorRank)
+		assert.Nil(t, res.Hits[1].VectorScore)
+		assert.Equal(t, idC, res.Hits[2].Record.FeedbackRecordID)
+		assert.Nil(t, res.Hits[2].KeywordRank)
+	})
+
+	t.Run("keyword weight shifts the ranking", func(t *testing.T) {
+		res, err := newService(keyword, vector).HybridSearch(t.Context(), "ERR-42 checkout", "env-1", "", 10, 1)
+		require.NoError(t, err)
+		assert.Equal(t, idA, res.Hits[0].Record.FeedbackRecordID)
+		assert.Zero(t, res.Hits[2].Record.Score, "a vector-only hit scores nothing at keyword_weight 1")
+	})
+
+	t.Run("limit truncates the fused list", func(t *testing.T) {
+		res, err := newService(keyword, vector).HybridSearch(t.Context(), "ERR-42 checkout", "env-1", "", 1, 0.5)
+		require.NoError(t, err)
+		require.Len(t, res.Hits, 1)
+		assert.Equal(t, idB, res.Hits[0].Record.FeedbackRecordID)
+	})
+
+	t.Run("validates like semantic search", func(t *testing.T) {
+		svc := newService(nil, nil)
+
+		_, err := svc.HybridSearch(t.Context(), "slow", "", "", 10, 0.5)
+		require.ErrorIs(t, err, ErrMissingTenantID)
+
+		_, err = svc.HybridSearch(t.Context(), "  ", "env-1", "", 10, 0.5)
+		require.ErrorIs(t, err, ErrEmptyQuery)
+	})
+
+	t.Run("keyword failure is returned", func(t *testing.T) {
+		svc := NewSearchService(SearchServiceParams{
+			EmbeddingClient: &mockEmbeddingClient{},
+			EmbeddingsRepo:  &mockEmbeddingsRepoForSearch{},
+			KeywordRepo: &mockKeywordRepo{
+				searchFunc: func(context.Context, string, []string, int) ([]models.FeedbackRecordWithScore, error) {
+					return nil, errors.New("db down")
+				},
+			},
+			Model: "test-model",
+		})
+
+		_, err := svc.HybridSearch(t.Context(), "slow", "env-1", "", 10, 0.5)
+		require.Error(t, err)
+	})
+
+	t.Run("without a keyword repo", func(t *testing.T) {
+		svc := NewSearchService(SearchServiceParams{
+			EmbeddingClient: &mockEmbeddingClient{},
+			EmbeddingsRepo:  &mockEmbeddingsRepoForSearch{},
+			Model:           "test-model",
+		})
+
+		_, err := svc.HybridSearch(t.Context(), "slow", "env-1", "", 10, 0.5)
+		require.ErrorIs(t, err, ErrKeywordSearchNotConfigured)
+	})
+}
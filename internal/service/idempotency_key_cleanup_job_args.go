@@ -0,0 +1,15 @@
+package service
+
+import "github.com/riverqueue/river"
+
+const idempotencyKeyCleanupKind = "idempotency_key_cleanup"
+
+// IdempotencyKeyCleanupArgs deletes idempotency keys past models.IdempotencyKeyTTL. The worker
+// process schedules it as a periodic job; it carries no arguments since every run sweeps the
+// whole table.
+type IdempotencyKeyCleanupArgs struct{}
+
+// Kind returns the River job kind.
+func (IdempotencyKeyCleanupArgs) Kind() string { return idempotencyKeyCleanupKind }
+
+var _ river.JobArgs = IdempotencyKeyCleanupArgs{}
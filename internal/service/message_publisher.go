@@ -105,10 +105,25 @@ func (m *MessagePublisherManager) PublishEventWithChangedFields(
 	}
 }
 
-// Shutdown stops the background worker and waits for the buffer to drain.
+// eventFlusher is implemented by providers that hold events back (e.g. WebhookDebouncer); Shutdown
+// flushes them once the channel has drained so no held event is lost.
+type eventFlusher interface {
+	Flush(ctx context.Context)
+}
+
+// Shutdown stops the background worker, waits for the buffer to drain, then flushes providers
+// that hold events back.
 func (m *MessagePublisherManager) Shutdown() {
 	close(m.eventChan)
 	m.wg.Wait()
+
+	for _, provider := range m.providers {
+		if flusher, ok := provider.(eventFlusher); ok {
+			ctx, cancel := context.WithTimeout(context.Background(), m.perEventTimeout)
+			flusher.Flush(ctx)
+			cancel()
+		}
+	}
 }
 
 // startWorker runs in a dedicated goroutine, reading events from the channel
@@ -63,4 +63,9 @@ func DecodeSearchCursor(cursor string) (distance float64, feedbackRecordID uuid.
 type SearchResult struct {
 	Results    []models.FeedbackRecordWithScore
 	NextCursor string // non-empty if there may be a next page (len(Results) == requested limit)
+	// Model is the embedding model actually searched, after tenant and default resolution.
+	Model string
+	// QueryEmbeddingCache is how a text query's vector was obtained (QueryEmbeddingCacheHit, Miss or
+	// Disabled); empty for similar feedback, which reuses a stored vector. Only explain output shows it.
+	QueryEmbeddingCache string
 }
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/golang-lru/v2"
 	"golang.org/x/sync/singleflight"
 
+	"github.com/formbricks/hub/internal/huberrors"
 	"github.com/formbricks/hub/internal/models"
 	"github.com/formbricks/hub/internal/observability"
 	"github.com/formbricks/hub/internal/repository"
@@ -18,11 +20,25 @@ import (
 
 const searchQueryEmbeddingCacheName = "search_query_embedding"
 
+// Values of SearchResult.QueryEmbeddingCache. A query that waited on an identical in-flight load
+// counts as a hit, matching the cache metrics: it did not cost a provider call of its own.
+const (
+	QueryEmbeddingCacheHit      = "hit"
+	QueryEmbeddingCacheMiss     = "miss"
+	QueryEmbeddingCacheDisabled = "disabled"
+)
+
 // Sentinel errors for search (used by handlers for status mapping).
 var (
 	ErrMissingTenantID   = errors.New("tenant_id is required")
 	ErrEmptyQuery        = errors.New("query is required and must be non-empty")
 	ErrEmbeddingNotFound = repository.ErrEmbeddingNotFound
+	// ErrUnknownEmbeddingModel is returned when a search names a model that is neither
+	// EMBEDDING_MODEL nor EMBEDDING_SECONDARY_MODEL.
+	ErrUnknownEmbeddingModel = errors.New("model is not a configured embedding model")
+	// ErrKeywordSearchNotConfigured is returned by HybridSearch on a service built without a
+	// KeywordRepo.
+	ErrKeywordSearchNotConfigured = errors.New("keyword search is not configured")
 )
 
 // EmbeddingsRepositoryForSearch provides the embedding read operations needed for semantic search.
@@ -31,6 +47,7 @@ type EmbeddingsRepositoryForSearch interface {
 	GetEmbeddingAndTenantByFeedbackRecordAndModel(
 		ctx context.Context, feedbackRecordID uuid.UUID, model string,
 	) ([]float32, string, error)
+	GetTenantByFeedbackRecord(ctx context.Context, feedbackRecordID uuid.UUID) (string, error)
 	NearestFeedbackRecordsByEmbedding(
 		ctx context.Context, model string, queryEmbedding []float32, tenantID string, limit int, excludeID *uuid.UUID, minScore float64,
 	) ([]models.FeedbackRecordWithScore, bool, error)
@@ -40,11 +57,19 @@ type EmbeddingsRepositoryForSearch interface {
 	) ([]models.FeedbackRecordWithScore, bool, error)
 }
 
+// DefaultSearchMinScore is the similarity floor applied when neither the request nor the tenant's
+// search_min_score setting names one; it filters out weak matches (reduces noise).
+const DefaultSearchMinScore = 0.7
+
 // SearchService performs semantic search and similar-feedback lookups using embeddings.
 type SearchService struct {
 	embeddingClient EmbeddingClient
 	embeddingsRepo  EmbeddingsRepositoryForSearch
+	keywordRepo     KeywordSearchRepository
 	model           string
+	secondary       *SecondaryEmbeddingModel
+	tenantSettings  TenantSettingsReader
+	maxQueryLen     int
 	queryCache      *lru.Cache[string, []float32]
 	queryLoadGroup  singleflight.Group
 	cacheMetrics    observability.CacheMetrics
@@ -52,15 +77,48 @@ type SearchService struct {
 }
 
 // SearchServiceParams configures SearchService. QueryCache and CacheMetrics may be nil (no caching).
+// KeywordRepo (optional) enables HybridSearch.
+// Secondary is nil unless EMBEDDING_SECONDARY_MODEL is set. TenantSettings (optional) resolves a
+// tenant's embedding_model and search_min_score for searches that do not name them. MaxQueryLen
+// (SEARCH_MAX_QUERY_LEN) caps the trimmed query in characters; 0 leaves it unbounded.
 type SearchServiceParams struct {
 	EmbeddingClient EmbeddingClient
 	EmbeddingsRepo  EmbeddingsRepositoryForSearch
+	KeywordRepo     KeywordSearchRepository
 	Model           string
+	Secondary       *SecondaryEmbeddingModel
+	TenantSettings  TenantSettingsReader
+	MaxQueryLen     int
 	QueryCache      *lru.Cache[string, []float32]
 	CacheMetrics    observability.CacheMetrics
 	Logger          *slog.Logger
 }
 
+// SecondaryEmbeddingModel is an embedding model stored alongside the primary one so the two can
+// be compared (A/B) on the same records. Client embeds search queries with Model. Searches opt in
+// by naming Model; everything else keeps using the primary.
+type SecondaryEmbeddingModel struct {
+	Model  string
+	Client EmbeddingClient
+}
+
+// NewSecondaryEmbeddingModel builds the secondary model's client from the primary's client config
+// (same provider and credentials, only the model differs) with the same retry policy. Call it only
+// when EMBEDDING_SECONDARY_MODEL is set.
+func NewSecondaryEmbeddingModel(
+	ctx context.Context, primary EmbeddingClientConfig, model string, retry EmbeddingRetryConfig,
+) (*SecondaryEmbeddingModel, error) {
+	clientCfg := primary
+	clientCfg.Model = model
+
+	client, err := NewEmbeddingClient(ctx, clientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("create secondary embedding client: %w", err)
+	}
+
+	return &SecondaryEmbeddingModel{Model: model, Client: WithEmbeddingRetry(client, retry)}, nil
+}
+
 // NewSearchService creates a SearchService.
 func NewSearchService(p SearchServiceParams) *SearchService {
 	logger := p.Logger
@@ -71,7 +129,11 @@ func NewSearchService(p SearchServiceParams) *SearchService {
 	return &SearchService{
 		embeddingClient: p.EmbeddingClient,
 		embeddingsRepo:  p.EmbeddingsRepo,
+		keywordRepo:     p.KeywordRepo,
 		model:           p.Model,
+		secondary:       p.Secondary,
+		tenantSettings:  p.TenantSettings,
+		maxQueryLen:     p.MaxQueryLen,
 		queryCache:      p.QueryCache,
 		cacheMetrics:    p.CacheMetrics,
 		logger:          logger,
@@ -81,32 +143,72 @@ func NewSearchService(p SearchServiceParams) *SearchService {
 // SemanticSearch returns feedback record IDs and similarity scores for the given query, scoped to tenantID.
 // Requires non-empty tenantID and non-empty (after trim) query. Uses cursor-based pagination.
 // minScore is the minimum similarity score (0..1). NextCursor is set when there may be a next page.
+// model selects the embedding model to search ("" is the tenant's embedding_model, falling back to
+// the primary); a cursor is only valid with the model it was issued for.
 func (s *SearchService) SemanticSearch(
-	ctx context.Context, query, tenantID string, limit int, minScore float64, cursor string,
+	ctx context.Context, query, tenantID, model string, limit int, minScore float64, cursor string,
+) (SearchResult, error) {
+	return s.searchByText(ctx, "query", query, tenantID, model, limit, minScore, cursor)
+}
+
+// SimilarToText returns the tenant's feedback records most similar to an arbitrary text snippet
+// ("find feedback like this paragraph"). It is semantic search under another name — the text is
+// embedded as a query through the same query cache and searched the same way — so the two never
+// drift apart; only a too-long text is reported against the "text" field.
+func (s *SearchService) SimilarToText(
+	ctx context.Context, text, tenantID, model string, limit int, minScore float64, cursor string,
+) (SearchResult, error) {
+	return s.searchByText(ctx, "text", text, tenantID, model, limit, minScore, cursor)
+}
+
+// searchByText embeds query and returns the nearest records. field names the request member the
+// query came from, for the length validation error.
+func (s *SearchService) searchByText(
+	ctx context.Context, field, query, tenantID, model string, limit int, minScore float64, cursor string,
 ) (SearchResult, error) {
 	out := SearchResult{}
 	if tenantID == "" {
 		return out, ErrMissingTenantID
 	}
 
+	// Trimmed before the length check, the embedding call, and the cache key alike, so " foo" and
+	// "foo" are one query (and one cache entry) and padding never counts against the limit.
 	query = strings.TrimSpace(query)
 	if query == "" {
 		return out, ErrEmptyQuery
 	}
 
-	var (
-		embedding []float32
-		err       error
-	)
+	// The query is embedded verbatim on every cache miss: an unbounded one wastes provider tokens
+	// and can exceed the model's input limit, turning a bad request into a provider error.
+	if s.maxQueryLen > 0 && utf8.RuneCountInString(query) > s.maxQueryLen {
+		return out, huberrors.NewValidationError(field, fmt.Sprintf("must be at most %d characters", s.maxQueryLen))
+	}
+
+	model, client, err := s.resolveModel(s.tenantModel(ctx, tenantID, model))
+	if err != nil {
+		return out, err
+	}
+
+	var embedding []float32
+
+	out.Model = model
+	out.QueryEmbeddingCache = QueryEmbeddingCacheDisabled
 
 	if s.queryCache != nil {
-		embedding, err = s.getQueryEmbeddingCached(ctx, query)
+		var hit bool
+
+		embedding, hit, err = s.getQueryEmbeddingCached(ctx, model, client, query)
+
+		out.QueryEmbeddingCache = QueryEmbeddingCacheMiss
+		if hit {
+			out.QueryEmbeddingCache = QueryEmbeddingCacheHit
+		}
 	} else {
-		embedding, err = s.embeddingClient.CreateEmbeddingForQuery(ctx, query)
+		embedding, err = client.CreateEmbeddingForQuery(ctx, query)
 	}
 
 	if err != nil {
-		s.logger.Error("semantic search: create embedding failed", "error", err, "model", s.model, "limit", limit)
+		s.logger.Error("semantic search: create embedding failed", "error", err, "model", model, "limit", limit)
 
 		return out, fmt.Errorf("create embedding: %w", err)
 	}
@@ -122,14 +224,14 @@ func (s *SearchService) SemanticSearch(
 		}
 
 		results, hasMore, err = s.embeddingsRepo.NearestFeedbackRecordsByEmbeddingAfterCursor(
-			ctx, s.model, embedding, tenantID, limit, lastDistance, lastID, nil, minScore)
+			ctx, model, embedding, tenantID, limit, lastDistance, lastID, nil, minScore)
 	} else {
 		results, hasMore, err = s.embeddingsRepo.NearestFeedbackRecordsByEmbedding(
-			ctx, s.model, embedding, tenantID, limit, nil, minScore)
+			ctx, model, embedding, tenantID, limit, nil, minScore)
 	}
 
 	if err != nil {
-		s.logger.Error("semantic search: nearest failed", "error", err, "model", s.model)
+		s.logger.Error("semantic search: nearest failed", "error", err, "model", model)
 
 		return out, fmt.Errorf("nearest feedback records: %w", err)
 	}
@@ -154,17 +256,38 @@ func (s *SearchService) SemanticSearch(
 // caller-supplied tenant check, by design: Hub sits behind the product gateway, which owns
 // record-level authorization (ENG-1289). If Hub ever becomes reachable without that gateway, this
 // endpoint needs a tenant parameter checked against the source record before the search.
-// Returns ErrEmbeddingNotFound when the record has no embedding for the current model. Uses cursor-based pagination.
+// Returns ErrEmbeddingNotFound when the record has no embedding for the selected model ("" is the
+// source record's tenant's embedding_model, falling back to the primary). Uses cursor-based pagination.
 func (s *SearchService) SimilarFeedback(
-	ctx context.Context, feedbackRecordID uuid.UUID, limit int, minScore float64, cursor string,
+	ctx context.Context, feedbackRecordID uuid.UUID, model string, limit int, minScore float64, cursor string,
 ) (SearchResult, error) {
 	out := SearchResult{}
 
-	embedding, tenantID, err := s.getSimilarFeedbackSourceEmbedding(ctx, feedbackRecordID)
+	if strings.TrimSpace(model) == "" && s.tenantModelsRoutable() {
+		tenantID, err := s.embeddingsRepo.GetTenantByFeedbackRecord(ctx, feedbackRecordID)
+		if err != nil {
+			if errors.Is(err, repository.ErrEmbeddingNotFound) {
+				return out, err
+			}
+
+			return out, fmt.Errorf("get feedback record tenant: %w", err)
+		}
+
+		model = s.tenantModel(ctx, tenantID, model)
+	}
+
+	model, _, err := s.resolveModel(model)
+	if err != nil {
+		return out, err
+	}
+
+	out.Model = model
+
+	embedding, tenantID, err := s.getSimilarFeedbackSourceEmbedding(ctx, feedbackRecordID, model)
 	if err != nil {
 		if errors.Is(err, repository.ErrEmbeddingNotFound) {
 			s.logger.Debug("similar feedback: no embedding",
-				"feedbackRecordId", feedbackRecordID.String(), "model", s.model)
+				"feedbackRecordId", feedbackRecordID.String(), "model", model)
 
 			return out, err
 		}
@@ -186,10 +309,10 @@ func (s *SearchService) SimilarFeedback(
 		}
 
 		results, hasMore, err = s.embeddingsRepo.NearestFeedbackRecordsByEmbeddingAfterCursor(
-			ctx, s.model, embedding, tenantID, limit, lastDistance, lastID, &feedbackRecordID, minScore)
+			ctx, model, embedding, tenantID, limit, lastDistance, lastID, &feedbackRecordID, minScore)
 	} else {
 		results, hasMore, err = s.embeddingsRepo.NearestFeedbackRecordsByEmbedding(
-			ctx, s.model, embedding, tenantID, limit, &feedbackRecordID, minScore)
+			ctx, model, embedding, tenantID, limit, &feedbackRecordID, minScore)
 	}
 
 	if err != nil {
@@ -213,12 +336,85 @@ func (s *SearchService) SimilarFeedback(
 	return out, nil
 }
 
+// resolveModel maps a caller-selected model to its stored model key and query client: "" or
+// EMBEDDING_MODEL is the primary, EMBEDDING_SECONDARY_MODEL the secondary.
+func (s *SearchService) resolveModel(model string) (string, EmbeddingClient, error) {
+	model = strings.TrimSpace(model)
+
+	switch {
+	case model == "" || model == s.model:
+		return s.model, s.embeddingClient, nil
+	case s.secondary != nil && model == s.secondary.Model:
+		return s.secondary.Model, s.secondary.Client, nil
+	default:
+		return "", nil, ErrUnknownEmbeddingModel
+	}
+}
+
+// DefaultMinScore returns the similarity floor for a tenant's search when the caller omits
+// min_score: the tenant's search_min_score setting, or DefaultSearchMinScore when it is unset,
+// settings are not wired, or the (cached) settings read fails — a settings outage degrades to the
+// global default rather than failing the search.
+func (s *SearchService) DefaultMinScore(ctx context.Context, tenantID string) float64 {
+	if s.tenantSettings == nil || tenantID == "" {
+		return DefaultSearchMinScore
+	}
+
+	settings, err := s.tenantSettings.GetSettings(ctx, tenantID)
+	if err != nil {
+		s.logger.Warn("search: tenant settings read failed, using the default min score",
+			"tenant_id", tenantID, "error", err)
+
+		return DefaultSearchMinScore
+	}
+
+	return settings.Settings.EffectiveSearchMinScore(DefaultSearchMinScore)
+}
+
+// tenantModelsRoutable reports whether a tenant's embedding_model can select anything but the
+// primary: without a secondary model the only valid setting is EMBEDDING_MODEL itself, so the
+// settings read (and, for similar feedback, the tenant lookup) is skipped.
+func (s *SearchService) tenantModelsRoutable() bool {
+	return s.tenantSettings != nil && s.secondary != nil
+}
+
+// tenantModel returns model unchanged when the caller named one; otherwise the tenant's
+// embedding_model ("" when unset, i.e. the primary). Only tenantID's own settings are consulted,
+// so one tenant's choice never changes the model another tenant is searched with. A settings read
+// failure, or a setting naming a model that is no longer configured (EMBEDDING_SECONDARY_MODEL
+// changed since it was saved), falls back to the primary rather than failing a search the caller
+// did not pin to a model.
+func (s *SearchService) tenantModel(ctx context.Context, tenantID, model string) string {
+	if strings.TrimSpace(model) != "" || !s.tenantModelsRoutable() {
+		return model
+	}
+
+	settings, err := s.tenantSettings.GetSettings(ctx, tenantID)
+	if err != nil {
+		s.logger.Warn("search: tenant settings read failed, using the default embedding model",
+			"tenant_id", tenantID, "error", err)
+
+		return ""
+	}
+
+	tenantModel := settings.Settings.EmbeddingModel
+	if tenantModel != "" && tenantModel != s.model && tenantModel != s.secondary.Model {
+		s.logger.Warn("search: tenant embedding_model is not configured, using the default embedding model",
+			"tenant_id", tenantID, "model", tenantModel)
+
+		return ""
+	}
+
+	return tenantModel
+}
+
 func (s *SearchService) getSimilarFeedbackSourceEmbedding(
 	ctx context.Context,
 	feedbackRecordID uuid.UUID,
+	model string,
 ) ([]float32, string, error) {
 	embedding, resolvedTenantID, err := s.embeddingsRepo.GetEmbeddingAndTenantByFeedbackRecordAndModel(
-		ctx, feedbackRecordID, s.model)
+		ctx, feedbackRecordID, model)
 	if err != nil {
 		return nil, "", fmt.Errorf("get embedding and tenant: %w", err)
 	}
@@ -230,27 +426,63 @@ func (s *SearchService) getSimilarFeedbackSourceEmbedding(
 	return embedding, resolvedTenantID, nil
 }
 
-func (s *SearchService) getQueryEmbeddingCached(ctx context.Context, query string) ([]float32, error) {
-	if vec, ok := s.queryCache.Get(query); ok {
+// EvictQueries removes the cached query vectors of the given texts under every model and returns
+// how many entries it removed. Subject erasure calls it with the erased records' value_text: a
+// "similar to this text" search with a record's own text leaves that text as a cache key. The
+// cache is per process, so only this replica's entries are evicted; the rest age out of their LRU.
+func (s *SearchService) EvictQueries(texts []string) int {
+	if s.queryCache == nil || len(texts) == 0 {
+		return 0
+	}
+
+	erased := make(map[string]struct{}, len(texts))
+	for _, text := range texts {
+		erased[strings.TrimSpace(text)] = struct{}{}
+	}
+
+	evicted := 0
+
+	for _, key := range s.queryCache.Keys() {
+		_, query, ok := strings.Cut(key, "\x00")
+		if !ok {
+			continue
+		}
+
+		if _, match := erased[query]; match && s.queryCache.Remove(key) {
+			evicted++
+		}
+	}
+
+	return evicted
+}
+
+// getQueryEmbeddingCached keys the cache by model as well as query: the same text has a different
+// vector under each model. The bool reports a hit: the vector came without a provider call of our own.
+func (s *SearchService) getQueryEmbeddingCached(
+	ctx context.Context, model string, client EmbeddingClient, query string,
+) ([]float32, bool, error) {
+	key := model + "\x00" + query
+
+	if vec, ok := s.queryCache.Get(key); ok {
 		if s.cacheMetrics != nil {
 			s.cacheMetrics.RecordHit(ctx, searchQueryEmbeddingCacheName)
 		}
 
-		return vec, nil
+		return vec, true, nil
 	}
 
-	val, err, shared := s.queryLoadGroup.Do(query, func() (any, error) {
-		vec, loadErr := s.embeddingClient.CreateEmbeddingForQuery(ctx, query)
+	val, err, shared := s.queryLoadGroup.Do(key, func() (any, error) {
+		vec, loadErr := client.CreateEmbeddingForQuery(ctx, query)
 		if loadErr != nil {
 			return nil, fmt.Errorf("create embedding: %w", loadErr)
 		}
 
-		s.queryCache.Add(query, vec)
+		s.queryCache.Add(key, vec)
 
 		return vec, nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("query embedding: %w", err)
+		return nil, false, fmt.Errorf("query embedding: %w", err)
 	}
 
 	if s.cacheMetrics != nil {
@@ -263,5 +495,5 @@ func (s *SearchService) getQueryEmbeddingCached(ctx context.Context, query strin
 		}
 	}
 
-	return val.([]float32), nil
+	return val.([]float32), shared, nil
 }
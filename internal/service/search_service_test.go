@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/golang-lru/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/formbricks/hub/internal/huberrors"
 	"github.com/formbricks/hub/internal/models"
 	"github.com/formbricks/hub/internal/repository"
 )
@@ -36,6 +38,7 @@ func (m *mockEmbeddingClient) CreateEmbeddingForQuery(ctx context.Context, input
 
 type mockEmbeddingsRepoForSearch struct {
 	getEmbeddingAndTenantFunc func(ctx context.Context, feedbackRecordID uuid.UUID, model string) ([]float32, string, error)
+	getTenantFunc             func(ctx context.Context, feedbackRecordID uuid.UUID) (string, error)
 	nearestFunc               func(
 		ctx context.Context, model string, queryEmbedding []float32,
 		tenantID string, limit int, excludeID *uuid.UUID, minScore float64,
@@ -56,6 +59,14 @@ func (m *mockEmbeddingsRepoForSearch) GetEmbeddingAndTenantByFeedbackRecordAndMo
 	return nil, "", repository.ErrEmbeddingNotFound
 }
 
+func (m *mockEmbeddingsRepoForSearch) GetTenantByFeedbackRecord(ctx context.Context, feedbackRecordID uuid.UUID) (string, error) {
+	if m.getTenantFunc != nil {
+		return m.getTenantFunc(ctx, feedbackRecordID)
+	}
+
+	return "", repository.ErrEmbeddingNotFound
+}
+
 func (m *mockEmbeddingsRepoForSearch) NearestFeedbackRecordsByEmbedding(
 	ctx context.Context, model string, queryEmbedding []float32, tenantID string, limit int, excludeID *uuid.UUID, minScore float64,
 ) ([]models.FeedbackRecordWithScore, bool, error) {
@@ -84,7 +95,7 @@ func TestSearchService_SemanticSearch(t *testing.T) {
 			EmbeddingsRepo:  &mockEmbeddingsRepoForSearch{},
 			Model:           "test-model",
 		})
-		res, err := svc.SemanticSearch(context.Background(), "query", "", 10, 0, "")
+		res, err := svc.SemanticSearch(context.Background(), "query", "", "", 10, 0, "")
 		assert.Empty(t, res.Results)
 		assert.ErrorIs(t, err, ErrMissingTenantID)
 	})
@@ -95,7 +106,7 @@ func TestSearchService_SemanticSearch(t *testing.T) {
 			EmbeddingsRepo:  &mockEmbeddingsRepoForSearch{},
 			Model:           "test-model",
 		})
-		res, err := svc.SemanticSearch(context.Background(), "  ", "tenant-1", 10, 0, "")
+		res, err := svc.SemanticSearch(context.Background(), "  ", "tenant-1", "", 10, 0, "")
 		assert.Empty(t, res.Results)
 		assert.ErrorIs(t, err, ErrEmptyQuery)
 	})
@@ -140,7 +151,7 @@ func TestSearchService_SemanticSearch(t *testing.T) {
 			},
 			Model: "test-model",
 		})
-		res, err := svc.SemanticSearch(context.Background(), "login slow", "env-1", 10, 0.5, "")
+		res, err := svc.SemanticSearch(context.Background(), "login slow", "env-1", "", 10, 0.5, "")
 		require.NoError(t, err)
 		require.True(t, queryClientCalled)
 		require.True(t, nearestCalled)
@@ -182,7 +193,7 @@ func TestSearchService_SimilarFeedback(t *testing.T) {
 			},
 			Model: "test-model",
 		})
-		res, err := svc.SimilarFeedback(context.Background(), sourceID, 10, 0.5, "")
+		res, err := svc.SimilarFeedback(context.Background(), sourceID, "", 10, 0.5, "")
 		require.NoError(t, err)
 		require.Len(t, res.Results, 1)
 		assert.Equal(t, similarID, res.Results[0].FeedbackRecordID)
@@ -202,7 +213,7 @@ func TestSearchService_SimilarFeedback(t *testing.T) {
 			},
 			Model: "test-model",
 		})
-		res, err := svc.SimilarFeedback(context.Background(), rid, 10, 0, "")
+		res, err := svc.SimilarFeedback(context.Background(), rid, "", 10, 0, "")
 		assert.Empty(t, res.Results)
 		assert.ErrorIs(t, err, repository.ErrEmbeddingNotFound)
 	})
@@ -224,7 +235,268 @@ func TestSearchService_SemanticSearch_EmbeddingError(t *testing.T) {
 		EmbeddingsRepo: &mockEmbeddingsRepoForSearch{},
 		Model:          "test-model",
 	})
-	res, err := svc.SemanticSearch(context.Background(), "query", "env-1", 10, 0, "")
+	res, err := svc.SemanticSearch(context.Background(), "query", "env-1", "", 10, 0, "")
 	assert.Empty(t, res.Results)
 	assert.ErrorIs(t, err, embeddingErr)
 }
+
+// TestSearchService_QueryLength covers SEARCH_MAX_QUERY_LEN: the limit counts characters of the
+// trimmed query and rejects before any embedding call, and surrounding whitespace does not split
+// the query cache.
+func TestSearchService_QueryLength(t *testing.T) {
+	cache, err := lru.New[string, []float32](10)
+	require.NoError(t, err)
+
+	var embedded []string
+
+	svc := NewSearchService(SearchServiceParams{
+		EmbeddingClient: &mockEmbeddingClient{createQueryFunc: func(_ context.Context, input string) ([]float32, error) {
+			embedded = append(embedded, input)
+
+			return []float32{1}, nil
+		}},
+		EmbeddingsRepo: &mockEmbeddingsRepoForSearch{},
+		Model:          "test-model",
+		MaxQueryLen:    5,
+		QueryCache:     cache,
+	})
+
+	res, err := svc.SemanticSearch(context.Background(), "  héllo \n", "env-1", "", 10, 0, "")
+	require.NoError(t, err, "five characters after trimming is within the limit")
+	assert.Equal(t, QueryEmbeddingCacheMiss, res.QueryEmbeddingCache)
+	assert.Equal(t, "test-model", res.Model)
+
+	res, err = svc.SemanticSearch(context.Background(), "héllo", "env-1", "", 10, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, QueryEmbeddingCacheHit, res.QueryEmbeddingCache, "explain reports the cached vector")
+
+	_, err = svc.SemanticSearch(context.Background(), "hello!", "env-1", "", 10, 0, "")
+	require.ErrorIs(t, err, huberrors.ErrValidation)
+
+	assert.Equal(t, []string{"héllo"}, embedded, "trimmed once, cached once, and the long query never embedded")
+}
+
+// TestSearchService_SecondaryModel locks the A/B model selection: naming the secondary model
+// embeds the query with its client and searches its stored vectors, the query cache keeps the
+// two models' vectors apart, and an unconfigured model is rejected before any embedding call.
+func TestSearchService_SecondaryModel(t *testing.T) {
+	var searchedModels []string
+
+	newService := func(t *testing.T) *SearchService {
+		t.Helper()
+
+		cache, err := lru.New[string, []float32](8)
+		require.NoError(t, err)
+
+		searchedModels = nil
+
+		return NewSearchService(SearchServiceParams{
+			EmbeddingClient: &mockEmbeddingClient{createQueryFunc: func(_ context.Context, _ string) ([]float32, error) {
+				return []float32{1}, nil
+			}},
+			EmbeddingsRepo: &mockEmbeddingsRepoForSearch{
+				nearestFunc: func(
+					_ context.Context, model string, queryEmbedding []float32,
+					_ string, _ int, _ *uuid.UUID, _ float64,
+				) ([]models.FeedbackRecordWithScore, bool, error) {
+					searchedModels = append(searchedModels, model)
+
+					wantVector := map[string]float32{"primary": 1, "candidate": 2}[model]
+					assert.Equal(t, []float32{wantVector}, queryEmbedding, "query embedded by the wrong model's client")
+
+					return nil, false, nil
+				},
+				getEmbeddingAndTenantFunc: func(_ context.Context, _ uuid.UUID, model string) ([]float32, string, error) {
+					searchedModels = append(searchedModels, model)
+
+					return []float32{2}, "env-1", nil
+				},
+			},
+			Model: "primary",
+			Secondary: &SecondaryEmbeddingModel{
+				Model: "candidate",
+				Client: &mockEmbeddingClient{createQueryFunc: func(_ context.Context, _ string) ([]float32, error) {
+					return []float32{2}, nil
+				}},
+			},
+			QueryCache: cache,
+		})
+	}
+
+	t.Run("semantic search per model", func(t *testing.T) {
+		svc := newService(t)
+
+		for _, model := range []string{"", "candidate", "primary", " candidate "} {
+			_, err := svc.SemanticSearch(context.Background(), "login slow", "env-1", model, 10, 0, "")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, []string{"primary", "candidate", "primary", "candidate"}, searchedModels)
+	})
+
+	t.Run("similar feedback reads the selected model", func(t *testing.T) {
+		svc := newService(t)
+
+		_, err := svc.SimilarFeedback(context.Background(), uuid.New(), "candidate", 10, 0, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"candidate", "candidate"}, searchedModels)
+	})
+
+	t.Run("unknown model", func(t *testing.T) {
+		svc := newService(t)
+
+		_, err := svc.SemanticSearch(context.Background(), "login slow", "env-1", "other", 10, 0, "")
+		require.ErrorIs(t, err, ErrUnknownEmbeddingModel)
+
+		_, err = svc.SimilarFeedback(context.Background(), uuid.New(), "other", 10, 0, "")
+		require.ErrorIs(t, err, ErrUnknownEmbeddingModel)
+		assert.Empty(t, searchedModels)
+	})
+}
+
+// settingsByTenant is a TenantSettingsReader with per-tenant settings; unknown tenants are unconfigured.
+type settingsByTenant map[string]models.EnrichmentSettings
+
+func (s settingsByTenant) GetSettings(_ context.Context, tenantID string) (*models.TenantSettings, error) {
+	return &models.TenantSettings{TenantID: tenantID, Settings: s[tenantID]}, nil
+}
+
+func TestSearchService_TenantEmbeddingModel(t *testing.T) {
+	var searched []string // "tenant:model" per nearest query
+
+	recordTenants := map[uuid.UUID]string{}
+	svc := NewSearchService(SearchServiceParams{
+		EmbeddingClient: &mockEmbeddingClient{},
+		EmbeddingsRepo: &mockEmbeddingsRepoForSearch{
+			nearestFunc: func(
+				_ context.Context, model string, _ []float32, tenantID string, _ int, _ *uuid.UUID, _ float64,
+			) ([]models.FeedbackRecordWithScore, bool, error) {
+				searched = append(searched, tenantID+":"+model)
+
+				return nil, false, nil
+			},
+			getTenantFunc: func(_ context.Context, id uuid.UUID) (string, error) {
+				return recordTenants[id], nil
+			},
+			getEmbeddingAndTenantFunc: func(_ context.Context, id uuid.UUID, _ string) ([]float32, string, error) {
+				return []float32{1}, recordTenants[id], nil
+			},
+		},
+		Model:     "primary",
+		Secondary: &SecondaryEmbeddingModel{Model: "candidate", Client: &mockEmbeddingClient{}},
+		TenantSettings: settingsByTenant{
+			"premium": {EmbeddingModel: "candidate"},
+			"stale":   {EmbeddingModel: "removed-model"},
+		},
+	})
+
+	for _, tenantID := range []string{"premium", "basic", "stale"} {
+		_, err := svc.SemanticSearch(context.Background(), "login slow", tenantID, "", 10, 0, "")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"premium:candidate", "basic:primary", "stale:primary"}, searched,
+		"each tenant is searched with its own model; an unconfigured or stale setting falls back to the primary")
+
+	searched = nil
+	premiumRecord, basicRecord := uuid.New(), uuid.New()
+	recordTenants[premiumRecord], recordTenants[basicRecord] = "premium", "basic"
+
+	for _, id := range []uuid.UUID{premiumRecord, basicRecord} {
+		_, err := svc.SimilarFeedback(context.Background(), id, "", 10, 0, "")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"premium:candidate", "basic:primary"}, searched,
+		"similar feedback uses the source record's tenant's model")
+
+	searched = nil
+	_, err := svc.SemanticSearch(context.Background(), "login slow", "premium", "primary", 10, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"premium:primary"}, searched, "an explicit model overrides the tenant's setting")
+}
+
+func TestSearchService_DefaultMinScore(t *testing.T) {
+	tuned := 0.55
+	svc := NewSearchService(SearchServiceParams{
+		Model:          "primary",
+		TenantSettings: settingsByTenant{"noisy": {SearchMinScore: &tuned}},
+	})
+
+	assert.InDelta(t, 0.55, svc.DefaultMinScore(context.Background(), "noisy"), 1e-9)
+	assert.InDelta(t, DefaultSearchMinScore, svc.DefaultMinScore(context.Background(), "basic"), 1e-9,
+		"an unconfigured tenant gets the search default")
+
+	unwired := NewSearchService(SearchServiceParams{Model: "primary"})
+	assert.InDelta(t, DefaultSearchMinScore, unwired.DefaultMinScore(context.Background(), "noisy"), 1e-9)
+}
+
+// TestSearchService_SimilarToText checks that text similarity goes through the same path as
+// semantic search: the query cache is shared, and errors name the text field.
+func TestSearchService_SimilarToText(t *testing.T) {
+	cache, err := lru.New[string, []float32](10)
+	require.NoError(t, err)
+
+	embedCalls := 0
+	svc := NewSearchService(SearchServiceParams{
+		EmbeddingClient: &mockEmbeddingClient{createQueryFunc: func(context.Context, string) ([]float32, error) {
+			embedCalls++
+
+			return []float32{1}, nil
+		}},
+		EmbeddingsRepo: &mockEmbeddingsRepoForSearch{},
+		Model:          "test-model",
+		MaxQueryLen:    20,
+		QueryCache:     cache,
+	})
+
+	_, err = svc.SemanticSearch(context.Background(), "checkout is broken", "env-1", "", 10, 0, "")
+	require.NoError(t, err)
+
+	_, err = svc.SimilarToText(context.Background(), "checkout is broken", "env-1", "", 10, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, embedCalls, "the text reuses the vector cached by semantic search")
+
+	_, err = svc.SimilarToText(context.Background(), "   ", "env-1", "", 10, 0, "")
+	require.ErrorIs(t, err, ErrEmptyQuery)
+
+	_, err = svc.SimilarToText(context.Background(), "this text is far too long", "env-1", "", 10, 0, "")
+
+	var validationErr *huberrors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "text", validationErr.Field)
+}
+
+func TestSearchService_EvictQueries(t *testing.T) {
+	cache, err := lru.New[string, []float32](10)
+	require.NoError(t, err)
+
+	embedCalls := 0
+	svc := NewSearchService(SearchServiceParams{
+		EmbeddingClient: &mockEmbeddingClient{createQueryFunc: func(context.Context, string) ([]float32, error) {
+			embedCalls++
+
+			return []float32{1}, nil
+		}},
+		EmbeddingsRepo: &mockEmbeddingsRepoForSearch{},
+		Model:          "test-model",
+		MaxQueryLen:    50,
+		QueryCache:     cache,
+	})
+
+	for _, query := range []string{"checkout is broken", "love the new design"} {
+		_, err = svc.SemanticSearch(context.Background(), query, "env-1", "", 10, 0, "")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, svc.EvictQueries([]string{" checkout is broken\n", "never searched"}))
+	assert.Equal(t, 0, svc.EvictQueries(nil))
+
+	_, err = svc.SemanticSearch(context.Background(), "love the new design", "env-1", "", 10, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, embedCalls, "the other query stays cached")
+
+	_, err = svc.SemanticSearch(context.Background(), "checkout is broken", "env-1", "", 10, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, 3, embedCalls, "the evicted query is embedded again")
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/datatypes"
+	"github.com/formbricks/hub/internal/models"
+)
+
+// SubjectErasureRepository is the transactional part of a subject erasure.
+type SubjectErasureRepository interface {
+	EraseSubject(ctx context.Context, filters *models.DeleteFeedbackRecordsByUserFilters) (*models.SubjectErasureCounts, error)
+}
+
+// ErasureJobCanceller cancels the pending embedding jobs of erased records (satisfied by
+// *EmbeddingJobCanceller).
+type ErasureJobCanceller interface {
+	CancelJobs(ctx context.Context, ids []uuid.UUID) (int, error)
+}
+
+// ErasureQueryCache evicts cached search queries by text (satisfied by *SearchService).
+type ErasureQueryCache interface {
+	EvictQueries(texts []string) int
+}
+
+// SubjectErasureService runs GDPR erasures (POST /v1/gdpr/erase): everything Hub keeps about one
+// subject (user_id), removed in one request with a report of what went.
+//
+// The database part — feedback records, their embeddings and taxonomy memberships, and webhook
+// dead letters carrying the subject's records — commits atomically in the repository. What lives
+// outside the database follows, best-effort: pending embedding jobs are cancelled and the search
+// query cache is evicted. A failure there is reported as a warning, never as an error, since the
+// data is already gone and a job that still runs finds no record. Hub keeps no audit log of
+// feedback, so there are no audit rows to remove; taxonomy node events record analysts' edits,
+// not the subject. Export files already written are not rewritten.
+type SubjectErasureService struct {
+	repo         SubjectErasureRepository
+	publisher    MessagePublisher
+	jobCanceller ErasureJobCanceller
+	queryCache   ErasureQueryCache
+	now          func() time.Time
+}
+
+// NewSubjectErasureService creates a subject erasure service. publisher (optional) receives one
+// FeedbackRecordDeleted event per tenant, exactly as for a delete by user.
+func NewSubjectErasureService(repo SubjectErasureRepository, publisher MessagePublisher) *SubjectErasureService {
+	return &SubjectErasureService{repo: repo, publisher: publisher, now: time.Now}
+}
+
+// SetJobCanceller makes erasures cancel the erased records' pending embedding jobs. Leave it unset
+// when embeddings are disabled.
+func (s *SubjectErasureService) SetJobCanceller(canceller ErasureJobCanceller) {
+	s.jobCanceller = canceller
+}
+
+// SetQueryCache makes erasures evict the erased records' text from the search query cache. Leave
+// it unset when search is disabled.
+func (s *SubjectErasureService) SetQueryCache(cache ErasureQueryCache) {
+	s.queryCache = cache
+}
+
+// EraseSubject erases everything Hub keeps about req.UserID, in one tenant or (without tenant_id)
+// in all of them, and reports what was removed. Like a delete by user it is idempotent: erasing an
+// already-erased subject succeeds with zero counts.
+func (s *SubjectErasureService) EraseSubject(
+	ctx context.Context, req *models.SubjectErasureRequest,
+) (*models.SubjectErasureReport, error) {
+	if req == nil {
+		return nil, ErrUserIDRequired
+	}
+
+	normalizedUserID, err := normalizeRequiredUserIDValue(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := &models.DeleteFeedbackRecordsByUserFilters{UserID: normalizedUserID}
+
+	if req.TenantID != nil {
+		normalizedTenantID, err := normalizeRequiredTenantID(req.TenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		filters.TenantID = &normalizedTenantID
+	}
+
+	counts, err := s.repo.EraseSubject(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("erase subject: %w", err)
+	}
+
+	report := &models.SubjectErasureReport{
+		UserID:                            normalizedUserID,
+		TenantID:                          filters.TenantID,
+		Tenants:                           make([]models.SubjectErasureTenant, 0, len(counts.DeletedByTenant)),
+		DeletedEmbeddings:                 counts.DeletedEmbeddings,
+		DeletedTaxonomyClusterMemberships: counts.DeletedTaxonomyClusterMemberships,
+		DeletedWebhookDeadLetters:         counts.DeletedWebhookDeadLetters,
+		ErasedAt:                          s.now().UTC(),
+	}
+
+	var erasedIDs []uuid.UUID
+
+	for _, group := range counts.DeletedByTenant {
+		report.DeletedFeedbackRecords += int64(len(group.IDs))
+		report.Tenants = append(report.Tenants, models.SubjectErasureTenant{
+			TenantID:               group.TenantID,
+			DeletedFeedbackRecords: int64(len(group.IDs)),
+		})
+		erasedIDs = append(erasedIDs, group.IDs...)
+	}
+
+	if s.jobCanceller != nil && len(erasedIDs) > 0 {
+		cancelled, err := s.jobCanceller.CancelJobs(ctx, erasedIDs)
+		report.CanceledEmbeddingJobs = int64(cancelled)
+
+		if err != nil {
+			slog.Warn("subject erasure: cancel embedding jobs failed", "records", len(erasedIDs), "error", err)
+			report.Warnings = append(report.Warnings,
+				"some pending embedding jobs could not be cancelled; they will find their records gone and skip")
+		}
+	}
+
+	if s.queryCache != nil {
+		report.EvictedQueryCacheEntries = int64(s.queryCache.EvictQueries(counts.ValueTexts))
+	}
+
+	if s.publisher != nil {
+		for _, group := range counts.DeletedByTenant {
+			if len(group.IDs) > 0 {
+				s.publisher.PublishEvent(ctx, datatypes.FeedbackRecordDeleted, models.DeletedIDsEventData(group))
+			}
+		}
+	}
+
+	// The user_id is deliberately not logged: the report returned to the caller is the record of
+	// the erasure, and the log should not keep the identifier it just erased.
+	slog.Info("subject erasure completed",
+		"tenants", len(report.Tenants),
+		"feedback_records", report.DeletedFeedbackRecords,
+		"embeddings", report.DeletedEmbeddings,
+		"webhook_dead_letters", report.DeletedWebhookDeadLetters,
+		"embedding_jobs_canceled", report.CanceledEmbeddingJobs,
+	)
+
+	return report, nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/datatypes"
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+)
+
+type mockSubjectErasureRepo struct {
+	filters *models.DeleteFeedbackRecordsByUserFilters
+	counts  *models.SubjectErasureCounts
+	err     error
+}
+
+func (m *mockSubjectErasureRepo) EraseSubject(
+	_ context.Context, filters *models.DeleteFeedbackRecordsByUserFilters,
+) (*models.SubjectErasureCounts, error) {
+	m.filters = filters
+
+	return m.counts, m.err
+}
+
+type mockErasureJobCanceller struct {
+	ids       []uuid.UUID
+	cancelled int
+	err       error
+}
+
+func (m *mockErasureJobCanceller) CancelJobs(_ context.Context, ids []uuid.UUID) (int, error) {
+	m.ids = ids
+
+	return m.cancelled, m.err
+}
+
+type mockErasureQueryCache struct {
+	texts []string
+}
+
+func (m *mockErasureQueryCache) EvictQueries(texts []string) int {
+	m.texts = texts
+
+	return len(texts)
+}
+
+func TestSubjectErasureService_EraseSubject(t *testing.T) {
+	firstID := uuid.Must(uuid.NewV7())
+	secondID := uuid.Must(uuid.NewV7())
+	thirdID := uuid.Must(uuid.NewV7())
+
+	newRepo := func() *mockSubjectErasureRepo {
+		return &mockSubjectErasureRepo{counts: &models.SubjectErasureCounts{
+			DeletedByTenant: []models.DeletedFeedbackRecordsByTenant{
+				{TenantID: "org-1", IDs: []uuid.UUID{firstID, secondID}},
+				{TenantID: "org-2", IDs: []uuid.UUID{thirdID}},
+			},
+			DeletedEmbeddings:                 3,
+			DeletedTaxonomyClusterMemberships: 2,
+			DeletedWebhookDeadLetters:         1,
+			ValueTexts:                        []string{"great product"},
+		}}
+	}
+
+	t.Run("reports every step and publishes one delete event per tenant", func(t *testing.T) {
+		repo := newRepo()
+		publisher := &capturePublisher{}
+		canceller := &mockErasureJobCanceller{cancelled: 2}
+		cache := &mockErasureQueryCache{}
+
+		svc := NewSubjectErasureService(repo, publisher)
+		svc.SetJobCanceller(canceller)
+		svc.SetQueryCache(cache)
+		svc.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+
+		report, err := svc.EraseSubject(context.Background(), &models.SubjectErasureRequest{UserID: " user-1 "})
+		if err != nil {
+			t.Fatalf("EraseSubject() error = %v", err)
+		}
+
+		if repo.filters.UserID != "user-1" || repo.filters.TenantID != nil {
+			t.Fatalf("repo filters = %+v, want user-1 across all tenants", repo.filters)
+		}
+
+		if report.DeletedFeedbackRecords != 3 || len(report.Tenants) != 2 || report.Tenants[1].DeletedFeedbackRecords != 1 {
+			t.Fatalf("report records = %d, tenants = %+v", report.DeletedFeedbackRecords, report.Tenants)
+		}
+
+		if report.DeletedEmbeddings != 3 || report.DeletedTaxonomyClusterMemberships != 2 || report.DeletedWebhookDeadLetters != 1 {
+			t.Fatalf("report counts = %+v", report)
+		}
+
+		if len(canceller.ids) != 3 || report.CanceledEmbeddingJobs != 2 {
+			t.Fatalf("cancelled jobs for %d records, reported %d", len(canceller.ids), report.CanceledEmbeddingJobs)
+		}
+
+		if report.EvictedQueryCacheEntries != 1 || len(cache.texts) != 1 {
+			t.Fatalf("evicted = %d for texts %v", report.EvictedQueryCacheEntries, cache.texts)
+		}
+
+		if len(report.Warnings) != 0 || !report.ErasedAt.Equal(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)) {
+			t.Fatalf("warnings = %v, erased_at = %v", report.Warnings, report.ErasedAt)
+		}
+
+		if publisher.callCount != 2 || publisher.events[0].eventType != datatypes.FeedbackRecordDeleted {
+			t.Fatalf("publish calls = %d, want 2 FeedbackRecordDeleted events", publisher.callCount)
+		}
+	})
+
+	t.Run("a failed job cancel is a warning, not an error", func(t *testing.T) {
+		svc := NewSubjectErasureService(newRepo(), nil)
+		svc.SetJobCanceller(&mockErasureJobCanceller{cancelled: 1, err: errors.New("river unavailable")})
+
+		report, err := svc.EraseSubject(context.Background(), &models.SubjectErasureRequest{UserID: "user-1"})
+		if err != nil {
+			t.Fatalf("EraseSubject() error = %v, want success with a warning", err)
+		}
+
+		if len(report.Warnings) != 1 || report.CanceledEmbeddingJobs != 1 {
+			t.Fatalf("warnings = %v, cancelled = %d", report.Warnings, report.CanceledEmbeddingJobs)
+		}
+	})
+
+	t.Run("normalizes tenant id", func(t *testing.T) {
+		repo := newRepo()
+		svc := NewSubjectErasureService(repo, nil)
+		tenantID := " org-1 "
+
+		report, err := svc.EraseSubject(context.Background(), &models.SubjectErasureRequest{UserID: "user-1", TenantID: &tenantID})
+		if err != nil {
+			t.Fatalf("EraseSubject() error = %v", err)
+		}
+
+		if repo.filters.TenantID == nil || *repo.filters.TenantID != "org-1" || *report.TenantID != "org-1" {
+			t.Fatalf("tenant filter = %v, report tenant = %v, want org-1", repo.filters.TenantID, report.TenantID)
+		}
+	})
+
+	t.Run("blank user id is rejected before the repository", func(t *testing.T) {
+		repo := newRepo()
+		svc := NewSubjectErasureService(repo, nil)
+
+		_, err := svc.EraseSubject(context.Background(), &models.SubjectErasureRequest{UserID: "  "})
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("EraseSubject() error = %v, want validation error", err)
+		}
+
+		if repo.filters != nil {
+			t.Fatal("repo was called, want validation before repository")
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/formbricks/hub/internal/models"
+)
+
+// ErrSyncEmbeddingSlotUnavailable is returned when an inline embedding could not acquire a
+// concurrency slot before its deadline; the caller falls back to the async job.
+var ErrSyncEmbeddingSlotUnavailable = errors.New("sync embedding: no concurrency slot available before timeout")
+
+// SyncEmbedder computes a feedback record's raw embedding inline on the create request
+// (POST /v1/feedback-records?sync_embedding=true) instead of deferring it to the embeddings queue.
+//
+// The tradeoff is latency for immediacy: the create response waits for one provider round trip
+// (typically 100–500ms, bounded by timeout) so the record is searchable the moment it returns.
+// That is only worth it for low-volume, latency-tolerant clients — every inline call holds an API
+// request open on a rate-limited provider — so inline calls are capped at maxConcurrent per API
+// process (a slot is acquired within the same timeout), independently of the hub-worker's
+// EMBEDDING_MAX_CONCURRENT. Anything that does not finish in time — no free slot, a slow provider,
+// a provider 429 — is not an error for the client: the caller falls back to the normal async job.
+type SyncEmbedder struct {
+	client    EmbeddingClient
+	docPrefix string
+	timeout   time.Duration
+	slots     chan struct{}
+}
+
+// NewSyncEmbedder creates an inline embedder. docPrefix is the provider's document prefix (from
+// EmbeddingPrefixForProvider) and must match the worker's so the inline vector is the one the job
+// would have produced. timeout bounds slot acquisition plus the provider call; maxConcurrent caps
+// in-flight inline calls (values <= 0 are treated as 1).
+func NewSyncEmbedder(client EmbeddingClient, docPrefix string, timeout time.Duration, maxConcurrent int) *SyncEmbedder {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+
+	return &SyncEmbedder{
+		client:    client,
+		docPrefix: docPrefix,
+		timeout:   timeout,
+		slots:     make(chan struct{}, maxConcurrent),
+	}
+}
+
+// embed returns the embedding input (the exact text the vector was computed from, for the
+// write's supersession guard) and its vector. An empty input returns ("", nil, nil): there is
+// nothing to embed, and the caller leaves the record to the async path's own skip logic.
+func (e *SyncEmbedder) embed(ctx context.Context, record *models.FeedbackRecord) (string, []float32, error) {
+	input := BuildEmbeddingInputForKind(record, models.EmbeddingInputKindRaw, e.docPrefix)
+	if input == "" {
+		return "", nil, nil
+	}
+
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+
+	select {
+	case e.slots <- struct{}{}:
+		defer func() { <-e.slots }()
+	case <-ctx.Done():
+		return "", nil, fmt.Errorf("%w: %w", ErrSyncEmbeddingSlotUnavailable, ctx.Err())
+	}
+
+	vector, err := e.client.CreateEmbedding(ctx, input)
+	if err != nil {
+		return "", nil, fmt.Errorf("create embedding: %w", err)
+	}
+
+	return input, vector, nil
+}
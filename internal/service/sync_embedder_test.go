@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/datatypes"
+	"github.com/formbricks/hub/internal/models"
+)
+
+// captureEmbeddingsRepo records Upsert calls and serves one reembed page and keyset pages of
+// backfillIDs; the other methods are unused by the sync path.
+type captureEmbeddingsRepo struct {
+	upserts     int
+	model       string
+	upsertErr   error
+	reembedIDs  []uuid.UUID
+	backfillIDs []uuid.UUID
+}
+
+func (r *captureEmbeddingsRepo) Upsert(
+	_ context.Context, _ uuid.UUID, model string, _ []float32,
+	_ func(fieldLabel, valueText, valueTextTranslated *string) bool,
+) error {
+	r.upserts++
+	r.model = model
+
+	return r.upsertErr
+}
+
+func (r *captureEmbeddingsRepo) DeleteByFeedbackRecordAndModel(
+	context.Context, uuid.UUID, string, func(fieldLabel, valueText, valueTextTranslated *string) bool,
+) error {
+	return nil
+}
+
+func (r *captureEmbeddingsRepo) ListFeedbackRecordIDsForBackfill(
+	context.Context, string, uuid.UUID, int,
+) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (r *captureEmbeddingsRepo) ListFeedbackRecordIDsForBackfillByInputKind(
+	_ context.Context, _ string, _ models.EmbeddingInputKind, afterID uuid.UUID, limit int,
+) ([]uuid.UUID, error) {
+	start := 0
+	if afterID != uuid.Nil {
+		start = slices.Index(r.backfillIDs, afterID) + 1
+	}
+
+	return r.backfillIDs[start:min(start+limit, len(r.backfillIDs))], nil
+}
+
+func (r *captureEmbeddingsRepo) ClearEmbeddingsForReembed(
+	_ context.Context, model string, _ *models.ReembedRequest, afterID uuid.UUID, _ int,
+) ([]uuid.UUID, error) {
+	r.model = model
+	if afterID != uuid.Nil {
+		return nil, nil
+	}
+
+	return r.reembedIDs, nil
+}
+
+func syncCreateRequest() *models.CreateFeedbackRecordRequest {
+	return &models.CreateFeedbackRecordRequest{
+		SourceType:   "formbricks",
+		FieldID:      "field-1",
+		FieldType:    models.FieldTypeText,
+		TenantID:     "org-123",
+		SubmissionID: "submission-1",
+	}
+}
+
+func newSyncTestService(
+	embeddingsRepo *captureEmbeddingsRepo, publisher *capturePublisher, client EmbeddingClient, timeout time.Duration,
+) *FeedbackRecordsService {
+	text := "The checkout flow is confusing"
+	repo := &mockFeedbackRecordsRepo{record: &models.FeedbackRecord{
+		ID: uuid.Must(uuid.NewV7()), TenantID: "org-123", FieldType: models.FieldTypeText, ValueText: &text,
+	}}
+	svc := NewFeedbackRecordsService(repo, embeddingsRepo, "embedding-model", publisher, nil, "", 0, "")
+	svc.SetSyncEmbedder(NewSyncEmbedder(client, "", timeout, 1))
+
+	return svc
+}
+
+func TestFeedbackRecordsService_CreateWithSyncEmbedding_StoresInline(t *testing.T) {
+	embeddingsRepo := &captureEmbeddingsRepo{}
+	publisher := &capturePublisher{}
+	svc := newSyncTestService(embeddingsRepo, publisher, &mockEmbeddingClient{}, time.Second)
+
+	record, err := svc.CreateFeedbackRecordWithSyncEmbedding(context.Background(), syncCreateRequest())
+	if err != nil {
+		t.Fatalf("CreateFeedbackRecordWithSyncEmbedding() error = %v", err)
+	}
+
+	if embeddingsRepo.upserts != 1 || embeddingsRepo.model != "embedding-model" {
+		t.Fatalf("upserts = %d (model %q), want 1 for embedding-model", embeddingsRepo.upserts, embeddingsRepo.model)
+	}
+
+	if !record.EmbeddedInline {
+		t.Fatal("EmbeddedInline = false, want true")
+	}
+
+	if publisher.callCount != 1 || publisher.eventType != datatypes.FeedbackRecordCreated {
+		t.Fatalf("published event = (%d, %s), want one feedback_record.created", publisher.callCount, publisher.eventType)
+	}
+}
+
+func TestFeedbackRecordsService_CreateWithSyncEmbedding_FallsBackOnProviderError(t *testing.T) {
+	embeddingsRepo := &captureEmbeddingsRepo{}
+	publisher := &capturePublisher{}
+	client := &mockEmbeddingClient{createFunc: func(context.Context, string) ([]float32, error) {
+		return nil, errors.New("provider down")
+	}}
+	svc := newSyncTestService(embeddingsRepo, publisher, client, time.Second)
+
+	record, err := svc.CreateFeedbackRecordWithSyncEmbedding(context.Background(), syncCreateRequest())
+	if err != nil {
+		t.Fatalf("CreateFeedbackRecordWithSyncEmbedding() error = %v, want fallback without error", err)
+	}
+
+	if record.EmbeddedInline {
+		t.Fatal("EmbeddedInline = true, want false so the async job is enqueued")
+	}
+
+	if embeddingsRepo.upserts != 0 {
+		t.Fatalf("upserts = %d, want 0", embeddingsRepo.upserts)
+	}
+
+	if publisher.callCount != 1 {
+		t.Fatalf("publish calls = %d, want 1", publisher.callCount)
+	}
+}
+
+func TestFeedbackRecordsService_CreateWithSyncEmbedding_FallsBackOnTimeout(t *testing.T) {
+	embeddingsRepo := &captureEmbeddingsRepo{}
+	client := &mockEmbeddingClient{createFunc: func(ctx context.Context, _ string) ([]float32, error) {
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}}
+	svc := newSyncTestService(embeddingsRepo, &capturePublisher{}, client, 10*time.Millisecond)
+
+	record, err := svc.CreateFeedbackRecordWithSyncEmbedding(context.Background(), syncCreateRequest())
+	if err != nil {
+		t.Fatalf("CreateFeedbackRecordWithSyncEmbedding() error = %v", err)
+	}
+
+	if record.EmbeddedInline || embeddingsRepo.upserts != 0 {
+		t.Fatalf("EmbeddedInline = %v, upserts = %d; want async fallback", record.EmbeddedInline, embeddingsRepo.upserts)
+	}
+}
+
+func TestSyncEmbedder_SlotUnavailableBeforeTimeout(t *testing.T) {
+	embedder := NewSyncEmbedder(&mockEmbeddingClient{}, "", 10*time.Millisecond, 1)
+	embedder.slots <- struct{}{} // saturate the only slot
+
+	text := "hello"
+
+	_, _, err := embedder.embed(context.Background(), &models.FeedbackRecord{ValueText: &text})
+	if !errors.Is(err, ErrSyncEmbeddingSlotUnavailable) {
+		t.Fatalf("embed() error = %v, want ErrSyncEmbeddingSlotUnavailable", err)
+	}
+}
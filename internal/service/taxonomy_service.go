@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -25,6 +26,10 @@ var (
 
 const defaultMinimumTaxonomyEmbeddingCount = 20
 
+// defaultMaxTaxonomyNodeLevel bounds manual node moves and merges. Generated trees are root → topic →
+// subtopic (levels 0–2); the headroom allows some manual nesting without unbounded depth.
+const defaultMaxTaxonomyNodeLevel = 5
+
 const directoryTaxonomyFieldLabel = "All feedback"
 
 // TaxonomyRepository persists taxonomy run state and generated artifacts.
@@ -40,6 +45,7 @@ type TaxonomyRepository interface { //nolint:interfacebloat // taxonomy service
 		message string,
 		errorCode models.TaxonomyRunFailureCode,
 	) (*models.TaxonomyRun, error)
+	CancelRun(ctx context.Context, runID uuid.UUID, tenantID string) (*models.TaxonomyRun, error)
 	Heartbeat(ctx context.Context, runID uuid.UUID, tenantID string) error
 	GetRunForInternalService(ctx context.Context, runID uuid.UUID) (*models.TaxonomyRun, error)
 	GetRunForTenant(ctx context.Context, runID uuid.UUID, tenantID string) (*models.TaxonomyRun, error)
@@ -60,8 +66,20 @@ type TaxonomyRepository interface { //nolint:interfacebloat // taxonomy service
 	GetTree(ctx context.Context, runID uuid.UUID, tenantID string) (*models.TaxonomyTreeResponse, error)
 	RenameNode(ctx context.Context, nodeID uuid.UUID, tenantID, actorID, label string) (*models.TaxonomyNode, error)
 	RemoveNode(ctx context.Context, nodeID uuid.UUID, tenantID, actorID string) (*models.TaxonomyNode, error)
+	RemoveNodes(
+		ctx context.Context, nodeIDs []uuid.UUID, tenantID, actorID string, dryRun bool,
+	) (*models.RemoveTaxonomyNodesResponse, error)
+	MoveNode(
+		ctx context.Context, nodeID uuid.UUID, tenantID, actorID string, parentID uuid.UUID, maxLevel int,
+	) (*models.TaxonomyNode, error)
+	MergeNode(
+		ctx context.Context, nodeID uuid.UUID, tenantID, actorID string, intoID uuid.UUID, maxLevel int,
+	) (*models.MergeTaxonomyNodeResponse, error)
 	ListNodeRecords(ctx context.Context, nodeID uuid.UUID, tenantID string, limit int) ([]models.FeedbackRecord, int, error)
 	CountNodeRecords(ctx context.Context, runID uuid.UUID, tenantID string) ([]models.TaxonomyNodeRecordCount, error)
+	ListRelatedTopics(
+		ctx context.Context, feedbackRecordID uuid.UUID, tenantID, embeddingModel string, limit int,
+	) ([]models.RelatedTopic, int, error)
 }
 
 // TaxonomyRunStarter starts asynchronous taxonomy compute work.
@@ -75,6 +93,7 @@ type TaxonomyService struct {
 	starter               TaxonomyRunStarter
 	embeddingModel        string
 	minimumEmbeddingCount int
+	maxNodeLevel          int
 }
 
 // NewTaxonomyServiceParams configures a TaxonomyService.
@@ -83,6 +102,8 @@ type NewTaxonomyServiceParams struct {
 	Starter               TaxonomyRunStarter
 	EmbeddingModel        string
 	MinimumEmbeddingCount int
+	// MaxNodeLevel is the deepest level a node move or merge may produce (root = 0); <= 0 uses the default.
+	MaxNodeLevel int
 }
 
 // NewTaxonomyService creates a taxonomy application service.
@@ -92,11 +113,17 @@ func NewTaxonomyService(params NewTaxonomyServiceParams) *TaxonomyService {
 		minimumEmbeddingCount = defaultMinimumTaxonomyEmbeddingCount
 	}
 
+	maxNodeLevel := params.MaxNodeLevel
+	if maxNodeLevel <= 0 {
+		maxNodeLevel = defaultMaxTaxonomyNodeLevel
+	}
+
 	return &TaxonomyService{
 		repo:                  params.Repo,
 		starter:               params.Starter,
 		embeddingModel:        strings.TrimSpace(params.EmbeddingModel),
 		minimumEmbeddingCount: minimumEmbeddingCount,
+		maxNodeLevel:          maxNodeLevel,
 	}
 }
 
@@ -250,10 +277,33 @@ func (s *TaxonomyService) GetRun(
 	return run, nil
 }
 
-// GetActiveTree returns the active taxonomy tree for a field scope.
+// CancelRun cancels a pending or running taxonomy run so a new run can start for its scope.
+// There is no call into the taxonomy service: it is told through its own callbacks — the input
+// fetch and the heartbeat reject a canceled run (see Heartbeat) — and a result it posts anyway is
+// refused because only a running run can complete.
+func (s *TaxonomyService) CancelRun(
+	ctx context.Context,
+	runID uuid.UUID,
+	tenantID string,
+) (*models.TaxonomyRun, error) {
+	normalizedTenantID, err := normalizeRequiredTenantIDValue(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := s.repo.CancelRun(ctx, runID, normalizedTenantID)
+	if err != nil {
+		return nil, fmt.Errorf("cancel taxonomy run: %w", err)
+	}
+
+	return run, nil
+}
+
+// GetActiveTree returns the active taxonomy tree for a field scope, shaped by opts.
 func (s *TaxonomyService) GetActiveTree(
 	ctx context.Context,
 	scope models.TaxonomyScope,
+	opts models.TaxonomyTreeOptions,
 ) (*models.TaxonomyTreeResponse, error) {
 	normalizedScope, err := normalizeTaxonomyScope(scope)
 	if err != nil {
@@ -270,14 +320,17 @@ func (s *TaxonomyService) GetActiveTree(
 		return nil, fmt.Errorf("get active taxonomy tree: %w", err)
 	}
 
+	shapeTaxonomyTree(tree.Root, opts, 0)
+
 	return tree, nil
 }
 
-// GetTree returns a taxonomy tree by run ID.
+// GetTree returns a taxonomy tree by run ID, shaped by opts.
 func (s *TaxonomyService) GetTree(
 	ctx context.Context,
 	runID uuid.UUID,
 	tenantID string,
+	opts models.TaxonomyTreeOptions,
 ) (*models.TaxonomyTreeResponse, error) {
 	normalizedTenantID, err := normalizeRequiredTenantIDValue(tenantID)
 	if err != nil {
@@ -289,9 +342,40 @@ func (s *TaxonomyService) GetTree(
 		return nil, fmt.Errorf("get taxonomy tree: %w", err)
 	}
 
+	shapeTaxonomyTree(tree.Root, opts, 0)
+
 	return tree, nil
 }
 
+// shapeTaxonomyTree applies opts to the subtree at node, which sits at depth: children below
+// MaxDepth are dropped and, for the label sort, siblings are reordered by label (case-insensitive,
+// ties by sort_order). The repository already returns siblings in sort_order.
+func shapeTaxonomyTree(node *models.TaxonomyNode, opts models.TaxonomyTreeOptions, depth int) {
+	if node == nil {
+		return
+	}
+
+	if opts.MaxDepth != nil && depth >= *opts.MaxDepth {
+		node.Children = nil
+
+		return
+	}
+
+	if opts.Sort == models.TaxonomyTreeSortLabel {
+		slices.SortStableFunc(node.Children, func(a, b models.TaxonomyNode) int {
+			if c := strings.Compare(strings.ToLower(a.Label), strings.ToLower(b.Label)); c != 0 {
+				return c
+			}
+
+			return a.SortOrder - b.SortOrder
+		})
+	}
+
+	for i := range node.Children {
+		shapeTaxonomyTree(&node.Children[i], opts, depth+1)
+	}
+}
+
 // GetNodeRecordCounts returns the feedback-record count for every visible node in a run, as
 // subtree totals (a branch reports the sum of its subtopics, the root reports the run total).
 func (s *TaxonomyService) GetNodeRecordCounts(
@@ -326,6 +410,10 @@ func (s *TaxonomyService) GetRunInput(
 		return nil, fmt.Errorf("get taxonomy run: %w", err)
 	}
 
+	if run.Status == models.TaxonomyRunStatusCanceled {
+		return nil, huberrors.NewConflictError("taxonomy run was canceled")
+	}
+
 	input, err := s.repo.GetRunInput(ctx, runID, run.TenantID, s.embeddingModel)
 	if err != nil {
 		return nil, fmt.Errorf("get taxonomy run input: %w", err)
@@ -377,6 +465,10 @@ func (s *TaxonomyService) FailRun(
 
 // Heartbeat records that a taxonomy run is still alive, keeping it out of the stuck-run reaper's
 // reach. Resolving the run first yields its tenant and a not-found error for unknown ids.
+//
+// A canceled run answers with a conflict instead: the heartbeat is the taxonomy service's only
+// regular contact with Hub while it computes, so it doubles as the cancellation signal telling
+// the service to stop working on the run.
 func (s *TaxonomyService) Heartbeat(
 	ctx context.Context,
 	runID uuid.UUID,
@@ -386,6 +478,10 @@ func (s *TaxonomyService) Heartbeat(
 		return fmt.Errorf("get taxonomy run: %w", err)
 	}
 
+	if existingRun.Status == models.TaxonomyRunStatusCanceled {
+		return huberrors.NewConflictError("taxonomy run was canceled")
+	}
+
 	if err := s.repo.Heartbeat(ctx, runID, existingRun.TenantID); err != nil {
 		return fmt.Errorf("heartbeat taxonomy run: %w", err)
 	}
@@ -475,6 +571,99 @@ func (s *TaxonomyService) RemoveNode(
 	return node, nil
 }
 
+// RemoveNodes soft-removes a batch of a tenant's taxonomy nodes in one transaction (or, with
+// req.DryRun, only reports the impact). Repeated ids are collapsed, keeping the first occurrence,
+// so each node has one result.
+func (s *TaxonomyService) RemoveNodes(
+	ctx context.Context,
+	req models.RemoveTaxonomyNodesRequest,
+) (*models.RemoveTaxonomyNodesResponse, error) {
+	tenantID, err := normalizeRequiredTenantIDValue(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID, err := normalizeRequiredIdentifier("actor_id", req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeIDs := make([]uuid.UUID, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		if !slices.Contains(nodeIDs, id) {
+			nodeIDs = append(nodeIDs, id)
+		}
+	}
+
+	if len(nodeIDs) == 0 {
+		return nil, huberrors.NewValidationError("ids", "at least one node id is required")
+	}
+
+	result, err := s.repo.RemoveNodes(ctx, nodeIDs, tenantID, actorID, req.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("remove taxonomy nodes: %w", err)
+	}
+
+	return result, nil
+}
+
+// MoveNode moves a taxonomy node and its subtree under a new parent node of the same run.
+func (s *TaxonomyService) MoveNode(
+	ctx context.Context,
+	nodeID uuid.UUID,
+	req models.MoveTaxonomyNodeRequest,
+) (*models.TaxonomyNode, error) {
+	tenantID, err := normalizeRequiredTenantIDValue(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID, err := normalizeRequiredIdentifier("actor_id", req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ParentID == nodeID {
+		return nil, huberrors.NewValidationError("parent_id", "must not be the node itself or one of its descendants")
+	}
+
+	node, err := s.repo.MoveNode(ctx, nodeID, tenantID, actorID, req.ParentID, s.maxNodeLevel)
+	if err != nil {
+		return nil, fmt.Errorf("move taxonomy node: %w", err)
+	}
+
+	return node, nil
+}
+
+// MergeNode merges a taxonomy node into another node of the same run: its feedback records and
+// children move to the target and the node is soft-removed, all in one transaction.
+func (s *TaxonomyService) MergeNode(
+	ctx context.Context,
+	nodeID uuid.UUID,
+	req models.MergeTaxonomyNodeRequest,
+) (*models.MergeTaxonomyNodeResponse, error) {
+	tenantID, err := normalizeRequiredTenantIDValue(req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID, err := normalizeRequiredIdentifier("actor_id", req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IntoNodeID == nodeID {
+		return nil, huberrors.NewValidationError("into_node_id", "must not be the node itself or one of its descendants")
+	}
+
+	result, err := s.repo.MergeNode(ctx, nodeID, tenantID, actorID, req.IntoNodeID, s.maxNodeLevel)
+	if err != nil {
+		return nil, fmt.Errorf("merge taxonomy node: %w", err)
+	}
+
+	return result, nil
+}
+
 // ListNodeRecords returns feedback records assigned to a taxonomy node.
 func (s *TaxonomyService) ListNodeRecords(
 	ctx context.Context,
@@ -494,6 +683,32 @@ func (s *TaxonomyService) ListNodeRecords(
 	return &models.TaxonomyNodeRecordsResponse{Data: records, Limit: limit}, nil
 }
 
+// RelatedTopics returns the topics across the tenant's active taxonomies that are most similar to
+// a feedback record, whichever cluster the record itself landed in, so analysts can see where else
+// it would fit. The record is compared in the taxonomy embedding space, the one topics are built
+// in; a record not embedded there yet yields ErrEmbeddingNotFound.
+func (s *TaxonomyService) RelatedTopics(
+	ctx context.Context,
+	feedbackRecordID uuid.UUID,
+	filters models.RelatedTopicsFilters,
+) (*models.RelatedTopicsResponse, error) {
+	if s.embeddingModel == "" {
+		return nil, ErrTaxonomyEmbeddingsNotConfigured
+	}
+
+	tenantID, err := normalizeRequiredTenantIDValue(filters.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, limit, err := s.repo.ListRelatedTopics(ctx, feedbackRecordID, tenantID, s.embeddingModel, filters.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list related topics: %w", err)
+	}
+
+	return &models.RelatedTopicsResponse{Data: topics, Limit: limit}, nil
+}
+
 func normalizeTaxonomyScope(scope models.TaxonomyScope) (models.TaxonomyScope, error) {
 	tenantID, err := normalizeRequiredTenantIDValue(scope.TenantID)
 	if err != nil {
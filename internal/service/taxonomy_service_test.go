@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/google/uuid"
 
+	"github.com/formbricks/hub/internal/huberrors"
 	"github.com/formbricks/hub/internal/models"
 	"github.com/formbricks/hub/internal/repository"
 )
@@ -23,11 +25,34 @@ type mockTaxonomyRepo struct {
 	markRunFailedCode    models.TaxonomyRunFailureCode
 	markRunFailedTenant  string
 	heartbeatTenant      string
+	cancelRunTenant      string
+	tree                 *models.TaxonomyTreeResponse
 
 	countNodeRecords       []models.TaxonomyNodeRecordCount
 	countNodeRecordsErr    error
 	countNodeRecordsRunID  uuid.UUID
 	countNodeRecordsTenant string
+
+	moveNodeTenant   string
+	moveNodeActor    string
+	moveNodeParent   uuid.UUID
+	moveNodeMaxLevel int
+	moveNodeCalled   bool
+
+	mergeNodeTenant   string
+	mergeNodeActor    string
+	mergeNodeInto     uuid.UUID
+	mergeNodeMaxLevel int
+	mergeNodeCalled   bool
+
+	removeNodesIDs    []uuid.UUID
+	removeNodesTenant string
+	removeNodesDryRun bool
+
+	relatedTopics       []models.RelatedTopic
+	relatedTopicsErr    error
+	relatedTopicsTenant string
+	relatedTopicsModel  string
 }
 
 func (m *mockTaxonomyRepo) ListFieldOptions(
@@ -84,6 +109,16 @@ func (m *mockTaxonomyRepo) MarkRunFailed(
 	}, nil
 }
 
+func (m *mockTaxonomyRepo) CancelRun(
+	_ context.Context,
+	runID uuid.UUID,
+	tenantID string,
+) (*models.TaxonomyRun, error) {
+	m.cancelRunTenant = tenantID
+
+	return &models.TaxonomyRun{ID: runID, TenantID: tenantID, Status: models.TaxonomyRunStatusCanceled}, nil
+}
+
 func (m *mockTaxonomyRepo) Heartbeat(_ context.Context, _ uuid.UUID, tenantID string) error {
 	m.heartbeatTenant = tenantID
 
@@ -143,7 +178,7 @@ func (m *mockTaxonomyRepo) GetTree(
 	_ uuid.UUID,
 	_ string,
 ) (*models.TaxonomyTreeResponse, error) {
-	return nil, nil
+	return m.tree, nil
 }
 
 func (m *mockTaxonomyRepo) RenameNode(
@@ -165,6 +200,54 @@ func (m *mockTaxonomyRepo) RemoveNode(
 	return nil, nil
 }
 
+func (m *mockTaxonomyRepo) RemoveNodes(
+	_ context.Context,
+	nodeIDs []uuid.UUID,
+	tenantID string,
+	_ string,
+	dryRun bool,
+) (*models.RemoveTaxonomyNodesResponse, error) {
+	m.removeNodesIDs = nodeIDs
+	m.removeNodesTenant = tenantID
+	m.removeNodesDryRun = dryRun
+
+	return &models.RemoveTaxonomyNodesResponse{DryRun: dryRun}, nil
+}
+
+func (m *mockTaxonomyRepo) MoveNode(
+	_ context.Context,
+	nodeID uuid.UUID,
+	tenantID string,
+	actorID string,
+	parentID uuid.UUID,
+	maxLevel int,
+) (*models.TaxonomyNode, error) {
+	m.moveNodeCalled = true
+	m.moveNodeTenant = tenantID
+	m.moveNodeActor = actorID
+	m.moveNodeParent = parentID
+	m.moveNodeMaxLevel = maxLevel
+
+	return &models.TaxonomyNode{ID: nodeID, ParentID: &parentID}, nil
+}
+
+func (m *mockTaxonomyRepo) MergeNode(
+	_ context.Context,
+	_ uuid.UUID,
+	tenantID string,
+	actorID string,
+	intoID uuid.UUID,
+	maxLevel int,
+) (*models.MergeTaxonomyNodeResponse, error) {
+	m.mergeNodeCalled = true
+	m.mergeNodeTenant = tenantID
+	m.mergeNodeActor = actorID
+	m.mergeNodeInto = intoID
+	m.mergeNodeMaxLevel = maxLevel
+
+	return &models.MergeTaxonomyNodeResponse{Node: models.TaxonomyNode{ID: intoID}}, nil
+}
+
 func (m *mockTaxonomyRepo) ListNodeRecords(
 	_ context.Context,
 	_ uuid.UUID,
@@ -189,6 +272,23 @@ func (m *mockTaxonomyRepo) CountNodeRecords(
 	return m.countNodeRecords, nil
 }
 
+func (m *mockTaxonomyRepo) ListRelatedTopics(
+	_ context.Context,
+	_ uuid.UUID,
+	tenantID string,
+	embeddingModel string,
+	limit int,
+) ([]models.RelatedTopic, int, error) {
+	m.relatedTopicsTenant = tenantID
+	m.relatedTopicsModel = embeddingModel
+
+	if m.relatedTopicsErr != nil {
+		return nil, 0, m.relatedTopicsErr
+	}
+
+	return m.relatedTopics, limit, nil
+}
+
 type failingTaxonomyStarter struct{}
 
 func (f failingTaxonomyStarter) StartRun(_ context.Context, _ string) error {
@@ -293,6 +393,49 @@ func TestTaxonomyService_HeartbeatResolvesTenant(t *testing.T) {
 	}
 }
 
+func TestTaxonomyService_CancelRunNormalizesTenant(t *testing.T) {
+	runID := uuid.MustParse("018e1234-5678-9abc-def0-666666666666")
+	repo := &mockTaxonomyRepo{}
+	svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: repo})
+
+	run, err := svc.CancelRun(context.Background(), runID, " tenant-3 ")
+	if err != nil {
+		t.Fatalf("CancelRun() error = %v", err)
+	}
+
+	if run.Status != models.TaxonomyRunStatusCanceled {
+		t.Fatalf("CancelRun() status = %q, want canceled", run.Status)
+	}
+
+	if repo.cancelRunTenant != "tenant-3" {
+		t.Fatalf("CancelRun tenant = %q, want tenant-3", repo.cancelRunTenant)
+	}
+
+	if _, err := svc.CancelRun(context.Background(), runID, " "); !errors.Is(err, huberrors.ErrValidation) {
+		t.Fatalf("CancelRun() without tenant error = %v, want validation error", err)
+	}
+}
+
+func TestTaxonomyService_CanceledRunRejectsCallbacks(t *testing.T) {
+	runID := uuid.MustParse("018e1234-5678-9abc-def0-777777777777")
+	repo := &mockTaxonomyRepo{internalRun: &models.TaxonomyRun{
+		ID: runID, TenantID: "tenant-7", Status: models.TaxonomyRunStatusCanceled,
+	}}
+	svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: repo, EmbeddingModel: "model"})
+
+	if err := svc.Heartbeat(context.Background(), runID); !errors.Is(err, huberrors.ErrConflict) {
+		t.Fatalf("Heartbeat() error = %v, want conflict", err)
+	}
+
+	if repo.heartbeatTenant != "" {
+		t.Fatalf("Heartbeat reached the repository for a canceled run")
+	}
+
+	if _, err := svc.GetRunInput(context.Background(), runID); !errors.Is(err, huberrors.ErrConflict) {
+		t.Fatalf("GetRunInput() error = %v, want conflict", err)
+	}
+}
+
 func TestTaxonomyService_FailRunDefaultsFailureCode(t *testing.T) {
 	runID := uuid.MustParse("018e1234-5678-9abc-def0-222222222222")
 	repo := &mockTaxonomyRepo{}
@@ -370,3 +513,232 @@ func TestTaxonomyService_GetNodeRecordCounts(t *testing.T) {
 		}
 	})
 }
+
+func TestTaxonomyService_GetTreeShapesTree(t *testing.T) {
+	runID := uuid.MustParse("018e1234-5678-9abc-def0-444444444444")
+	newTree := func() *models.TaxonomyTreeResponse {
+		return &models.TaxonomyTreeResponse{Root: &models.TaxonomyNode{
+			Label: "root",
+			Children: []models.TaxonomyNode{
+				{Label: "pricing", SortOrder: 0, Children: []models.TaxonomyNode{{Label: "discounts"}}},
+				{Label: "Billing", SortOrder: 1},
+				{Label: "onboarding", SortOrder: 2},
+			},
+		}}
+	}
+
+	labels := func(nodes []models.TaxonomyNode) []string {
+		out := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			out = append(out, node.Label)
+		}
+
+		return out
+	}
+
+	t.Run("default keeps sort_order and every level", func(t *testing.T) {
+		svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: &mockTaxonomyRepo{tree: newTree()}})
+
+		result, err := svc.GetTree(context.Background(), runID, "tenant-1", models.TaxonomyTreeOptions{})
+		if err != nil {
+			t.Fatalf("GetTree() error = %v", err)
+		}
+
+		if got := labels(result.Root.Children); !slices.Equal(got, []string{"pricing", "Billing", "onboarding"}) {
+			t.Fatalf("children = %v, want sort_order", got)
+		}
+
+		if len(result.Root.Children[0].Children) != 1 {
+			t.Fatal("grandchildren were dropped without max_depth")
+		}
+	})
+
+	t.Run("label sort is case-insensitive", func(t *testing.T) {
+		svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: &mockTaxonomyRepo{tree: newTree()}})
+
+		result, err := svc.GetTree(context.Background(), runID, "tenant-1",
+			models.TaxonomyTreeOptions{Sort: models.TaxonomyTreeSortLabel})
+		if err != nil {
+			t.Fatalf("GetTree() error = %v", err)
+		}
+
+		if got := labels(result.Root.Children); !slices.Equal(got, []string{"Billing", "onboarding", "pricing"}) {
+			t.Fatalf("children = %v, want label order", got)
+		}
+	})
+
+	t.Run("max_depth cuts deeper levels", func(t *testing.T) {
+		svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: &mockTaxonomyRepo{tree: newTree()}})
+		depth := 1
+
+		result, err := svc.GetTree(context.Background(), runID, "tenant-1", models.TaxonomyTreeOptions{MaxDepth: &depth})
+		if err != nil {
+			t.Fatalf("GetTree() error = %v", err)
+		}
+
+		if len(result.Root.Children) != 3 || result.Root.Children[0].Children != nil {
+			t.Fatalf("root children = %+v, want top-level topics without their children", result.Root.Children)
+		}
+	})
+}
+
+func TestTaxonomyService_RelatedTopics(t *testing.T) {
+	recordID := uuid.MustParse("018e1234-5678-9abc-def0-444444444444")
+	nodeID := uuid.MustParse("018e1234-5678-9abc-def0-555555555555")
+
+	t.Run("searches the taxonomy embedding space for the normalized tenant", func(t *testing.T) {
+		repo := &mockTaxonomyRepo{
+			relatedTopics: []models.RelatedTopic{{NodeID: nodeID, Label: "Login", Path: []string{"Auth", "Login"}, Score: 0.9}},
+		}
+		svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: repo, EmbeddingModel: "taxonomy:model-a:translated-v1"})
+
+		result, err := svc.RelatedTopics(context.Background(), recordID, models.RelatedTopicsFilters{TenantID: " tenant-1 ", Limit: 3})
+		if err != nil {
+			t.Fatalf("RelatedTopics() error = %v", err)
+		}
+
+		if len(result.Data) != 1 || result.Data[0].NodeID != nodeID || result.Limit != 3 {
+			t.Fatalf("result = %+v, want the repo's single topic with limit 3", result)
+		}
+
+		if repo.relatedTopicsTenant != "tenant-1" || repo.relatedTopicsModel != "taxonomy:model-a:translated-v1" {
+			t.Fatalf("repo called with tenant %q model %q", repo.relatedTopicsTenant, repo.relatedTopicsModel)
+		}
+	})
+
+	t.Run("record without a taxonomy embedding", func(t *testing.T) {
+		repo := &mockTaxonomyRepo{relatedTopicsErr: repository.ErrEmbeddingNotFound}
+		svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: repo, EmbeddingModel: "taxonomy:model-a:translated-v1"})
+
+		_, err := svc.RelatedTopics(context.Background(), recordID, models.RelatedTopicsFilters{TenantID: "tenant-1"})
+		if !errors.Is(err, ErrEmbeddingNotFound) {
+			t.Fatalf("RelatedTopics() error = %v, want ErrEmbeddingNotFound", err)
+		}
+	})
+
+	t.Run("embeddings not configured", func(t *testing.T) {
+		svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: &mockTaxonomyRepo{}})
+
+		_, err := svc.RelatedTopics(context.Background(), recordID, models.RelatedTopicsFilters{TenantID: "tenant-1"})
+		if !errors.Is(err, ErrTaxonomyEmbeddingsNotConfigured) {
+			t.Fatalf("RelatedTopics() error = %v, want ErrTaxonomyEmbeddingsNotConfigured", err)
+		}
+	})
+}
+
+func TestTaxonomyService_MoveNodeNormalizesAndUsesMaxLevel(t *testing.T) {
+	nodeID := uuid.MustParse("018e1234-5678-9abc-def0-444444444444")
+	parentID := uuid.MustParse("018e1234-5678-9abc-def0-555555555555")
+	repo := &mockTaxonomyRepo{}
+	svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: repo, MaxNodeLevel: 3})
+
+	node, err := svc.MoveNode(context.Background(), nodeID, models.MoveTaxonomyNodeRequest{
+		TenantID: " tenant-1 ", ActorID: " actor-1 ", ParentID: parentID,
+	})
+	if err != nil {
+		t.Fatalf("MoveNode() error = %v", err)
+	}
+
+	if node.ParentID == nil || *node.ParentID != parentID {
+		t.Fatalf("moved node parent = %v, want %s", node.ParentID, parentID)
+	}
+
+	if repo.moveNodeTenant != "tenant-1" || repo.moveNodeActor != "actor-1" || repo.moveNodeMaxLevel != 3 {
+		t.Fatalf("repo call = (%q, %q, max %d), want trimmed ids and max 3",
+			repo.moveNodeTenant, repo.moveNodeActor, repo.moveNodeMaxLevel)
+	}
+}
+
+func TestTaxonomyService_MergeNodeNormalizesAndRejectsSelfMerge(t *testing.T) {
+	nodeID := uuid.MustParse("018e1234-5678-9abc-def0-444444444444")
+	intoID := uuid.MustParse("018e1234-5678-9abc-def0-555555555555")
+	repo := &mockTaxonomyRepo{}
+	svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: repo, MaxNodeLevel: 3})
+
+	result, err := svc.MergeNode(context.Background(), nodeID, models.MergeTaxonomyNodeRequest{
+		TenantID: " tenant-1 ", ActorID: " actor-1 ", IntoNodeID: intoID,
+	})
+	if err != nil {
+		t.Fatalf("MergeNode() error = %v", err)
+	}
+
+	if result.Node.ID != intoID {
+		t.Fatalf("merge result node = %s, want the target %s", result.Node.ID, intoID)
+	}
+
+	if repo.mergeNodeTenant != "tenant-1" || repo.mergeNodeActor != "actor-1" || repo.mergeNodeMaxLevel != 3 {
+		t.Fatalf("repo call = (%q, %q, max %d), want trimmed ids and max 3",
+			repo.mergeNodeTenant, repo.mergeNodeActor, repo.mergeNodeMaxLevel)
+	}
+
+	repo = &mockTaxonomyRepo{}
+	svc = NewTaxonomyService(NewTaxonomyServiceParams{Repo: repo})
+
+	_, err = svc.MergeNode(context.Background(), nodeID, models.MergeTaxonomyNodeRequest{
+		TenantID: "tenant-1", ActorID: "actor-1", IntoNodeID: nodeID,
+	})
+	if !errors.Is(err, huberrors.ErrValidation) {
+		t.Fatalf("MergeNode() error = %v, want validation", err)
+	}
+
+	if repo.mergeNodeCalled {
+		t.Fatal("repository called for a self merge")
+	}
+}
+
+func TestTaxonomyService_MoveNodeDefaultsMaxLevel(t *testing.T) {
+	repo := &mockTaxonomyRepo{}
+	svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: repo})
+
+	if _, err := svc.MoveNode(context.Background(), uuid.New(), models.MoveTaxonomyNodeRequest{
+		TenantID: "tenant-1", ActorID: "actor-1", ParentID: uuid.New(),
+	}); err != nil {
+		t.Fatalf("MoveNode() error = %v", err)
+	}
+
+	if repo.moveNodeMaxLevel != defaultMaxTaxonomyNodeLevel {
+		t.Fatalf("max level = %d, want default %d", repo.moveNodeMaxLevel, defaultMaxTaxonomyNodeLevel)
+	}
+}
+
+func TestTaxonomyService_MoveNodeRejectsSelfParent(t *testing.T) {
+	nodeID := uuid.New()
+	repo := &mockTaxonomyRepo{}
+	svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: repo})
+
+	_, err := svc.MoveNode(context.Background(), nodeID, models.MoveTaxonomyNodeRequest{
+		TenantID: "tenant-1", ActorID: "actor-1", ParentID: nodeID,
+	})
+	if !errors.Is(err, huberrors.ErrValidation) {
+		t.Fatalf("MoveNode() error = %v, want validation", err)
+	}
+
+	if repo.moveNodeCalled {
+		t.Fatal("repository called for a self-parent move")
+	}
+}
+
+func TestTaxonomyService_RemoveNodesCollapsesRepeatedIDs(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	repo := &mockTaxonomyRepo{}
+	svc := NewTaxonomyService(NewTaxonomyServiceParams{Repo: repo})
+
+	result, err := svc.RemoveNodes(context.Background(), models.RemoveTaxonomyNodesRequest{
+		TenantID: " tenant-1 ", ActorID: "actor-1", IDs: []uuid.UUID{first, second, first}, DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("RemoveNodes() error = %v", err)
+	}
+
+	if !result.DryRun || !repo.removeNodesDryRun {
+		t.Fatal("dry run not passed through")
+	}
+
+	if repo.removeNodesTenant != "tenant-1" {
+		t.Fatalf("tenant = %q, want trimmed tenant-1", repo.removeNodesTenant)
+	}
+
+	if len(repo.removeNodesIDs) != 2 || repo.removeNodesIDs[0] != first || repo.removeNodesIDs[1] != second {
+		t.Fatalf("ids = %v, want [%s %s]", repo.removeNodesIDs, first, second)
+	}
+}
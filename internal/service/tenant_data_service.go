@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/formbricks/hub/internal/models"
 )
@@ -17,7 +18,8 @@ type TenantDataRepository interface {
 
 // TenantDataService handles tenant data purge business logic.
 type TenantDataService struct {
-	repo TenantDataRepository
+	repo      TenantDataRepository
+	exportDir string
 }
 
 // NewTenantDataService creates a new tenant data service.
@@ -25,7 +27,14 @@ func NewTenantDataService(repo TenantDataRepository) *TenantDataService {
 	return &TenantDataService{repo: repo}
 }
 
-// DeleteTenantData deletes all Hub-owned data for a tenant.
+// SetExportDir makes the purge also remove the tenant's export files under dir (EXPORT_DIR).
+func (s *TenantDataService) SetExportDir(dir string) {
+	s.exportDir = dir
+}
+
+// DeleteTenantData deletes all Hub-owned data for a tenant. Export files live outside the
+// database, so they are removed after the purge commits; if that fails the error is returned and
+// a retried purge (idempotent, zero counts) removes them.
 func (s *TenantDataService) DeleteTenantData(ctx context.Context, tenantID string) (*models.TenantDataDeleteResult, error) {
 	normalizedTenantID, err := normalizeRequiredTenantIDValue(tenantID)
 	if err != nil {
@@ -41,6 +50,12 @@ func (s *TenantDataService) DeleteTenantData(ctx context.Context, tenantID strin
 		return nil, fmt.Errorf("delete tenant data: %w", errTenantDataNilCounts)
 	}
 
+	if s.exportDir != "" {
+		if err := os.RemoveAll(ExportTenantDir(s.exportDir, normalizedTenantID)); err != nil {
+			return nil, fmt.Errorf("delete tenant export files: %w", err)
+		}
+	}
+
 	return &models.TenantDataDeleteResult{
 		TenantID:               normalizedTenantID,
 		TenantDataDeleteCounts: *counts,
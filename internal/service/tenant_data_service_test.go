@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"os"
 	"strings"
 	"testing"
 
@@ -53,6 +54,33 @@ func TestTenantDataService_DeleteTenantData(t *testing.T) {
 		}
 	})
 
+	t.Run("removes the tenant's export files", func(t *testing.T) {
+		dir := t.TempDir()
+		tenantDir := ExportTenantDir(dir, "org-123")
+		otherDir := ExportTenantDir(dir, "org-456")
+
+		for _, d := range []string{tenantDir, otherDir} {
+			if err := os.MkdirAll(d, 0o750); err != nil {
+				t.Fatalf("MkdirAll: %v", err)
+			}
+		}
+
+		svc := NewTenantDataService(&mockTenantDataRepo{counts: &models.TenantDataDeleteCounts{}})
+		svc.SetExportDir(dir)
+
+		if _, err := svc.DeleteTenantData(context.Background(), "org-123"); err != nil {
+			t.Fatalf("DeleteTenantData() error = %v", err)
+		}
+
+		if _, err := os.Stat(tenantDir); !os.IsNotExist(err) {
+			t.Fatalf("tenant export dir still present (stat err = %v)", err)
+		}
+
+		if _, err := os.Stat(otherDir); err != nil {
+			t.Fatalf("other tenant's export dir removed: %v", err)
+		}
+	})
+
 	t.Run("rejects invalid tenant id", func(t *testing.T) {
 		repo := &mockTenantDataRepo{}
 		svc := NewTenantDataService(repo)
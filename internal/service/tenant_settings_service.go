@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
@@ -39,6 +40,16 @@ const settingKeySentimentEnabled = "sentiment_enabled"
 // sends an explicit null.
 const settingKeyEmotionsEnabled = "emotions_enabled"
 
+// settingKeyEmbeddingModel is the JSONB key for the tenant's embedding model. It must match the
+// json tag on models.EnrichmentSettings.EmbeddingModel; it is the key removed when a PATCH sends
+// an explicit null.
+const settingKeyEmbeddingModel = "embedding_model"
+
+// settingKeySearchMinScore is the JSONB key for the tenant's default search similarity floor. It
+// must match the json tag on models.EnrichmentSettings.SearchMinScore; it is the key removed when a
+// PATCH sends an explicit null.
+const settingKeySearchMinScore = "search_min_score"
+
 // maxTargetLanguageLen bounds a provided target_language value. It mirrors the
 // `max=35` struct tag on UpdateTenantSettingsRequest (the PUT path) and the
 // OpenAPI maxLength, so PUT and PATCH enforce the same limit.
@@ -49,6 +60,10 @@ const maxTargetLanguageLen = 35
 type TenantSettingsService struct {
 	repo     TenantSettingsRepository
 	listener SettingsChangeListener // optional; set via SetSettingsChangeListener
+	// embeddingModels are the models embedding_model may name (EMBEDDING_MODEL and, when set,
+	// EMBEDDING_SECONDARY_MODEL); empty when embeddings are disabled, so any value is rejected.
+	embeddingModels []string
+	allowedModels   []string
 }
 
 // NewTenantSettingsService creates a new tenant settings service.
@@ -64,6 +79,25 @@ func (s *TenantSettingsService) SetSettingsChangeListener(listener SettingsChang
 	s.listener = listener
 }
 
+// SetEmbeddingModels sets the configured embedding models a tenant's embedding_model may name.
+// Blank values are ignored, so the optional secondary model can be passed unconditionally.
+func (s *TenantSettingsService) SetEmbeddingModels(embeddingModels ...string) {
+	s.embeddingModels = nil
+
+	for _, model := range embeddingModels {
+		if model = strings.TrimSpace(model); model != "" {
+			s.embeddingModels = append(s.embeddingModels, model)
+		}
+	}
+}
+
+// SetAllowedEmbeddingModels sets EMBEDDING_ALLOWED_MODELS; empty allows every configured model.
+// Startup already refuses configured models outside the list, so this only makes the rejection
+// of a tenant's choice name the allowlist rather than the configured set.
+func (s *TenantSettingsService) SetAllowedEmbeddingModels(allowed []string) {
+	s.allowedModels = allowed
+}
+
 // GetSettings returns the tenant's enrichment settings. When the tenant has no
 // settings yet it returns a zero-value settings bag (target language unset)
 // rather than a not-found error: an unconfigured tenant is a valid state, and
@@ -104,18 +138,29 @@ func (s *TenantSettingsService) UpdateSettings(
 		return nil, err
 	}
 
+	embeddingModel := strings.TrimSpace(req.EmbeddingModel)
+	if embeddingModel != "" {
+		if err := s.validateEmbeddingModel(embeddingModel); err != nil {
+			return nil, err
+		}
+	}
+
 	settings, err := s.repo.Upsert(ctx, normalizedTenantID, models.EnrichmentSettings{
 		TargetLanguage:   targetLanguage,
 		SentimentEnabled: req.SentimentEnabled,
 		EmotionsEnabled:  req.EmotionsEnabled,
+		EmbeddingModel:   embeddingModel,
+		SearchMinScore:   req.SearchMinScore,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("update tenant settings: %w", err)
 	}
 
 	// PUT is a full replace, so every settable key is (re)written.
-	s.notifyChanged(ctx, normalizedTenantID,
-		[]string{settingKeyTargetLanguage, settingKeySentimentEnabled, settingKeyEmotionsEnabled})
+	s.notifyChanged(ctx, normalizedTenantID, []string{
+		settingKeyTargetLanguage, settingKeySentimentEnabled, settingKeyEmotionsEnabled, settingKeyEmbeddingModel,
+		settingKeySearchMinScore,
+	})
 
 	return settings, nil
 }
@@ -178,6 +223,40 @@ func (s *TenantSettingsService) PatchSettings(
 		}
 	}
 
+	if req.EmbeddingModel.Present {
+		changedKeys = append(changedKeys, settingKeyEmbeddingModel)
+
+		if req.EmbeddingModel.Value == nil {
+			// Explicit null: remove the setting, restoring the default (EMBEDDING_MODEL) (RFC 7396).
+			removeKeys = append(removeKeys, settingKeyEmbeddingModel)
+		} else {
+			// Unlike PUT, "" is not a clear (null is), so it fails validation as an unknown model.
+			model := strings.TrimSpace(*req.EmbeddingModel.Value)
+			if err := s.validateEmbeddingModel(model); err != nil {
+				return nil, err
+			}
+
+			set.EmbeddingModel = model
+		}
+	}
+
+	if req.SearchMinScore.Present {
+		changedKeys = append(changedKeys, settingKeySearchMinScore)
+
+		if req.SearchMinScore.Value == nil {
+			// Explicit null: remove the setting, restoring the search default (RFC 7396).
+			removeKeys = append(removeKeys, settingKeySearchMinScore)
+		} else {
+			// The PUT path gets this bound from struct tags, which Optional[float64] cannot carry.
+			if score := *req.SearchMinScore.Value; score < 0 || score > 1 {
+				return nil, huberrors.NewValidationError(
+					settingKeySearchMinScore, "search_min_score must be between 0 and 1")
+			}
+
+			set.SearchMinScore = req.SearchMinScore.Value
+		}
+	}
+
 	settings, err := s.repo.Patch(ctx, normalizedTenantID, set, removeKeys)
 	if err != nil {
 		return nil, fmt.Errorf("patch tenant settings: %w", err)
@@ -199,6 +278,27 @@ func (s *TenantSettingsService) notifyChanged(ctx context.Context, tenantID stri
 	s.listener.OnSettingsChanged(ctx, tenantID, changedKeys)
 }
 
+// validateEmbeddingModel rejects a model that is not one of the configured embedding models: no
+// other model has a client to embed queries or stored vectors to search, so the tenant's search
+// would fail (or find nothing) until the setting was corrected.
+func (s *TenantSettingsService) validateEmbeddingModel(model string) error {
+	if len(s.allowedModels) > 0 && !slices.Contains(s.allowedModels, model) {
+		return huberrors.NewValidationError("embedding_model",
+			"embedding_model is not an allowed embedding model: "+strings.Join(s.allowedModels, ", "))
+	}
+
+	if slices.Contains(s.embeddingModels, model) {
+		return nil
+	}
+
+	if len(s.embeddingModels) == 0 {
+		return huberrors.NewValidationError("embedding_model", "embedding_model cannot be set: embeddings are not configured")
+	}
+
+	return huberrors.NewValidationError("embedding_model",
+		"embedding_model must be a configured embedding model: "+strings.Join(s.embeddingModels, ", "))
+}
+
 // normalizeTargetLanguage trims and canonicalizes a BCP-47 locale (e.g. "en-us"
 // -> "en-US"). An empty value is allowed and normalizes to "" (target language
 // not configured) — this is the PUT full-replace semantics, where omitting the
@@ -127,9 +127,11 @@ func TestTenantSettingsService_NotifiesListenerOnChange(t *testing.T) {
 		}
 
 		// PUT is a full replace: it notifies every settable key, in a stable order.
-		if got := listener.calls[0]; len(got) != 3 || got[0] != "target_language" ||
-			got[1] != "sentiment_enabled" || got[2] != "emotions_enabled" {
-			t.Fatalf("PUT changedKeys = %v, want [target_language sentiment_enabled emotions_enabled]", got)
+		if got := listener.calls[0]; len(got) != 5 || got[0] != "target_language" ||
+			got[1] != "sentiment_enabled" || got[2] != "emotions_enabled" || got[3] != "embedding_model" ||
+			got[4] != "search_min_score" {
+			t.Fatalf("PUT changedKeys = %v, want [target_language sentiment_enabled emotions_enabled "+
+				"embedding_model search_min_score]", got)
 		}
 
 		// The sentiment switch reaches the repo as part of the full-replace upsert.
@@ -620,14 +622,157 @@ func TestTenantSettingsService_PatchSettings_NullByteRejected(t *testing.T) {
 func TestSettingKeyMatchesModelTag(t *testing.T) {
 	enabled := true
 
-	raw, err := json.Marshal(models.EnrichmentSettings{TargetLanguage: "en-US", SentimentEnabled: &enabled})
+	raw, err := json.Marshal(models.EnrichmentSettings{
+		TargetLanguage: "en-US", SentimentEnabled: &enabled, EmbeddingModel: "text-embedding-3-large",
+		SearchMinScore: new(0.6),
+	})
 	if err != nil {
 		t.Fatalf("marshal: %v", err)
 	}
 
-	for _, key := range []string{settingKeyTargetLanguage, settingKeySentimentEnabled} {
+	for _, key := range []string{
+		settingKeyTargetLanguage, settingKeySentimentEnabled, settingKeyEmbeddingModel, settingKeySearchMinScore,
+	} {
 		if want := `"` + key + `":`; !strings.Contains(string(raw), want) {
 			t.Fatalf("setting key %q is not a json key in %s — const and model tag have drifted", key, raw)
 		}
 	}
 }
+
+func TestTenantSettingsService_EmbeddingModel(t *testing.T) {
+	newService := func() (*TenantSettingsService, *mockTenantSettingsRepo) {
+		repo := &mockTenantSettingsRepo{}
+		svc := NewTenantSettingsService(repo)
+		svc.SetEmbeddingModels("text-embedding-3-small", "", "text-embedding-3-large")
+
+		return svc, repo
+	}
+
+	t.Run("PUT accepts a configured model", func(t *testing.T) {
+		svc, repo := newService()
+
+		_, err := svc.UpdateSettings(context.Background(), "org-1", &models.UpdateTenantSettingsRequest{
+			EmbeddingModel: " text-embedding-3-large ",
+		})
+		if err != nil {
+			t.Fatalf("UpdateSettings() error = %v", err)
+		}
+
+		if repo.upsertSettings.EmbeddingModel != "text-embedding-3-large" {
+			t.Fatalf("upserted embedding_model = %q, want the trimmed configured model", repo.upsertSettings.EmbeddingModel)
+		}
+	})
+
+	t.Run("PUT rejects an unconfigured model", func(t *testing.T) {
+		svc, repo := newService()
+
+		_, err := svc.UpdateSettings(context.Background(), "org-1", &models.UpdateTenantSettingsRequest{
+			EmbeddingModel: "some-other-model",
+		})
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("UpdateSettings() error = %v, want validation error", err)
+		}
+
+		if repo.upsertCalled {
+			t.Fatal("repo.Upsert called despite an unconfigured model")
+		}
+	})
+
+	t.Run("PATCH null removes the setting, empty string is rejected", func(t *testing.T) {
+		svc, repo := newService()
+
+		_, err := svc.PatchSettings(context.Background(), "org-1", &models.PatchTenantSettingsRequest{
+			EmbeddingModel: models.Optional[string]{Present: true},
+		})
+		if err != nil {
+			t.Fatalf("PatchSettings() error = %v", err)
+		}
+
+		if len(repo.patchRemoveKeys) != 1 || repo.patchRemoveKeys[0] != "embedding_model" {
+			t.Fatalf("removeKeys = %v, want [embedding_model]", repo.patchRemoveKeys)
+		}
+
+		empty := ""
+
+		_, err = svc.PatchSettings(context.Background(), "org-1", &models.PatchTenantSettingsRequest{
+			EmbeddingModel: models.Optional[string]{Present: true, Value: &empty},
+		})
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("PatchSettings() error = %v, want validation error for an empty model", err)
+		}
+	})
+
+	t.Run("PUT rejects a model outside the allowlist", func(t *testing.T) {
+		svc, repo := newService()
+		svc.SetAllowedEmbeddingModels([]string{"text-embedding-3-small"})
+
+		_, err := svc.UpdateSettings(context.Background(), "org-1", &models.UpdateTenantSettingsRequest{
+			EmbeddingModel: "text-embedding-3-large",
+		})
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("UpdateSettings() error = %v, want validation error", err)
+		}
+
+		if repo.upsertCalled {
+			t.Fatal("repo.Upsert called despite a disallowed model")
+		}
+	})
+
+	t.Run("rejected when embeddings are not configured", func(t *testing.T) {
+		svc := NewTenantSettingsService(&mockTenantSettingsRepo{})
+
+		_, err := svc.UpdateSettings(context.Background(), "org-1", &models.UpdateTenantSettingsRequest{
+			EmbeddingModel: "text-embedding-3-small",
+		})
+		if !errors.Is(err, huberrors.ErrValidation) {
+			t.Fatalf("UpdateSettings() error = %v, want validation error", err)
+		}
+	})
+}
+
+func TestTenantSettingsService_SearchMinScore(t *testing.T) {
+	t.Run("PATCH sets an in-range value and null removes it", func(t *testing.T) {
+		repo := &mockTenantSettingsRepo{}
+		svc := NewTenantSettingsService(repo)
+
+		_, err := svc.PatchSettings(context.Background(), "org-1", &models.PatchTenantSettingsRequest{
+			SearchMinScore: models.Optional[float64]{Present: true, Value: new(0.55)},
+		})
+		if err != nil {
+			t.Fatalf("PatchSettings() error = %v", err)
+		}
+
+		if repo.patchSet.SearchMinScore == nil || *repo.patchSet.SearchMinScore != 0.55 {
+			t.Fatalf("patched search_min_score = %v, want 0.55", repo.patchSet.SearchMinScore)
+		}
+
+		_, err = svc.PatchSettings(context.Background(), "org-1", &models.PatchTenantSettingsRequest{
+			SearchMinScore: models.Optional[float64]{Present: true},
+		})
+		if err != nil {
+			t.Fatalf("PatchSettings() error = %v", err)
+		}
+
+		if len(repo.patchRemoveKeys) != 1 || repo.patchRemoveKeys[0] != "search_min_score" {
+			t.Fatalf("removeKeys = %v, want [search_min_score]", repo.patchRemoveKeys)
+		}
+	})
+
+	t.Run("PATCH rejects a value outside [0, 1]", func(t *testing.T) {
+		repo := &mockTenantSettingsRepo{}
+		svc := NewTenantSettingsService(repo)
+
+		for _, score := range []float64{-0.1, 1.5} {
+			_, err := svc.PatchSettings(context.Background(), "org-1", &models.PatchTenantSettingsRequest{
+				SearchMinScore: models.Optional[float64]{Present: true, Value: &score},
+			})
+			if !errors.Is(err, huberrors.ErrValidation) {
+				t.Fatalf("PatchSettings(%v) error = %v, want validation error", score, err)
+			}
+		}
+
+		if repo.patchCalled {
+			t.Fatal("repo.Patch called despite an out-of-range score")
+		}
+	})
+}
@@ -79,6 +79,21 @@ func (m *mockTranslationInserter) Insert(
 	return result, m.err
 }
 
+// captureBatchInserter records the embedding batch jobs a backfill enqueues.
+type captureBatchInserter struct {
+	batches []FeedbackEmbeddingBatchArgs
+}
+
+func (m *captureBatchInserter) Insert(
+	_ context.Context, args river.JobArgs, _ *river.InsertOpts,
+) (*rivertype.JobInsertResult, error) {
+	if a, ok := args.(FeedbackEmbeddingBatchArgs); ok {
+		m.batches = append(m.batches, a)
+	}
+
+	return &rivertype.JobInsertResult{}, nil
+}
+
 type mockTargetResolver struct {
 	target string
 	err    error
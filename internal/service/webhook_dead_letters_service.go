@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/pkg/cursor"
+)
+
+// WebhookDeadLettersRepository is the data access the dead-letter endpoints need
+// (satisfied by *repository.WebhooksRepository).
+type WebhookDeadLettersRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error)
+	ListDeadLetters(
+		ctx context.Context, webhookID uuid.UUID, limit int, cursorCreatedAt *time.Time, cursorID uuid.UUID,
+	) ([]models.WebhookDeadLetter, bool, error)
+	GetDeadLetter(ctx context.Context, webhookID, id uuid.UUID) (*models.WebhookDeadLetter, error)
+	MarkDeadLetterRetried(ctx context.Context, tenantID string, id uuid.UUID, at time.Time) error
+}
+
+// WebhookDeadLettersService lists a webhook's permanently failed deliveries and re-enqueues them.
+type WebhookDeadLettersService struct {
+	repo        WebhookDeadLettersRepository
+	inserter    RiverJobInserter
+	maxAttempts int
+}
+
+// NewWebhookDeadLettersService creates the service. maxAttempts is WEBHOOK_DELIVERY_MAX_ATTEMPTS,
+// so a retried delivery gets the same budget as the original. The River inserter is set
+// separately (SetInserter) because the API's River client is created later.
+func NewWebhookDeadLettersService(repo WebhookDeadLettersRepository, maxAttempts int) *WebhookDeadLettersService {
+	return &WebhookDeadLettersService{repo: repo, maxAttempts: maxAttempts}
+}
+
+// SetInserter sets the River inserter retried deliveries are enqueued with; it must be set
+// before RetryDeadLetter is called.
+func (s *WebhookDeadLettersService) SetInserter(inserter RiverJobInserter) {
+	s.inserter = inserter
+}
+
+// ListDeadLetters returns one page of the webhook's dead letters, newest first. An unknown
+// webhook is not found rather than an empty list.
+func (s *WebhookDeadLettersService) ListDeadLetters(
+	ctx context.Context, webhookID uuid.UUID, filters *models.ListWebhookDeadLettersFilters,
+) (*models.ListWebhookDeadLettersResponse, error) {
+	if _, err := s.repo.GetByID(ctx, webhookID); err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = 100
+	}
+
+	var (
+		cursorCreatedAt *time.Time
+		cursorID        uuid.UUID
+	)
+
+	if cursorStr := strings.TrimSpace(filters.Cursor); cursorStr != "" {
+		createdAt, id, err := cursor.Decode(cursorStr)
+		if err != nil {
+			return nil, fmt.Errorf("decode cursor: %w", err)
+		}
+
+		cursorCreatedAt, cursorID = &createdAt, id
+	}
+
+	deadLetters, hasMore, err := s.repo.ListDeadLetters(ctx, webhookID, filters.Limit, cursorCreatedAt, cursorID)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook dead letters: %w", err)
+	}
+
+	if hasMore && len(deadLetters) == 0 {
+		return nil, fmt.Errorf("list webhook dead letters: %w", ErrPaginationInvariantViolated)
+	}
+
+	var encodeLast func() (string, error)
+	if hasMore {
+		encodeLast = func() (string, error) {
+			last := deadLetters[len(deadLetters)-1]
+
+			return cursor.Encode(last.CreatedAt, last.ID)
+		}
+	}
+
+	meta, err := BuildListPaginationMeta(filters.Limit, hasMore, encodeLast)
+	if err != nil {
+		return nil, fmt.Errorf("encode next cursor: %w", err)
+	}
+
+	return &models.ListWebhookDeadLettersResponse{
+		Data:       deadLetters,
+		Limit:      meta.Limit,
+		NextCursor: meta.NextCursor,
+	}, nil
+}
+
+// RetryDeadLetter re-enqueues a dead letter's delivery with a fresh attempt budget and stamps
+// retried_at. The webhook must be enabled again first — exhausting the attempts disabled it, and
+// the worker skips disabled webhooks — so retrying a disabled one is a conflict. The retry keeps
+// the original event id, so receivers can deduplicate; if it fails for good again it is recorded
+// as a new dead letter.
+func (s *WebhookDeadLettersService) RetryDeadLetter(
+	ctx context.Context, webhookID, id uuid.UUID,
+) (*models.WebhookDeadLetter, error) {
+	webhook, err := s.repo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+
+	deadLetter, err := s.repo.GetDeadLetter(ctx, webhookID, id)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook dead letter: %w", err)
+	}
+
+	if !webhook.Enabled {
+		return nil, huberrors.NewConflictError("webhook is disabled; enable it (PATCH enabled=true) before retrying its dead letters")
+	}
+
+	var args WebhookDispatchArgs
+	if err := json.Unmarshal(deadLetter.Payload, &args); err != nil {
+		return nil, fmt.Errorf("decode webhook dead letter payload: %w", err)
+	}
+
+	args.WebhookID = webhookID
+
+	if _, err := s.inserter.Insert(ctx, args, &river.InsertOpts{MaxAttempts: s.maxAttempts}); err != nil {
+		return nil, fmt.Errorf("enqueue webhook retry: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if err := s.repo.MarkDeadLetterRetried(ctx, deadLetter.TenantID, id, now); err != nil {
+		return nil, err
+	}
+
+	deadLetter.RetriedAt = &now
+
+	return deadLetter, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+)
+
+type mockDeadLettersRepo struct {
+	webhook         *models.Webhook
+	deadLetter      *models.WebhookDeadLetter
+	retriedID       uuid.UUID
+	retriedTenantID string
+}
+
+func (m *mockDeadLettersRepo) GetByID(context.Context, uuid.UUID) (*models.Webhook, error) {
+	if m.webhook == nil {
+		return nil, huberrors.NewNotFoundError("webhook", "webhook not found")
+	}
+
+	return m.webhook, nil
+}
+
+func (m *mockDeadLettersRepo) ListDeadLetters(
+	context.Context, uuid.UUID, int, *time.Time, uuid.UUID,
+) ([]models.WebhookDeadLetter, bool, error) {
+	return []models.WebhookDeadLetter{*m.deadLetter}, true, nil
+}
+
+func (m *mockDeadLettersRepo) GetDeadLetter(context.Context, uuid.UUID, uuid.UUID) (*models.WebhookDeadLetter, error) {
+	return m.deadLetter, nil
+}
+
+func (m *mockDeadLettersRepo) MarkDeadLetterRetried(
+	_ context.Context, tenantID string, id uuid.UUID, _ time.Time,
+) error {
+	m.retriedTenantID = tenantID
+	m.retriedID = id
+
+	return nil
+}
+
+func newDeadLetterFixture(t *testing.T, enabled bool) (*mockDeadLettersRepo, WebhookDispatchArgs) {
+	t.Helper()
+
+	tenantID := "org-1"
+	args := WebhookDispatchArgs{
+		EventID: uuid.Must(uuid.NewV7()), EventType: "feedback_record.created", Timestamp: time.Now().UTC(),
+		Data: map[string]any{"id": "r1"}, TenantID: &tenantID, WebhookID: uuid.Must(uuid.NewV7()),
+	}
+
+	payload, err := json.Marshal(args)
+	require.NoError(t, err)
+
+	return &mockDeadLettersRepo{
+		webhook: &models.Webhook{ID: args.WebhookID, Enabled: enabled},
+		deadLetter: &models.WebhookDeadLetter{
+			ID: uuid.Must(uuid.NewV7()), WebhookID: args.WebhookID, EventID: args.EventID, Payload: payload,
+			CreatedAt: time.Now().UTC(),
+		},
+	}, args
+}
+
+func TestWebhookDeadLettersService_RetryEnqueuesOriginalDelivery(t *testing.T) {
+	repo, args := newDeadLetterFixture(t, true)
+	inserter := &recordingInserter{}
+	svc := NewWebhookDeadLettersService(repo, 5)
+	svc.SetInserter(inserter)
+
+	deadLetter, err := svc.RetryDeadLetter(t.Context(), args.WebhookID, repo.deadLetter.ID)
+	require.NoError(t, err)
+
+	require.Len(t, inserter.args, 1)
+	retried, ok := inserter.args[0].(WebhookDispatchArgs)
+	require.True(t, ok)
+	assert.Equal(t, args.EventID, retried.EventID, "the retry keeps the event id so receivers can deduplicate")
+	assert.Equal(t, args.WebhookID, retried.WebhookID)
+	assert.Equal(t, 5, inserter.opts[0].MaxAttempts)
+	assert.Equal(t, repo.deadLetter.ID, repo.retriedID)
+	assert.Equal(t, repo.deadLetter.TenantID, repo.retriedTenantID)
+	assert.NotNil(t, deadLetter.RetriedAt)
+}
+
+func TestWebhookDeadLettersService_RetryRequiresEnabledWebhook(t *testing.T) {
+	repo, args := newDeadLetterFixture(t, false)
+	inserter := &recordingInserter{}
+	svc := NewWebhookDeadLettersService(repo, 5)
+	svc.SetInserter(inserter)
+
+	_, err := svc.RetryDeadLetter(t.Context(), args.WebhookID, repo.deadLetter.ID)
+	require.ErrorIs(t, err, huberrors.ErrConflict)
+	assert.Empty(t, inserter.args)
+}
+
+func TestWebhookDeadLettersService_ListEncodesNextCursor(t *testing.T) {
+	repo, args := newDeadLetterFixture(t, true)
+	svc := NewWebhookDeadLettersService(repo, 5)
+
+	result, err := svc.ListDeadLetters(t.Context(), args.WebhookID, &models.ListWebhookDeadLettersFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, 100, result.Limit)
+	assert.NotEmpty(t, result.NextCursor)
+
+	repo.webhook = nil
+	_, err = svc.ListDeadLetters(t.Context(), args.WebhookID, &models.ListWebhookDeadLettersFilters{})
+	require.ErrorIs(t, err, huberrors.ErrNotFound)
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/datatypes"
+	"github.com/formbricks/hub/internal/models"
+)
+
+// WebhookDebouncer delays feedback_record.created/updated webhook events for a short window and
+// coalesces every event for the same record within it into one delivery carrying the latest
+// state. A Formbricks response arrives as responseCreated → responseUpdated → responseFinished,
+// each upserting the same records; without this every step is a separate delivery.
+//
+// The window is fixed from the first event for a record (not sliding), so a delivery is never
+// delayed by more than the window however often the record changes. The coalesced event keeps
+// the first event's id and type — subscribers that never saw the created event still get one —
+// with the latest data and timestamp; an updated event's changed_fields is the union of the
+// coalesced updates. Every other event passes straight through, and a feedback_record.deleted
+// event first flushes the deleted records' pending events so deliveries stay in order.
+//
+// Coalescing is per API process: with several replicas, events for one record handled by
+// different replicas are delivered separately, as before.
+type WebhookDebouncer struct {
+	next           eventPublisher
+	window         time.Duration
+	publishTimeout time.Duration
+
+	// publishMu serializes calls into next and is always taken before mu, so a timer flush and a
+	// delete's flush cannot reorder deliveries for the same record.
+	publishMu sync.Mutex
+	mu        sync.Mutex
+	pending   map[uuid.UUID]*debouncedEvent
+	closed    bool
+}
+
+type debouncedEvent struct {
+	event Event
+	timer *time.Timer
+}
+
+// NewWebhookDebouncer wraps next (the webhook provider) with a coalescing window. publishTimeout
+// bounds each delayed delivery, like the message publisher's per-event timeout bounds the rest.
+func NewWebhookDebouncer(next eventPublisher, window, publishTimeout time.Duration) *WebhookDebouncer {
+	return &WebhookDebouncer{
+		next:           next,
+		window:         window,
+		publishTimeout: publishTimeout,
+		pending:        make(map[uuid.UUID]*debouncedEvent),
+	}
+}
+
+// PublishEvent implements eventPublisher.
+func (d *WebhookDebouncer) PublishEvent(ctx context.Context, event Event) {
+	if recordID, ok := debounceRecordID(event); ok && d.add(recordID, event) {
+		return
+	}
+
+	d.publishMu.Lock()
+	defer d.publishMu.Unlock()
+
+	if event.Type == datatypes.FeedbackRecordDeleted {
+		d.publishPending(ctx, d.take(deletedEventIDs(event)))
+	}
+
+	d.next.PublishEvent(ctx, event)
+}
+
+// Flush delivers every pending event now. The message publisher calls it on shutdown, after the
+// event channel has drained; events published afterwards are delivered immediately.
+func (d *WebhookDebouncer) Flush(ctx context.Context) {
+	d.publishMu.Lock()
+	defer d.publishMu.Unlock()
+
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+
+	d.publishPending(ctx, d.takeAll())
+}
+
+// add merges event into the record's pending event, starting the window for a new one. It
+// reports false (deliver immediately) once the debouncer has been flushed for shutdown.
+func (d *WebhookDebouncer) add(recordID uuid.UUID, event Event) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return false
+	}
+
+	if entry, ok := d.pending[recordID]; ok {
+		entry.event = coalesceEvents(entry.event, event)
+
+		return true
+	}
+
+	entry := &debouncedEvent{event: event}
+	entry.timer = time.AfterFunc(d.window, func() { d.flushRecord(recordID, entry) })
+	d.pending[recordID] = entry
+
+	return true
+}
+
+// flushRecord is the window timer: it delivers the record's event unless a delete or shutdown
+// flush already took it.
+func (d *WebhookDebouncer) flushRecord(recordID uuid.UUID, entry *debouncedEvent) {
+	d.publishMu.Lock()
+	defer d.publishMu.Unlock()
+
+	d.mu.Lock()
+	if d.pending[recordID] != entry {
+		d.mu.Unlock()
+
+		return
+	}
+
+	delete(d.pending, recordID)
+	event := entry.event
+	d.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.publishTimeout)
+	defer cancel()
+
+	d.next.PublishEvent(ctx, event)
+}
+
+// takeAll removes and returns every pending event, oldest first.
+func (d *WebhookDebouncer) takeAll() []Event {
+	d.mu.Lock()
+	ids := make([]uuid.UUID, 0, len(d.pending))
+
+	for id := range d.pending {
+		ids = append(ids, id)
+	}
+	d.mu.Unlock()
+
+	return d.take(ids)
+}
+
+// take removes and returns the pending events for ids, oldest first, with their timers stopped.
+func (d *WebhookDebouncer) take(ids []uuid.UUID) []Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	events := make([]Event, 0, len(ids))
+
+	for _, id := range ids {
+		entry, ok := d.pending[id]
+		if !ok {
+			continue
+		}
+
+		entry.timer.Stop()
+		delete(d.pending, id)
+		events = append(events, entry.event)
+	}
+
+	// Event ids are UUIDv7, so id order is publish order.
+	slices.SortFunc(events, func(a, b Event) int { return slices.Compare(a.ID[:], b.ID[:]) })
+
+	return events
+}
+
+func (d *WebhookDebouncer) publishPending(ctx context.Context, events []Event) {
+	for _, event := range events {
+		d.next.PublishEvent(ctx, event)
+	}
+
+	if len(events) > 0 {
+		slog.Debug("webhook debouncer: flushed pending events early", "count", len(events))
+	}
+}
+
+// debounceRecordID returns the record a created/updated event is coalesced by.
+func debounceRecordID(event Event) (uuid.UUID, bool) {
+	if event.Type != datatypes.FeedbackRecordCreated && event.Type != datatypes.FeedbackRecordUpdated {
+		return uuid.Nil, false
+	}
+
+	record, ok := event.Data.(*models.FeedbackRecord)
+	if !ok || record == nil {
+		return uuid.Nil, false
+	}
+
+	return record.ID, true
+}
+
+func deletedEventIDs(event Event) []uuid.UUID {
+	switch data := event.Data.(type) {
+	case models.DeletedIDsEventData:
+		return data.IDs
+	case *models.DeletedIDsEventData:
+		if data != nil {
+			return data.IDs
+		}
+	}
+
+	return nil
+}
+
+// coalesceEvents folds next into the pending event for the same record.
+func coalesceEvents(pending, next Event) Event {
+	merged := pending
+	merged.Data = next.Data
+	merged.Timestamp = next.Timestamp
+
+	if pending.Type == datatypes.FeedbackRecordUpdated {
+		merged.ChangedFields = slices.Clone(pending.ChangedFields)
+
+		for _, field := range next.ChangedFields {
+			if !slices.Contains(merged.ChangedFields, field) {
+				merged.ChangedFields = append(merged.ChangedFields, field)
+			}
+		}
+	}
+
+	return merged
+}
+
+// Ensure WebhookDebouncer implements eventPublisher.
+var _ eventPublisher = (*WebhookDebouncer)(nil)
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/datatypes"
+	"github.com/formbricks/hub/internal/models"
+)
+
+// collectingPublisher records the events it receives.
+type collectingPublisher struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (p *collectingPublisher) PublishEvent(_ context.Context, event Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, event)
+}
+
+func (p *collectingPublisher) snapshot() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Event(nil), p.events...)
+}
+
+func debounceTestEvent(eventType datatypes.EventType, data any, changed ...string) Event {
+	return Event{
+		ID: uuid.Must(uuid.NewV7()), Type: eventType, Timestamp: time.Now(), Data: data, ChangedFields: changed,
+	}
+}
+
+func debounceTestRecord(id uuid.UUID, text string) *models.FeedbackRecord {
+	return &models.FeedbackRecord{ID: id, TenantID: "org-1", ValueText: &text}
+}
+
+func TestWebhookDebouncer_CoalescesCreatedAndUpdates(t *testing.T) {
+	next := &collectingPublisher{}
+	d := NewWebhookDebouncer(next, time.Hour, time.Second)
+	recordID := uuid.New()
+
+	created := debounceTestEvent(datatypes.FeedbackRecordCreated, debounceTestRecord(recordID, "a"))
+	d.PublishEvent(context.Background(), created)
+	d.PublishEvent(context.Background(),
+		debounceTestEvent(datatypes.FeedbackRecordUpdated, debounceTestRecord(recordID, "b"), "value_text"))
+	d.PublishEvent(context.Background(),
+		debounceTestEvent(datatypes.FeedbackRecordUpdated, debounceTestRecord(recordID, "c"), "value_text", "tags"))
+
+	assert.Empty(t, next.snapshot(), "events must be held for the window")
+
+	d.Flush(context.Background())
+
+	events := next.snapshot()
+	require.Len(t, events, 1)
+	assert.Equal(t, created.ID, events[0].ID)
+	assert.Equal(t, datatypes.FeedbackRecordCreated, events[0].Type)
+	assert.Equal(t, "c", *events[0].Data.(*models.FeedbackRecord).ValueText)
+	assert.Empty(t, events[0].ChangedFields, "a coalesced created event carries no changed fields")
+}
+
+func TestWebhookDebouncer_UnionsChangedFieldsOfUpdates(t *testing.T) {
+	next := &collectingPublisher{}
+	d := NewWebhookDebouncer(next, time.Hour, time.Second)
+	recordID := uuid.New()
+
+	d.PublishEvent(context.Background(),
+		debounceTestEvent(datatypes.FeedbackRecordUpdated, debounceTestRecord(recordID, "a"), "value_text"))
+	d.PublishEvent(context.Background(),
+		debounceTestEvent(datatypes.FeedbackRecordUpdated, debounceTestRecord(recordID, "b"), "tags", "value_text"))
+	d.Flush(context.Background())
+
+	events := next.snapshot()
+	require.Len(t, events, 1)
+	assert.Equal(t, datatypes.FeedbackRecordUpdated, events[0].Type)
+	assert.Equal(t, []string{"value_text", "tags"}, events[0].ChangedFields)
+}
+
+func TestWebhookDebouncer_DeliversAfterWindow(t *testing.T) {
+	next := &collectingPublisher{}
+	d := NewWebhookDebouncer(next, 20*time.Millisecond, time.Second)
+
+	d.PublishEvent(context.Background(), debounceTestEvent(datatypes.FeedbackRecordCreated, debounceTestRecord(uuid.New(), "a")))
+	d.PublishEvent(context.Background(), debounceTestEvent(datatypes.FeedbackRecordCreated, debounceTestRecord(uuid.New(), "b")))
+
+	assert.Eventually(t, func() bool { return len(next.snapshot()) == 2 }, 5*time.Second, 5*time.Millisecond,
+		"each record's event is delivered once its window ends")
+}
+
+func TestWebhookDebouncer_DeleteFlushesPendingFirst(t *testing.T) {
+	next := &collectingPublisher{}
+	d := NewWebhookDebouncer(next, time.Hour, time.Second)
+	deletedID, otherID := uuid.New(), uuid.New()
+
+	d.PublishEvent(context.Background(), debounceTestEvent(datatypes.FeedbackRecordCreated, debounceTestRecord(deletedID, "a")))
+	d.PublishEvent(context.Background(), debounceTestEvent(datatypes.FeedbackRecordCreated, debounceTestRecord(otherID, "b")))
+	d.PublishEvent(context.Background(), debounceTestEvent(datatypes.FeedbackRecordDeleted,
+		models.DeletedIDsEventData{TenantID: "org-1", IDs: []uuid.UUID{deletedID}}))
+
+	events := next.snapshot()
+	require.Len(t, events, 2, "only the deleted record's pending event is flushed")
+	assert.Equal(t, datatypes.FeedbackRecordCreated, events[0].Type)
+	assert.Equal(t, deletedID, events[0].Data.(*models.FeedbackRecord).ID)
+	assert.Equal(t, datatypes.FeedbackRecordDeleted, events[1].Type)
+}
+
+func TestWebhookDebouncer_PassesOtherEventsThrough(t *testing.T) {
+	next := &collectingPublisher{}
+	d := NewWebhookDebouncer(next, time.Hour, time.Second)
+
+	d.PublishEvent(context.Background(), debounceTestEvent(datatypes.WebhookCreated, &models.Webhook{ID: uuid.New()}))
+
+	assert.Len(t, next.snapshot(), 1)
+}
+
+func TestWebhookDebouncer_DeliversImmediatelyAfterFlush(t *testing.T) {
+	next := &collectingPublisher{}
+	d := NewWebhookDebouncer(next, time.Hour, time.Second)
+	d.Flush(context.Background())
+
+	d.PublishEvent(context.Background(), debounceTestEvent(datatypes.FeedbackRecordCreated, debounceTestRecord(uuid.New(), "a")))
+
+	assert.Len(t, next.snapshot(), 1)
+}
+
+func TestMessagePublisher_ShutdownFlushesDebouncer(t *testing.T) {
+	next := &collectingPublisher{}
+
+	m := NewMessagePublisherManager(8, time.Second, nil)
+	m.RegisterProvider(NewWebhookDebouncer(next, time.Hour, time.Second))
+
+	m.PublishEvent(context.Background(), datatypes.FeedbackRecordCreated, debounceTestRecord(uuid.New(), "a"))
+	m.Shutdown()
+
+	assert.Len(t, next.snapshot(), 1, "a held event must be delivered on shutdown, not lost")
+}
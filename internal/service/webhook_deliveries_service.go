@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/pkg/cursor"
+)
+
+// WebhookDeliveriesRepository is the data access the delivery log endpoint needs
+// (satisfied by *repository.WebhooksRepository).
+type WebhookDeliveriesRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error)
+	ListDeliveries(
+		ctx context.Context, webhookID uuid.UUID, limit int, cursorCreatedAt *time.Time, cursorID uuid.UUID,
+	) ([]models.WebhookDelivery, bool, error)
+}
+
+// WebhookDeliveriesService lists a webhook's recent delivery attempts, the record of why an
+// endpoint stopped receiving events: each failed attempt's status, response and error, and when
+// the next one was due.
+type WebhookDeliveriesService struct {
+	repo WebhookDeliveriesRepository
+}
+
+// NewWebhookDeliveriesService creates the service.
+func NewWebhookDeliveriesService(repo WebhookDeliveriesRepository) *WebhookDeliveriesService {
+	return &WebhookDeliveriesService{repo: repo}
+}
+
+// ListDeliveries returns one page of the webhook's delivery attempts, newest first. An unknown
+// webhook is not found rather than an empty list.
+func (s *WebhookDeliveriesService) ListDeliveries(
+	ctx context.Context, webhookID uuid.UUID, filters *models.ListWebhookDeliveriesFilters,
+) (*models.ListWebhookDeliveriesResponse, error) {
+	if _, err := s.repo.GetByID(ctx, webhookID); err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = 100
+	}
+
+	var (
+		cursorCreatedAt *time.Time
+		cursorID        uuid.UUID
+	)
+
+	if cursorStr := strings.TrimSpace(filters.Cursor); cursorStr != "" {
+		createdAt, id, err := cursor.Decode(cursorStr)
+		if err != nil {
+			return nil, fmt.Errorf("decode cursor: %w", err)
+		}
+
+		cursorCreatedAt, cursorID = &createdAt, id
+	}
+
+	deliveries, hasMore, err := s.repo.ListDeliveries(ctx, webhookID, filters.Limit, cursorCreatedAt, cursorID)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+
+	if hasMore && len(deliveries) == 0 {
+		return nil, fmt.Errorf("list webhook deliveries: %w", ErrPaginationInvariantViolated)
+	}
+
+	var encodeLast func() (string, error)
+	if hasMore {
+		encodeLast = func() (string, error) {
+			last := deliveries[len(deliveries)-1]
+
+			return cursor.Encode(last.CreatedAt, last.ID)
+		}
+	}
+
+	meta, err := BuildListPaginationMeta(filters.Limit, hasMore, encodeLast)
+	if err != nil {
+		return nil, fmt.Errorf("encode next cursor: %w", err)
+	}
+
+	return &models.ListWebhookDeliveriesResponse{
+		Data:       deliveries,
+		Limit:      meta.Limit,
+		NextCursor: meta.NextCursor,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/pkg/cursor"
+)
+
+type mockDeliveriesRepo struct {
+	webhook    *models.Webhook
+	deliveries []models.WebhookDelivery
+	hasMore    bool
+	cursorID   uuid.UUID
+}
+
+func (m *mockDeliveriesRepo) GetByID(context.Context, uuid.UUID) (*models.Webhook, error) {
+	if m.webhook == nil {
+		return nil, huberrors.NewNotFoundError("webhook", "webhook not found")
+	}
+
+	return m.webhook, nil
+}
+
+func (m *mockDeliveriesRepo) ListDeliveries(
+	_ context.Context, _ uuid.UUID, _ int, _ *time.Time, cursorID uuid.UUID,
+) ([]models.WebhookDelivery, bool, error) {
+	m.cursorID = cursorID
+
+	return m.deliveries, m.hasMore, nil
+}
+
+func TestWebhookDeliveriesService_ListPagesNewestFirst(t *testing.T) {
+	last := models.WebhookDelivery{ID: uuid.Must(uuid.NewV7()), CreatedAt: time.Now().UTC()}
+	repo := &mockDeliveriesRepo{
+		webhook:    &models.Webhook{ID: uuid.Must(uuid.NewV7())},
+		deliveries: []models.WebhookDelivery{last},
+		hasMore:    true,
+	}
+	svc := NewWebhookDeliveriesService(repo)
+
+	result, err := svc.ListDeliveries(t.Context(), repo.webhook.ID, &models.ListWebhookDeliveriesFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, 100, result.Limit)
+	require.NotEmpty(t, result.NextCursor)
+
+	_, err = svc.ListDeliveries(t.Context(), repo.webhook.ID, &models.ListWebhookDeliveriesFilters{Cursor: result.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, last.ID, repo.cursorID, "the next page starts after the last delivery")
+
+	_, err = svc.ListDeliveries(t.Context(), repo.webhook.ID, &models.ListWebhookDeliveriesFilters{Cursor: "not-a-cursor"})
+	require.ErrorIs(t, err, cursor.ErrInvalidCursor)
+
+	repo.webhook = nil
+	_, err = svc.ListDeliveries(t.Context(), uuid.Must(uuid.NewV7()), &models.ListWebhookDeliveriesFilters{})
+	require.ErrorIs(t, err, huberrors.ErrNotFound)
+}
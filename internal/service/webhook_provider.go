@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"math/rand"
 	"time"
@@ -37,7 +38,8 @@ type WebhookProvider struct {
 }
 
 // NewWebhookProvider creates a provider that lists enabled webhooks and enqueues jobs via InsertMany.
-// maxFanOut is the batch size for InsertMany (all matching webhooks are enqueued in batches of maxFanOut).
+// maxFanOut caps the deliveries per event (WEBHOOK_MAX_FAN_OUT_PER_EVENT); matching webhooks beyond it
+// are skipped, logged, and counted (see PublishEvent).
 // enqueueMaxRetries, enqueueInitialBackoff, enqueueMaxBackoff configure retries when InsertMany fails (transient River/DB errors).
 // metrics may be nil when metrics are disabled.
 func NewWebhookProvider(
@@ -58,8 +60,9 @@ func NewWebhookProvider(
 	}
 }
 
-// PublishEvent lists enabled webhooks for the event type and tenant, then enqueues one job per webhook.
-// Webhooks are only eligible when the event payload has the same tenant_id.
+// PublishEvent lists enabled webhooks for the event type and tenant, then enqueues one job per webhook,
+// at most maxFanOut of them (the oldest). Webhooks are only eligible when the event payload has the
+// same tenant_id.
 func (p *WebhookProvider) PublishEvent(ctx context.Context, event Event) {
 	tenantID := TenantIDPointerFromEventData(event.Data)
 	if tenantID == nil {
@@ -107,6 +110,27 @@ func (p *WebhookProvider) PublishEvent(ctx context.Context, event Event) {
 		return
 	}
 
+	// The cap bounds how many deliveries one event can put on the queue. The repository lists
+	// webhooks in creation order, so which ones still fire when capped is predictable: the
+	// oldest maxFanOut subscriptions, never a different subset per event.
+	if dropped := len(webhooks) - p.maxFanOut; p.maxFanOut > 0 && dropped > 0 {
+		firstDropped := webhooks[p.maxFanOut].ID
+		webhooks = webhooks[:p.maxFanOut]
+
+		if p.metrics != nil {
+			p.metrics.RecordFanOutCapped(ctx, event.Type.String(), int64(dropped))
+		}
+
+		slog.Warn("webhook provider: fan-out capped; newest matching webhooks not notified",
+			"event_id", event.ID,
+			"event_type", event.Type,
+			"tenant_id", tenantIDValue,
+			"max_fan_out", p.maxFanOut,
+			"dropped", dropped,
+			"first_dropped_webhook_id", firstDropped,
+		)
+	}
+
 	const uniqueByPeriodHours = 24
 
 	opts := &river.InsertOpts{
@@ -118,67 +142,57 @@ func (p *WebhookProvider) PublishEvent(ctx context.Context, event Event) {
 	}
 	baseArgs := p.eventToArgs(event, tenantID)
 
-	var enqueued int64
-
-	for start := 0; start < len(webhooks); start += p.maxFanOut {
-		end := min(start+p.maxFanOut, len(webhooks))
-		chunk := webhooks[start:end]
+	params := make([]river.InsertManyParams, 0, len(webhooks))
+	for i := range webhooks {
+		args := baseArgs
+		args.WebhookID = webhooks[i].ID
+		params = append(params, river.InsertManyParams{Args: args, InsertOpts: opts})
+	}
 
-		params := make([]river.InsertManyParams, 0, len(chunk))
-		for i := range chunk {
-			args := baseArgs
-			args.WebhookID = chunk[i].ID
-			params = append(params, river.InsertManyParams{Args: args, InsertOpts: opts})
+	if err := p.insertWithRetry(ctx, params); err != nil {
+		if p.metrics != nil {
+			p.metrics.RecordProviderError(ctx, "enqueue_failed")
 		}
 
-		var insertErr error
-		for attempt := 0; attempt <= p.enqueueMaxRetries; attempt++ {
-			_, insertErr = p.inserter.InsertMany(ctx, params)
-			if insertErr == nil {
-				break
-			}
-
-			if attempt == p.enqueueMaxRetries {
-				break
-			}
-
-			backoff := p.enqueueBackoffWithJitter(attempt)
-			select {
-			case <-ctx.Done():
-				insertErr = ctx.Err()
-
-				goto afterInsertRetry
-			case <-time.After(backoff):
-				// retry
-			}
-		}
+		slog.Error("failed to enqueue webhook jobs after retries",
+			"event_id", event.ID,
+			"event_type", event.Type,
+			"tenant_id", tenantIDValue,
+			"error", err,
+		)
 
-	afterInsertRetry:
-		if insertErr != nil {
-			if p.metrics != nil {
-				p.metrics.RecordProviderError(ctx, "enqueue_failed")
-			}
+		return
+	}
 
-			slog.Error("failed to enqueue webhook jobs after retries",
-				"event_id", event.ID,
-				"event_type", event.Type,
-				"tenant_id", tenantIDValue,
-				"error", insertErr,
-			)
+	if p.metrics != nil {
+		p.metrics.RecordJobsEnqueued(ctx, event.Type.String(), int64(len(params)))
+	}
+}
 
-			if p.metrics != nil && enqueued > 0 {
-				p.metrics.RecordJobsEnqueued(ctx, event.Type.String(), enqueued)
-			}
+// insertWithRetry inserts params in one InsertMany, retrying transient failures with jittered
+// exponential backoff up to enqueueMaxRetries times. The batch fails or succeeds as a whole.
+func (p *WebhookProvider) insertWithRetry(ctx context.Context, params []river.InsertManyParams) error {
+	var insertErr error
 
-			return
+	for attempt := 0; attempt <= p.enqueueMaxRetries; attempt++ {
+		_, insertErr = p.inserter.InsertMany(ctx, params)
+		if insertErr == nil || attempt == p.enqueueMaxRetries {
+			break
 		}
 
-		enqueued += int64(len(chunk))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("enqueue webhook jobs: %w", ctx.Err())
+		case <-time.After(p.enqueueBackoffWithJitter(attempt)):
+			// retry
+		}
 	}
 
-	if p.metrics != nil {
-		p.metrics.RecordJobsEnqueued(ctx, event.Type.String(), enqueued)
+	if insertErr != nil {
+		return fmt.Errorf("enqueue webhook jobs: %w", insertErr)
 	}
+
+	return nil
 }
 
 // enqueueBackoffJitterFactor: jitter is up to 50% of backoff.
@@ -12,6 +12,7 @@ import (
 
 	"github.com/formbricks/hub/internal/datatypes"
 	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/internal/observability"
 )
 
 type mockWebhookInserter struct {
@@ -180,29 +181,38 @@ func TestWebhookProvider_PublishEvent(t *testing.T) {
 		}
 	})
 
-	t.Run("enqueues all webhooks in batches of maxFanOut", func(t *testing.T) {
+	t.Run("caps fan-out at maxFanOut keeping the oldest webhooks", func(t *testing.T) {
 		inserter := &mockWebhookInserter{}
 
-		webhooks := make([]models.Webhook, 501)
+		webhooks := make([]models.Webhook, 5)
 		for i := range webhooks {
 			webhooks[i] = models.Webhook{ID: uuid.Must(uuid.NewV7()), TenantID: &tenantID}
 		}
 
 		repo := &mockProviderRepo{webhooks: webhooks}
-		provider := NewWebhookProvider(inserter, repo, 3, 500, 0, 0, 0, nil)
+		metrics := &fanOutMetrics{}
+		provider := NewWebhookProvider(inserter, repo, 3, 3, 0, 0, 0, metrics)
 		event := Event{ID: eventID, Type: eventType, Timestamp: time.Now(), Data: map[string]string{"tenant_id": tenantID}}
 		provider.PublishEvent(ctx, event)
 
-		if len(inserter.insertManyCalls) != 2 {
-			t.Fatalf("InsertMany called %d times, want 2 (batches of 500 and 1)", len(inserter.insertManyCalls))
+		if len(inserter.insertManyCalls) != 1 {
+			t.Fatalf("InsertMany called %d times, want 1", len(inserter.insertManyCalls))
+		}
+
+		params := inserter.insertManyCalls[0]
+		if len(params) != 3 {
+			t.Fatalf("InsertMany params length = %d, want 3", len(params))
 		}
 
-		if len(inserter.insertManyCalls[0]) != 500 {
-			t.Errorf("first batch params length = %d, want 500", len(inserter.insertManyCalls[0]))
+		for i, p := range params {
+			args, ok := p.Args.(WebhookDispatchArgs)
+			if !ok || args.WebhookID != webhooks[i].ID {
+				t.Errorf("param %d webhook = %v, want the %d-th oldest webhook %s", i, args.WebhookID, i, webhooks[i].ID)
+			}
 		}
 
-		if len(inserter.insertManyCalls[1]) != 1 {
-			t.Errorf("second batch params length = %d, want 1", len(inserter.insertManyCalls[1]))
+		if metrics.dropped != 2 || metrics.enqueued != 3 {
+			t.Errorf("metrics dropped = %d, enqueued = %d; want 2 and 3", metrics.dropped, metrics.enqueued)
 		}
 	})
 
@@ -283,6 +293,22 @@ func TestWebhookProvider_PublishEvent(t *testing.T) {
 	})
 }
 
+// fanOutMetrics counts enqueued and capped deliveries; the other webhook metrics are no-ops.
+type fanOutMetrics struct {
+	observability.WebhookMetrics
+
+	enqueued int64
+	dropped  int64
+}
+
+func (m *fanOutMetrics) RecordJobsEnqueued(_ context.Context, _ string, count int64) {
+	m.enqueued += count
+}
+
+func (m *fanOutMetrics) RecordFanOutCapped(_ context.Context, _ string, dropped int64) {
+	m.dropped += dropped
+}
+
 func cloneStringPointer(value *string) *string {
 	if value == nil {
 		return nil
@@ -6,10 +6,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
+	"runtime/debug"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,8 +28,60 @@ import (
 var (
 	ErrWebhookGone   = errors.New("webhook returned 410 Gone (endpoint disabled)")
 	ErrWebhookNon2xx = errors.New("webhook returned non-2xx status")
+	// ErrWebhookHostBusy means the delivery gave up waiting for a per-host slot: nothing was sent,
+	// so the attempt is not a delivery failure and should be queued again instead.
+	ErrWebhookHostBusy = errors.New("no delivery slot free for the webhook's host")
 )
 
+// WebhookResponseBodyLimit is how much of a failed delivery's response body is kept for the
+// delivery log: enough for an error message, not for an endpoint's whole HTML error page.
+const WebhookResponseBodyLimit = 1024
+
+// WebhookStatusError is returned when the endpoint answers with a non-2xx status. It matches
+// ErrWebhookNon2xx (errors.Is) and carries the status, which dead letters record, and the first
+// WebhookResponseBodyLimit bytes of the response body, which the delivery log records.
+type WebhookStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *WebhookStatusError) Error() string {
+	return fmt.Sprintf("%s: %d", ErrWebhookNon2xx, e.StatusCode)
+}
+
+// Is reports whether target is ErrWebhookNon2xx.
+func (e *WebhookStatusError) Is(target error) bool {
+	return target == ErrWebhookNon2xx
+}
+
+// WebhookFailureStatus returns the HTTP status a failed delivery got from the endpoint, or nil
+// when no response was received (connection error, timeout).
+func WebhookFailureStatus(err error) *int {
+	var statusErr *WebhookStatusError
+	if errors.As(err, &statusErr) {
+		return &statusErr.StatusCode
+	}
+
+	if errors.Is(err, ErrWebhookGone) {
+		status := http.StatusGone
+
+		return &status
+	}
+
+	return nil
+}
+
+// WebhookFailureBody returns the start of a failed delivery's response body, or nil when no
+// response was received or it had no body.
+func WebhookFailureBody(err error) *string {
+	var statusErr *WebhookStatusError
+	if errors.As(err, &statusErr) && statusErr.Body != "" {
+		return &statusErr.Body
+	}
+
+	return nil
+}
+
 // WebhookSender sends a single webhook payload to an endpoint (Standard Webhooks: signing, headers, 410 handling).
 type WebhookSender interface {
 	Send(ctx context.Context, webhook *models.Webhook, payload *WebhookPayload) error
@@ -41,6 +98,35 @@ type WebhookSenderImpl struct {
 	httpClient       *http.Client
 	metrics          observability.WebhookMetrics
 	urlHostBlacklist map[string]struct{}
+	hostSlots        *hostSlots
+	userAgent        string
+}
+
+// webhookRequestIDHeader carries a fresh id per delivery attempt. webhook-id stays the same across
+// retries (receivers dedupe on it), so this is what pins one attempt down in both sides' logs; it
+// is part of the error of a failed attempt, which the retry log and dead letters record.
+const webhookRequestIDHeader = "X-Request-ID"
+
+// DefaultUserAgent is the User-Agent of outbound deliveries when HTTP_USER_AGENT is unset:
+// formbricks-hub/<version>, with the module version of a tagged build or the VCS revision of a
+// source build ("dev" when neither is stamped). It names no host or instance, which would leak
+// internal topology to every receiver; set HTTP_USER_AGENT to add one.
+func DefaultUserAgent() string {
+	version := "dev"
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if info.Main.Version != "" && info.Main.Version != "(devel)" {
+			version = info.Main.Version
+		} else {
+			for _, setting := range info.Settings {
+				if setting.Key == "vcs.revision" && setting.Value != "" {
+					version = setting.Value[:min(len(setting.Value), 12)]
+				}
+			}
+		}
+	}
+
+	return "formbricks-hub/" + version
 }
 
 // NewWebhookSenderImpl creates a sender that uses the given repo.
@@ -99,11 +185,54 @@ func NewWebhookSenderImpl(
 		httpClient:       httpClient,
 		metrics:          metrics,
 		urlHostBlacklist: urlHostBlacklist,
+		userAgent:        DefaultUserAgent(),
 	}
 }
 
+// SetUserAgent overrides the User-Agent of outbound deliveries (HTTP_USER_AGENT), e.g. to add an
+// instance or environment name receivers can tell apart. Empty keeps DefaultUserAgent.
+func (s *WebhookSenderImpl) SetUserAgent(userAgent string) {
+	if userAgent = strings.TrimSpace(userAgent); userAgent != "" {
+		s.userAgent = userAgent
+	}
+}
+
+// SetMaxConcurrentPerHost caps the deliveries in flight to any one destination host at n
+// (WEBHOOK_MAX_CONCURRENT_PER_HOST); further deliveries to that host wait for a slot, bounded by
+// their job's context. WEBHOOK_DELIVERY_MAX_CONCURRENT alone lets every delivery worker pile onto
+// one slow host — many webhooks share an endpoint, and a replay or dead-letter retry storm
+// enqueues them together. The cap is per process. n <= 0 leaves hosts unlimited, as does never
+// calling this; the config default is 10.
+func (s *WebhookSenderImpl) SetMaxConcurrentPerHost(n int) {
+	if n <= 0 {
+		s.hostSlots = nil
+
+		return
+	}
+
+	s.hostSlots = newHostSlots(n)
+}
+
 // Send signs and POSTs the payload to the webhook URL. On 410 Gone, disables the webhook and returns an error.
+// A failed attempt's error names its X-Request-ID. A delivery that ends while still waiting for a
+// per-host slot returns ErrWebhookHostBusy without having sent anything.
 func (s *WebhookSenderImpl) Send(ctx context.Context, webhook *models.Webhook, payload *WebhookPayload) error {
+	release, err := s.hostSlots.acquire(ctx, webhookHostKey(webhook.URL))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWebhookHostBusy, err)
+	}
+	defer release()
+
+	requestID := uuid.Must(uuid.NewV7()).String()
+
+	if err := s.send(ctx, webhook, payload, requestID); err != nil {
+		return fmt.Errorf("request %s: %w", requestID, err)
+	}
+
+	return nil
+}
+
+func (s *WebhookSenderImpl) send(ctx context.Context, webhook *models.Webhook, payload *WebhookPayload, requestID string) error {
 	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("marshal webhook payload: %w", err)
@@ -129,6 +258,8 @@ func (s *WebhookSenderImpl) Send(ctx context.Context, webhook *models.Webhook, p
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set(webhookRequestIDHeader, requestID)
 	req.Header.Set(standardwebhooks.HeaderWebhookID, messageID)
 	req.Header.Set(standardwebhooks.HeaderWebhookSignature, signature)
 	req.Header.Set(standardwebhooks.HeaderWebhookTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
@@ -175,8 +306,87 @@ func (s *WebhookSenderImpl) Send(ctx context.Context, webhook *models.Webhook, p
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("%w: %d", ErrWebhookNon2xx, resp.StatusCode)
+		return &WebhookStatusError{StatusCode: resp.StatusCode, Body: readWebhookResponseBody(resp.Body)}
 	}
 
 	return nil
 }
+
+// readWebhookResponseBody reads at most WebhookResponseBodyLimit bytes of a failed delivery's
+// response, as text Postgres accepts: invalid UTF-8 (a cut multi-byte rune, a binary body) is
+// replaced and NUL bytes dropped. A read error keeps what was read; the status is what matters.
+func readWebhookResponseBody(body io.Reader) string {
+	data, _ := io.ReadAll(io.LimitReader(body, WebhookResponseBodyLimit))
+
+	return strings.ReplaceAll(strings.ToValidUTF8(string(data), "\uFFFD"), "\x00", "")
+}
+
+// webhookHostKey is the per-host limit's key: the URL's hostname, lowercased, so the ports and
+// paths of one host share its slots. An unparsable URL keys on itself; the request fails anyway.
+func webhookHostKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return rawURL
+	}
+
+	return strings.ToLower(u.Hostname())
+}
+
+// hostSlots is a counting semaphore per destination host. Entries exist only while a host has
+// deliveries in flight or waiting, so the map stays as small as the set of busy hosts. A nil
+// *hostSlots never blocks.
+type hostSlots struct {
+	limit int
+	mu    sync.Mutex
+	hosts map[string]*hostSlot
+}
+
+type hostSlot struct {
+	slots chan struct{}
+	users int // holders plus waiters; the entry is dropped when it reaches zero
+}
+
+func newHostSlots(limit int) *hostSlots {
+	return &hostSlots{limit: limit, hosts: make(map[string]*hostSlot)}
+}
+
+// acquire waits for a free slot of host and returns its release func, or ctx's error if ctx ends
+// first.
+func (h *hostSlots) acquire(ctx context.Context, host string) (func(), error) {
+	if h == nil {
+		return func() {}, nil
+	}
+
+	h.mu.Lock()
+
+	slot, ok := h.hosts[host]
+	if !ok {
+		slot = &hostSlot{slots: make(chan struct{}, h.limit)}
+		h.hosts[host] = slot
+	}
+
+	slot.users++
+	h.mu.Unlock()
+
+	select {
+	case slot.slots <- struct{}{}:
+		return func() {
+			<-slot.slots
+			h.done(host, slot)
+		}, nil
+	case <-ctx.Done():
+		h.done(host, slot)
+
+		return nil, context.Cause(ctx)
+	}
+}
+
+func (h *hostSlots) done(host string, slot *hostSlot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	slot.users--
+	if slot.users == 0 {
+		delete(h.hosts, host)
+	}
+}
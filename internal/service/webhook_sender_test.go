@@ -2,13 +2,19 @@ package service
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	standardwebhooks "github.com/standard-webhooks/standard-webhooks/libraries/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/formbricks/hub/internal/models"
 )
@@ -84,6 +90,38 @@ func TestWebhookSenderImpl_Send(t *testing.T) {
 		}
 	})
 
+	t.Run("identifies itself and tags each attempt", func(t *testing.T) {
+		var requestIDs []string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("User-Agent"); got != "formbricks-hub/test (eu-1)" {
+				t.Errorf("User-Agent = %q, want the configured one", got)
+			}
+
+			requestIDs = append(requestIDs, r.Header.Get("X-Request-ID"))
+
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		webhook.URL = server.URL
+
+		sender := NewWebhookSenderImpl(&mockSenderRepo{}, nil, nil, 5*time.Second, &http.Client{Timeout: 5 * time.Second})
+		sender.SetUserAgent("formbricks-hub/test (eu-1)")
+		payload := &WebhookPayload{ID: uuid.Must(uuid.NewV7()), Type: "test", Timestamp: time.Now(), Data: nil}
+
+		firstErr := sender.Send(ctx, webhook, payload)
+		secondErr := sender.Send(ctx, webhook, payload)
+
+		if len(requestIDs) != 2 || requestIDs[0] == "" || requestIDs[0] == requestIDs[1] {
+			t.Fatalf("X-Request-ID values = %q, want a distinct id per attempt", requestIDs)
+		}
+
+		if firstErr == nil || !strings.Contains(firstErr.Error(), requestIDs[0]) || !errors.Is(secondErr, ErrWebhookNon2xx) {
+			t.Errorf("Send() errors = %v / %v, want non-2xx errors naming their request id", firstErr, secondErr)
+		}
+	})
+
 	t.Run("disables webhook and returns error on 410", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 			w.WriteHeader(http.StatusGone)
@@ -123,12 +161,119 @@ func TestWebhookSenderImpl_Send(t *testing.T) {
 		payload := &WebhookPayload{ID: uuid.Must(uuid.NewV7()), Type: "test", Timestamp: time.Now(), Data: nil}
 
 		err := sender.Send(ctx, webhook, payload)
-		if err == nil {
-			t.Error("Send() error = nil, want error on 500")
+		if !errors.Is(err, ErrWebhookNon2xx) {
+			t.Errorf("Send() error = %v, want ErrWebhookNon2xx on 500", err)
+		}
+
+		if status := WebhookFailureStatus(err); status == nil || *status != http.StatusInternalServerError {
+			t.Errorf("WebhookFailureStatus() = %v, want 500", status)
 		}
 
 		if repo.updateCalled {
 			t.Error("Update should not be called on 500")
 		}
 	})
+
+	t.Run("keeps the start of a failed response body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("upstream down\x00" + strings.Repeat("x", 2*WebhookResponseBodyLimit)))
+		}))
+		defer server.Close()
+
+		webhook.URL = server.URL
+
+		client := &http.Client{Timeout: 5 * time.Second}
+		sender := NewWebhookSenderImpl(&mockSenderRepo{}, nil, nil, 5*time.Second, client)
+		payload := &WebhookPayload{ID: uuid.Must(uuid.NewV7()), Type: "test", Timestamp: time.Now(), Data: nil}
+
+		body := WebhookFailureBody(sender.Send(ctx, webhook, payload))
+		if body == nil || !strings.HasPrefix(*body, "upstream down") || len(*body) != WebhookResponseBodyLimit-1 {
+			t.Errorf("WebhookFailureBody() = %v, want the first %d bytes without the NUL", body, WebhookResponseBodyLimit)
+		}
+	})
+}
+
+func TestWebhookSenderImpl_MaxConcurrentPerHost(t *testing.T) {
+	var inFlight, peak atomic.Int32
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSenderImpl(&mockSenderRepo{}, nil, nil, 5*time.Second, &http.Client{Timeout: 5 * time.Second})
+	sender.SetMaxConcurrentPerHost(2)
+
+	webhook := &models.Webhook{
+		ID: uuid.Must(uuid.NewV7()), URL: server.URL, SigningKey: "whsec_" + "abcdefghijklmnopqrstuvwxyz123456", Enabled: true,
+	}
+	payload := &WebhookPayload{ID: uuid.Must(uuid.NewV7()), Type: "feedback_record.created", Timestamp: time.Now()}
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 5)
+
+	for range 5 {
+		wg.Go(func() { errs <- sender.Send(context.Background(), webhook, payload) })
+	}
+
+	// Let the first two deliveries reach the server and the rest queue behind them.
+	require.Eventually(t, func() bool { return inFlight.Load() == 2 }, time.Second, 5*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(2), inFlight.Load(), "the other deliveries wait for a slot")
+
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(2), peak.Load())
+
+	t.Run("a waiting delivery gives up with its context", func(t *testing.T) {
+		slots := newHostSlots(1)
+		release, err := slots.acquire(t.Context(), "example.com")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = slots.acquire(ctx, "example.com")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		release()
+		assert.Empty(t, slots.hosts, "an idle host's entry is dropped")
+	})
+
+	t.Run("a delivery that never got a slot is host busy", func(t *testing.T) {
+		busy := NewWebhookSenderImpl(&mockSenderRepo{}, nil, nil, 5*time.Second, &http.Client{Timeout: 5 * time.Second})
+		busy.SetMaxConcurrentPerHost(1)
+
+		release, err := busy.hostSlots.acquire(t.Context(), webhookHostKey(webhook.URL))
+		require.NoError(t, err)
+
+		defer release()
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		err = busy.Send(ctx, webhook, payload)
+		require.ErrorIs(t, err, ErrWebhookHostBusy)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
 }
@@ -25,6 +25,7 @@ type FeedbackEmbeddingWorker struct {
 	embeddingClient  service.EmbeddingClient
 	docPrefix        string // model-specific prefix for document embedding
 	metrics          observability.EmbeddingMetrics
+	secondary        *service.SecondaryEmbeddingModel
 }
 
 // feedbackEmbeddingService is the minimal interface needed by the worker.
@@ -53,6 +54,21 @@ func NewFeedbackEmbeddingWorker(
 	}
 }
 
+// SetSecondaryModel routes jobs for EMBEDDING_SECONDARY_MODEL to its own client; every other
+// model key (the primary and the taxonomy model) keeps the primary client. Set it in every process
+// that runs embedding jobs, or secondary jobs would store the primary model's vectors.
+func (w *FeedbackEmbeddingWorker) SetSecondaryModel(secondary *service.SecondaryEmbeddingModel) {
+	w.secondary = secondary
+}
+
+func (w *FeedbackEmbeddingWorker) clientFor(model string) service.EmbeddingClient {
+	if w.secondary != nil && model == w.secondary.Model {
+		return w.secondary.Client
+	}
+
+	return w.embeddingClient
+}
+
 // Timeout limits how long a single embedding job can run.
 func (w *FeedbackEmbeddingWorker) Timeout(*river.Job[service.FeedbackEmbeddingArgs]) time.Duration {
 	return enrichmentJobTimeout
@@ -120,7 +136,7 @@ func (w *FeedbackEmbeddingWorker) Work(ctx context.Context, job *river.Job[servi
 		return w.handleEmptyText(ctx, job, record, log, start, stillCurrent)
 	}
 
-	embedding, err := w.embeddingClient.CreateEmbedding(ctx, text)
+	embedding, err := w.clientFor(args.Model).CreateEmbedding(ctx, text)
 	if err != nil {
 		return w.handleEmbedError(ctx, err, job, log, start)
 	}
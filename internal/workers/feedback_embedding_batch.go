@@ -0,0 +1,263 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/internal/service"
+)
+
+// embeddingBatchTimeoutPerRecord is added to enrichmentJobTimeout per record of a batch job: a
+// full batch is one request on OpenAI but up to one per record on Vertex's Gemini models (see
+// googleai.Client.CreateEmbeddingsBatch), plus a read and a write per record either way.
+const embeddingBatchTimeoutPerRecord = 500 * time.Millisecond
+
+// FeedbackEmbeddingBatchWorker embeds the records of a FeedbackEmbeddingBatchArgs job with one
+// provider call (service.CreateEmbeddings). It shares the per-record worker's client, prefix,
+// metrics and secondary-model routing, and its per-record outcomes: a record gone or superseded
+// is skipped, and a text record with empty text has its embedding cleared.
+//
+// A record the batch cannot finish — its read or its write failed — is re-enqueued alone as a
+// FeedbackEmbeddingArgs job with its own retry budget, and the batch completes; the records
+// already stored are not embedded again. Only a failed provider call fails the batch as a whole,
+// since nothing was stored: River retries all of it, or snoozes it on a provider rate limit.
+type FeedbackEmbeddingBatchWorker struct {
+	river.WorkerDefaults[service.FeedbackEmbeddingBatchArgs]
+
+	single *FeedbackEmbeddingWorker
+	// inserter returns the job inserter for the re-enqueued records; River's client from the job
+	// context outside tests.
+	inserter func(ctx context.Context) service.RiverJobInserter
+}
+
+// NewFeedbackEmbeddingBatchWorker creates the batch worker on top of the per-record worker, so both
+// embed with the same client (and SetSecondaryModel on single covers both).
+func NewFeedbackEmbeddingBatchWorker(single *FeedbackEmbeddingWorker) *FeedbackEmbeddingBatchWorker {
+	return &FeedbackEmbeddingBatchWorker{
+		single: single,
+		inserter: func(ctx context.Context) service.RiverJobInserter {
+			return river.ClientFromContext[pgx.Tx](ctx)
+		},
+	}
+}
+
+// Timeout scales the job timeout with the batch size.
+func (w *FeedbackEmbeddingBatchWorker) Timeout(job *river.Job[service.FeedbackEmbeddingBatchArgs]) time.Duration {
+	return enrichmentJobTimeout + time.Duration(len(job.Args.FeedbackRecordIDs))*embeddingBatchTimeoutPerRecord
+}
+
+// embeddingBatchItem is one record of a batch awaiting its vector.
+type embeddingBatchItem struct {
+	id           uuid.UUID
+	text         string
+	stillCurrent func(fieldLabel, valueText, valueTextTranslated *string) bool
+}
+
+// Work loads the batch's records, embeds the non-empty ones in one call, stores every vector and
+// re-enqueues the records it could not finish.
+func (w *FeedbackEmbeddingBatchWorker) Work(ctx context.Context, job *river.Job[service.FeedbackEmbeddingBatchArgs]) error {
+	args := job.Args
+	start := time.Now()
+	inputKind := models.NormalizeEmbeddingInputKind(args.InputKind)
+	log := slog.With("batch_size", len(args.FeedbackRecordIDs), "model", args.Model)
+
+	var (
+		pending []embeddingBatchItem
+		requeue []uuid.UUID
+		stored  int
+	)
+
+	for _, id := range args.FeedbackRecordIDs {
+		record, err := w.single.embeddingService.GetFeedbackRecord(ctx, id)
+		if errors.Is(err, huberrors.ErrNotFound) {
+			w.recordOutcome(ctx, "skipped", start)
+
+			continue
+		}
+
+		if err != nil {
+			w.recordWorkerError(ctx, "get_record_failed")
+			log.Warn("embedding batch: get record failed, re-enqueueing it alone", "feedback_record_id", id, "error", err)
+
+			requeue = append(requeue, id)
+
+			continue
+		}
+
+		text := service.BuildEmbeddingInputForKind(record, inputKind, w.single.docPrefix)
+		stillCurrent := func(fieldLabel, valueText, valueTextTranslated *string) bool {
+			return service.BuildEmbeddingInputFromValues(fieldLabel, valueText, valueTextTranslated, inputKind, w.single.docPrefix) == text
+		}
+
+		if text != "" {
+			pending = append(pending, embeddingBatchItem{id: id, text: text, stillCurrent: stillCurrent})
+
+			continue
+		}
+
+		if record.FieldType != models.FieldTypeText {
+			w.recordOutcome(ctx, "skipped", start)
+
+			continue
+		}
+
+		err = w.single.embeddingService.SetEmbedding(ctx, id, args.Model, nil, stillCurrent)
+		if w.storeFailed(ctx, err, log, id, start) {
+			requeue = append(requeue, id)
+		}
+	}
+
+	if len(pending) > 0 {
+		inputs := make([]string, len(pending))
+		for i, item := range pending {
+			inputs[i] = item.text
+		}
+
+		vectors, err := service.CreateEmbeddings(ctx, w.single.clientFor(args.Model), inputs)
+		if err != nil {
+			return w.handleBatchEmbedError(ctx, err, job, log, start, len(pending))
+		}
+
+		for i, item := range pending {
+			err := w.single.embeddingService.SetEmbedding(ctx, item.id, args.Model, vectors[i], item.stillCurrent)
+			if w.storeFailed(ctx, err, log, item.id, start) {
+				requeue = append(requeue, item.id)
+			} else if err == nil {
+				stored++
+			}
+		}
+	}
+
+	if err := w.requeue(ctx, job, inputKind, requeue); err != nil {
+		return err
+	}
+
+	log.Info("embedding batch: done", "stored", stored, "requeued", len(requeue))
+
+	return nil
+}
+
+// storeFailed records the outcome of one record's write and reports whether the record must be
+// re-enqueued: a record gone or superseded is done, any other failure (a tenant purge in
+// progress, a database error) is retried on its own.
+func (w *FeedbackEmbeddingBatchWorker) storeFailed(
+	ctx context.Context, err error, log *slog.Logger, id uuid.UUID, start time.Time,
+) bool {
+	switch {
+	case err == nil:
+		w.recordOutcome(ctx, "success", start)
+
+		return false
+	case errors.Is(err, huberrors.ErrNotFound):
+		w.recordOutcome(ctx, "skipped", start)
+
+		return false
+	case errors.Is(err, huberrors.ErrEmbeddingSuperseded):
+		w.recordWorkerError(ctx, "superseded")
+		w.recordOutcome(ctx, "skipped", start)
+
+		return false
+	case errors.Is(err, huberrors.ErrTenantWriteConflict):
+		w.recordWorkerError(ctx, "tenant_write_conflict")
+	default:
+		w.recordWorkerError(ctx, "update_failed")
+	}
+
+	w.recordOutcome(ctx, "retry", start)
+	log.Warn("embedding batch: write failed, re-enqueueing the record alone", "feedback_record_id", id, "error", err)
+
+	return true
+}
+
+// requeue enqueues each id as a per-record embedding job on the batch's queue, priority and
+// attempt budget. The dedupe hash names this batch job, so a retry of the batch after a failed
+// insert does not enqueue the records it already handed off twice.
+func (w *FeedbackEmbeddingBatchWorker) requeue(
+	ctx context.Context, job *river.Job[service.FeedbackEmbeddingBatchArgs], inputKind models.EmbeddingInputKind, ids []uuid.UUID,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	inserter := w.inserter(ctx)
+	opts := &river.InsertOpts{
+		Queue:       job.Queue,
+		MaxAttempts: job.MaxAttempts,
+		Priority:    job.Priority,
+		UniqueOpts:  river.UniqueOpts{ByArgs: true},
+	}
+
+	for _, id := range ids {
+		if _, err := inserter.Insert(ctx, service.FeedbackEmbeddingArgs{
+			FeedbackRecordID: id,
+			Model:            job.Args.Model,
+			InputKind:        inputKind,
+			ValueTextHash:    "batch-retry:" + strconv.FormatInt(job.ID, 10),
+			TenantID:         job.Args.TenantID,
+		}, opts); err != nil {
+			return fmt.Errorf("re-enqueue embedding job for %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// handleBatchEmbedError maps a failed provider call to the batch's outcome, as
+// FeedbackEmbeddingWorker.handleEmbedError does for one record: a rate limit snoozes, anything
+// else retries the whole batch.
+func (w *FeedbackEmbeddingBatchWorker) handleBatchEmbedError(
+	ctx context.Context, err error, job *river.Job[service.FeedbackEmbeddingBatchArgs], log *slog.Logger, start time.Time, records int,
+) error {
+	if delay, ok := rateLimitSnoozeDelay(err, job.CreatedAt); ok {
+		w.recordWorkerError(ctx, "rate_limited")
+
+		for range records {
+			w.recordOutcome(ctx, "retry", start)
+		}
+
+		log.Warn("embedding batch: provider rate limited, snoozing", "retry_after", delay)
+
+		//nolint:wrapcheck // river sentinel: JobSnooze must be returned unwrapped for River to detect the snooze
+		return river.JobSnooze(delay)
+	}
+
+	isLastAttempt := job.Attempt >= job.MaxAttempts
+
+	outcome := "retry"
+	if isLastAttempt {
+		outcome = "failed_final"
+	}
+
+	w.recordWorkerError(ctx, "embedding_api_failed")
+
+	for range records {
+		w.recordOutcome(ctx, outcome, start)
+	}
+
+	log.Error("embedding batch: API failed", "final_attempt", isLastAttempt, "error", err)
+
+	return fmt.Errorf("embedding batch API: %w", err)
+}
+
+func (w *FeedbackEmbeddingBatchWorker) recordOutcome(ctx context.Context, outcome string, start time.Time) {
+	if w.single.metrics != nil {
+		w.single.metrics.RecordEmbeddingOutcome(ctx, outcome)
+		w.single.metrics.RecordEmbeddingDuration(ctx, time.Since(start), outcome)
+	}
+}
+
+func (w *FeedbackEmbeddingBatchWorker) recordWorkerError(ctx context.Context, reason string) {
+	if w.single.metrics != nil {
+		w.single.metrics.RecordWorkerError(ctx, reason)
+	}
+}
@@ -0,0 +1,208 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	"github.com/formbricks/hub/internal/huberrors"
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/internal/service"
+)
+
+// batchEmbeddingService serves records and write results per id.
+type batchEmbeddingService struct {
+	records map[uuid.UUID]*models.FeedbackRecord
+	getErrs map[uuid.UUID]error
+	setErrs map[uuid.UUID]error
+	stored  map[uuid.UUID][]float32
+}
+
+func (m *batchEmbeddingService) GetFeedbackRecord(_ context.Context, id uuid.UUID) (*models.FeedbackRecord, error) {
+	if err := m.getErrs[id]; err != nil {
+		return nil, err
+	}
+
+	record, ok := m.records[id]
+	if !ok {
+		return nil, huberrors.NewNotFoundError("feedback record", "gone")
+	}
+
+	return record, nil
+}
+
+func (m *batchEmbeddingService) SetEmbedding(
+	_ context.Context, id uuid.UUID, _ string, embedding []float32,
+	_ func(fieldLabel, valueText, valueTextTranslated *string) bool,
+) error {
+	if err := m.setErrs[id]; err != nil {
+		return err
+	}
+
+	m.stored[id] = embedding
+
+	return nil
+}
+
+// batchEmbeddingClient embeds a batch in one call and counts the calls.
+type batchEmbeddingClient struct {
+	mockEmbeddingClient
+
+	batchCalls int
+	inputs     []string
+	err        error
+}
+
+func (m *batchEmbeddingClient) CreateEmbeddingsBatch(_ context.Context, inputs []string) ([][]float32, error) {
+	m.batchCalls++
+	m.inputs = inputs
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	vectors := make([][]float32, len(inputs))
+	for i := range vectors {
+		vectors[i] = []float32{float32(i + 1)}
+	}
+
+	return vectors, nil
+}
+
+type recordingInserter struct {
+	args []river.JobArgs
+	opts []*river.InsertOpts
+}
+
+func (r *recordingInserter) Insert(_ context.Context, args river.JobArgs, opts *river.InsertOpts) (*rivertype.JobInsertResult, error) {
+	r.args = append(r.args, args)
+	r.opts = append(r.opts, opts)
+
+	return &rivertype.JobInsertResult{Job: &rivertype.JobRow{}}, nil
+}
+
+func newBatchWorker(
+	svc *batchEmbeddingService, client *batchEmbeddingClient, metrics *countingEmbeddingMetrics,
+) (*FeedbackEmbeddingBatchWorker, *recordingInserter) {
+	inserter := &recordingInserter{}
+	worker := NewFeedbackEmbeddingBatchWorker(NewFeedbackEmbeddingWorker(svc, client, "", metrics))
+	worker.inserter = func(context.Context) service.RiverJobInserter { return inserter }
+
+	return worker, inserter
+}
+
+func embeddingBatchJob(ids ...uuid.UUID) *river.Job[service.FeedbackEmbeddingBatchArgs] {
+	return &river.Job[service.FeedbackEmbeddingBatchArgs]{
+		JobRow: &rivertype.JobRow{ID: 42, Attempt: 1, MaxAttempts: 3, Queue: service.EmbeddingsQueueName, Priority: 4},
+		Args:   service.FeedbackEmbeddingBatchArgs{FeedbackRecordIDs: ids, Model: "test-model", TenantID: "tenant-a"},
+	}
+}
+
+func TestFeedbackEmbeddingBatchWorker_EmbedsInOneCallAndRequeuesFailures(t *testing.T) {
+	stored, gone, empty, readFails, writeFails := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	svc := &batchEmbeddingService{
+		records: map[uuid.UUID]*models.FeedbackRecord{
+			stored:     textRecord("Great product"),
+			empty:      textRecord(""),
+			writeFails: textRecord("Slow support"),
+		},
+		getErrs: map[uuid.UUID]error{readFails: errors.New("connection reset")},
+		setErrs: map[uuid.UUID]error{writeFails: huberrors.ErrTenantWriteConflict},
+		stored:  map[uuid.UUID][]float32{},
+	}
+	client := &batchEmbeddingClient{}
+	metrics := newCountingEmbeddingMetrics()
+	worker, inserter := newBatchWorker(svc, client, metrics)
+
+	if err := worker.Work(context.Background(), embeddingBatchJob(stored, gone, empty, readFails, writeFails)); err != nil {
+		t.Fatalf("Work() error = %v, want nil (per-record failures are re-enqueued)", err)
+	}
+
+	if client.batchCalls != 1 || len(client.inputs) != 2 {
+		t.Fatalf("batch calls = %d with %d inputs, want 1 call with the 2 non-empty records", client.batchCalls, len(client.inputs))
+	}
+
+	if v, ok := svc.stored[empty]; !ok || v != nil {
+		t.Fatalf("empty text record: stored = %v (present %v), want its embedding cleared", v, ok)
+	}
+
+	if len(svc.stored[stored]) != 1 {
+		t.Fatalf("stored record vector = %v, want the batch's vector", svc.stored[stored])
+	}
+
+	if len(inserter.args) != 2 {
+		t.Fatalf("re-enqueued %d jobs, want 2 (the failed read and the failed write)", len(inserter.args))
+	}
+
+	requeued := map[uuid.UUID]bool{}
+
+	for i, args := range inserter.args {
+		single, ok := args.(service.FeedbackEmbeddingArgs)
+		if !ok {
+			t.Fatalf("re-enqueued %T, want service.FeedbackEmbeddingArgs", args)
+		}
+
+		requeued[single.FeedbackRecordID] = true
+
+		if single.TenantID != "tenant-a" {
+			t.Fatalf("re-enqueued tenant_id = %q, want the batch's tenant-a", single.TenantID)
+		}
+
+		if opts := inserter.opts[i]; opts.Queue != service.EmbeddingsQueueName || opts.MaxAttempts != 3 || opts.Priority != 4 {
+			t.Fatalf("re-enqueue opts = %+v, want the batch's queue, attempts and priority", opts)
+		}
+	}
+
+	if !requeued[readFails] || !requeued[writeFails] {
+		t.Fatalf("re-enqueued %v, want %s and %s", requeued, readFails, writeFails)
+	}
+
+	if metrics.outcomes["success"] != 2 || metrics.outcomes["skipped"] != 1 || metrics.outcomes["retry"] != 1 {
+		t.Fatalf("outcomes = %v, want success=2 skipped=1 retry=1", metrics.outcomes)
+	}
+}
+
+func TestFeedbackEmbeddingBatchWorker_RateLimitSnoozesWholeBatch(t *testing.T) {
+	id := uuid.New()
+	svc := &batchEmbeddingService{
+		records: map[uuid.UUID]*models.FeedbackRecord{id: textRecord("Great product")},
+		stored:  map[uuid.UUID][]float32{},
+	}
+	client := &batchEmbeddingClient{err: huberrors.NewRateLimitError(30*time.Second, errors.New("429"))}
+	worker, inserter := newBatchWorker(svc, client, newCountingEmbeddingMetrics())
+
+	err := worker.Work(context.Background(), embeddingBatchJob(id))
+
+	var snooze *river.JobSnoozeError
+	if !errors.As(err, &snooze) || snooze.Duration != 30*time.Second {
+		t.Fatalf("Work() error = %v, want a 30s snooze", err)
+	}
+
+	if len(svc.stored) != 0 || len(inserter.args) != 0 {
+		t.Fatalf("stored %d, re-enqueued %d on rate limit; want nothing (the batch is deferred)", len(svc.stored), len(inserter.args))
+	}
+}
+
+func TestFeedbackEmbeddingBatchWorker_ProviderErrorRetriesWholeBatch(t *testing.T) {
+	id := uuid.New()
+	svc := &batchEmbeddingService{
+		records: map[uuid.UUID]*models.FeedbackRecord{id: textRecord("Great product")},
+		stored:  map[uuid.UUID][]float32{},
+	}
+	client := &batchEmbeddingClient{err: huberrors.ErrProviderUnavailable}
+	worker, inserter := newBatchWorker(svc, client, newCountingEmbeddingMetrics())
+
+	err := worker.Work(context.Background(), embeddingBatchJob(id))
+	if !errors.Is(err, huberrors.ErrProviderUnavailable) {
+		t.Fatalf("Work() error = %v, want the provider error (River retries the batch)", err)
+	}
+
+	if len(inserter.args) != 0 {
+		t.Fatalf("re-enqueued %d jobs on a provider failure, want 0", len(inserter.args))
+	}
+}
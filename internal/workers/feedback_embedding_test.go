@@ -223,6 +223,33 @@ func TestFeedbackEmbeddingWorker_Work_TaxonomyInputUsesTranslatedText(t *testing
 	}
 }
 
+func TestFeedbackEmbeddingWorker_Work_SecondaryModelUsesItsClient(t *testing.T) {
+	svc := &mockEmbeddingService{record: textRecord("slow login")}
+	primary := &mockEmbeddingClient{embedding: []float32{0.1}}
+	secondary := &mockEmbeddingClient{embedding: []float32{0.2}}
+	worker := NewFeedbackEmbeddingWorker(svc, primary, "", nil)
+	worker.SetSecondaryModel(&service.SecondaryEmbeddingModel{Model: "candidate-model", Client: secondary})
+
+	job := embeddingJob()
+	job.Args.Model = "candidate-model"
+
+	if err := worker.Work(context.Background(), job); err != nil {
+		t.Fatalf("Work() error = %v, want nil", err)
+	}
+
+	if secondary.input == "" || primary.input != "" {
+		t.Fatalf("secondary job embedded by primary=%q secondary=%q, want the secondary client only", primary.input, secondary.input)
+	}
+
+	if err := worker.Work(context.Background(), embeddingJob()); err != nil {
+		t.Fatalf("Work() error = %v, want nil", err)
+	}
+
+	if primary.input == "" {
+		t.Fatal("primary job must keep the primary client")
+	}
+}
+
 func TestFeedbackEmbeddingWorker_Work_EmptyTextConflict(t *testing.T) {
 	ctx := context.Background()
 
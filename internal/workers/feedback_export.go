@@ -0,0 +1,51 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+
+	"github.com/formbricks/hub/internal/service"
+)
+
+// feedbackExportService is the minimal interface the export worker needs.
+type feedbackExportService interface {
+	RunExport(ctx context.Context, id uuid.UUID, finalAttempt bool) error
+}
+
+// feedbackExportTimeout bounds one export attempt. Exports page through the tenant's records
+// without holding a transaction, so a long one costs only the worker slot; past this River
+// rescues the job and the next attempt rewrites the file from scratch.
+const feedbackExportTimeout = 30 * time.Minute
+
+// FeedbackExportWorker writes one asynchronous feedback export (see service.ExportService).
+type FeedbackExportWorker struct {
+	river.WorkerDefaults[service.FeedbackExportArgs]
+
+	service feedbackExportService
+}
+
+// NewFeedbackExportWorker creates the export worker.
+func NewFeedbackExportWorker(svc feedbackExportService) *FeedbackExportWorker {
+	return &FeedbackExportWorker{service: svc}
+}
+
+// Timeout limits how long a single export attempt can run.
+func (w *FeedbackExportWorker) Timeout(*river.Job[service.FeedbackExportArgs]) time.Duration {
+	return feedbackExportTimeout
+}
+
+// Work runs the export. Only the final attempt marks the job failed, so a transient error leaves
+// it running (visible to pollers) while River retries.
+func (w *FeedbackExportWorker) Work(ctx context.Context, job *river.Job[service.FeedbackExportArgs]) error {
+	finalAttempt := job.Attempt >= job.MaxAttempts
+
+	if err := w.service.RunExport(ctx, job.Args.ExportJobID, finalAttempt); err != nil {
+		return fmt.Errorf("run export %s: %w", job.Args.ExportJobID, err)
+	}
+
+	return nil
+}
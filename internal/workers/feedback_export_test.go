@@ -0,0 +1,61 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+
+	"github.com/formbricks/hub/internal/service"
+)
+
+type recordingExportService struct {
+	id           uuid.UUID
+	finalAttempt bool
+	err          error
+}
+
+func (s *recordingExportService) RunExport(_ context.Context, id uuid.UUID, finalAttempt bool) error {
+	s.id = id
+	s.finalAttempt = finalAttempt
+
+	return s.err
+}
+
+func exportJob(id uuid.UUID, attempt int) *river.Job[service.FeedbackExportArgs] {
+	return &river.Job[service.FeedbackExportArgs]{
+		JobRow: &rivertype.JobRow{Attempt: attempt, MaxAttempts: 3},
+		Args:   service.FeedbackExportArgs{ExportJobID: id},
+	}
+}
+
+func TestFeedbackExportWorker_FinalAttempt(t *testing.T) {
+	id := uuid.New()
+
+	for _, tc := range []struct {
+		attempt int
+		want    bool
+	}{{1, false}, {2, false}, {3, true}} {
+		svc := &recordingExportService{}
+
+		if err := NewFeedbackExportWorker(svc).Work(context.Background(), exportJob(id, tc.attempt)); err != nil {
+			t.Fatalf("Work(attempt %d): %v", tc.attempt, err)
+		}
+
+		if svc.id != id || svc.finalAttempt != tc.want {
+			t.Fatalf("attempt %d: RunExport(%s, final=%v), want (%s, final=%v)",
+				tc.attempt, svc.id, svc.finalAttempt, id, tc.want)
+		}
+	}
+}
+
+func TestFeedbackExportWorker_ReturnsErrorForRetry(t *testing.T) {
+	svc := &recordingExportService{err: errors.New("disk full")}
+
+	if err := NewFeedbackExportWorker(svc).Work(context.Background(), exportJob(uuid.New(), 1)); err == nil {
+		t.Fatal("Work succeeded, want error so River retries")
+	}
+}
@@ -0,0 +1,55 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/formbricks/hub/internal/service"
+)
+
+const (
+	// feedbackRecordPurgeInterval is how often soft-deleted records past retention are purged.
+	// They are hidden from every read already, so the purge need not be prompt.
+	feedbackRecordPurgeInterval = time.Hour
+	// feedbackRecordPurgeBatchSize is the rows per DELETE of a purge.
+	feedbackRecordPurgeBatchSize = 500
+)
+
+// feedbackRecordPurgeRepo is the minimal interface the purge worker needs.
+type feedbackRecordPurgeRepo interface {
+	PurgeDeleted(ctx context.Context, cutoff time.Time, batchSize int) (int64, error)
+}
+
+// FeedbackRecordPurgeWorker hard-deletes feedback records soft-deleted longer ago than the
+// retention period.
+type FeedbackRecordPurgeWorker struct {
+	river.WorkerDefaults[service.FeedbackRecordPurgeArgs]
+
+	repo      feedbackRecordPurgeRepo
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewFeedbackRecordPurgeWorker creates the purge worker.
+func NewFeedbackRecordPurgeWorker(repo feedbackRecordPurgeRepo, retention time.Duration) *FeedbackRecordPurgeWorker {
+	return &FeedbackRecordPurgeWorker{repo: repo, retention: retention, now: time.Now}
+}
+
+// Work purges the records past retention. A failed purge is retried by River, and the next
+// periodic run picks up whatever a failed one left anyway.
+func (w *FeedbackRecordPurgeWorker) Work(ctx context.Context, _ *river.Job[service.FeedbackRecordPurgeArgs]) error {
+	purged, err := w.repo.PurgeDeleted(ctx, w.now().Add(-w.retention), feedbackRecordPurgeBatchSize)
+	if err != nil {
+		return fmt.Errorf("purge deleted feedback records: %w", err)
+	}
+
+	if purged > 0 {
+		slog.Info("feedback record purge: purged soft-deleted records past retention", "purged", purged)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/service"
+)
+
+type mockFeedbackRecordPurgeRepo struct {
+	cutoff    time.Time
+	batchSize int
+	err       error
+}
+
+func (m *mockFeedbackRecordPurgeRepo) PurgeDeleted(_ context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	m.cutoff = cutoff
+	m.batchSize = batchSize
+
+	return 2, m.err
+}
+
+func TestFeedbackRecordPurgeWorker_Work(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+	job := &river.Job[service.FeedbackRecordPurgeArgs]{}
+
+	t.Run("purges records deleted before the retention window", func(t *testing.T) {
+		repo := &mockFeedbackRecordPurgeRepo{}
+		worker := NewFeedbackRecordPurgeWorker(repo, retention)
+		worker.now = func() time.Time { return now }
+
+		require.NoError(t, worker.Work(t.Context(), job))
+		assert.Equal(t, now.Add(-retention), repo.cutoff)
+		assert.Equal(t, feedbackRecordPurgeBatchSize, repo.batchSize)
+	})
+
+	t.Run("a failed purge is retried", func(t *testing.T) {
+		worker := NewFeedbackRecordPurgeWorker(&mockFeedbackRecordPurgeRepo{err: errors.New("db down")}, retention)
+
+		require.Error(t, worker.Work(t.Context(), job))
+	})
+}
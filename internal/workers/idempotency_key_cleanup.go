@@ -0,0 +1,83 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/internal/service"
+)
+
+const (
+	// idempotencyKeyCleanupInterval is how often expired keys are swept. Expired keys are already
+	// ignored by lookups, so the sweep only bounds the table's size and need not be prompt.
+	idempotencyKeyCleanupInterval = time.Hour
+	// idempotencyKeyCleanupBatchSize is the rows per DELETE of a sweep.
+	idempotencyKeyCleanupBatchSize = 1000
+)
+
+// idempotencyKeyCleanupRepo is the minimal interface the cleanup worker needs.
+type idempotencyKeyCleanupRepo interface {
+	DeleteExpired(ctx context.Context, cutoff time.Time, batchSize int) (int64, error)
+}
+
+// IdempotencyKeyCleanupWorker deletes idempotency keys older than models.IdempotencyKeyTTL.
+type IdempotencyKeyCleanupWorker struct {
+	river.WorkerDefaults[service.IdempotencyKeyCleanupArgs]
+
+	repo idempotencyKeyCleanupRepo
+	now  func() time.Time
+}
+
+// NewIdempotencyKeyCleanupWorker creates the cleanup worker.
+func NewIdempotencyKeyCleanupWorker(repo idempotencyKeyCleanupRepo) *IdempotencyKeyCleanupWorker {
+	return &IdempotencyKeyCleanupWorker{repo: repo, now: time.Now}
+}
+
+// Work deletes the expired keys. A failed sweep is retried by River, and the next periodic run
+// picks up whatever a failed one left anyway.
+func (w *IdempotencyKeyCleanupWorker) Work(ctx context.Context, _ *river.Job[service.IdempotencyKeyCleanupArgs]) error {
+	deleted, err := w.repo.DeleteExpired(ctx, w.now().Add(-models.IdempotencyKeyTTL), idempotencyKeyCleanupBatchSize)
+	if err != nil {
+		return fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+
+	if deleted > 0 {
+		slog.Info("idempotency key cleanup: deleted expired keys", "deleted", deleted)
+	}
+
+	return nil
+}
+
+// NewPeriodicJobs returns the periodic jobs for the worker process's River client. River runs
+// periodic jobs on the elected leader only, so several worker replicas still sweep once per
+// interval. Only the worker process should configure them; hub-api's client is insert-only.
+func NewPeriodicJobs(deps RiverDeps) []*river.PeriodicJob {
+	var jobs []*river.PeriodicJob
+
+	if deps.IdempotencyKeysRepo != nil {
+		jobs = append(jobs, river.NewPeriodicJob(
+			river.PeriodicInterval(idempotencyKeyCleanupInterval),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return service.IdempotencyKeyCleanupArgs{}, nil
+			},
+			&river.PeriodicJobOpts{RunOnStart: true},
+		))
+	}
+
+	if deps.FeedbackRecordPurgeRepo != nil {
+		jobs = append(jobs, river.NewPeriodicJob(
+			river.PeriodicInterval(feedbackRecordPurgeInterval),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return service.FeedbackRecordPurgeArgs{}, nil
+			},
+			&river.PeriodicJobOpts{RunOnStart: true},
+		))
+	}
+
+	return jobs
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/internal/service"
+)
+
+type mockIdempotencyKeyCleanupRepo struct {
+	cutoff    time.Time
+	batchSize int
+	err       error
+}
+
+func (m *mockIdempotencyKeyCleanupRepo) DeleteExpired(_ context.Context, cutoff time.Time, batchSize int) (int64, error) {
+	m.cutoff = cutoff
+	m.batchSize = batchSize
+
+	return 3, m.err
+}
+
+func TestIdempotencyKeyCleanupWorker_Work(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	job := &river.Job[service.IdempotencyKeyCleanupArgs]{}
+
+	t.Run("deletes keys past the TTL", func(t *testing.T) {
+		repo := &mockIdempotencyKeyCleanupRepo{}
+		worker := NewIdempotencyKeyCleanupWorker(repo)
+		worker.now = func() time.Time { return now }
+
+		require.NoError(t, worker.Work(t.Context(), job))
+		assert.Equal(t, now.Add(-models.IdempotencyKeyTTL), repo.cutoff)
+		assert.Equal(t, idempotencyKeyCleanupBatchSize, repo.batchSize)
+	})
+
+	t.Run("a failed sweep is retried", func(t *testing.T) {
+		worker := NewIdempotencyKeyCleanupWorker(&mockIdempotencyKeyCleanupRepo{err: errors.New("db down")})
+
+		require.Error(t, worker.Work(t.Context(), job))
+	})
+}
+
+func TestNewPeriodicJobs(t *testing.T) {
+	assert.Empty(t, NewPeriodicJobs(RiverDeps{}))
+	assert.Len(t, NewPeriodicJobs(RiverDeps{IdempotencyKeysRepo: &mockIdempotencyKeyCleanupRepo{}}), 1)
+	assert.Len(t, NewPeriodicJobs(RiverDeps{
+		IdempotencyKeysRepo:     &mockIdempotencyKeyCleanupRepo{},
+		FeedbackRecordPurgeRepo: &mockFeedbackRecordPurgeRepo{},
+	}), 2)
+}
@@ -2,6 +2,7 @@ package workers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -19,6 +20,18 @@ import (
 // WebhookDeliveryTimeoutBuffer is added to HTTP timeout for the River job timeout.
 const WebhookDeliveryTimeoutBuffer = 5 * time.Second
 
+// Webhook retry backoff: the first retry waits webhookRetryBaseDelay, each later one twice the
+// previous, up to webhookRetryMaxDelay — so the default 3 attempts span 30s and 1m of waiting,
+// and a raised WEBHOOK_DELIVERY_MAX_ATTEMPTS keeps probing a down endpoint hourly.
+const (
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = time.Hour
+)
+
+// webhookHostBusySnooze is how long a delivery that found its host's slots all taken waits before
+// it is tried again. The snooze does not use up an attempt.
+const webhookHostBusySnooze = 5 * time.Second
+
 // WebhookDispatchWorker delivers one event to one webhook endpoint.
 type WebhookDispatchWorker struct {
 	river.WorkerDefaults[service.WebhookDispatchArgs]
@@ -33,6 +46,8 @@ type WebhookDispatchWorker struct {
 type webhookDispatchRepo interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error)
 	Update(ctx context.Context, id uuid.UUID, req *models.UpdateWebhookRequest) (*models.Webhook, error)
+	CreateDeadLetter(ctx context.Context, req *models.CreateWebhookDeadLetter) error
+	CreateDelivery(ctx context.Context, req *models.CreateWebhookDelivery) error
 }
 
 // NewWebhookDispatchWorker creates a worker that uses the given repo and sender.
@@ -55,6 +70,37 @@ func (w *WebhookDispatchWorker) Timeout(*river.Job[service.WebhookDispatchArgs])
 	return w.jobTimeout
 }
 
+// NextRetry schedules a failed delivery's next attempt with exponential backoff (see
+// webhookRetryBackoff) instead of River's default policy, so the delivery log can record exactly
+// when it is due.
+func (w *WebhookDispatchWorker) NextRetry(job *river.Job[service.WebhookDispatchArgs]) time.Time {
+	return webhookNextRetryAt(job)
+}
+
+// webhookNextRetryAt is when the attempt after job's current one runs: its start plus the backoff.
+func webhookNextRetryAt(job *river.Job[service.WebhookDispatchArgs]) time.Time {
+	attemptedAt := time.Now()
+	if job.AttemptedAt != nil {
+		attemptedAt = *job.AttemptedAt
+	}
+
+	return attemptedAt.Add(webhookRetryBackoff(job.Attempt))
+}
+
+// webhookRetryBackoff is the wait after failed attempt n (1-based).
+func webhookRetryBackoff(attempt int) time.Duration {
+	delay := webhookRetryBaseDelay
+	for range max(attempt-1, 0) {
+		if delay >= webhookRetryMaxDelay/2 {
+			return webhookRetryMaxDelay
+		}
+
+		delay *= 2
+	}
+
+	return delay
+}
+
 // Work loads the webhook, builds the payload, and sends once.
 func (w *WebhookDispatchWorker) Work(ctx context.Context, job *river.Job[service.WebhookDispatchArgs]) error {
 	args := job.Args
@@ -151,6 +197,8 @@ func (w *WebhookDispatchWorker) Work(ctx context.Context, job *river.Job[service
 
 	err = w.sender.Send(ctx, webhook, payload)
 	if err == nil {
+		w.recordDelivery(ctx, job, *tenantID, models.WebhookDeliveryStatusSuccess, nil, start)
+
 		if w.metrics != nil {
 			w.metrics.RecordDelivery(ctx, args.EventType, "success")
 			w.metrics.RecordWebhookDeliveryDuration(ctx, time.Since(start), args.EventType, "success")
@@ -159,6 +207,22 @@ func (w *WebhookDispatchWorker) Work(ctx context.Context, job *river.Job[service
 		return nil
 	}
 
+	// Nothing reached the endpoint: requeue instead of counting a failed attempt against it.
+	if errors.Is(err, service.ErrWebhookHostBusy) {
+		if w.metrics != nil {
+			w.metrics.RecordDispatchError(ctx, "host_busy")
+		}
+
+		slog.Warn("webhook dispatch: no delivery slot free for host, snoozing",
+			"event_id", args.EventID,
+			"webhook_id", webhook.ID,
+			"snooze", webhookHostBusySnooze,
+		)
+
+		//nolint:wrapcheck // river sentinel: JobSnooze must be returned unwrapped for River to detect the snooze
+		return river.JobSnooze(webhookHostBusySnooze)
+	}
+
 	// Send failed
 	isLastAttempt := job.Attempt >= job.MaxAttempts
 	if isLastAttempt {
@@ -168,8 +232,11 @@ func (w *WebhookDispatchWorker) Work(ctx context.Context, job *river.Job[service
 			w.metrics.RecordWebhookDeliveryDuration(ctx, time.Since(start), args.EventType, "failed_final")
 		}
 
+		w.recordDelivery(ctx, job, *tenantID, models.WebhookDeliveryStatusFailedFinal, err, start)
+		w.recordDeadLetter(ctx, job, *tenantID, err)
+
 		enabled := false
-		reason := err.Error()
+		reason := models.WebhookDisabledReasonDeliveryFailures
 		now := time.Now()
 
 		_, updateErr := w.repo.Update(ctx, webhook.ID, &models.UpdateWebhookRequest{
@@ -207,6 +274,8 @@ func (w *WebhookDispatchWorker) Work(ctx context.Context, job *river.Job[service
 		return fmt.Errorf("webhook send (final attempt): %w", err)
 	}
 
+	w.recordDelivery(ctx, job, *tenantID, models.WebhookDeliveryStatusRetry, err, start)
+
 	if w.metrics != nil {
 		w.metrics.RecordDelivery(ctx, args.EventType, "retry")
 		w.metrics.RecordWebhookDeliveryDuration(ctx, time.Since(start), args.EventType, "retry")
@@ -222,3 +291,90 @@ func (w *WebhookDispatchWorker) Work(ctx context.Context, job *river.Job[service
 
 	return fmt.Errorf("webhook send: %w", err)
 }
+
+// recordDelivery logs one attempt in the webhook's delivery log (GET /v1/webhooks/{id}/deliveries).
+// Like recordDeadLetter it never changes the job outcome: a failure to record is logged and
+// counted, and a webhook deleted or a tenant being purged meanwhile needs no record at all.
+func (w *WebhookDispatchWorker) recordDelivery(
+	ctx context.Context, job *river.Job[service.WebhookDispatchArgs], tenantID, status string, sendErr error, start time.Time,
+) {
+	args := job.Args
+	req := &models.CreateWebhookDelivery{
+		WebhookID:  args.WebhookID,
+		TenantID:   tenantID,
+		EventID:    args.EventID,
+		EventType:  args.EventType,
+		Attempt:    job.Attempt,
+		Status:     status,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+
+	if sendErr != nil {
+		message := sendErr.Error()
+		req.Error = &message
+		req.StatusCode = service.WebhookFailureStatus(sendErr)
+		req.ResponseBodyTruncated = service.WebhookFailureBody(sendErr)
+	}
+
+	if status == models.WebhookDeliveryStatusRetry {
+		nextRetryAt := webhookNextRetryAt(job)
+		req.NextRetryAt = &nextRetryAt
+	}
+
+	err := w.repo.CreateDelivery(ctx, req)
+	if err == nil || errors.Is(err, huberrors.ErrNotFound) || errors.Is(err, huberrors.ErrTenantWriteConflict) {
+		return
+	}
+
+	if w.metrics != nil {
+		w.metrics.RecordDispatchError(ctx, "delivery_record_failed")
+	}
+
+	slog.Error("webhook dispatch: failed to record delivery attempt",
+		"webhook_id", args.WebhookID,
+		"event_id", args.EventID,
+		"attempt", job.Attempt,
+		"error", err,
+	)
+}
+
+// recordDeadLetter persists a delivery that exhausted its attempts, so it outlives River's
+// discarded job and can be listed and retried. Failing to record it is logged and counted but
+// does not change the job outcome.
+func (w *WebhookDispatchWorker) recordDeadLetter(
+	ctx context.Context, job *river.Job[service.WebhookDispatchArgs], tenantID string, sendErr error,
+) {
+	args := job.Args
+
+	payload, err := json.Marshal(args)
+	if err == nil {
+		err = w.repo.CreateDeadLetter(ctx, &models.CreateWebhookDeadLetter{
+			WebhookID:  args.WebhookID,
+			TenantID:   tenantID,
+			EventID:    args.EventID,
+			EventType:  args.EventType,
+			Payload:    payload,
+			LastStatus: service.WebhookFailureStatus(sendErr),
+			Error:      sendErr.Error(),
+			Attempts:   job.Attempt,
+		})
+	}
+
+	if err != nil {
+		if w.metrics != nil {
+			w.metrics.RecordDispatchError(ctx, "dead_letter_record_failed")
+		}
+
+		slog.Error("webhook dispatch: failed to record dead letter",
+			"webhook_id", args.WebhookID,
+			"event_id", args.EventID,
+			"error", err,
+		)
+
+		return
+	}
+
+	if w.metrics != nil {
+		w.metrics.RecordDeadLetter(ctx, args.EventType)
+	}
+}
@@ -2,7 +2,9 @@ package workers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -19,8 +21,9 @@ import (
 // countingWebhookMetrics records how often each webhook metric fired, for
 // asserting that "disabled" is signaled only when the disable write succeeds.
 type countingWebhookMetrics struct {
-	disabled  int
-	delivered map[string]int
+	disabled    int
+	deadLetters int
+	delivered   map[string]int
 }
 
 func newCountingWebhookMetrics() *countingWebhookMetrics {
@@ -38,14 +41,19 @@ func (m *countingWebhookMetrics) RecordWebhookDeliveryDuration(
 	context.Context, time.Duration, string, string,
 ) {
 }
+func (m *countingWebhookMetrics) RecordDeadLetter(context.Context, string)          { m.deadLetters++ }
+func (m *countingWebhookMetrics) RecordFanOutCapped(context.Context, string, int64) {}
 
 var _ observability.WebhookMetrics = (*countingWebhookMetrics)(nil)
 
 type mockDispatchRepo struct {
-	webhook   *models.Webhook
-	err       error
-	update    *models.UpdateWebhookRequest
-	updateErr error
+	webhook       *models.Webhook
+	err           error
+	update        *models.UpdateWebhookRequest
+	updateErr     error
+	deadLetter    *models.CreateWebhookDeadLetter
+	deadLetterErr error
+	deliveries    []*models.CreateWebhookDelivery
 }
 
 func (m *mockDispatchRepo) GetByID(_ context.Context, _ uuid.UUID) (*models.Webhook, error) {
@@ -62,6 +70,18 @@ func (m *mockDispatchRepo) Update(_ context.Context, _ uuid.UUID, req *models.Up
 	return nil, nil
 }
 
+func (m *mockDispatchRepo) CreateDeadLetter(_ context.Context, req *models.CreateWebhookDeadLetter) error {
+	m.deadLetter = req
+
+	return m.deadLetterErr
+}
+
+func (m *mockDispatchRepo) CreateDelivery(_ context.Context, req *models.CreateWebhookDelivery) error {
+	m.deliveries = append(m.deliveries, req)
+
+	return nil
+}
+
 type mockSender struct {
 	err      error
 	calls    int
@@ -327,6 +347,10 @@ func TestWebhookDispatchWorker_Work(t *testing.T) {
 		if repo.update != nil {
 			t.Error("Update should not be called when attempt < max")
 		}
+
+		if repo.deadLetter != nil {
+			t.Error("CreateDeadLetter should not be called when attempt < max")
+		}
 	})
 
 	t.Run("updates webhook and returns error when send fails on last attempt", func(t *testing.T) {
@@ -353,7 +377,7 @@ func TestWebhookDispatchWorker_Work(t *testing.T) {
 			t.Error("Update should set Enabled = false")
 		}
 
-		if repo.update.DisabledReason == nil || *repo.update.DisabledReason != "final failure" {
+		if repo.update.DisabledReason == nil || *repo.update.DisabledReason != models.WebhookDisabledReasonDeliveryFailures {
 			t.Errorf("DisabledReason = %v", repo.update.DisabledReason)
 		}
 
@@ -410,6 +434,64 @@ func TestWebhookDispatchWorker_Work(t *testing.T) {
 		}
 	})
 
+	t.Run("records a dead letter when send fails on last attempt", func(t *testing.T) {
+		repo := &mockDispatchRepo{
+			webhook: &models.Webhook{ID: webhookID, Enabled: true, URL: "http://x", SigningKey: "sk", TenantID: &tenantID},
+		}
+		metrics := newCountingWebhookMetrics()
+		sender := &mockSender{err: fmt.Errorf("send: %w", &service.WebhookStatusError{StatusCode: 503})}
+		worker := NewWebhookDispatchWorker(repo, sender, 15*time.Second, metrics)
+		job := &river.Job[service.WebhookDispatchArgs]{
+			JobRow: &rivertype.JobRow{Attempt: 3, MaxAttempts: 3},
+			Args:   args,
+		}
+
+		_ = worker.Work(ctx, job)
+
+		dl := repo.deadLetter
+		if dl == nil {
+			t.Fatal("CreateDeadLetter should be called on last attempt failure")
+		}
+
+		if dl.WebhookID != webhookID || dl.EventID != eventID || dl.TenantID != tenantID || dl.Attempts != 3 {
+			t.Errorf("dead letter = %+v", dl)
+		}
+
+		if dl.LastStatus == nil || *dl.LastStatus != 503 {
+			t.Errorf("LastStatus = %v, want 503", dl.LastStatus)
+		}
+
+		var stored service.WebhookDispatchArgs
+		if err := json.Unmarshal(dl.Payload, &stored); err != nil || stored.EventID != eventID {
+			t.Errorf("Payload = %s (err %v), want the job args", dl.Payload, err)
+		}
+
+		if metrics.deadLetters != 1 {
+			t.Errorf("RecordDeadLetter called %d times, want 1", metrics.deadLetters)
+		}
+	})
+
+	t.Run("still disables the webhook when the dead letter cannot be recorded", func(t *testing.T) {
+		repo := &mockDispatchRepo{
+			webhook:       &models.Webhook{ID: webhookID, Enabled: true, URL: "http://x", SigningKey: "sk", TenantID: &tenantID},
+			deadLetterErr: errors.New("db down"),
+		}
+		metrics := newCountingWebhookMetrics()
+		worker := NewWebhookDispatchWorker(repo, &mockSender{err: errors.New("final failure")}, 15*time.Second, metrics)
+		job := &river.Job[service.WebhookDispatchArgs]{
+			JobRow: &rivertype.JobRow{Attempt: 3, MaxAttempts: 3},
+			Args:   args,
+		}
+
+		if err := worker.Work(ctx, job); err == nil {
+			t.Error("Work() error = nil, want the send error")
+		}
+
+		if repo.update == nil || metrics.deadLetters != 0 {
+			t.Errorf("update = %v, dead letters recorded = %d; want disable attempted and none recorded", repo.update, metrics.deadLetters)
+		}
+	})
+
 	t.Run("does not record disabled when disable is skipped during purge", func(t *testing.T) {
 		repo := &mockDispatchRepo{
 			webhook:   &models.Webhook{ID: webhookID, Enabled: true, URL: "http://x", SigningKey: "sk", TenantID: &tenantID},
@@ -435,6 +517,131 @@ func TestWebhookDispatchWorker_Work(t *testing.T) {
 	})
 }
 
+func TestWebhookDispatchWorker_RecordsEveryAttempt(t *testing.T) {
+	ctx := context.Background()
+	tenantID := "org-123"
+	webhookID := uuid.Must(uuid.NewV7())
+	args := service.WebhookDispatchArgs{
+		EventID: uuid.Must(uuid.NewV7()), EventType: "feedback_record.created", Timestamp: time.Now(),
+		TenantID: &tenantID, WebhookID: webhookID,
+	}
+	webhook := &models.Webhook{ID: webhookID, Enabled: true, URL: "http://x", SigningKey: "sk", TenantID: &tenantID}
+	attemptedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("a failed attempt records status, body and the next retry", func(t *testing.T) {
+		repo := &mockDispatchRepo{webhook: webhook}
+		sender := &mockSender{err: fmt.Errorf("send: %w", &service.WebhookStatusError{StatusCode: 502, Body: "bad gateway"})}
+		worker := NewWebhookDispatchWorker(repo, sender, 15*time.Second, nil)
+		job := &river.Job[service.WebhookDispatchArgs]{
+			JobRow: &rivertype.JobRow{Attempt: 2, MaxAttempts: 3, AttemptedAt: &attemptedAt},
+			Args:   args,
+		}
+
+		if err := worker.Work(ctx, job); err == nil {
+			t.Fatal("Work() error = nil, want the send error (River retries)")
+		}
+
+		if len(repo.deliveries) != 1 {
+			t.Fatalf("recorded %d deliveries, want 1", len(repo.deliveries))
+		}
+
+		d := repo.deliveries[0]
+		if d.Status != models.WebhookDeliveryStatusRetry || d.Attempt != 2 || d.TenantID != tenantID {
+			t.Errorf("delivery = %+v, want a retry of attempt 2", d)
+		}
+
+		if d.StatusCode == nil || *d.StatusCode != 502 || d.ResponseBodyTruncated == nil || *d.ResponseBodyTruncated != "bad gateway" {
+			t.Errorf("status code = %v, body = %v; want 502 and the response body", d.StatusCode, d.ResponseBodyTruncated)
+		}
+
+		want := attemptedAt.Add(time.Minute)
+		if d.NextRetryAt == nil || !d.NextRetryAt.Equal(want) || !worker.NextRetry(job).Equal(want) {
+			t.Errorf("next retry = %v (NextRetry %v), want %v", d.NextRetryAt, worker.NextRetry(job), want)
+		}
+	})
+
+	t.Run("a successful attempt records success without error", func(t *testing.T) {
+		repo := &mockDispatchRepo{webhook: webhook}
+		worker := NewWebhookDispatchWorker(repo, &mockSender{}, 15*time.Second, nil)
+		job := &river.Job[service.WebhookDispatchArgs]{JobRow: &rivertype.JobRow{Attempt: 1, MaxAttempts: 3}, Args: args}
+
+		if err := worker.Work(ctx, job); err != nil {
+			t.Fatalf("Work() error = %v", err)
+		}
+
+		if len(repo.deliveries) != 1 || repo.deliveries[0].Status != models.WebhookDeliveryStatusSuccess ||
+			repo.deliveries[0].Error != nil || repo.deliveries[0].NextRetryAt != nil {
+			t.Errorf("deliveries = %+v, want one success without error or retry", repo.deliveries)
+		}
+	})
+
+	t.Run("the final attempt records failed_final without a retry", func(t *testing.T) {
+		repo := &mockDispatchRepo{webhook: webhook}
+		worker := NewWebhookDispatchWorker(repo, &mockSender{err: errors.New("dial tcp: refused")}, 15*time.Second, nil)
+		job := &river.Job[service.WebhookDispatchArgs]{JobRow: &rivertype.JobRow{Attempt: 3, MaxAttempts: 3}, Args: args}
+
+		_ = worker.Work(ctx, job)
+
+		if len(repo.deliveries) != 1 || repo.deliveries[0].Status != models.WebhookDeliveryStatusFailedFinal ||
+			repo.deliveries[0].NextRetryAt != nil || repo.deliveries[0].StatusCode != nil {
+			t.Errorf("deliveries = %+v, want one failed_final without status code or retry", repo.deliveries)
+		}
+	})
+}
+
+// A delivery that never got a per-host slot sent nothing, so it must be snoozed without recording
+// a failed attempt, a dead letter, or disabling the webhook — even on its last attempt.
+func TestWebhookDispatchWorker_HostBusySnoozes(t *testing.T) {
+	tenantID := "org-123"
+	webhookID := uuid.Must(uuid.NewV7())
+	args := service.WebhookDispatchArgs{
+		EventID: uuid.Must(uuid.NewV7()), EventType: "feedback_record.created", Timestamp: time.Now(),
+		TenantID: &tenantID, WebhookID: webhookID,
+	}
+	repo := &mockDispatchRepo{
+		webhook: &models.Webhook{ID: webhookID, Enabled: true, URL: "http://x", SigningKey: "sk", TenantID: &tenantID},
+	}
+	metrics := newCountingWebhookMetrics()
+	sender := &mockSender{err: fmt.Errorf("%w: %w", service.ErrWebhookHostBusy, context.DeadlineExceeded)}
+	worker := NewWebhookDispatchWorker(repo, sender, 15*time.Second, metrics)
+	job := &river.Job[service.WebhookDispatchArgs]{JobRow: &rivertype.JobRow{Attempt: 3, MaxAttempts: 3}, Args: args}
+
+	err := worker.Work(t.Context(), job)
+
+	var snooze *river.JobSnoozeError
+	if !errors.As(err, &snooze) {
+		t.Fatalf("Work() error = %v, want *river.JobSnoozeError", err)
+	}
+
+	if len(repo.deliveries) != 0 || repo.deadLetter != nil || repo.update != nil {
+		t.Errorf("deliveries = %d, dead letter = %v, update = %v; want none", len(repo.deliveries), repo.deadLetter, repo.update)
+	}
+
+	if len(metrics.delivered) != 0 {
+		t.Errorf("deliveries recorded in metrics = %v, want none", metrics.delivered)
+	}
+}
+
+func TestWebhookRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 3, want: 2 * time.Minute},
+		{attempt: 7, want: 32 * time.Minute},
+		{attempt: 8, want: time.Hour},
+		{attempt: 25, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := webhookRetryBackoff(tt.attempt); got != tt.want {
+			t.Errorf("webhookRetryBackoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
 func TestWebhookDispatchWorker_Timeout(t *testing.T) {
 	worker := NewWebhookDispatchWorker(nil, nil, 15*time.Second, nil)
 	job := &river.Job[service.WebhookDispatchArgs]{JobRow: &rivertype.JobRow{}}
@@ -24,6 +24,7 @@ type RiverDeps struct {
 	EmbeddingClient    service.EmbeddingClient
 	EmbeddingDocPrefix string
 	EmbeddingMetrics   observability.EmbeddingMetrics
+	EmbeddingSecondary *service.SecondaryEmbeddingModel // optional A/B model (EMBEDDING_SECONDARY_MODEL)
 
 	// Translation worker (optional; if TranslationClient is nil, translation worker is not registered)
 	TranslationService translationWorkerService
@@ -44,6 +45,18 @@ type RiverDeps struct {
 	EmotionsResolver tenantSettingsReader
 	EmotionsClient   service.EmotionsClient
 	EmotionsMetrics  observability.EmotionsMetrics
+
+	// Export worker (optional; if ExportService is nil, export worker is not registered)
+	ExportService feedbackExportService
+
+	// Idempotency key cleanup (optional; if IdempotencyKeysRepo is nil, neither the worker nor its
+	// periodic job is registered — see NewPeriodicJobs)
+	IdempotencyKeysRepo idempotencyKeyCleanupRepo
+
+	// Soft-deleted feedback record purge (optional; if FeedbackRecordPurgeRepo is nil, neither the
+	// worker nor its periodic job is registered). FeedbackRetention is FEEDBACK_RETENTION_DAYS.
+	FeedbackRecordPurgeRepo feedbackRecordPurgeRepo
+	FeedbackRetention       time.Duration
 }
 
 // NewRiverWorkersAndQueues builds River workers and queue config from cfg and deps.
@@ -77,7 +90,9 @@ func NewRiverWorkersAndQueues(
 
 	if deps.EmbeddingClient != nil {
 		embeddingWorker := NewFeedbackEmbeddingWorker(deps.EmbeddingService, deps.EmbeddingClient, deps.EmbeddingDocPrefix, deps.EmbeddingMetrics)
+		embeddingWorker.SetSecondaryModel(deps.EmbeddingSecondary)
 		river.AddWorker(workers, embeddingWorker)
+		river.AddWorker(workers, NewFeedbackEmbeddingBatchWorker(embeddingWorker))
 
 		queues[service.EmbeddingsQueueName] = river.QueueConfig{MaxWorkers: maxEmbedding}
 	}
@@ -110,5 +125,20 @@ func NewRiverWorkersAndQueues(
 		queues[service.EmotionsQueueName] = river.QueueConfig{MaxWorkers: maxEmotions}
 	}
 
+	if deps.ExportService != nil {
+		river.AddWorker(workers, NewFeedbackExportWorker(deps.ExportService))
+
+		// Exports are few and long; one per worker process keeps them off the enrichment budget.
+		queues[service.ExportsQueueName] = river.QueueConfig{MaxWorkers: 1}
+	}
+
+	if deps.IdempotencyKeysRepo != nil {
+		river.AddWorker(workers, NewIdempotencyKeyCleanupWorker(deps.IdempotencyKeysRepo))
+	}
+
+	if deps.FeedbackRecordPurgeRepo != nil {
+		river.AddWorker(workers, NewFeedbackRecordPurgeWorker(deps.FeedbackRecordPurgeRepo, deps.FeedbackRetention))
+	}
+
 	return workers, queues
 }
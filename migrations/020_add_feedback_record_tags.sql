@@ -0,0 +1,22 @@
+-- +goose NO TRANSACTION
+-- +goose up
+-- tags are analyst-curated, free-form labels on a feedback record — the human counterpart to the
+-- AI-derived taxonomy. Hub normalizes them on write (trimmed, lowercased, de-duplicated) so "Bug"
+-- and "bug " facet as one label; the array is never NULL, an untagged record carries '{}'.
+--
+-- Runs without a transaction, like the other feedback_records column/index migrations, so it
+-- never holds a long lock on the primary, high-write table:
+--   * ADD COLUMN with a constant default is metadata-only (instant) since Postgres 11.
+--   * the index is built CONCURRENTLY (no ACCESS EXCLUSIVE / write block).
+-- Every statement is RE-RUNNABLE (see 018) so an interrupted deploy re-runs the file cleanly.
+ALTER TABLE feedback_records ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- The tag list filter is array containment (tags @> $N for "all", tags && $N for "any"), which a
+-- GIN index serves directly; the tenant_id equality that accompanies it is combined by a bitmap AND
+-- with the existing tenant indexes. DROP-then-CREATE so a re-run replaces an INVALID leftover.
+DROP INDEX CONCURRENTLY IF EXISTS idx_feedback_records_tags;
+CREATE INDEX CONCURRENTLY idx_feedback_records_tags ON feedback_records USING gin (tags);
+
+-- +goose down
+DROP INDEX CONCURRENTLY IF EXISTS idx_feedback_records_tags;
+ALTER TABLE feedback_records DROP COLUMN IF EXISTS tags;
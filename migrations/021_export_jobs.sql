@@ -0,0 +1,38 @@
+-- +goose up
+-- Asynchronous feedback exports (POST /v1/feedback-records/export). The API inserts a pending row
+-- and a River job; hub-worker streams the matching records into a CSV/JSONL file under EXPORT_DIR
+-- and records the outcome here. filters is the export's list-filter snapshot, so a retried job
+-- exports the same selection. The file itself is not stored in Postgres — file_name is relative to
+-- EXPORT_DIR, which both processes must mount.
+CREATE TYPE export_job_status_enum AS ENUM (
+  'pending',
+  'running',
+  'completed',
+  'failed'
+);
+
+CREATE TABLE export_jobs (
+  id UUID PRIMARY KEY DEFAULT uuidv7(),
+  tenant_id VARCHAR(255) NOT NULL,
+  format VARCHAR(16) NOT NULL,
+  filters JSONB NOT NULL DEFAULT '{}'::jsonb,
+  status export_job_status_enum NOT NULL DEFAULT 'pending',
+  record_count BIGINT NOT NULL DEFAULT 0,
+  file_name TEXT,
+  error TEXT,
+  started_at TIMESTAMPTZ,
+  finished_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT export_jobs_tenant_id_required CHECK (btrim(tenant_id) <> ''),
+  CONSTRAINT export_jobs_format_valid CHECK (format IN ('csv', 'jsonl')),
+  CONSTRAINT export_jobs_filters_object CHECK (jsonb_typeof(filters) = 'object'),
+  CONSTRAINT export_jobs_record_count_nonnegative CHECK (record_count >= 0)
+);
+
+-- Tenant purge deletes by tenant_id.
+CREATE INDEX idx_export_jobs_tenant_created_at ON export_jobs (tenant_id, created_at DESC);
+
+-- +goose down
+DROP TABLE IF EXISTS export_jobs;
+DROP TYPE IF EXISTS export_job_status_enum;
@@ -0,0 +1,9 @@
+-- +goose up
+-- Taxonomy nodes can be moved to a new parent (POST /v1/taxonomy/nodes/{node_id}/move); each move
+-- is recorded in taxonomy_node_events like renames and soft-removes. Adding an enum value is safe
+-- inside the migration transaction since Postgres 12 (the value is only used after commit).
+ALTER TYPE taxonomy_node_event_type_enum ADD VALUE IF NOT EXISTS 'move';
+
+-- +goose down
+-- Postgres cannot drop an enum value. 'move' stays defined; without the API nothing writes it.
+SELECT 1;
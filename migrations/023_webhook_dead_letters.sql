@@ -0,0 +1,29 @@
+-- +goose up
+-- Permanently failed webhook deliveries. When a webhook_dispatch job exhausts its attempts the
+-- worker records the delivery here (the job's args, the endpoint's last status and the error)
+-- before River discards the job, so operators can list what a downstream missed
+-- (GET /v1/webhooks/{id}/dead-letters) and re-enqueue it (POST .../dead-letters/{id}/retry).
+-- Rows go with their webhook; a tenant purge deletes the tenant's webhooks and so these too.
+CREATE TABLE webhook_dead_letters (
+  id UUID PRIMARY KEY DEFAULT uuidv7(),
+  webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+  tenant_id VARCHAR(255) NOT NULL,
+  event_id UUID NOT NULL,
+  event_type VARCHAR(64) NOT NULL,
+  payload JSONB NOT NULL,
+  last_status INTEGER,
+  error TEXT NOT NULL,
+  attempts INTEGER NOT NULL,
+  retried_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT webhook_dead_letters_tenant_id_required CHECK (btrim(tenant_id) <> ''),
+  CONSTRAINT webhook_dead_letters_payload_object CHECK (jsonb_typeof(payload) = 'object'),
+  CONSTRAINT webhook_dead_letters_attempts_positive CHECK (attempts > 0)
+);
+
+-- Listing is per webhook, newest first (keyset on created_at, id).
+CREATE INDEX idx_webhook_dead_letters_webhook_created_at
+  ON webhook_dead_letters (webhook_id, created_at DESC, id);
+
+-- +goose down
+DROP TABLE IF EXISTS webhook_dead_letters;
@@ -0,0 +1,32 @@
+-- +goose up
+-- Every webhook delivery attempt, so an operator can see why an endpoint stopped receiving events
+-- (GET /v1/webhooks/{id}/deliveries) without searching the worker logs. The dispatch worker writes
+-- one row per attempt: its outcome, the endpoint's status and the start of its response body,
+-- the error, and when the next attempt is due. Only the latest attempts of each webhook are kept
+-- (the insert trims the rest), so the table stays bounded however busy a webhook is. Rows go with
+-- their webhook; a tenant purge deletes the tenant's webhooks and so these too.
+CREATE TABLE webhook_deliveries (
+  id UUID PRIMARY KEY DEFAULT uuidv7(),
+  webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+  tenant_id VARCHAR(255) NOT NULL,
+  event_id UUID NOT NULL,
+  event_type VARCHAR(64) NOT NULL,
+  attempt INTEGER NOT NULL,
+  status VARCHAR(16) NOT NULL,
+  status_code INTEGER,
+  response_body_truncated TEXT,
+  error TEXT,
+  duration_ms BIGINT NOT NULL,
+  next_retry_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT webhook_deliveries_tenant_id_required CHECK (btrim(tenant_id) <> ''),
+  CONSTRAINT webhook_deliveries_attempt_positive CHECK (attempt > 0),
+  CONSTRAINT webhook_deliveries_status_valid CHECK (status IN ('success', 'retry', 'failed_final'))
+);
+
+-- Listing and trimming are per webhook, newest first (keyset on created_at, id).
+CREATE INDEX idx_webhook_deliveries_webhook_created_at
+  ON webhook_deliveries (webhook_id, created_at DESC, id);
+
+-- +goose down
+DROP TABLE IF EXISTS webhook_deliveries;
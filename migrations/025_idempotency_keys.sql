@@ -0,0 +1,22 @@
+-- +goose up
+-- Idempotency keys of create requests (the Idempotency-Key header on POST /v1/feedback-records),
+-- so a client retrying a create whose response it never saw gets the original record back
+-- instead of a duplicate. Only the SHA-256 of the key is stored. A key is honored for 24 hours;
+-- the worker's periodic cleanup job deletes older rows, and a row goes with its record (so a
+-- tenant purge or an erasure takes the keys of the deleted records too).
+CREATE TABLE idempotency_keys (
+  key_hash CHAR(64) NOT NULL,
+  endpoint VARCHAR(64) NOT NULL,
+  feedback_record_id UUID NOT NULL REFERENCES feedback_records(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (key_hash, endpoint)
+);
+
+-- The cleanup job deletes by age.
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys (created_at);
+
+-- ON DELETE CASCADE looks keys up by record.
+CREATE INDEX idx_idempotency_keys_feedback_record_id ON idempotency_keys (feedback_record_id);
+
+-- +goose down
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,38 @@
+-- +goose NO TRANSACTION
+-- +goose up
+-- DELETE /v1/feedback-records/{id} soft-deletes: it stamps deleted_at instead of removing the row,
+-- so an accidental delete stays recoverable until the worker's retention purge hard-deletes rows
+-- deleted more than FEEDBACK_RETENTION_DAYS ago. Every read excludes soft-deleted rows unless it
+-- asks for them (include_deleted=true on list). User erasure (DELETE /v1/feedback-records?user_id=,
+-- POST /v1/gdpr/erase) and tenant data purges stay hard deletes and take soft-deleted rows too.
+--
+-- Runs without a transaction, like the other feedback_records column/index migrations (see 018):
+--   * ADD COLUMN of a nullable column with no default is metadata-only (instant).
+--   * the indexes are built CONCURRENTLY (no ACCESS EXCLUSIVE / write block).
+-- Every statement is RE-RUNNABLE so an interrupted deploy re-runs the file cleanly.
+ALTER TABLE feedback_records ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- The retention purge scans by deletion time. Soft-deleted rows are a small minority, so a
+-- PARTIAL index over them stays small and off the write path of live records.
+DROP INDEX CONCURRENTLY IF EXISTS idx_feedback_records_deleted_at;
+CREATE INDEX CONCURRENTLY idx_feedback_records_deleted_at
+  ON feedback_records (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- One value per field_id per submission per tenant now holds among LIVE records only, so a
+-- submission whose record was deleted can be ingested again while the deleted row awaits its
+-- purge. The partial replacement is built before the full index is dropped, so uniqueness is
+-- enforced throughout.
+DROP INDEX CONCURRENTLY IF EXISTS idx_feedback_records_tenant_submission_field_live;
+CREATE UNIQUE INDEX CONCURRENTLY idx_feedback_records_tenant_submission_field_live
+  ON feedback_records (tenant_id, submission_id, field_id) NULLS NOT DISTINCT WHERE deleted_at IS NULL;
+DROP INDEX CONCURRENTLY IF EXISTS idx_feedback_records_tenant_submission_field;
+
+-- +goose down
+-- Without deleted_at the soft-deleted rows would come back to life; they were deleted as far as
+-- any client knows, so the down migration finishes the deletes before restoring the full index.
+DELETE FROM feedback_records WHERE deleted_at IS NOT NULL;
+CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS idx_feedback_records_tenant_submission_field
+  ON feedback_records (tenant_id, submission_id, field_id) NULLS NOT DISTINCT;
+DROP INDEX CONCURRENTLY IF EXISTS idx_feedback_records_tenant_submission_field_live;
+DROP INDEX CONCURRENTLY IF EXISTS idx_feedback_records_deleted_at;
+ALTER TABLE feedback_records DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,9 @@
+-- +goose up
+-- Taxonomy nodes can be merged into another node (POST /v1/taxonomy/nodes/{node_id}/merge): the
+-- merged node is soft-removed and its merge recorded in taxonomy_node_events like moves and
+-- removes. Adding an enum value is safe inside the migration transaction since Postgres 12.
+ALTER TYPE taxonomy_node_event_type_enum ADD VALUE IF NOT EXISTS 'merge';
+
+-- +goose down
+-- Postgres cannot drop an enum value. 'merge' stays defined; without the API nothing writes it.
+SELECT 1;
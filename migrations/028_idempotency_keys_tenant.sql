@@ -0,0 +1,27 @@
+-- +goose up
+-- Idempotency keys are scoped to the tenant of the request: two tenants may use the same
+-- Idempotency-Key independently, and a key never looks up, replaces or reveals another tenant's
+-- record. Existing keys take the tenant of their record; the table only holds 24 hours of keys,
+-- so the backfill is small.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255);
+
+UPDATE idempotency_keys ik
+SET tenant_id = fr.tenant_id
+FROM feedback_records fr
+WHERE fr.id = ik.feedback_record_id AND ik.tenant_id IS NULL;
+
+ALTER TABLE idempotency_keys ALTER COLUMN tenant_id SET NOT NULL;
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (tenant_id, key_hash, endpoint);
+
+-- +goose down
+-- Without tenant_id two tenants' rows for one key would collide; keep the newest of each.
+DELETE FROM idempotency_keys ik
+USING idempotency_keys newer
+WHERE newer.key_hash = ik.key_hash AND newer.endpoint = ik.endpoint
+  AND (newer.created_at, newer.tenant_id) > (ik.created_at, ik.tenant_id);
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (key_hash, endpoint);
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS tenant_id;
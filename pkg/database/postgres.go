@@ -22,6 +22,23 @@ type PoolConfig struct {
 	ConnectTimeout    time.Duration
 }
 
+// ConnectRetry is the initial-connect retry policy of NewPostgresPoolWithRetry.
+type ConnectRetry struct {
+	// Attempts is how many times the initial connect is tried before giving up (<= 1 = a single
+	// attempt, no retry).
+	Attempts int
+	// MaxWait caps the total time spent retrying, so a database that never comes up still fails
+	// startup (0 = bounded by Attempts only).
+	MaxWait time.Duration
+}
+
+// Initial-connect backoff: the delay doubles from connectRetryBaseDelay per attempt, capped at
+// connectRetryMaxDelay so a long outage is polled at a steady rate rather than ever more slowly.
+const (
+	connectRetryBaseDelay = 500 * time.Millisecond
+	connectRetryMaxDelay  = 5 * time.Second
+)
+
 // PoolOption configures the connection pool.
 type PoolOption func(*pgxpool.Config)
 
@@ -70,8 +87,18 @@ func WithAfterConnect(fn func(context.Context, *pgx.Conn) error) PoolOption {
 	}
 }
 
-// NewPostgresPool creates a new PostgreSQL connection pool.
+// NewPostgresPool creates a new PostgreSQL connection pool, failing if the first ping does.
 func NewPostgresPool(ctx context.Context, databaseURL string, opts ...PoolOption) (*pgxpool.Pool, error) {
+	return NewPostgresPoolWithRetry(ctx, databaseURL, ConnectRetry{}, opts...)
+}
+
+// NewPostgresPoolWithRetry creates a new PostgreSQL connection pool like NewPostgresPool, but
+// retries the initial ping per retry, so a process started alongside its database in compose/k8s
+// waits for it instead of crash-looping. The retry is bounded by attempts and total wait, so a
+// database that never comes up still fails startup.
+func NewPostgresPoolWithRetry(
+	ctx context.Context, databaseURL string, retry ConnectRetry, opts ...PoolOption,
+) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(databaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse database URL: %w", err)
@@ -86,7 +113,9 @@ func NewPostgresPool(ctx context.Context, databaseURL string, opts ...PoolOption
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
 
-	if err := pool.Ping(ctx); err != nil {
+	if err := pingWithRetry(ctx, pool, retry.Attempts, retry.MaxWait); err != nil {
+		pool.Close()
+
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
@@ -94,3 +123,53 @@ func NewPostgresPool(ctx context.Context, databaseURL string, opts ...PoolOption
 
 	return pool, nil
 }
+
+// pingWithRetry pings until success, attempts are exhausted, maxWait has elapsed, or ctx ends.
+// Each failed attempt is logged with the delay before the next one.
+func pingWithRetry(ctx context.Context, pool *pgxpool.Pool, attempts int, maxWait time.Duration) error {
+	attempts = max(attempts, 1)
+
+	var deadline time.Time
+	if maxWait > 0 {
+		deadline = time.Now().Add(maxWait)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := pool.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= attempts {
+			return err
+		}
+
+		delay := connectRetryDelay(attempt)
+		if !deadline.IsZero() && time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("gave up after %d attempts (max wait %s): %w", attempt, maxWait, err)
+		}
+
+		slog.Warn("database not ready, retrying connect",
+			"attempt", attempt, "max_attempts", attempts, "retry_in", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return fmt.Errorf("connect retry canceled: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// connectRetryDelay returns the backoff before retry number attempt (1-based): base * 2^(attempt-1),
+// capped at connectRetryMaxDelay.
+func connectRetryDelay(attempt int) time.Duration {
+	delay := connectRetryBaseDelay
+	for i := 1; i < attempt && delay < connectRetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, connectRetryMaxDelay)
+}
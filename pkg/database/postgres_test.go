@@ -0,0 +1,26 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConnectRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 500 * time.Millisecond},
+		{attempt: 2, want: time.Second},
+		{attempt: 3, want: 2 * time.Second},
+		{attempt: 4, want: 4 * time.Second},
+		{attempt: 5, want: connectRetryMaxDelay},
+		{attempt: 50, want: connectRetryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := connectRetryDelay(tt.attempt); got != tt.want {
+			t.Errorf("connectRetryDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,458 @@
+// Package jsonpatch applies RFC 6902 JSON Patch documents to JSON values. It supports every
+// operation (add, remove, replace, move, copy, test) with RFC 6901 JSON Pointer paths; patches
+// are applied in order and atomically — on any error the input document is left untouched.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPatch is returned for a malformed operation: unknown op, bad pointer, or a
+	// missing value/from member.
+	ErrInvalidPatch = errors.New("invalid json patch")
+	// ErrPathNotFound is returned when an operation references a location that does not exist
+	// (or, for add, whose parent does not exist).
+	ErrPathNotFound = errors.New("json patch path not found")
+	// ErrTestFailed is returned when a test operation's value does not match the document.
+	ErrTestFailed = errors.New("json patch test failed")
+)
+
+// Operation names (RFC 6902 section 4).
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+// Operation is one JSON Patch operation. Value is nil when the member is absent and the literal
+// null when it is JSON null, so "add null" and "add with no value" stay distinguishable.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch is an ordered list of operations (the application/json-patch+json body).
+type Patch []Operation
+
+// Writes reports whether the operation modifies the document (every op except test).
+func (o Operation) Writes() bool {
+	return o.Op != OpTest
+}
+
+// Validate checks every operation's shape without a document: a known op, well-formed
+// pointers, and the value/from members the op requires.
+func (p Patch) Validate() error {
+	for i, op := range p {
+		if err := op.validate(); err != nil {
+			return fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (o Operation) validate() error {
+	if _, err := ParsePointer(o.Path); err != nil {
+		return err
+	}
+
+	switch o.Op {
+	case OpAdd, OpReplace, OpTest:
+		if o.Value == nil {
+			return fmt.Errorf("%w: %s requires a value", ErrInvalidPatch, o.Op)
+		}
+	case OpMove, OpCopy:
+		if _, err := ParsePointer(o.From); err != nil {
+			return err
+		}
+	case OpRemove:
+	default:
+		return fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, o.Op)
+	}
+
+	return nil
+}
+
+// Apply applies p to the JSON document doc and returns the patched document.
+func (p Patch) Apply(doc []byte) ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	root, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("decode document: %w", err)
+	}
+
+	for i, op := range p {
+		root, err = op.apply(root)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	out, err := json.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("encode document: %w", err)
+	}
+
+	return out, nil
+}
+
+// ParsePointer splits an RFC 6901 JSON Pointer into its unescaped reference tokens. The empty
+// pointer (the whole document) yields no tokens.
+func ParsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: pointer %q must start with /", ErrInvalidPatch, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		if strings.Contains(strings.ReplaceAll(strings.ReplaceAll(token, "~0", ""), "~1", ""), "~") {
+			return nil, fmt.Errorf("%w: pointer %q has an invalid ~ escape", ErrInvalidPatch, pointer)
+		}
+
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func (o Operation) apply(root any) (any, error) {
+	path, _ := ParsePointer(o.Path) // validated
+
+	switch o.Op {
+	case OpAdd:
+		value, err := decode(o.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return add(root, path, value)
+	case OpRemove:
+		return remove(root, path)
+	case OpReplace:
+		value, err := decode(o.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return replace(root, path, value)
+	case OpMove:
+		from, _ := ParsePointer(o.From)
+		if isPrefix(from, path) && len(from) < len(path) {
+			return nil, fmt.Errorf("%w: cannot move %q into its own child", ErrInvalidPatch, o.From)
+		}
+
+		value, err := get(root, from)
+		if err != nil {
+			return nil, err
+		}
+
+		if root, err = remove(root, from); err != nil {
+			return nil, err
+		}
+
+		return add(root, path, value)
+	case OpCopy:
+		from, _ := ParsePointer(o.From)
+
+		value, err := get(root, from)
+		if err != nil {
+			return nil, err
+		}
+
+		return add(root, path, deepCopy(value))
+	default: // OpTest
+		want, err := decode(o.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		got, err := get(root, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !equal(got, want) {
+			return nil, ErrTestFailed
+		}
+
+		return root, nil
+	}
+}
+
+func add(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return mutate(root, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			container[token] = value
+
+			return container, nil
+		case []any:
+			index := len(container)
+			if token != "-" {
+				var err error
+				if index, err = arrayIndex(token, len(container)+1); err != nil {
+					return nil, err
+				}
+			}
+
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+
+			return container, nil
+		default:
+			return nil, ErrPathNotFound
+		}
+	})
+}
+
+func remove(root any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+
+	return mutate(root, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			if _, ok := container[token]; !ok {
+				return nil, ErrPathNotFound
+			}
+
+			delete(container, token)
+
+			return container, nil
+		case []any:
+			index, err := arrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+
+			return append(container[:index], container[index+1:]...), nil
+		default:
+			return nil, ErrPathNotFound
+		}
+	})
+}
+
+func replace(root any, path []string, value any) (any, error) {
+	if _, err := get(root, path); err != nil {
+		return nil, err
+	}
+
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return mutate(root, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			container[token] = value
+
+			return container, nil
+		case []any:
+			index, _ := arrayIndex(token, len(container)) // existence checked above
+			container[index] = value
+
+			return container, nil
+		default:
+			return nil, ErrPathNotFound
+		}
+	})
+}
+
+// mutate walks to the parent of path's last token and replaces that parent with fn's result,
+// re-linking every container on the way back up (appending to a slice may reallocate it).
+func mutate(node any, path []string, fn func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+
+	child, err := child(node, path[0])
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := mutate(child, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch container := node.(type) {
+	case map[string]any:
+		container[path[0]] = updated
+	case []any:
+		index, _ := arrayIndex(path[0], len(container)) // resolved by child above
+		container[index] = updated
+	}
+
+	return node, nil
+}
+
+func get(root any, path []string) (any, error) {
+	node := root
+
+	for _, token := range path {
+		var err error
+		if node, err = child(node, token); err != nil {
+			return nil, err
+		}
+	}
+
+	return node, nil
+}
+
+func child(node any, token string) (any, error) {
+	switch container := node.(type) {
+	case map[string]any:
+		value, ok := container[token]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+
+		return value, nil
+	case []any:
+		index, err := arrayIndex(token, len(container))
+		if err != nil {
+			return nil, err
+		}
+
+		return container[index], nil
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+// arrayIndex parses an array reference token (no sign, no leading zeros) below limit.
+func arrayIndex(token string, limit int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.ContainsAny(token, "+-") {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+
+	if index >= limit {
+		return 0, ErrPathNotFound
+	}
+
+	return index, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// decode parses JSON keeping numbers as json.Number, so values the patch does not touch are
+// re-encoded exactly as they came in.
+func decode(raw []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	return value, nil
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = deepCopy(item)
+		}
+
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = deepCopy(item)
+		}
+
+		return out
+	default:
+		return v
+	}
+}
+
+// equal compares two decoded JSON values per RFC 6902 section 4.6: numbers by numeric value,
+// objects irrespective of member order.
+func equal(a, b any) bool {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for key, item := range av {
+			other, ok := bv[key]
+			if !ok || !equal(item, other) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+
+		return true
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+
+		af, aErr := av.Float64()
+		bf, bErr := bv.Float64()
+
+		return aErr == nil && bErr == nil && af == bf
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,111 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustPatch(t *testing.T, raw string) Patch {
+	t.Helper()
+
+	var patch Patch
+	require.NoError(t, json.Unmarshal([]byte(raw), &patch))
+
+	return patch
+}
+
+func TestApply(t *testing.T) {
+	tests := map[string]struct {
+		doc   string
+		patch string
+		want  string
+	}{
+		"add object member": {
+			doc: `{"a":1}`, patch: `[{"op":"add","path":"/b","value":{"c":2}}]`, want: `{"a":1,"b":{"c":2}}`,
+		},
+		"add null value": {
+			doc: `{}`, patch: `[{"op":"add","path":"/a","value":null}]`, want: `{"a":null}`,
+		},
+		"add inserts into array": {
+			doc: `{"a":[1,3]}`, patch: `[{"op":"add","path":"/a/1","value":2}]`, want: `{"a":[1,2,3]}`,
+		},
+		"add appends with dash": {
+			doc: `{"a":[1]}`, patch: `[{"op":"add","path":"/a/-","value":2}]`, want: `{"a":[1,2]}`,
+		},
+		"remove member and element": {
+			doc: `{"a":1,"b":[1,2]}`, patch: `[{"op":"remove","path":"/a"},{"op":"remove","path":"/b/0"}]`, want: `{"b":[2]}`,
+		},
+		"replace": {
+			doc: `{"a":{"b":1}}`, patch: `[{"op":"replace","path":"/a/b","value":"x"}]`, want: `{"a":{"b":"x"}}`,
+		},
+		"move": {
+			doc: `{"a":{"b":1},"c":{}}`, patch: `[{"op":"move","from":"/a/b","path":"/c/d"}]`, want: `{"a":{},"c":{"d":1}}`,
+		},
+		"copy is deep": {
+			doc:   `{"a":{"b":1}}`,
+			patch: `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`,
+			want:  `{"a":{"b":1},"c":{"b":2}}`,
+		},
+		"test passes on numerically equal values": {
+			doc: `{"a":1.0,"b":{"x":[1]}}`, patch: `[{"op":"test","path":"/a","value":1},{"op":"test","path":"/b","value":{"x":[1]}}]`,
+			want: `{"a":1.0,"b":{"x":[1]}}`,
+		},
+		"escaped pointer tokens": {
+			doc: `{"a/b":1,"m~n":2}`, patch: `[{"op":"remove","path":"/a~1b"},{"op":"replace","path":"/m~0n","value":3}]`, want: `{"m~n":3}`,
+		},
+		"replace whole document": {
+			doc: `{"a":1}`, patch: `[{"op":"replace","path":"","value":[1]}]`, want: `[1]`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := mustPatch(t, tc.patch).Apply([]byte(tc.doc))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	tests := map[string]struct {
+		doc   string
+		patch string
+		want  error
+	}{
+		"unknown op":            {doc: `{}`, patch: `[{"op":"merge","path":"/a"}]`, want: ErrInvalidPatch},
+		"missing value":         {doc: `{}`, patch: `[{"op":"add","path":"/a"}]`, want: ErrInvalidPatch},
+		"pointer without slash": {doc: `{}`, patch: `[{"op":"remove","path":"a"}]`, want: ErrInvalidPatch},
+		"bad escape":            {doc: `{}`, patch: `[{"op":"remove","path":"/a~2"}]`, want: ErrInvalidPatch},
+		"leading zero index":    {doc: `{"a":[1,2]}`, patch: `[{"op":"remove","path":"/a/01"}]`, want: ErrInvalidPatch},
+		"move into own child":   {doc: `{"a":{}}`, patch: `[{"op":"move","from":"/a","path":"/a/b"}]`, want: ErrInvalidPatch},
+		"remove missing member": {doc: `{}`, patch: `[{"op":"remove","path":"/a"}]`, want: ErrPathNotFound},
+		"replace missing":       {doc: `{}`, patch: `[{"op":"replace","path":"/a","value":1}]`, want: ErrPathNotFound},
+		"add missing parent":    {doc: `{}`, patch: `[{"op":"add","path":"/a/b","value":1}]`, want: ErrPathNotFound},
+		"index out of range":    {doc: `{"a":[1]}`, patch: `[{"op":"add","path":"/a/2","value":1}]`, want: ErrPathNotFound},
+		"test mismatch":         {doc: `{"a":"x"}`, patch: `[{"op":"test","path":"/a","value":"y"}]`, want: ErrTestFailed},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := mustPatch(t, tc.patch).Apply([]byte(tc.doc))
+			require.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestApply_PreservesUntouchedNumbers(t *testing.T) {
+	got, err := mustPatch(t, `[{"op":"add","path":"/b","value":1}]`).Apply([]byte(`{"a":12345678901234567890}`))
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "12345678901234567890", "large integers are not rounded through float64")
+}
+
+func TestOperation_DistinguishesNullFromMissingValue(t *testing.T) {
+	patch := mustPatch(t, `[{"op":"add","path":"/a","value":null},{"op":"remove","path":"/a"}]`)
+
+	assert.Equal(t, json.RawMessage("null"), patch[0].Value)
+	assert.Nil(t, patch[1].Value)
+}
@@ -307,3 +307,50 @@ func TestDeleteEmbeddingsForOtherModels(t *testing.T) {
 		require.ErrorIs(t, err, repository.ErrEmbeddingNotFound, "stale-model rows must be gone")
 	}
 }
+
+// TestReembedFeedbackRecords_ClearsAndEnqueuesTenantScope re-embeds one tenant: its embedded
+// records lose their vector and get a job, while another tenant's records are left untouched.
+func TestReembedFeedbackRecords_ClearsAndEnqueuesTenantScope(t *testing.T) {
+	ctx := context.Background()
+	feedbackRepo, embeddingsRepo := embeddingBackfillRepos(t)
+
+	model := "reembed-" + uuid.NewString()
+	tenant := uuid.NewString()
+	otherTenant := uuid.NewString()
+	text := "re-embed me"
+	embedding := make([]float32, models.EmbeddingVectorDimensions)
+
+	makeEmbedded := func(tenantID string) uuid.UUID {
+		rec, err := feedbackRepo.Create(ctx, &models.CreateFeedbackRecordRequest{
+			SourceType:   "formbricks",
+			SubmissionID: uuid.NewString(),
+			TenantID:     tenantID,
+			FieldID:      "q1",
+			FieldType:    models.FieldTypeText,
+			ValueText:    &text,
+		})
+		require.NoError(t, err)
+		require.NoError(t, embeddingsRepo.Upsert(ctx, rec.ID, model, embedding, nil))
+
+		return rec.ID
+	}
+
+	mine := []uuid.UUID{makeEmbedded(tenant), makeEmbedded(tenant)}
+	other := makeEmbedded(otherTenant)
+
+	inserter := &countingEmbeddingInserter{}
+	svc := service.NewFeedbackRecordsService(feedbackRepo, embeddingsRepo, model, nil, inserter, "embeddings", 3, "")
+
+	enqueued, err := svc.ReembedFeedbackRecords(ctx, &models.ReembedRequest{TenantID: tenant})
+	require.NoError(t, err)
+	assert.Equal(t, len(mine), enqueued)
+	assert.ElementsMatch(t, mine, inserter.ids)
+
+	for _, id := range mine {
+		_, err := embeddingsRepo.GetEmbeddingByFeedbackRecordAndModel(ctx, id, model)
+		require.ErrorIs(t, err, repository.ErrEmbeddingNotFound, "re-embedded records must be cleared")
+	}
+
+	_, err = embeddingsRepo.GetEmbeddingByFeedbackRecordAndModel(ctx, other, model)
+	require.NoError(t, err, "another tenant's embedding must survive")
+}
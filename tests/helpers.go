@@ -11,7 +11,10 @@ import (
 	"github.com/formbricks/hub/pkg/database"
 )
 
-const testAPIKey = "test-api-key-12345"
+const (
+	testAPIKey      = "test-api-key-12345"
+	testAdminAPIKey = "test-admin-api-key-12345"
+)
 
 // CleanupTestData removes test data from the database.
 func CleanupTestData(t *testing.T) {
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/formbricks/hub/internal/config"
+	"github.com/formbricks/hub/internal/models"
+	"github.com/formbricks/hub/internal/repository"
+	"github.com/formbricks/hub/pkg/database"
+)
+
+// TestFeedbackRecordsRepository_IdempotencyKey creates records with Idempotency-Keys against
+// Postgres: a repeat returns the original record without inserting, another tenant's use of the
+// key creates its own record, an expired key or one whose record was soft-deleted creates anew, and the
+// cleanup deletes only expired keys.
+func TestFeedbackRecordsRepository_IdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	db, err := database.NewPostgresPool(ctx, cfg.Database.URL, database.WithPoolConfig(cfg.Database.PoolConfig()))
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	recordsRepo := repository.NewFeedbackRecordsRepository(db)
+	keysRepo := repository.NewIdempotencyKeysRepository(db)
+
+	tenant := testTenantID("idempotency")
+	key := testTenantID("key")
+
+	newRequest := func(tenantID, submissionID, idempotencyKey string) *models.CreateFeedbackRecordRequest {
+		return &models.CreateFeedbackRecordRequest{
+			SourceType:     "formbricks",
+			FieldID:        "q1",
+			FieldType:      models.FieldTypeText,
+			TenantID:       tenantID,
+			SubmissionID:   submissionID,
+			IdempotencyKey: idempotencyKey,
+		}
+	}
+
+	first, err := recordsRepo.Create(ctx, newRequest(tenant, testTenantID("sub"), key))
+	require.NoError(t, err)
+	assert.False(t, first.Replayed)
+
+	// The retry differs in submission_id, so only the key can make it a replay.
+	again, err := recordsRepo.Create(ctx, newRequest(tenant, testTenantID("sub"), key))
+	require.NoError(t, err)
+	assert.True(t, again.Replayed)
+	assert.Equal(t, first.ID, again.ID)
+
+	otherTenant := testTenantID("idempotency-other")
+	other, err := recordsRepo.Create(ctx, newRequest(otherTenant, testTenantID("sub"), key))
+	require.NoError(t, err)
+	assert.False(t, other.Replayed, "another tenant's use of the key is a different key")
+	assert.NotEqual(t, first.ID, other.ID)
+
+	otherAgain, err := recordsRepo.Create(ctx, newRequest(otherTenant, testTenantID("sub"), key))
+	require.NoError(t, err)
+	assert.True(t, otherAgain.Replayed)
+	assert.Equal(t, other.ID, otherAgain.ID)
+
+	_, err = db.Exec(ctx, `UPDATE idempotency_keys SET created_at = NOW() - INTERVAL '25 hours'
+		WHERE tenant_id = $1 AND key_hash = $2`, tenant, repository.IdempotencyKeyHash(key))
+	require.NoError(t, err)
+
+	renewed, err := recordsRepo.Create(ctx, newRequest(tenant, testTenantID("sub"), key))
+	require.NoError(t, err)
+	assert.False(t, renewed.Replayed, "an expired key creates a new record")
+	assert.NotEqual(t, first.ID, renewed.ID)
+
+	require.NoError(t, recordsRepo.Delete(ctx, renewed.ID))
+
+	recreated, err := recordsRepo.Create(ctx, newRequest(tenant, testTenantID("sub"), key))
+	require.NoError(t, err)
+	assert.False(t, recreated.Replayed, "a key whose record was soft-deleted creates a new record")
+	assert.NotEqual(t, renewed.ID, recreated.ID)
+	assert.Nil(t, recreated.DeletedAt)
+
+	staleKey := testTenantID("stale-key")
+	_, err = recordsRepo.Create(ctx, newRequest(tenant, testTenantID("sub"), staleKey))
+	require.NoError(t, err)
+
+	_, err = db.Exec(ctx, `UPDATE idempotency_keys SET created_at = NOW() - INTERVAL '25 hours'
+		WHERE tenant_id = $1 AND key_hash = $2`, tenant, repository.IdempotencyKeyHash(staleKey))
+	require.NoError(t, err)
+
+	deleted, err := keysRepo.DeleteExpired(ctx, time.Now().Add(-models.IdempotencyKeyTTL), 100)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	var live int
+
+	require.NoError(t, db.QueryRow(ctx, `SELECT COUNT(*) FROM idempotency_keys WHERE tenant_id = $1 AND key_hash = ANY($2)`,
+		tenant, []string{repository.IdempotencyKeyHash(key), repository.IdempotencyKeyHash(staleKey)}).Scan(&live))
+	assert.Equal(t, 1, live, "only the recreated key survives the cleanup")
+}
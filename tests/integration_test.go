@@ -126,6 +126,7 @@ func setupTestServerWithEventProviders(
 	// Set up protected endpoints
 	protectedMux := http.NewServeMux()
 	protectedMux.HandleFunc("POST /v1/feedback-records", feedbackRecordsHandler.Create)
+	protectedMux.HandleFunc("POST /v1/feedback-records/batch", feedbackRecordsHandler.CreateBatch)
 	protectedMux.HandleFunc("GET /v1/feedback-records", feedbackRecordsHandler.List)
 	protectedMux.HandleFunc("GET /v1/feedback-records/count", feedbackRecordsHandler.Count)
 	protectedMux.HandleFunc("GET /v1/feedback-records/{id}", feedbackRecordsHandler.Get)
@@ -146,9 +147,13 @@ func setupTestServerWithEventProviders(
 
 	protectedHandler = middleware.Auth(cfg.Server.HubAPIKey)(protectedHandler)
 
+	adminMux := http.NewServeMux()
+	adminMux.HandleFunc("GET /v1/admin/feedback-records", feedbackRecordsHandler.List)
+
 	// Combine both handlers
 	mainMux := http.NewServeMux()
 	mainMux.Handle("/v1/", protectedHandler)
+	mainMux.Handle("/v1/admin/", middleware.Auth(testAdminAPIKey)(middleware.AdminScope(adminMux)))
 	mainMux.Handle("/", publicHandler)
 
 	// Create test server
@@ -676,6 +681,68 @@ func TestFeedbackRecordsSubmissionIDUniqueConstraint(t *testing.T) {
 	assert.Equal(t, http.StatusConflict, resp2.StatusCode)
 }
 
+func TestCreateFeedbackRecordsBatch(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	tenantID := "tenant-batch-" + uuid.NewString()
+	record := func(submissionID string) map[string]any {
+		return map[string]any{
+			"source_type":   "formbricks",
+			"submission_id": submissionID,
+			"tenant_id":     tenantID,
+			"field_id":      "reason",
+			"field_type":    "text",
+			"value_text":    "batch " + submissionID,
+		}
+	}
+
+	// The duplicate of s1 fails alone at the database; the record missing submission_id fails validation.
+	body, err := json.Marshal(map[string]any{"records": []map[string]any{
+		record("s1"), record("s1"), record(""), record("s2"),
+	}})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		server.URL+"/v1/feedback-records/batch", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testAPIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{}).Do(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+
+	var got handlers.FeedbackRecordsBatchResponse
+	require.NoError(t, decodeData(resp, &got))
+	assert.Equal(t, 2, got.Created)
+	assert.Equal(t, 2, got.Failed)
+	require.Len(t, got.Results, 4)
+	require.NotNil(t, got.Results[0].ID)
+	require.NotNil(t, got.Results[1].Error)
+	assert.Equal(t, http.StatusConflict, got.Results[1].Error.Status)
+	require.NotNil(t, got.Results[2].Error)
+	assert.Equal(t, http.StatusBadRequest, got.Results[2].Error.Status)
+	require.NotNil(t, got.Results[3].ID)
+
+	countReq, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+		server.URL+"/v1/feedback-records/count?tenant_id="+tenantID, http.NoBody)
+	require.NoError(t, err)
+	countReq.Header.Set("Authorization", "Bearer "+testAPIKey)
+
+	countResp, err := (&http.Client{}).Do(countReq)
+	require.NoError(t, err)
+
+	defer func() { _ = countResp.Body.Close() }()
+
+	var count models.CountFeedbackRecordsResponse
+	require.NoError(t, decodeData(countResp, &count))
+	assert.Equal(t, int64(2), count.Count, "the failed records were rolled back alone")
+}
+
 func TestGetFeedbackRecord(t *testing.T) {
 	server, cleanup := setupTestServer(t)
 	defer cleanup()
@@ -837,6 +904,115 @@ func TestUpdateFeedbackRecord(t *testing.T) {
 		assert.NotNil(t, result.ValueText)
 		assert.Equal(t, "Updated comment", *result.ValueText)
 	})
+
+	jsonPatch := func(t *testing.T, patch string) *http.Response {
+		t.Helper()
+
+		patchURL := fmt.Sprintf("%s/v1/feedback-records/%s", server.URL, created.ID)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, patchURL, bytes.NewBufferString(patch))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testAPIKey)
+		req.Header.Set("Content-Type", "application/json-patch+json")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	t.Run("JSON Patch updates through the regular path", func(t *testing.T) {
+		resp := jsonPatch(t, `[
+			{"op":"test","path":"/value_text","value":"Updated comment"},
+			{"op":"add","path":"/metadata","value":{"priority":"high"}},
+			{"op":"replace","path":"/value_text","value":"Patched comment"}
+		]`)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result models.FeedbackRecord
+
+		require.NoError(t, decodeData(resp, &result))
+		require.NoError(t, resp.Body.Close())
+
+		require.NotNil(t, result.ValueText)
+		assert.Equal(t, "Patched comment", *result.ValueText)
+		assert.JSONEq(t, `{"priority":"high"}`, string(result.Metadata))
+	})
+
+	t.Run("JSON Patch rejects immutable fields and failed tests", func(t *testing.T) {
+		resp := jsonPatch(t, `[{"op":"replace","path":"/source_type","value":"other"}]`)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		resp = jsonPatch(t, `[{"op":"test","path":"/value_text","value":"stale"},{"op":"replace","path":"/value_text","value":"x"}]`)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("If-Match guards against a concurrent update", func(t *testing.T) {
+		recordURL := fmt.Sprintf("%s/v1/feedback-records/%s", server.URL, created.ID)
+
+		conditionalPatch := func(ifMatch, text string) *http.Response {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, recordURL,
+				bytes.NewBufferString(`{"value_text":"`+text+`"}`))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+testAPIKey)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", ifMatch)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+
+			return resp
+		}
+
+		getReq, err := http.NewRequestWithContext(context.Background(), http.MethodGet, recordURL, http.NoBody)
+		require.NoError(t, err)
+		getReq.Header.Set("Authorization", "Bearer "+testAPIKey)
+
+		getResp, err := client.Do(getReq)
+		require.NoError(t, err)
+		require.NoError(t, getResp.Body.Close())
+
+		readETag := getResp.Header.Get("ETag")
+		require.NotEmpty(t, readETag)
+
+		// First writer wins with the ETag it read and gets the next one back.
+		resp := conditionalPatch(readETag, "First writer")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NotEqual(t, readETag, resp.Header.Get("ETag"))
+		require.NoError(t, resp.Body.Close())
+
+		// Second writer still holds the old ETag: refused, nothing written.
+		resp = conditionalPatch(readETag, "Second writer")
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		record, err := decodeFeedbackRecordAt(client, recordURL)
+		require.NoError(t, err)
+		require.NotNil(t, record.ValueText)
+		assert.Equal(t, "First writer", *record.ValueText)
+	})
+}
+
+// decodeFeedbackRecordAt GETs a feedback record and decodes it.
+func decodeFeedbackRecordAt(client *http.Client, recordURL string) (*models.FeedbackRecord, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, recordURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+testAPIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	var record models.FeedbackRecord
+
+	return &record, decodeData(resp, &record)
 }
 
 func TestDeleteFeedbackRecord(t *testing.T) {
@@ -908,6 +1084,82 @@ func TestDeleteFeedbackRecord(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 		require.NoError(t, resp.Body.Close())
 	})
+
+	// The delete is soft: the admin API lists the record again with include_deleted=true until it
+	// is purged; the regular API key may not ask for deleted records at all.
+	t.Run("Deleted record is listed with include_deleted", func(t *testing.T) {
+		query := "?tenant_id=test-tenant&submission_id=" + url.QueryEscape(created.SubmissionID)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			server.URL+"/v1/feedback-records"+query+"&include_deleted=true", http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testAPIKey)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		cases := map[string]bool{
+			"/v1/feedback-records" + query:                                 false,
+			"/v1/admin/feedback-records" + query + "&include_deleted=true": true,
+		}
+		for path, wantListed := range cases {
+			apiKey := testAPIKey
+			if wantListed {
+				apiKey = testAdminAPIKey
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+path, http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var list models.ListFeedbackRecordsResponse
+
+			require.NoError(t, decodeData(resp, &list))
+			require.NoError(t, resp.Body.Close())
+
+			if !wantListed {
+				assert.Empty(t, list.Data)
+
+				continue
+			}
+
+			require.Len(t, list.Data, 1)
+			assert.Equal(t, created.ID, list.Data[0].ID)
+			assert.NotNil(t, list.Data[0].DeletedAt)
+		}
+	})
+
+	t.Run("Deleting again is not found", func(t *testing.T) {
+		delURL := fmt.Sprintf("%s/v1/feedback-records/%s", server.URL, created.ID)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, delURL, http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testAPIKey)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	})
+
+	// Uniqueness of (tenant_id, submission_id, field_id) covers live records only.
+	t.Run("Deleted submission field can be created again", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+			server.URL+"/v1/feedback-records", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testAPIKey)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	})
 }
 
 func TestDeleteFeedbackRecordsByUser(t *testing.T) {
@@ -1540,6 +1792,98 @@ func TestFeedbackRecordsRepository_DeleteByUser(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestFeedbackRecordsRepository_EraseSubject(t *testing.T) {
+	ctx := context.Background()
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		databaseURL = defaultTestDatabaseURL
+	}
+
+	t.Setenv("API_KEY", testAPIKey)
+	t.Setenv("DATABASE_URL", databaseURL)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	db, err := database.NewPostgresPool(ctx, cfg.Database.URL,
+		database.WithPoolConfig(cfg.Database.PoolConfig()),
+	)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	repo := repository.NewFeedbackRecordsRepository(db)
+	webhooksRepo := repository.NewWebhooksRepository(db)
+	userID := "repo-user-erase-" + uuid.New().String()
+	tenantA := "erase-tenant-" + uuid.NewString()
+	tenantB := "erase-tenant-other-" + uuid.NewString()
+
+	valueText := "  erase this text  "
+	recA, err := repo.CreateWithEmbedding(ctx, &models.CreateFeedbackRecordRequest{
+		SourceType:   "formbricks",
+		SubmissionID: userID,
+		TenantID:     tenantA,
+		FieldID:      "erase-f1",
+		FieldType:    models.FieldTypeText,
+		ValueText:    &valueText,
+		UserID:       &userID,
+	}, "model-name", make([]float32, models.EmbeddingVectorDimensions))
+	require.NoError(t, err)
+
+	valueNumber := 3.0
+	recB, err := repo.Create(ctx, &models.CreateFeedbackRecordRequest{
+		SourceType:   "formbricks",
+		SubmissionID: userID,
+		TenantID:     tenantB,
+		FieldID:      "erase-f2",
+		FieldType:    models.FieldTypeNumber,
+		ValueNumber:  &valueNumber,
+		UserID:       &userID,
+	})
+	require.NoError(t, err)
+
+	webhook, err := webhooksRepo.Create(ctx, &models.CreateWebhookRequest{
+		URL:        "https://erase.example.com/" + uuid.NewString(),
+		SigningKey: "whsec_abcdefghijklmnopqrstuvwxyz123456",
+		TenantID:   &tenantA,
+		EventTypes: []datatypes.EventType{datatypes.FeedbackRecordCreated},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _, _ = webhooksRepo.Delete(context.Background(), webhook.ID) })
+
+	require.NoError(t, webhooksRepo.CreateDeadLetter(ctx, &models.CreateWebhookDeadLetter{
+		WebhookID: webhook.ID, TenantID: tenantA, EventID: uuid.Must(uuid.NewV7()),
+		EventType: datatypes.FeedbackRecordCreated.String(),
+		Payload:   []byte(`{"data":{"user_id":"` + userID + `"}}`), Attempts: 3,
+	}))
+
+	counts, err := repo.EraseSubject(ctx, &models.DeleteFeedbackRecordsByUserFilters{UserID: userID})
+	require.NoError(t, err)
+	require.Len(t, counts.DeletedByTenant, 2)
+
+	deletedIDs := make([]uuid.UUID, 0, 2)
+	for _, group := range counts.DeletedByTenant {
+		deletedIDs = append(deletedIDs, group.IDs...)
+	}
+
+	assert.ElementsMatch(t, []uuid.UUID{recA.ID, recB.ID}, deletedIDs)
+	assert.Equal(t, int64(1), counts.DeletedEmbeddings)
+	assert.Equal(t, int64(1), counts.DeletedWebhookDeadLetters)
+	assert.Equal(t, []string{"erase this text"}, counts.ValueTexts)
+
+	deadLetters, _, err := webhooksRepo.ListDeadLetters(ctx, webhook.ID, 10, nil, uuid.Nil)
+	require.NoError(t, err)
+	assert.Empty(t, deadLetters)
+
+	// A second erasure of the same subject succeeds with nothing left to remove.
+	counts, err = repo.EraseSubject(ctx, &models.DeleteFeedbackRecordsByUserFilters{UserID: userID})
+	require.NoError(t, err)
+	assert.Empty(t, counts.DeletedByTenant)
+	assert.Zero(t, counts.DeletedEmbeddings)
+	assert.Zero(t, counts.DeletedWebhookDeadLetters)
+}
+
 // TestWebhooksCRUD tests webhook create, get, list, update, delete.
 func TestWebhooksCRUD(t *testing.T) {
 	server, cleanup := setupTestServer(t)
@@ -137,3 +137,56 @@ func TestSemanticSearch_NearestFeedbackRecords(t *testing.T) {
 		assert.Equal(t, middle, page2[0].FeedbackRecordID, "page 2 starts at the next-nearest row")
 	})
 }
+
+// TestKeywordSearch_FeedbackRecords runs the keyword half of hybrid search against Postgres:
+// records matching more terms rank first, LIKE wildcards in a term match literally, and other
+// tenants' records never match.
+func TestKeywordSearch_FeedbackRecords(t *testing.T) {
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	db, err := database.NewPostgresPool(ctx, cfg.Database.URL, database.WithPoolConfig(cfg.Database.PoolConfig()))
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	recordsRepo := repository.NewFeedbackRecordsRepository(db)
+	embeddingsRepo := repository.NewEmbeddingsRepository(db)
+
+	tenant := testTenantID("keyword")
+	otherTenant := testTenantID("keyword-other")
+
+	mkText := func(tenantID, text string) uuid.UUID {
+		valueText := text
+		rec, createErr := recordsRepo.Create(ctx, &models.CreateFeedbackRecordRequest{
+			SourceType:   "formbricks",
+			FieldID:      "q1",
+			FieldType:    models.FieldTypeText,
+			ValueText:    &valueText,
+			TenantID:     tenantID,
+			SubmissionID: testTenantID("sub"),
+		})
+		require.NoError(t, createErr)
+
+		return rec.ID
+	}
+
+	both := mkText(tenant, "Checkout fails with ERR-42")
+	one := mkText(tenant, "checkout is slow")
+	mkText(tenant, "100 percent happy")
+	mkText(otherTenant, "checkout fails with err-42 too")
+
+	results, err := embeddingsRepo.KeywordSearchFeedbackRecords(ctx, tenant, []string{"err-42", "CHECKOUT"}, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, both, results[0].FeedbackRecordID)
+	assert.InDelta(t, 1, results[0].Score, 1e-9)
+	assert.Equal(t, one, results[1].FeedbackRecordID)
+	assert.InDelta(t, 0.5, results[1].Score, 1e-9)
+
+	results, err = embeddingsRepo.KeywordSearchFeedbackRecords(ctx, tenant, []string{"100%"}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, results, "% is matched literally, not as a wildcard")
+}
@@ -323,6 +323,16 @@ func TestTaxonomyAPI_RecordCounts(t *testing.T) {
 		requestTaxonomyJSON(ctx, t, http.MethodGet, countsURL(ids.RunID.String(), url.Values{}),
 			harness.apiKey, nil, http.StatusBadRequest, nil)
 	})
+
+	t.Run("removal impact leaves out soft-deleted records", func(t *testing.T) {
+		_, err := harness.db.Exec(ctx, `UPDATE feedback_records SET deleted_at = NOW() WHERE id = $1`, secondRecordID)
+		require.NoError(t, err)
+
+		impact, err := repository.NewTaxonomyRepository(harness.db).RemoveNodes(
+			ctx, []uuid.UUID{ids.BranchID}, scope.TenantID, "api-actor", true)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), impact.FeedbackAffected, "only the live record is affected")
+	})
 }
 
 // TestTaxonomyAPI_CreateRun covers the public run-creation endpoint: validation failures,
@@ -261,6 +261,69 @@ func TestTaxonomyRepository_Heartbeat(t *testing.T) {
 	})
 }
 
+// TestTaxonomyRepository_CancelRun covers user cancellation: a pending or running run moves to
+// canceled and stops blocking a new run for its scope, while a run that already finished conflicts
+// and a canceled run cannot be completed by a late result.
+func TestTaxonomyRepository_CancelRun(t *testing.T) {
+	ctx := context.Background()
+	db := taxonomyTestDB(t)
+	repo := repository.NewTaxonomyRepository(db)
+
+	t.Run("running run is canceled and frees the scope", func(t *testing.T) {
+		scope := uniqueTaxonomyScope("tax-cancel-running")
+		cleanupTaxonomyTenant(ctx, t, db, scope.TenantID)
+
+		run, _, err := repo.CreateRunIfAvailable(ctx, repository.CreateTaxonomyRunParams{TaxonomyScope: scope})
+		require.NoError(t, err)
+		_, err = repo.MarkRunRunning(ctx, run.ID, scope.TenantID)
+		require.NoError(t, err)
+
+		canceled, err := repo.CancelRun(ctx, run.ID, scope.TenantID)
+		require.NoError(t, err)
+		assert.Equal(t, models.TaxonomyRunStatusCanceled, canceled.Status)
+		assert.NotNil(t, canceled.FinishedAt)
+
+		next, created, err := repo.CreateRunIfAvailable(ctx, repository.CreateTaxonomyRunParams{TaxonomyScope: scope})
+		require.NoError(t, err)
+		assert.True(t, created, "a canceled run must not block a new one")
+		assert.NotEqual(t, run.ID, next.ID)
+
+		_, err = repo.StoreResultAndActivate(ctx, run.ID, scope.TenantID, models.TaxonomyRunResultRequest{})
+		require.ErrorIs(t, err, huberrors.ErrConflict, "a late result must not complete a canceled run")
+	})
+
+	t.Run("pending run is canceled", func(t *testing.T) {
+		scope := uniqueTaxonomyScope("tax-cancel-pending")
+		cleanupTaxonomyTenant(ctx, t, db, scope.TenantID)
+
+		run, _, err := repo.CreateRunIfAvailable(ctx, repository.CreateTaxonomyRunParams{TaxonomyScope: scope})
+		require.NoError(t, err)
+
+		canceled, err := repo.CancelRun(ctx, run.ID, scope.TenantID)
+		require.NoError(t, err)
+		assert.Equal(t, models.TaxonomyRunStatusCanceled, canceled.Status)
+
+		_, err = repo.MarkRunRunning(ctx, run.ID, scope.TenantID)
+		require.ErrorIs(t, err, huberrors.ErrConflict, "canceled->running must conflict")
+	})
+
+	t.Run("terminal run conflicts", func(t *testing.T) {
+		scope := uniqueTaxonomyScope("tax-cancel-terminal")
+		cleanupTaxonomyTenant(ctx, t, db, scope.TenantID)
+
+		run, _, err := repo.CreateRunIfAvailable(ctx, repository.CreateTaxonomyRunParams{TaxonomyScope: scope})
+		require.NoError(t, err)
+		_, err = repo.MarkRunFailed(ctx, run.ID, scope.TenantID, "boom", models.TaxonomyRunFailureCodeInternalError)
+		require.NoError(t, err)
+
+		_, err = repo.CancelRun(ctx, run.ID, scope.TenantID)
+		require.ErrorIs(t, err, huberrors.ErrConflict, "failed->canceled must conflict")
+
+		_, err = repo.CancelRun(ctx, run.ID, "other-tenant")
+		require.ErrorIs(t, err, huberrors.ErrNotFound, "another tenant's run is not found")
+	})
+}
+
 // TestTaxonomyRepository_FailStuckRunsCoordinatesWithPurge is a purge-race regression test: the
 // reaper fails runs through the shared tenant write lock, so it must serialize with a concurrent
 // tenant-data purge (which takes the exclusive tenant lock) rather than deadlock on row locks, and
@@ -431,6 +494,287 @@ func TestTaxonomyRepository_RenameAndRemoveNode(t *testing.T) {
 	})
 }
 
+// TestTaxonomyRepository_RemoveNodes covers the batch remove: per-node impact with overlapping
+// subtrees counted once in the totals, dry runs writing nothing, and unknown ids reported
+// not_found without failing the batch.
+func TestTaxonomyRepository_RemoveNodes(t *testing.T) {
+	ctx := context.Background()
+	db := taxonomyTestDB(t)
+	repo := repository.NewTaxonomyRepository(db)
+
+	scope := uniqueTaxonomyScope("tax-batch-remove")
+	ids := seedTaxonomyGraph(ctx, t, db, scope)
+	unknownID := uuid.New()
+
+	t.Run("dry run reports the impact and removes nothing", func(t *testing.T) {
+		result, err := repo.RemoveNodes(ctx, []uuid.UUID{ids.BranchID, ids.LeafID, unknownID}, scope.TenantID, "actor-batch", true)
+		require.NoError(t, err)
+		require.Len(t, result.Results, 3)
+
+		assert.Equal(t, models.RemoveTaxonomyNodeResult{
+			ID: ids.BranchID, Status: models.TaxonomyNodeRemovable, DescendantsAffected: 1, FeedbackAffected: 1,
+		}, result.Results[0])
+		assert.Equal(t, models.RemoveTaxonomyNodeResult{
+			ID: ids.LeafID, Status: models.TaxonomyNodeRemovable, DescendantsAffected: 0, FeedbackAffected: 1,
+		}, result.Results[1])
+		assert.Equal(t, models.TaxonomyNodeNotFound, result.Results[2].Status)
+
+		assert.Equal(t, 2, result.RemovedCount)
+		assert.Equal(t, int64(0), result.DescendantsAffected, "the leaf was requested, so it is not also a descendant")
+		assert.Equal(t, int64(1), result.FeedbackAffected, "the shared record is counted once")
+
+		tree, err := repo.GetTree(ctx, ids.RunID, scope.TenantID)
+		require.NoError(t, err)
+		require.True(t, treeContainsNode(tree.Root, ids.BranchID), "a dry run must not remove anything")
+	})
+
+	t.Run("removes the nodes and records an event per node", func(t *testing.T) {
+		result, err := repo.RemoveNodes(ctx, []uuid.UUID{ids.BranchID}, scope.TenantID, "actor-batch", false)
+		require.NoError(t, err)
+		require.Len(t, result.Results, 1)
+		assert.Equal(t, models.TaxonomyNodeRemoved, result.Results[0].Status)
+		assert.Equal(t, int64(1), result.DescendantsAffected)
+
+		tree, err := repo.GetTree(ctx, ids.RunID, scope.TenantID)
+		require.NoError(t, err)
+		require.False(t, treeContainsNode(tree.Root, ids.BranchID))
+
+		events := countTenantDataRows(ctx, t, db, `
+			SELECT COUNT(*) FROM taxonomy_node_events
+			WHERE node_id = $1 AND event_type = 'soft_remove' AND actor_id = 'actor-batch'`, ids.BranchID)
+		assert.Equal(t, int64(1), events)
+	})
+
+	t.Run("already removed and other tenants' nodes are not found", func(t *testing.T) {
+		result, err := repo.RemoveNodes(ctx, []uuid.UUID{ids.BranchID}, scope.TenantID, "actor-batch", false)
+		require.NoError(t, err)
+		assert.Equal(t, models.TaxonomyNodeNotFound, result.Results[0].Status)
+
+		result, err = repo.RemoveNodes(ctx, []uuid.UUID{ids.RootID}, "other-tenant-"+uuid.NewString(), "attacker", false)
+		require.NoError(t, err)
+		assert.Equal(t, models.TaxonomyNodeNotFound, result.Results[0].Status)
+		assert.Zero(t, result.RemovedCount)
+	})
+}
+
+// TestTaxonomyRepository_MoveNode covers re-parenting: subtree level shifts, the move event,
+// and the cycle, leaf-parent, root, and depth guards.
+func TestTaxonomyRepository_MoveNode(t *testing.T) {
+	ctx := context.Background()
+	db := taxonomyTestDB(t)
+	repo := repository.NewTaxonomyRepository(db)
+
+	scope := uniqueTaxonomyScope("tax-move")
+	ids := seedTaxonomyGraph(ctx, t, db, scope)
+
+	var otherBranchID uuid.UUID
+
+	err := db.QueryRow(ctx, `
+		INSERT INTO taxonomy_nodes (run_id, parent_id, node_type, label, original_label, level, sort_order)
+		VALUES ($1, $2, 'branch'::taxonomy_node_type_enum, 'Billing', 'Billing', 1, 1)
+		RETURNING id`,
+		ids.RunID, ids.RootID,
+	).Scan(&otherBranchID)
+	require.NoError(t, err)
+
+	const maxLevel = 5
+
+	t.Run("moving the root is rejected", func(t *testing.T) {
+		_, err := repo.MoveNode(ctx, ids.RootID, scope.TenantID, "actor-move", otherBranchID, maxLevel)
+		require.ErrorIs(t, err, huberrors.ErrValidation)
+	})
+
+	t.Run("a leaf cannot become a parent", func(t *testing.T) {
+		_, err := repo.MoveNode(ctx, otherBranchID, scope.TenantID, "actor-move", ids.LeafID, maxLevel)
+		require.ErrorIs(t, err, huberrors.ErrValidation)
+	})
+
+	t.Run("moving a branch shifts its subtree and records a move event", func(t *testing.T) {
+		moved, err := repo.MoveNode(ctx, ids.BranchID, scope.TenantID, "actor-move", otherBranchID, maxLevel)
+		require.NoError(t, err)
+		require.NotNil(t, moved.ParentID)
+		require.Equal(t, otherBranchID, *moved.ParentID)
+		require.Equal(t, 2, moved.Level)
+
+		var leafLevel int
+		require.NoError(t, db.QueryRow(ctx, `SELECT level FROM taxonomy_nodes WHERE id = $1`, ids.LeafID).Scan(&leafLevel))
+		assert.Equal(t, 3, leafLevel, "descendants must move down with their parent")
+
+		events := countTenantDataRows(ctx, t, db, `
+			SELECT COUNT(*) FROM taxonomy_node_events
+			WHERE node_id = $1 AND event_type = 'move' AND actor_id = 'actor-move'`, ids.BranchID)
+		assert.Equal(t, int64(1), events)
+	})
+
+	t.Run("moving a node under its own descendant is rejected", func(t *testing.T) {
+		_, err := repo.MoveNode(ctx, otherBranchID, scope.TenantID, "actor-move", ids.BranchID, maxLevel)
+		require.ErrorIs(t, err, huberrors.ErrValidation)
+	})
+
+	t.Run("a move past the maximum level is rejected", func(t *testing.T) {
+		// Under the level-1 branch the leaf would sit at level 2.
+		_, err := repo.MoveNode(ctx, ids.LeafID, scope.TenantID, "actor-move", otherBranchID, 1)
+		require.ErrorIs(t, err, huberrors.ErrValidation)
+	})
+
+	t.Run("another tenant cannot move the node", func(t *testing.T) {
+		_, err := repo.MoveNode(ctx, ids.BranchID, "other-tenant-"+uuid.NewString(), "attacker", ids.RootID, maxLevel)
+		require.ErrorIs(t, err, huberrors.ErrNotFound)
+	})
+}
+
+// TestTaxonomyRepository_MergeNode covers consolidating a duplicate topic: children and cluster
+// memberships move to the target, the merged node is soft-removed with a merge event, and the
+// cycle, root, leaf-target, and tenant guards hold.
+func TestTaxonomyRepository_MergeNode(t *testing.T) {
+	ctx := context.Background()
+	db := taxonomyTestDB(t)
+	repo := repository.NewTaxonomyRepository(db)
+
+	scope := uniqueTaxonomyScope("tax-merge")
+	ids := seedTaxonomyGraph(ctx, t, db, scope)
+
+	insertNode := func(parentID uuid.UUID, clusterID *uuid.UUID, nodeType, label string, level int) uuid.UUID {
+		t.Helper()
+
+		var id uuid.UUID
+
+		require.NoError(t, db.QueryRow(ctx, `
+			INSERT INTO taxonomy_nodes (run_id, parent_id, cluster_id, node_type, label, original_label, level, sort_order)
+			VALUES ($1, $2, $3, $4::taxonomy_node_type_enum, $5, $5, $6, 1)
+			RETURNING id`,
+			ids.RunID, parentID, clusterID, nodeType, label, level,
+		).Scan(&id))
+
+		return id
+	}
+
+	var duplicateClusterID uuid.UUID
+
+	require.NoError(t, db.QueryRow(ctx, `
+		INSERT INTO taxonomy_clusters (run_id, cluster_key, label, size)
+		VALUES ($1, 2, 'sign in', 0)
+		RETURNING id`,
+		ids.RunID,
+	).Scan(&duplicateClusterID))
+
+	otherBranchID := insertNode(ids.RootID, nil, "branch", "Sign In", 1)
+	duplicateLeafID := insertNode(otherBranchID, &duplicateClusterID, "leaf", "Sign-in Problems", 2)
+	standaloneLeafID := insertNode(ids.RootID, nil, "leaf", "Misc", 1)
+
+	const maxLevel = 5
+
+	t.Run("merging the root is rejected", func(t *testing.T) {
+		_, err := repo.MergeNode(ctx, ids.RootID, scope.TenantID, "actor-merge", otherBranchID, maxLevel)
+		require.ErrorIs(t, err, huberrors.ErrValidation)
+	})
+
+	t.Run("merging into a descendant is rejected", func(t *testing.T) {
+		_, err := repo.MergeNode(ctx, ids.BranchID, scope.TenantID, "actor-merge", ids.LeafID, maxLevel)
+		require.ErrorIs(t, err, huberrors.ErrValidation)
+	})
+
+	t.Run("children cannot be merged into a leaf", func(t *testing.T) {
+		_, err := repo.MergeNode(ctx, ids.BranchID, scope.TenantID, "actor-merge", standaloneLeafID, maxLevel)
+		require.ErrorIs(t, err, huberrors.ErrValidation)
+	})
+
+	t.Run("another tenant cannot merge the node", func(t *testing.T) {
+		_, err := repo.MergeNode(ctx, ids.BranchID, "other-tenant-"+uuid.NewString(), "attacker", otherBranchID, maxLevel)
+		require.ErrorIs(t, err, huberrors.ErrNotFound)
+	})
+
+	t.Run("merging a leaf moves its records to the target's cluster", func(t *testing.T) {
+		result, err := repo.MergeNode(ctx, ids.LeafID, scope.TenantID, "actor-merge", duplicateLeafID, maxLevel)
+		require.NoError(t, err)
+		assert.Equal(t, duplicateLeafID, result.Node.ID)
+		assert.Equal(t, int64(1), result.RecordsMoved)
+
+		var clusterID uuid.UUID
+		require.NoError(t, db.QueryRow(ctx, `
+			SELECT cluster_id FROM taxonomy_cluster_memberships WHERE run_id = $1 AND feedback_record_id = $2`,
+			ids.RunID, ids.FeedbackRecordID,
+		).Scan(&clusterID))
+		assert.Equal(t, duplicateClusterID, clusterID)
+
+		var size int
+		require.NoError(t, db.QueryRow(ctx, `SELECT size FROM taxonomy_clusters WHERE id = $1`, duplicateClusterID).Scan(&size))
+		assert.Equal(t, 1, size)
+
+		var removed bool
+		require.NoError(t, db.QueryRow(ctx,
+			`SELECT removed_at IS NOT NULL FROM taxonomy_nodes WHERE id = $1`, ids.LeafID).Scan(&removed))
+		assert.True(t, removed, "the merged node is soft-removed")
+
+		events := countTenantDataRows(ctx, t, db, `
+			SELECT COUNT(*) FROM taxonomy_node_events
+			WHERE node_id = $1 AND event_type = 'merge' AND actor_id = 'actor-merge'`, ids.LeafID)
+		assert.Equal(t, int64(1), events)
+	})
+
+	t.Run("merging a branch re-parents its children", func(t *testing.T) {
+		movedLeafID := insertNode(ids.BranchID, nil, "leaf", "Password Resets", 2)
+
+		result, err := repo.MergeNode(ctx, ids.BranchID, scope.TenantID, "actor-merge", otherBranchID, maxLevel)
+		require.NoError(t, err)
+		assert.Equal(t, otherBranchID, result.Node.ID)
+		assert.Equal(t, 2, result.ChildCount, "the target's own leaf plus the moved one")
+		assert.Equal(t, 2, result.ChildrenMoved, "the removed leaf moves too")
+
+		var (
+			parentID uuid.UUID
+			level    int
+		)
+		require.NoError(t, db.QueryRow(ctx,
+			`SELECT parent_id, level FROM taxonomy_nodes WHERE id = $1`, movedLeafID).Scan(&parentID, &level))
+		assert.Equal(t, otherBranchID, parentID)
+		assert.Equal(t, 2, level)
+	})
+}
+
+func TestTaxonomyRepository_RepairNodeLevels(t *testing.T) {
+	ctx := context.Background()
+	db := taxonomyTestDB(t)
+	repo := repository.NewTaxonomyRepository(db)
+
+	scope := uniqueTaxonomyScope("tax-levels")
+	ids := seedTaxonomyGraph(ctx, t, db, scope)
+
+	// Simulate an import that wrote the leaf (depth 2) at level 5.
+	_, err := db.Exec(ctx, `UPDATE taxonomy_nodes SET level = 5 WHERE id = $1`, ids.LeafID)
+	require.NoError(t, err)
+
+	driftForRun := func() int {
+		drift, err := repo.ListNodeLevelDrift(ctx)
+		require.NoError(t, err)
+
+		for _, d := range drift {
+			if d.RunID == ids.RunID {
+				assert.Equal(t, scope.TenantID, d.TenantID)
+
+				return d.DriftedNodes
+			}
+		}
+
+		return 0
+	}
+
+	require.Equal(t, 1, driftForRun())
+
+	fixed, err := repo.RepairNodeLevels(ctx, ids.RunID, scope.TenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), fixed)
+
+	var leafLevel int
+	require.NoError(t, db.QueryRow(ctx, `SELECT level FROM taxonomy_nodes WHERE id = $1`, ids.LeafID).Scan(&leafLevel))
+	assert.Equal(t, 2, leafLevel)
+	assert.Equal(t, 0, driftForRun())
+
+	fixed, err = repo.RepairNodeLevels(ctx, ids.RunID, scope.TenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), fixed, "a repaired run has nothing left to fix")
+}
+
 // treeContainsNode reports whether nodeID appears anywhere in the visible tree.
 func treeContainsNode(node *models.TaxonomyNode, nodeID uuid.UUID) bool {
 	if node == nil {
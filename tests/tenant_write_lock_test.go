@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -503,6 +504,136 @@ func TestWebhookTenantMoveLocksTargetTenant(t *testing.T) {
 	cleanupTenantDataBestEffort(ctx, client, server.URL, tenantB)
 }
 
+// TestFeedbackRecordTenantMove covers PATCH tenant_id on a feedback record: a
+// move is refused while the record has topic memberships, while the target
+// tenant is under purge, and when the target already holds the same
+// submission_id/field_id; a successful move takes the record out of the old
+// tenant's list, search and export and into the new tenant's.
+func TestFeedbackRecordTenantMove(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	db := newTenantLockDB(ctx, t)
+	client := &http.Client{}
+	embeddingsRepo := repository.NewEmbeddingsRepository(db)
+	tenantA := "tenant-record-move-a-" + uuid.NewString()
+	tenantB := "tenant-record-move-b-" + uuid.NewString()
+
+	defer cleanupTenantDataBestEffort(ctx, client, server.URL, tenantA)
+	defer cleanupTenantDataBestEffort(ctx, client, server.URL, tenantB)
+
+	moveBody := map[string]any{"tenant_id": tenantB}
+
+	t.Run("refused while the record has memberships", func(t *testing.T) {
+		record := createTenantDataFeedbackRecord(ctx, t, client, server.URL, tenantA, uuid.NewString(), "move-classified")
+		createTenantDataTaxonomyGraph(ctx, t, db, tenantA, record.ID, "move-source-"+uuid.NewString(), record.FieldID)
+
+		status, body := doTenantLockRequest(ctx, t, client, http.MethodPatch,
+			server.URL+"/v1/feedback-records/"+record.ID.String(), moveBody)
+		require.Equal(t, http.StatusConflict, status, "body: %s", string(body))
+	})
+
+	t.Run("refused while the target tenant is under purge", func(t *testing.T) {
+		record := createTenantDataFeedbackRecord(ctx, t, client, server.URL, tenantA, uuid.NewString(), "move-purge")
+
+		release := holdTenantWriteLock(ctx, t, db, tenantB, false)
+		defer release()
+
+		status, body := doTenantLockRequest(ctx, t, client, http.MethodPatch,
+			server.URL+"/v1/feedback-records/"+record.ID.String(), moveBody)
+		requireTenantWriteConflict(t, status, body)
+	})
+
+	t.Run("refused on a submission and field collision in the target", func(t *testing.T) {
+		submissionID := uuid.NewString()
+		record := createTenantDataFeedbackRecord(ctx, t, client, server.URL, tenantA, submissionID, "move-collision")
+		createTenantDataFeedbackRecord(ctx, t, client, server.URL, tenantB, submissionID, "move-collision")
+
+		status, body := doTenantLockRequest(ctx, t, client, http.MethodPatch,
+			server.URL+"/v1/feedback-records/"+record.ID.String(), moveBody)
+		require.Equal(t, http.StatusConflict, status, "body: %s", string(body))
+	})
+
+	t.Run("moves the record out of every read of the old tenant", func(t *testing.T) {
+		record := createTenantDataFeedbackRecord(ctx, t, client, server.URL, tenantA, uuid.NewString(), "move-ok")
+		require.NoError(t, embeddingsRepo.Upsert(ctx, record.ID, searchTestModel, searchVec(0), nil))
+
+		status, body := doTenantLockRequest(ctx, t, client, http.MethodPatch,
+			server.URL+"/v1/feedback-records/"+record.ID.String(), moveBody)
+		require.Equal(t, http.StatusOK, status, "body: %s", string(body))
+
+		assert.NotContains(t, listTenantRecordIDs(ctx, t, client, server.URL, tenantA), record.ID)
+		assert.Contains(t, listTenantRecordIDs(ctx, t, client, server.URL, tenantB), record.ID)
+		assert.NotContains(t, exportTenantRecordIDs(ctx, t, client, server.URL, tenantA), record.ID)
+		assert.Contains(t, exportTenantRecordIDs(ctx, t, client, server.URL, tenantB), record.ID)
+		assert.NotContains(t, searchTenantRecordIDs(ctx, t, embeddingsRepo, tenantA), record.ID)
+		assert.Contains(t, searchTenantRecordIDs(ctx, t, embeddingsRepo, tenantB), record.ID)
+	})
+}
+
+func listTenantRecordIDs(
+	ctx context.Context, t *testing.T, client *http.Client, serverURL, tenantID string,
+) []uuid.UUID {
+	t.Helper()
+
+	status, body := doTenantLockRequest(ctx, t, client, http.MethodGet,
+		serverURL+"/v1/feedback-records?limit=1000&tenant_id="+url.QueryEscape(tenantID), nil)
+	require.Equal(t, http.StatusOK, status, "body: %s", string(body))
+
+	var list models.ListFeedbackRecordsResponse
+	require.NoError(t, json.Unmarshal(body, &list))
+
+	ids := make([]uuid.UUID, 0, len(list.Data))
+	for _, record := range list.Data {
+		ids = append(ids, record.ID)
+	}
+
+	return ids
+}
+
+func exportTenantRecordIDs(
+	ctx context.Context, t *testing.T, client *http.Client, serverURL, tenantID string,
+) []uuid.UUID {
+	t.Helper()
+
+	status, body := doTenantLockRequest(ctx, t, client, http.MethodGet,
+		serverURL+"/v1/feedback-records/export?format=jsonl&tenant_id="+url.QueryEscape(tenantID), nil)
+	require.Equal(t, http.StatusOK, status, "body: %s", string(body))
+
+	var ids []uuid.UUID
+
+	for line := range strings.SplitSeq(strings.TrimSpace(string(body)), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var record models.FeedbackRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+
+		ids = append(ids, record.ID)
+	}
+
+	return ids
+}
+
+func searchTenantRecordIDs(
+	ctx context.Context, t *testing.T, embeddingsRepo *repository.EmbeddingsRepository, tenantID string,
+) []uuid.UUID {
+	t.Helper()
+
+	results, _, err := embeddingsRepo.NearestFeedbackRecordsByEmbedding(
+		ctx, searchTestModel, searchVec(0), tenantID, 100, nil, 0)
+	require.NoError(t, err)
+
+	ids := make([]uuid.UUID, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.FeedbackRecordID)
+	}
+
+	return ids
+}
+
 // TestRepositoryWritesConflictDuringPurge covers the non-HTTP write surfaces
 // (worker-driven webhook disable, embedding writes, taxonomy writes) directly
 // at the repository layer, which every caller shares.
@@ -535,9 +666,24 @@ func TestRepositoryWritesConflictDuringPurge(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, created)
 
+	deadLetterEventID := uuid.Must(uuid.NewV7())
+	require.NoError(t, webhooksRepo.CreateDeadLetter(ctx, &models.CreateWebhookDeadLetter{
+		WebhookID: webhook.ID, TenantID: tenantA, EventID: deadLetterEventID,
+		EventType: "feedback_record.created", Payload: []byte(`{}`), Attempts: 3,
+	}))
+
+	deadLetters, _, err := webhooksRepo.ListDeadLetters(ctx, webhook.ID, 1, nil, uuid.Nil)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+
 	release := holdTenantWriteLock(ctx, t, db, tenantA, false)
 	defer release()
 
+	t.Run("dead letter retry mark conflicts", func(t *testing.T) {
+		err := webhooksRepo.MarkDeadLetterRetried(ctx, tenantA, deadLetters[0].ID, time.Now())
+		require.ErrorIs(t, err, huberrors.ErrTenantWriteConflict)
+	})
+
 	t.Run("webhook delivery disable conflicts", func(t *testing.T) {
 		enabled := false
 		reason := "max attempts"
@@ -566,6 +712,33 @@ func TestRepositoryWritesConflictDuringPurge(t *testing.T) {
 		require.ErrorIs(t, err, huberrors.ErrTenantWriteConflict)
 	})
 
+	t.Run("reembed clear conflicts", func(t *testing.T) {
+		_, err := embeddingsRepo.ClearEmbeddingsForReembed(
+			ctx, "model-name", &models.ReembedRequest{TenantID: tenantA}, uuid.Nil, 10)
+		require.ErrorIs(t, err, huberrors.ErrTenantWriteConflict)
+	})
+
+	t.Run("create with supplied embedding conflicts and writes neither row", func(t *testing.T) {
+		embedding := make([]float32, models.EmbeddingVectorDimensions)
+		embedding[0] = 0.5
+
+		_, err := repository.NewFeedbackRecordsRepository(db).CreateWithEmbedding(ctx, &models.CreateFeedbackRecordRequest{
+			SourceType:   "formbricks",
+			FieldID:      "tenant-lock-byo-embedding",
+			FieldType:    models.FieldTypeText,
+			TenantID:     tenantA,
+			SubmissionID: uuid.NewString(),
+		}, "model-name", embedding)
+		require.ErrorIs(t, err, huberrors.ErrTenantWriteConflict)
+
+		var count int64
+
+		require.NoError(t, db.QueryRow(ctx,
+			`SELECT COUNT(*) FROM feedback_records WHERE tenant_id = $1 AND field_id = 'tenant-lock-byo-embedding'`,
+			tenantA).Scan(&count))
+		assert.Zero(t, count, "rejected create must not leave a record behind")
+	})
+
 	t.Run("taxonomy run create and transitions conflict", func(t *testing.T) {
 		_, _, err := taxonomyRepo.CreateRunIfAvailable(ctx, repository.CreateTaxonomyRunParams{
 			TaxonomyScope: models.TaxonomyScope{
@@ -606,6 +779,53 @@ func TestRepositoryWritesConflictDuringPurge(t *testing.T) {
 	cleanupTenantDataBestEffort(ctx, client, server.URL, tenantA)
 }
 
+// TestPurgeDeletedSkipsTenantUnderPurge verifies the retention purge of soft-deleted records
+// takes each tenant's write lock: a tenant whose data purge holds the lock is skipped (and purged
+// by a later run) while other tenants' expired records are still removed.
+func TestPurgeDeletedSkipsTenantUnderPurge(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	db := newTenantLockDB(ctx, t)
+	client := &http.Client{}
+	tenantA := "tenant-retention-a-" + uuid.NewString()
+	tenantB := "tenant-retention-b-" + uuid.NewString()
+
+	repo := repository.NewFeedbackRecordsRepository(db)
+
+	recordA := createTenantDataFeedbackRecord(ctx, t, client, server.URL, tenantA, uuid.NewString(), "tenant-retention-field")
+	recordB := createTenantDataFeedbackRecord(ctx, t, client, server.URL, tenantB, uuid.NewString(), "tenant-retention-field")
+	require.NoError(t, repo.Delete(ctx, recordA.ID))
+	require.NoError(t, repo.Delete(ctx, recordB.ID))
+
+	exists := func(id uuid.UUID) bool {
+		var found bool
+		require.NoError(t, db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM feedback_records WHERE id = $1)`, id).Scan(&found))
+
+		return found
+	}
+
+	release := holdTenantWriteLock(ctx, t, db, tenantA, false)
+	defer release()
+
+	cutoff := time.Now().Add(time.Minute)
+
+	_, err := repo.PurgeDeleted(ctx, cutoff, 500)
+	require.NoError(t, err)
+	assert.True(t, exists(recordA.ID), "a tenant under purge must be skipped")
+	assert.False(t, exists(recordB.ID), "other tenants' expired records are purged")
+
+	release()
+
+	_, err = repo.PurgeDeleted(ctx, cutoff, 500)
+	require.NoError(t, err)
+	assert.False(t, exists(recordA.ID), "the skipped tenant is purged by the next run")
+
+	cleanupTenantDataBestEffort(ctx, client, server.URL, tenantA)
+	cleanupTenantDataBestEffort(ctx, client, server.URL, tenantB)
+}
+
 // TestTaxonomyNodeMutationsAreTenantScoped verifies getNodeForUpdate's tenant
 // predicate: a node may only be renamed/removed by its owning tenant, so a
 // caller can never lock or mutate another tenant's node row.
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -127,6 +128,161 @@ func TestWebhooksRepository_DeleteReturnsDeletedWebhook(t *testing.T) {
 	assert.ErrorIs(t, err, huberrors.ErrNotFound)
 }
 
+func TestWebhooksRepository_DeadLetterRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	urlPrefix := "https://dead-letter.test/" + uuid.NewString() + "/"
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		databaseURL = defaultTestDatabaseURL
+	}
+
+	t.Setenv("API_KEY", testAPIKey)
+	t.Setenv("DATABASE_URL", databaseURL)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	db, err := database.NewPostgresPool(ctx, cfg.Database.URL,
+		database.WithPoolConfig(cfg.Database.PoolConfig()),
+	)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	cleanupRepositoryDeadLetterTestRows := func() {
+		// Dead letters cascade with their webhook.
+		_, cleanupErr := db.Exec(ctx, "DELETE FROM webhooks WHERE url LIKE $1", urlPrefix+"%")
+		require.NoError(t, cleanupErr)
+	}
+
+	cleanupRepositoryDeadLetterTestRows()
+	defer cleanupRepositoryDeadLetterTestRows()
+
+	repo := repository.NewWebhooksRepository(db)
+	tenantID := "repo-dead-letter-tenant"
+	webhook := createWebhookForRepositoryScopeTest(
+		ctx, t, repo, urlPrefix, "dead-letter", &tenantID, []datatypes.EventType{datatypes.FeedbackRecordCreated},
+	)
+
+	status := 503
+	eventID := uuid.Must(uuid.NewV7())
+	require.NoError(t, repo.CreateDeadLetter(ctx, &models.CreateWebhookDeadLetter{
+		WebhookID:  webhook.ID,
+		TenantID:   tenantID,
+		EventID:    eventID,
+		EventType:  datatypes.FeedbackRecordCreated.String(),
+		Payload:    []byte(`{"event_id":"` + eventID.String() + `"}`),
+		LastStatus: &status,
+		Error:      "webhook endpoint returned non-2xx status: 503",
+		Attempts:   3,
+	}))
+
+	deadLetters, hasMore, err := repo.ListDeadLetters(ctx, webhook.ID, 10, nil, uuid.Nil)
+	require.NoError(t, err)
+	assert.False(t, hasMore)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, eventID, deadLetters[0].EventID)
+	require.NotNil(t, deadLetters[0].LastStatus)
+	assert.Equal(t, status, *deadLetters[0].LastStatus)
+	assert.Nil(t, deadLetters[0].RetriedAt)
+
+	_, err = repo.GetDeadLetter(ctx, uuid.Must(uuid.NewV7()), deadLetters[0].ID)
+	require.ErrorIs(t, err, huberrors.ErrNotFound, "a dead letter is only visible under its own webhook")
+
+	err = repo.MarkDeadLetterRetried(ctx, "another-tenant", deadLetters[0].ID, time.Now())
+	require.ErrorIs(t, err, huberrors.ErrNotFound, "a dead letter is only marked under its own tenant")
+
+	require.NoError(t, repo.MarkDeadLetterRetried(ctx, tenantID, deadLetters[0].ID, time.Now()))
+
+	retried, err := repo.GetDeadLetter(ctx, webhook.ID, deadLetters[0].ID)
+	require.NoError(t, err)
+	assert.NotNil(t, retried.RetriedAt)
+
+	err = repo.CreateDeadLetter(ctx, &models.CreateWebhookDeadLetter{
+		WebhookID: uuid.Must(uuid.NewV7()), TenantID: tenantID, EventID: eventID,
+		EventType: datatypes.FeedbackRecordCreated.String(), Payload: []byte(`{}`), Attempts: 3,
+	})
+	require.ErrorIs(t, err, huberrors.ErrNotFound)
+}
+
+func TestWebhooksRepository_DeliveriesKeepLatestAttempts(t *testing.T) {
+	ctx := context.Background()
+	urlPrefix := "https://deliveries.test/" + uuid.NewString() + "/"
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		databaseURL = defaultTestDatabaseURL
+	}
+
+	t.Setenv("API_KEY", testAPIKey)
+	t.Setenv("DATABASE_URL", databaseURL)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	db, err := database.NewPostgresPool(ctx, cfg.Database.URL,
+		database.WithPoolConfig(cfg.Database.PoolConfig()),
+	)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	cleanupRepositoryDeliveryTestRows := func() {
+		// Deliveries cascade with their webhook.
+		_, cleanupErr := db.Exec(ctx, "DELETE FROM webhooks WHERE url LIKE $1", urlPrefix+"%")
+		require.NoError(t, cleanupErr)
+	}
+
+	cleanupRepositoryDeliveryTestRows()
+	defer cleanupRepositoryDeliveryTestRows()
+
+	repo := repository.NewWebhooksRepository(db)
+	tenantID := "repo-deliveries-tenant"
+	webhook := createWebhookForRepositoryScopeTest(
+		ctx, t, repo, urlPrefix, "deliveries", &tenantID, []datatypes.EventType{datatypes.FeedbackRecordCreated},
+	)
+
+	const attempts = 205 // past the 200 kept per webhook
+
+	status := 503
+	body := "upstream down"
+	message := "webhook returned non-2xx status: 503"
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		require.NoError(t, repo.CreateDelivery(ctx, &models.CreateWebhookDelivery{
+			WebhookID:             webhook.ID,
+			TenantID:              tenantID,
+			EventID:               uuid.Must(uuid.NewV7()),
+			EventType:             datatypes.FeedbackRecordCreated.String(),
+			Attempt:               attempt,
+			Status:                models.WebhookDeliveryStatusRetry,
+			StatusCode:            &status,
+			ResponseBodyTruncated: &body,
+			Error:                 &message,
+			DurationMs:            12,
+		}))
+	}
+
+	var kept int
+	require.NoError(t, db.QueryRow(ctx, "SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id = $1", webhook.ID).Scan(&kept))
+	assert.Equal(t, 200, kept, "older attempts are trimmed")
+
+	deliveries, hasMore, err := repo.ListDeliveries(ctx, webhook.ID, 10, nil, uuid.Nil)
+	require.NoError(t, err)
+	assert.True(t, hasMore)
+	require.Len(t, deliveries, 10)
+	assert.Equal(t, attempts, deliveries[0].Attempt, "newest first")
+	require.NotNil(t, deliveries[0].ResponseBodyTruncated)
+	assert.Equal(t, body, *deliveries[0].ResponseBodyTruncated)
+
+	err = repo.CreateDelivery(ctx, &models.CreateWebhookDelivery{
+		WebhookID: uuid.Must(uuid.NewV7()), TenantID: tenantID, EventID: uuid.Must(uuid.NewV7()),
+		EventType: datatypes.FeedbackRecordCreated.String(), Attempt: 1, Status: models.WebhookDeliveryStatusSuccess,
+	})
+	require.ErrorIs(t, err, huberrors.ErrNotFound)
+}
+
 func createWebhookForRepositoryScopeTest(
 	ctx context.Context,
 	t *testing.T,